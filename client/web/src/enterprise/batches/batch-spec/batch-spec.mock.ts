@@ -220,6 +220,9 @@ export const mockStep = (
     diffStat: { __typename: 'DiffStat', added: 15, deleted: 10 },
     environment: [],
     exitCode: 0,
+    attempts: 1,
+    timedOut: false,
+    continuedOnError: false,
     finishedAt: subMinutes(now, 1).toISOString(),
     ifCondition: null,
     number,
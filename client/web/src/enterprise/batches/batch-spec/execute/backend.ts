@@ -113,6 +113,9 @@ const batchSpecWorkspaceFieldsFragment = gql`
         startedAt
         finishedAt
         exitCode
+        attempts
+        timedOut
+        continuedOnError
         environment {
             name
             value
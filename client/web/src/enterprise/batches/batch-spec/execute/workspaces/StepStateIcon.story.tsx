@@ -16,6 +16,10 @@ export default config
 const options = [
     { label: 'Cache Found', value: { cachedResultFound: true } },
     { label: 'Failed', value: { startedAt: 'start-time', finishedAt: 'start-time', exitCode: 1 } },
+    {
+        label: 'Continued On Error',
+        value: { startedAt: 'start-time', finishedAt: 'start-time', exitCode: 1, attempts: 3, continuedOnError: true },
+    },
     { label: 'Not Started', value: { startedAt: null } },
     { label: 'Running', value: { startedAt: 'start-time' } },
     { label: 'Skipped', value: { skipped: true } },
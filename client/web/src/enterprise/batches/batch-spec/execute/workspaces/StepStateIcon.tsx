@@ -32,7 +32,16 @@ const getProps = (
     if (step.exitCode === 0) {
         return ['text-success', CheckBoldIcon, 'This step finished running successfully.']
     }
-    return ['text-danger', AlertCircleIcon, `This step failed with exit code ${String(step.exitCode)}.`]
+    const reason = step.timedOut ? 'timed out' : `failed with exit code ${String(step.exitCode)}`
+    const attempts = step.attempts && step.attempts > 1 ? ` after ${step.attempts} attempts` : ''
+    if (step.continuedOnError) {
+        return [
+            'text-warning',
+            AlertCircleIcon,
+            `This step ${reason}${attempts}. The execution continued because the step is configured to continue on error.`,
+        ]
+    }
+    return ['text-danger', AlertCircleIcon, `This step ${reason}${attempts}.`]
 }
 
 export const StepStateIcon: React.FunctionComponent<React.PropsWithChildren<StepStateIconProps>> = ({ step }) => {
@@ -512,11 +512,14 @@ const WorkspaceStep: React.FunctionComponent<React.PropsWithChildren<WorkspaceSt
 
             if (step.exitCode !== null && step.exitCode !== 0) {
                 outputLines.push(`stderr: Command failed with status ${step.exitCode}`)
+                if (step.continuedOnError) {
+                    outputLines.push('stderr: Continued with the next step, because the step continues on error')
+                }
             }
         }
 
         return outputLines
-    }, [step.exitCode, step.outputLines])
+    }, [step.exitCode, step.continuedOnError, step.outputLines])
     const tabsNames = ['logs', 'output', 'diff', 'files_env', 'cmd_container']
     return (
         <Collapse isOpen={isExpanded} onOpenChange={setIsExpanded}>
@@ -27,6 +27,9 @@ type Services struct {
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
 
+	// Code Insights Services
+	InsightsExportHandler http.Handler
+
 	// Repo related webhook handlers, currently only handle `push` events.
 	ReposGithubWebhook          webhooks.Registerer
 	ReposGitLabWebhook          webhooks.Registerer
@@ -96,6 +99,7 @@ func DefaultServices() Services {
 		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
 		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		InsightsExportHandler:           makeNotFoundHandler("code insights export handler"),
 	}
 }
 
@@ -0,0 +1,194 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type AuditLogsArgs struct {
+	graphqlutil.ConnectionArgs
+	After  *string
+	Actor  *string
+	Entity *string
+	Action *string
+	Since  *time.Time
+	Until  *time.Time
+}
+
+// toListOpts transforms the GraphQL arguments into options that can be provided to the
+// AuditLogStore's Count and List methods.
+func (args *AuditLogsArgs) toListOpts() (database.AuditLogListOpts, error) {
+	opts := database.AuditLogListOpts{
+		ActorUID: args.Actor,
+		Entity:   args.Entity,
+		Action:   args.Action,
+		Since:    args.Since,
+		Until:    args.Until,
+	}
+
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	} else {
+		opts.Limit = 50
+	}
+
+	if args.After != nil {
+		var err error
+		opts.Cursor, err = strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return opts, errors.Wrap(err, "parsing the after cursor")
+		}
+	}
+
+	return opts, nil
+}
+
+func (r *schemaResolver) AuditLogs(ctx context.Context, args *AuditLogsArgs) (*auditLogConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can read the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := args.toListOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditLogConnectionResolver{
+		opts:  opts,
+		store: r.db.AuditLogs(),
+	}, nil
+}
+
+type auditLogConnectionResolver struct {
+	opts  database.AuditLogListOpts
+	store database.AuditLogStore
+
+	once    sync.Once
+	entries []*audit.Entry
+	next    int64
+	err     error
+}
+
+func (r *auditLogConnectionResolver) Nodes(ctx context.Context) ([]*auditLogEntryResolver, error) {
+	entries, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*auditLogEntryResolver, len(entries))
+	for i, entry := range entries {
+		nodes[i] = &auditLogEntryResolver{entry: entry}
+	}
+	return nodes, nil
+}
+
+func (r *auditLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.Count(ctx, r.opts)
+	return int32(count), err
+}
+
+func (r *auditLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(fmt.Sprint(next)), nil
+}
+
+func (r *auditLogConnectionResolver) compute(ctx context.Context) ([]*audit.Entry, int64, error) {
+	r.once.Do(func() {
+		r.entries, r.next, r.err = r.store.List(ctx, r.opts)
+	})
+	return r.entries, r.next, r.err
+}
+
+type auditLogEntryResolver struct {
+	entry *audit.Entry
+}
+
+func marshalAuditLogEntryID(id int64) graphql.ID {
+	return relay.MarshalID("AuditLogEntry", id)
+}
+
+func (r *auditLogEntryResolver) ID() graphql.ID {
+	return marshalAuditLogEntryID(r.entry.ID)
+}
+
+func (r *auditLogEntryResolver) AuditID() string { return r.entry.AuditID }
+
+func (r *auditLogEntryResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.entry.CreatedAt}
+}
+
+func (r *auditLogEntryResolver) Actor() string        { return r.entry.ActorUID }
+func (r *auditLogEntryResolver) IP() string           { return r.entry.ActorIP }
+func (r *auditLogEntryResolver) ForwardedFor() string { return r.entry.ForwardedFor }
+func (r *auditLogEntryResolver) Entity() string       { return r.entry.Entity }
+func (r *auditLogEntryResolver) Action() string       { return r.entry.Action }
+func (r *auditLogEntryResolver) PreviousHash() string { return r.entry.PreviousHash }
+func (r *auditLogEntryResolver) Hash() string         { return r.entry.Hash }
+
+func (r *auditLogEntryResolver) Fields() JSONValue {
+	return JSONValue{Value: r.entry.Fields}
+}
+
+func (r *schemaResolver) VerifyAuditLog(ctx context.Context) (*auditLogVerificationResolver, error) {
+	// 🚨 SECURITY: Only site admins can read the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	key := audit.HashKey()
+	if key == nil {
+		return nil, errors.New("audit log records can't be verified: AUDIT_LOG_HASH_KEY is not set")
+	}
+
+	checked, err := r.db.AuditLogs().Verify(ctx, key)
+	var chainErr *audit.ChainError
+	if err != nil && !errors.As(err, &chainErr) {
+		return nil, err
+	}
+	return &auditLogVerificationResolver{checked: checked, chainErr: chainErr}, nil
+}
+
+type auditLogVerificationResolver struct {
+	checked  int
+	chainErr *audit.ChainError
+}
+
+func (r *auditLogVerificationResolver) Valid() bool { return r.chainErr == nil }
+
+func (r *auditLogVerificationResolver) CheckedEntries() int32 { return int32(r.checked) }
+
+func (r *auditLogVerificationResolver) FirstInvalidEntry() *graphql.ID {
+	if r.chainErr == nil {
+		return nil
+	}
+	id := marshalAuditLogEntryID(r.chainErr.EntryID)
+	return &id
+}
+
+func (r *auditLogVerificationResolver) Reason() *string {
+	if r.chainErr == nil {
+		return nil
+	}
+	return &r.chainErr.Reason
+}
@@ -444,6 +444,7 @@ type ChangesetApplyPreviewConnectionStatsResolver interface {
 	Detach() int32
 	Archive() int32
 	Reattach() int32
+	UpdateMetadata() int32
 
 	Added() int32
 	Modified() int32
@@ -495,6 +496,9 @@ type ChangesetSpecDeltaResolver interface {
 	CommitMessageChanged() bool
 	AuthorNameChanged() bool
 	AuthorEmailChanged() bool
+	ReviewersChanged() bool
+	LabelsChanged() bool
+	AssigneesChanged() bool
 }
 
 type ChangesetDescription interface {
@@ -914,6 +918,9 @@ type BatchSpecWorkspaceStepResolver interface {
 	FinishedAt() *gqlutil.DateTime
 
 	ExitCode() *int32
+	Attempts() *int32
+	TimedOut() bool
+	ContinuedOnError() bool
 	Environment() ([]BatchSpecWorkspaceEnvironmentVariableResolver, error)
 	OutputVariables() *[]BatchSpecWorkspaceOutputVariableResolver
 
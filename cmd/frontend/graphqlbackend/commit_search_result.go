@@ -96,3 +96,6 @@ func (r *CommitSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)
 func (r *CommitSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return r, true
 }
+func (r *CommitSearchResultResolver) ToOwnerSearchResult() (*OwnerSearchResultResolver, bool) {
+	return nil, false
+}
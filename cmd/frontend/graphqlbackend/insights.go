@@ -44,6 +44,12 @@ type InsightsResolver interface {
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
+
+	// Alerts
+	InsightSeriesAlerts(ctx context.Context, args InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	UpdateInsightSeriesAlert(ctx context.Context, args *UpdateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -514,3 +520,62 @@ type RepositoryPreviewPayloadResolver interface {
 	Query(ctx context.Context) string
 	NumberOfRepositories(ctx context.Context) *int32
 }
+
+type InsightSeriesAlertsArgs struct {
+	InsightViewId graphql.ID
+	SeriesId      string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	InsightViewId graphql.ID
+	SeriesId      string
+	Kind          string
+	Direction     string
+	Threshold     float64
+	WindowSeconds *int32
+	Channel       string
+	URL           *string
+	Enabled       *bool
+}
+
+type UpdateInsightSeriesAlertArgs struct {
+	Id      graphql.ID
+	Enabled bool
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertEventsArgs struct {
+	First *int32
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Direction() string
+	Threshold() float64
+	WindowSeconds() *int32
+	Channel() string
+	URL(ctx context.Context) *string
+	Recipient(ctx context.Context) (*UserResolver, error)
+	Enabled() bool
+	CreatedBy(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	Events(ctx context.Context, args *InsightSeriesAlertEventsArgs) ([]InsightSeriesAlertEventResolver, error)
+}
+
+type InsightSeriesAlertEventResolver interface {
+	Capture() *string
+	Time() gqlutil.DateTime
+	Value() float64
+	ReferenceValue() *float64
+	DeliveredAt() *gqlutil.DateTime
+	Error() *string
+}
@@ -0,0 +1,39 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// OwnerSearchResultResolver is a resolver for the GraphQL type `OwnerSearchResult`
+type OwnerSearchResultResolver struct {
+	owner result.OwnerMatch
+
+	RepoResolver *RepositoryResolver
+}
+
+func (r *OwnerSearchResultResolver) Handle() *string {
+	if r.owner.Handle == "" {
+		return nil
+	}
+	return strptr(r.owner.Handle)
+}
+
+func (r *OwnerSearchResultResolver) Email() *string {
+	if r.owner.Email == "" {
+		return nil
+	}
+	return strptr(r.owner.Email)
+}
+
+func (r *OwnerSearchResultResolver) Repository() *RepositoryResolver {
+	return r.RepoResolver
+}
+
+func (r *OwnerSearchResultResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (r *OwnerSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (r *OwnerSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *OwnerSearchResultResolver) ToOwnerSearchResult() (*OwnerSearchResultResolver, bool) {
+	return r, true
+}
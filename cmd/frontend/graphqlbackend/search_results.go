@@ -163,6 +163,11 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.OwnerMatch:
+			resolvers = append(resolvers, &OwnerSearchResultResolver{
+				owner:        *v,
+				RepoResolver: getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -299,8 +304,8 @@ loop:
 	for _, r := range sr.Matches {
 		r := r // shadow so it doesn't change in the goroutine
 		switch m := r.(type) {
-		case *result.RepoMatch:
-			// We don't care about repo results here.
+		case *result.RepoMatch, *result.OwnerMatch:
+			// We don't care about repo or owner results here.
 			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
@@ -561,10 +566,12 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 //   - *RepositoryResolver         // repo name match
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
+//   - *OwnerSearchResultResolver  // owner match
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToOwnerSearchResult() (*OwnerSearchResultResolver, bool)
 }
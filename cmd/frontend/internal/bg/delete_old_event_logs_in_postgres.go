@@ -5,7 +5,10 @@ import (
 	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 )
 
@@ -38,3 +41,34 @@ func DeleteOldSecurityEventLogsInPostgres(ctx context.Context, db database.DB) {
 		time.Sleep(time.Hour)
 	}
 }
+
+func DeleteOldAuditLogsInPostgres(ctx context.Context, db database.DB) {
+	for {
+		// Persisted audit logs are retained indefinitely unless a retention period is
+		// configured.
+		if days := audit.RetentionDays(conf.SiteConfig()); days > 0 {
+			err := db.AuditLogs().DeleteStale(ctx, time.Duration(days)*24*time.Hour)
+			if err != nil {
+				log15.Error("deleting expired rows from audit_logs table", "error", err)
+			}
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+// CheckpointAuditLogs periodically logs the head of the persisted audit log hash chain,
+// so that truncating or rewriting the chain can be detected from outside the database.
+func CheckpointAuditLogs(ctx context.Context, logger log.Logger, db database.DB) {
+	logger = logger.Scoped("auditLogCheckpoint", "logs the head of the persisted audit log")
+	for {
+		if audit.IsEnabled(conf.SiteConfig(), audit.Persist) {
+			head, err := db.AuditLogs().Head(ctx)
+			if err != nil {
+				logger.Error("getting head of audit log", log.Error(err))
+			} else if head != nil {
+				audit.LogCheckpoint(logger, head)
+			}
+		}
+		time.Sleep(time.Hour)
+	}
+}
@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// auditLogExportFlushInterval is the number of entries written between flushes of the
+// response, so that clients receive entries as they are read.
+const auditLogExportFlushInterval = 1000
+
+// auditLogExportEntry is the JSON representation of an exported audit log entry.
+type auditLogExportEntry struct {
+	ID           int64           `json:"id"`
+	AuditID      string          `json:"auditId"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	Actor        string          `json:"actor"`
+	IP           string          `json:"ip"`
+	ForwardedFor string          `json:"forwardedFor"`
+	Entity       string          `json:"entity"`
+	Action       string          `json:"action"`
+	Fields       json.RawMessage `json:"fields"`
+	PreviousHash string          `json:"previousHash"`
+	Hash         string          `json:"hash"`
+}
+
+// serveAuditLogExport streams the persisted audit log, oldest entries first, as JSON
+// lines for ingestion into SIEM systems. Entries can be filtered with the actor, entity,
+// action, since and until query parameters, the latter two as RFC 3339 timestamps.
+func serveAuditLogExport(db database.DB) func(http.ResponseWriter, *http.Request) error {
+	logger := log.Scoped("serveAuditLogExport", "")
+	return func(w http.ResponseWriter, r *http.Request) error {
+		ctx := r.Context()
+
+		// 🚨 SECURITY: Only site admins can read the audit log.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			return err
+		}
+
+		opts, err := auditLogListOptsFromQuery(r)
+		if err != nil {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="audit-log.jsonl"`)
+
+		bw := bufio.NewWriter(w)
+		enc := json.NewEncoder(bw)
+		written := 0
+		err = db.AuditLogs().Iterate(ctx, opts, func(entry *audit.Entry) error {
+			if err := enc.Encode(auditLogExportEntry{
+				ID:           entry.ID,
+				AuditID:      entry.AuditID,
+				CreatedAt:    entry.CreatedAt.UTC(),
+				Actor:        entry.ActorUID,
+				IP:           entry.ActorIP,
+				ForwardedFor: entry.ForwardedFor,
+				Entity:       entry.Entity,
+				Action:       entry.Action,
+				Fields:       entry.Fields,
+				PreviousHash: entry.PreviousHash,
+				Hash:         entry.Hash,
+			}); err != nil {
+				return err
+			}
+
+			written++
+			if written%auditLogExportFlushInterval == 0 {
+				if err := bw.Flush(); err != nil {
+					return err
+				}
+				if f, ok := w.(http.Flusher); ok {
+					f.Flush()
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			// The response status has already been sent, so the client is left with a
+			// truncated export.
+			logger.Error("failed to export audit log", log.Int("written", written), log.Error(err))
+		}
+		return nil
+	}
+}
+
+func auditLogListOptsFromQuery(r *http.Request) (database.AuditLogListOpts, error) {
+	query := r.URL.Query()
+
+	var opts database.AuditLogListOpts
+	for name, dst := range map[string]**string{
+		"actor":  &opts.ActorUID,
+		"entity": &opts.Entity,
+		"action": &opts.Action,
+	} {
+		if v := query.Get(name); v != "" {
+			*dst = &v
+		}
+	}
+	for name, dst := range map[string]**time.Time{
+		"since": &opts.Since,
+		"until": &opts.Until,
+	} {
+		if v := query.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opts, errors.Wrapf(err, "invalid %s", name)
+			}
+			*dst = &t
+		}
+	}
+	return opts, nil
+}
@@ -57,6 +57,9 @@ type Handlers struct {
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
 
+	// Code insights
+	InsightsExportHandler http.Handler
+
 	// Code intel
 	NewCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler
 
@@ -95,6 +98,7 @@ func NewHandler(
 	// Set handlers for the installed routes.
 	m.Get(apirouter.RepoShield).Handler(trace.Route(handler(serveRepoShield())))
 	m.Get(apirouter.RepoRefresh).Handler(trace.Route(handler(serveRepoRefresh(db))))
+	m.Get(apirouter.AuditLogExport).Handler(trace.Route(handler(serveAuditLogExport(db))))
 
 	webhookMiddleware := webhooks.NewLogMiddleware(
 		db.WebhookLogs(keyring.Default().WebhookLogKey),
@@ -132,6 +136,7 @@ func NewHandler(
 	m.Get(apirouter.BatchesFileGet).Handler(trace.Route(handlers.BatchesChangesFileGetHandler))
 	m.Get(apirouter.BatchesFileExists).Handler(trace.Route(handlers.BatchesChangesFileExistsHandler))
 	m.Get(apirouter.BatchesFileUpload).Handler(trace.Route(handlers.BatchesChangesFileUploadHandler))
+	m.Get(apirouter.InsightsExport).Handler(trace.Route(handlers.InsightsExportHandler))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUploadExists).Handler(trace.Route(noopHandler))
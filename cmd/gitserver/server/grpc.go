@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/internal/accesslog"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewGRPCServer returns a gRPC server serving the gitserver API on top of s.
+// The actors of incoming requests are propagated as for the HTTP API, and
+// accesses are recorded in the access log. s.Handler must have been called
+// before the returned server is served, as it initializes the state of s.
+func NewGRPCServer(s *Server) *grpc.Server {
+	logger := s.Logger.Scoped("grpc", "gRPC API")
+	accessLogger := accesslog.NewGRPCAccessLogger(
+		s.Logger.Scoped("grpc.accesslog", "gRPC endpoint access log"),
+		conf.DefaultClient(),
+	)
+
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(protocol.GRPCMaxMessageSize),
+		grpc.MaxSendMsgSize(protocol.GRPCMaxMessageSize),
+		// The actor must be set before the access log reads it.
+		grpc.ChainUnaryInterceptor(actor.UnaryServerInterceptor(logger), accessLogger.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(actor.StreamServerInterceptor(logger), accessLogger.StreamServerInterceptor),
+	)
+	proto.RegisterGitserverServiceServer(grpcServer, &grpcServerImpl{server: s})
+	return grpcServer
+}
+
+type grpcServerImpl struct {
+	server *Server
+	proto.UnimplementedGitserverServiceServer
+}
+
+func (gs *grpcServerImpl) Exec(req *proto.ExecRequest, ss proto.GitserverService_ExecServer) error {
+	var execReq protocol.ExecRequest
+	execReq.FromProto(req)
+
+	// Log which actor is accessing the repo.
+	args := execReq.Args
+	cmd := ""
+	if len(execReq.Args) > 0 {
+		cmd = execReq.Args[0]
+		args = args[1:]
+	}
+	accesslog.Record(ss.Context(), string(execReq.Repo),
+		log.String("cmd", cmd),
+		log.Strings("args", args),
+	)
+
+	return gs.doExec(ss.Context(), &execReq, ss)
+}
+
+func (gs *grpcServerImpl) Archive(req *proto.ArchiveRequest, ss proto.GitserverService_ArchiveServer) error {
+	// Log which actor is accessing the repo.
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("treeish", req.GetTreeish()),
+		log.String("format", req.GetFormat()),
+		log.Strings("path", req.GetPathspecs()),
+	)
+
+	if err := checkSpecArgSafety(req.GetTreeish()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetRepo() == "" || req.GetFormat() == "" {
+		return status.Error(codes.InvalidArgument, "empty repo or format")
+	}
+
+	execReq := archiveExecRequest(api.RepoName(req.GetRepo()), req.GetTreeish(), req.GetFormat(), req.GetPathspecs())
+	return gs.doExec(ss.Context(), execReq, ss)
+}
+
+// doExec runs the given request and streams its output in chunks, followed by
+// the exit status of the command.
+func (gs *grpcServerImpl) doExec(ctx context.Context, req *protocol.ExecRequest, ss proto.GitserverService_ExecServer) error {
+	logger := gs.server.Logger.Scoped("exec", "").With(log.Strings("req.Args", req.Args))
+
+	var userAgent string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			userAgent = values[0]
+		}
+	}
+
+	w := &execOutputWriter{send: ss.Send}
+	execStatus, err := gs.server.execGit(ctx, logger, req, userAgent, w, func() {})
+	if err != nil {
+		var notFound *execNotFoundError
+		switch {
+		case errors.Is(err, errExecBlockedCommand):
+			var remoteAddr string
+			if p, ok := peer.FromContext(ctx); ok {
+				remoteAddr = p.Addr.String()
+			}
+			logger.Warn("exec: bad command", log.String("RemoteAddr", remoteAddr))
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.As(err, &notFound):
+			st, detailsErr := status.New(codes.NotFound, err.Error()).WithDetails(notFound.payload.ToProto(req.Repo))
+			if detailsErr != nil {
+				return detailsErr
+			}
+			return st.Err()
+		default:
+			return err
+		}
+	}
+	if w.err != nil {
+		// The client went away, there is no one left to report the status to.
+		return w.err
+	}
+
+	return ss.Send(&proto.ExecResponse{Payload: &proto.ExecResponse_Status{Status: &proto.ExecStatus{
+		ExitStatus: int32(execStatus.exitStatus),
+		Stderr:     execStatus.stderr,
+		Error:      errorString(execStatus.err),
+	}}})
+}
+
+// execOutputWriter sends writes as chunks of the output of a command. Writes
+// larger than protocol.GRPCChunkSize are split into several chunks.
+type execOutputWriter struct {
+	send func(*proto.ExecResponse) error
+	err  error
+}
+
+func (w *execOutputWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	var n int
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > protocol.GRPCChunkSize {
+			chunk = chunk[:protocol.GRPCChunkSize]
+		}
+		// Send marshals the message before returning, so chunk may be passed as is.
+		if w.err = w.send(&proto.ExecResponse{Payload: &proto.ExecResponse_Data{Data: chunk}}); w.err != nil {
+			return n, w.err
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+func (gs *grpcServerImpl) Search(req *proto.SearchRequest, ss proto.GitserverService_SearchServer) error {
+	var args protocol.SearchRequest
+	if err := args.FromProto(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if args.Query == nil {
+		return status.Error(codes.InvalidArgument, "missing query")
+	}
+
+	tr, ctx := trace.New(ss.Context(), "search", "")
+	defer tr.Finish()
+
+	matchesBuf := &grpcMatchesBuf{
+		flushSize:  64,
+		flushBytes: protocol.GRPCChunkSize,
+		send: func(matches []*proto.CommitMatch) error {
+			tr.AddEvent("flushing data", attribute.Int("matches.len", len(matches)))
+			return ss.Send(&proto.SearchResponse{Event: &proto.SearchResponse_Matches{Matches: &proto.CommitMatches{Matches: matches}}})
+		},
+	}
+
+	limitHit, searchErr := gs.server.searchWithObservability(ctx, tr, &args, matchesBuf)
+	return ss.Send(&proto.SearchResponse{Event: &proto.SearchResponse_Done{
+		Done: protocol.NewSearchEventDone(limitHit, searchErr).ToProto(),
+	}})
+}
+
+// grpcMatchesBuf is the searchMatchesBuf of the gRPC API. It sends matches once
+// flushSize matches or flushBytes bytes of matches are buffered, or when
+// flushed.
+type grpcMatchesBuf struct {
+	flushSize  int
+	flushBytes int
+	send       func([]*proto.CommitMatch) error
+	matches    []*proto.CommitMatch
+	size       int
+}
+
+func (b *grpcMatchesBuf) Append(v any) error {
+	match, ok := v.(*protocol.CommitMatch)
+	if !ok {
+		return errors.Newf("unexpected match type %T", v)
+	}
+	m := match.ToProto()
+	b.matches = append(b.matches, m)
+	b.size += protobuf.Size(m)
+	if len(b.matches) >= b.flushSize || b.size >= b.flushBytes {
+		return b.Flush()
+	}
+	return nil
+}
+
+func (b *grpcMatchesBuf) Flush() error {
+	if len(b.matches) == 0 {
+		return nil
+	}
+	matches := b.matches
+	b.matches = nil
+	b.size = 0
+	return b.send(matches)
+}
+
+func (gs *grpcServerImpl) BatchLog(req *proto.BatchLogRequest, ss proto.GitserverService_BatchLogServer) error {
+	var batchLogReq protocol.BatchLogRequest
+	batchLogReq.FromProto(req)
+
+	resp, err := gs.server.batchGitLog(ss.Context(), batchLogReq)
+	if err != nil {
+		if errors.Is(err, errBatchLogFormat) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return err
+	}
+
+	// The output of git log is not bounded in size, so the results are sent in
+	// batches of about protocol.GRPCChunkSize bytes.
+	var batch []*proto.BatchLogResult
+	var batchSize int
+	for _, result := range resp.ToProto().GetResults() {
+		size := protobuf.Size(result)
+		if len(batch) > 0 && batchSize+size > protocol.GRPCChunkSize {
+			if err := ss.Send(&proto.BatchLogResponse{Results: batch}); err != nil {
+				return err
+			}
+			batch, batchSize = nil, 0
+		}
+		batch = append(batch, result)
+		batchSize += size
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return ss.Send(&proto.BatchLogResponse{Results: batch})
+}
+
+func (gs *grpcServerImpl) RepoUpdate(ctx context.Context, req *proto.RepoUpdateRequest) (*proto.RepoUpdateResponse, error) {
+	var repoUpdateReq protocol.RepoUpdateRequest
+	repoUpdateReq.FromProto(req)
+
+	resp := gs.server.repoUpdate(&repoUpdateReq)
+	return resp.ToProto(), nil
+}
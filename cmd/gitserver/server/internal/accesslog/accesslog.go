@@ -1,5 +1,5 @@
 // accesslog provides instrumentation to record logs of access made by a given actor to a repo at
-// the http handler level.
+// the http handler and gRPC server level.
 // access logs may optionally (as per site configuration) be included in the audit log.
 package accesslog
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/log"
 	"go.uber.org/atomic"
+	"google.golang.org/grpc"
 
 	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -46,83 +47,110 @@ func fromContext(ctx context.Context) *paramsContext {
 	return pc
 }
 
-// accessLogger handles HTTP requests and, if logEnabled, logs accesses.
+// accessLogger logs the accesses recorded in the context of requests, if
+// logEnabled.
 type accessLogger struct {
 	logger     log.Logger
-	next       http.HandlerFunc
 	logEnabled *atomic.Bool
 }
 
-var _ http.Handler = &accessLogger{}
-
 // messages are defined here to make assertions in testing.
 const (
 	accessEventMessage          = "access"
 	accessLoggingEnabledMessage = "access logging enabled"
 )
 
-func (a *accessLogger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Prepare the context to hold the params which the handler is going to set.
-	ctx := r.Context()
-	r = r.WithContext(withContext(ctx, &paramsContext{}))
-	a.next(w, r)
-
-	// If access logging is not enabled, we are done
-	if !a.logEnabled.Load() {
-		return
+func newAccessLogger(logger log.Logger, watcher conftypes.WatchableSiteConfig) *accessLogger {
+	a := &accessLogger{
+		logger:     logger,
+		logEnabled: atomic.NewBool(audit.IsEnabled(watcher.SiteConfig(), audit.GitserverAccess)),
+	}
+	if a.logEnabled.Load() {
+		logger.Info(accessLoggingEnabledMessage)
 	}
 
-	// Otherwise, log this access
-	var fields []log.Field
+	// Allow live toggling of access logging
+	watcher.Watch(func() {
+		newShouldLog := audit.IsEnabled(watcher.SiteConfig(), audit.GitserverAccess)
+		changed := a.logEnabled.Swap(newShouldLog) != newShouldLog
+		if changed {
+			if newShouldLog {
+				logger.Info(accessLoggingEnabledMessage)
+			} else {
+				logger.Info("access logging disabled")
+			}
+		}
+	})
 
-	// Now we've gone through the handler, we can get the params that the handler
-	// got from the request body.
-	paramsCtx := fromContext(r.Context())
-	if paramsCtx == nil {
+	return a
+}
+
+// serve prepares the context to hold the params which next is going to set, and
+// logs the access once next returns.
+func (a *accessLogger) serve(ctx context.Context, next func(context.Context)) {
+	pc := &paramsContext{}
+	next(withContext(ctx, pc))
+
+	// If access logging is not enabled, we are done
+	if !a.logEnabled.Load() {
 		return
 	}
-	if paramsCtx.repo == "" {
+	if pc.repo == "" {
 		return
 	}
 
-	if paramsCtx != nil {
-		params := append([]log.Field{log.String("repo", paramsCtx.repo)}, paramsCtx.metadata...)
-		fields = append(fields, log.Object("params", params...))
-	} else {
-		fields = append(fields, log.String("params", "nil"))
-	}
-
+	// Otherwise, log this access
+	params := append([]log.Field{log.String("repo", pc.repo)}, pc.metadata...)
 	audit.Log(ctx, a.logger, audit.Record{
 		Entity: "gitserver",
 		Action: "access",
-		Fields: fields,
+		Fields: []log.Field{log.Object("params", params...)},
 	})
 }
 
 // HTTPMiddleware will extract actor information and params collected by Record that has
 // been stored in the context, in order to log a trace of the access.
 func HTTPMiddleware(logger log.Logger, watcher conftypes.WatchableSiteConfig, next http.HandlerFunc) http.HandlerFunc {
-	handler := &accessLogger{
-		logger:     logger,
-		next:       next,
-		logEnabled: atomic.NewBool(audit.IsEnabled(watcher.SiteConfig(), audit.GitserverAccess)),
-	}
-	if handler.logEnabled.Load() {
-		logger.Info(accessLoggingEnabledMessage)
+	a := newAccessLogger(logger, watcher)
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.serve(r.Context(), func(ctx context.Context) {
+			next(w, r.WithContext(ctx))
+		})
 	}
+}
 
-	// Allow live toggling of access logging
-	watcher.Watch(func() {
-		newShouldLog := audit.IsEnabled(watcher.SiteConfig(), audit.GitserverAccess)
-		changed := handler.logEnabled.Swap(newShouldLog) != newShouldLog
-		if changed {
-			if newShouldLog {
-				logger.Info(accessLoggingEnabledMessage)
-			} else {
-				logger.Info("access logging disabled")
-			}
-		}
+// GRPCAccessLogger is the counterpart of HTTPMiddleware for gRPC servers. Its
+// interceptors log the params collected by Record during requests.
+type GRPCAccessLogger struct {
+	a *accessLogger
+}
+
+func NewGRPCAccessLogger(logger log.Logger, watcher conftypes.WatchableSiteConfig) *GRPCAccessLogger {
+	return &GRPCAccessLogger{a: newAccessLogger(logger, watcher)}
+}
+
+// UnaryServerInterceptor is a grpc.UnaryServerInterceptor.
+func (l *GRPCAccessLogger) UnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	l.a.serve(ctx, func(ctx context.Context) {
+		resp, err = handler(ctx, req)
 	})
+	return resp, err
+}
+
+// StreamServerInterceptor is a grpc.StreamServerInterceptor.
+func (l *GRPCAccessLogger) StreamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	l.a.serve(ss.Context(), func(ctx context.Context) {
+		err = handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx})
+	})
+	return err
+}
+
+// serverStreamWithContext overrides the context of a grpc.ServerStream.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-	return handler.ServeHTTP
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
 }
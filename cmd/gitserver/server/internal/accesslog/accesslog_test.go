@@ -10,9 +10,11 @@ import (
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -143,3 +145,62 @@ func TestHTTPMiddleware(t *testing.T) {
 		assert.Contains(t, logs[1].Message, accessEventMessage)
 	})
 }
+
+func TestGRPCAccessLogger(t *testing.T) {
+	t.Run("unary", func(t *testing.T) {
+		logger, exportLogs := logtest.Captured(t)
+		l := NewGRPCAccessLogger(logger, &accessLogConf{})
+
+		resp, err := l.UnaryServerInterceptor(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			Record(ctx, "github.com/foo/bar", log.String("cmd", "git"), log.String("args", "grep foo"))
+			return "resp", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "resp", resp)
+
+		logs := exportLogs()
+		require.Len(t, logs, 2)
+		assert.Equal(t, accessLoggingEnabledMessage, logs[0].Message)
+		assert.Contains(t, logs[1].Message, accessEventMessage)
+		assert.Equal(t, "github.com/foo/bar", logs[1].Fields["params"].(map[string]any)["repo"])
+	})
+
+	t.Run("stream, no recording", func(t *testing.T) {
+		logger, exportLogs := logtest.Captured(t)
+		l := NewGRPCAccessLogger(logger, &accessLogConf{})
+
+		wantErr := errors.New("boom")
+		err := l.StreamServerInterceptor(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+			return wantErr
+		})
+		assert.Equal(t, wantErr, err)
+
+		// Should have handled but not logged
+		logs := exportLogs()
+		require.Len(t, logs, 1)
+		assert.NotEqual(t, accessEventMessage, logs[0].Message)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		logger, exportLogs := logtest.Captured(t)
+		l := NewGRPCAccessLogger(logger, &accessLogConf{})
+
+		err := l.StreamServerInterceptor(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+			Record(ss.Context(), "github.com/foo/bar", log.String("cmd", "git"), log.String("args", "grep foo"))
+			return nil
+		})
+		require.NoError(t, err)
+
+		logs := exportLogs()
+		require.Len(t, logs, 2)
+		assert.Contains(t, logs[1].Message, accessEventMessage)
+		assert.Equal(t, "github.com/foo/bar", logs[1].Fields["params"].(map[string]any)["repo"])
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
@@ -1014,12 +1014,25 @@ func (s *Server) handleIsRepoCloneable(w http.ResponseWriter, r *http.Request) {
 // unconditional; we debounce them based on the provided
 // interval, to avoid spam.
 func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
-	logger := s.Logger.Scoped("handleRepoUpdate", "synchronous http handler for repo updates")
 	var req protocol.RepoUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+
+	resp := s.repoUpdate(&req)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// repoUpdate updates or clones the repository of the given request. Errors are
+// reported in-band in the returned response. It is shared by the HTTP and the
+// gRPC API.
+func (s *Server) repoUpdate(req *protocol.RepoUpdateRequest) protocol.RepoUpdateResponse {
+	logger := s.Logger.Scoped("repoUpdate", "synchronous handler for repo updates")
 	var resp protocol.RepoUpdateResponse
 	req.Repo = protocol.NormalizeRepo(req.Repo)
 	dir := s.dir(req.Repo)
@@ -1077,10 +1090,7 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	return resp
 }
 
 // handleRepoClone is an asynchronous (does not wait for update to complete or
@@ -1137,8 +1147,14 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.exec(w, r, archiveExecRequest(api.RepoName(repo), treeish, format, pathspecs))
+}
+
+// archiveExecRequest returns the request to execute `git archive` for the given
+// tree of a repository.
+func archiveExecRequest(repo api.RepoName, treeish, format string, pathspecs []string) *protocol.ExecRequest {
 	req := &protocol.ExecRequest{
-		Repo: api.RepoName(repo),
+		Repo: repo,
 		Args: []string{
 			"archive",
 
@@ -1163,7 +1179,7 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	req.Args = append(req.Args, treeish, "--")
 	req.Args = append(req.Args, pathspecs...)
 
-	s.exec(w, r, req)
+	return req
 }
 
 func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
@@ -1178,21 +1194,6 @@ func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	tr.SetAttributes(
-		attribute.String("repo", string(args.Repo)),
-		attribute.Bool("include_diff", args.IncludeDiff),
-		attribute.String("query", args.Query.String()),
-		attribute.Int("limit", args.Limit),
-		attribute.Bool("include_modified_files", args.IncludeModifiedFiles),
-	)
-
-	searchStart := time.Now()
-	searchRunning.Inc()
-	defer searchRunning.Dec()
-
-	observeLatency := syncx.OnceFunc(func() {
-		searchLatency.Observe(time.Since(searchStart).Seconds())
-	})
 
 	eventWriter, err := streamhttp.NewWriter(w)
 	if err != nil {
@@ -1203,17 +1204,61 @@ func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 
 	matchesBuf := streamhttp.NewJSONArrayBuf(8*1024, func(data []byte) error {
 		tr.AddEvent("flushing data", attribute.Int("data.len", len(data)))
-		observeLatency()
 		return eventWriter.EventBytes("matches", data)
 	})
 
 	// Run the search
-	limitHit, searchErr := s.search(ctx, &args, matchesBuf)
+	limitHit, searchErr := s.searchWithObservability(ctx, tr, &args, matchesBuf)
 	if writeErr := eventWriter.Event("done", protocol.NewSearchEventDone(limitHit, searchErr)); writeErr != nil {
 		if !errors.Is(writeErr, syscall.EPIPE) {
 			logger.Error("failed to send done event", log.Error(writeErr))
 		}
 	}
+}
+
+// searchMatchesBuf buffers the matches of a search until they are flushed to
+// the client.
+type searchMatchesBuf interface {
+	Append(any) error
+	Flush() error
+}
+
+// latencyObservingMatchesBuf records the latency of a search once its first
+// match is appended.
+type latencyObservingMatchesBuf struct {
+	searchMatchesBuf
+	observeLatency func()
+}
+
+func (b *latencyObservingMatchesBuf) Append(v any) error {
+	b.observeLatency()
+	return b.searchMatchesBuf.Append(v)
+}
+
+// searchWithObservability runs search and records its traces, metrics and
+// events. It is shared by the HTTP and the gRPC API.
+func (s *Server) searchWithObservability(ctx context.Context, tr *trace.Trace, args *protocol.SearchRequest, matchesBuf searchMatchesBuf) (bool, error) {
+	logger := s.Logger.Scoped("searchWithObservability", "full observability for a search")
+	tr.SetAttributes(
+		attribute.String("repo", string(args.Repo)),
+		attribute.Bool("include_diff", args.IncludeDiff),
+		attribute.String("query", args.Query.String()),
+		attribute.Int("limit", args.Limit),
+		attribute.Bool("include_modified_files", args.IncludeModifiedFiles),
+	)
+
+	searchStart := time.Now()
+	searchRunning.Inc()
+	defer searchRunning.Dec()
+
+	matchesBuf = &latencyObservingMatchesBuf{
+		searchMatchesBuf: matchesBuf,
+		observeLatency: syncx.OnceFunc(func() {
+			searchLatency.Observe(time.Since(searchStart).Seconds())
+		}),
+	}
+
+	limitHit, searchErr := s.search(ctx, args, matchesBuf)
 	tr.AddEvent("done", attribute.Bool("limit_hit", limitHit))
 	tr.SetError(searchErr)
 	searchDuration.
@@ -1246,11 +1291,13 @@ func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 			logger.Debug("TRACE gitserver search", log.Object("ev.Fields", mapToLoggerField(ev.Fields())...))
 		}
 	}
+
+	return limitHit, searchErr
 }
 
 // search handles the core logic of the search. It is passed a matchesBuf so it doesn't need to
 // concern itself with event types, and all instrumentation is handled in the calling function.
-func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, matchesBuf *streamhttp.JSONArrayBuf) (limitHit bool, err error) {
+func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, matchesBuf searchMatchesBuf) (limitHit bool, err error) {
 	args.Repo = protocol.NormalizeRepo(args.Repo)
 	if args.Limit == 0 {
 		args.Limit = math.MaxInt32
@@ -1402,6 +1449,34 @@ func (s *Server) handleBatchLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read request body
+	var req protocol.BatchLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Handle unexpected error conditions. We expect batchGitLog to not have
+	// produced a response if this error value is non-nil.
+	resp, err := s.batchGitLog(r.Context(), req)
+	if err != nil {
+		statusCodeOnError := http.StatusInternalServerError
+		if errors.Is(err, errBatchLogFormat) {
+			statusCodeOnError = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), statusCodeOnError)
+		return
+	}
+
+	// Write payload to client: implicitly writes 200 OK
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+var errBatchLogFormat = errors.New("format parameter expected to be of the form `--format=<git log format>`")
+
+// batchGitLog runs `git log` for each of the repository and commit pairs of the
+// given request. It is shared by the HTTP and the gRPC API.
+func (s *Server) batchGitLog(ctx context.Context, req protocol.BatchLogRequest) (_ protocol.BatchLogResponse, err error) {
 	operations := s.ensureOperations()
 
 	// Run git log for a single repository.
@@ -1445,94 +1520,71 @@ func (s *Server) handleBatchLog(w http.ResponseWriter, r *http.Request) {
 		return buf.String(), true, nil
 	}
 
-	// Handles the /batch-log route
-	instrumentedHandler := func(ctx context.Context) (statusCodeOnError int, err error) {
-		ctx, logger, endObservation := operations.batchLog.With(ctx, &err, observation.Args{})
-		defer func() {
-			endObservation(1, observation.Args{LogFields: []otlog.Field{
-				otlog.Int("statusCodeOnError", statusCodeOnError),
-			}})
-		}()
+	ctx, logger, endObservation := operations.batchLog.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	logger.AddEvent("read request.body", req.SpanAttributes()...)
 
-		// Read request body
-		var req protocol.BatchLogRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			return http.StatusBadRequest, err
-		}
-		logger.AddEvent("read request.body", req.SpanAttributes()...)
+	// Validate request parameters
+	if len(req.RepoCommits) == 0 {
+		// Early exit
+		return protocol.BatchLogResponse{Results: []protocol.BatchLogResult{}}, nil
+	}
+	if !strings.HasPrefix(req.Format, "--format=") {
+		return protocol.BatchLogResponse{}, errBatchLogFormat
+	}
 
-		// Validate request parameters
-		if len(req.RepoCommits) == 0 {
-			// Early exit: implicitly writes 200 OK
-			_ = json.NewEncoder(w).Encode(protocol.BatchLogResponse{Results: []protocol.BatchLogResult{}})
-			return 0, nil
-		}
-		if !strings.HasPrefix(req.Format, "--format=") {
-			return http.StatusUnprocessableEntity, errors.New("format parameter expected to be of the form `--format=<git log format>`")
-		}
+	// Perform requests in each repository in the input batch. We perform these commands
+	// concurrently, but only allow for so many commands to be in-flight at a time so that
+	// we don't overwhelm a shard with either a large request or too many concurrent batch
+	// requests.
 
-		// Perform requests in each repository in the input batch. We perform these commands
-		// concurrently, but only allow for so many commands to be in-flight at a time so that
-		// we don't overwhelm a shard with either a large request or too many concurrent batch
-		// requests.
+	g, ctx := errgroup.WithContext(ctx)
+	results := make([]protocol.BatchLogResult, len(req.RepoCommits))
 
-		g, ctx := errgroup.WithContext(ctx)
-		results := make([]protocol.BatchLogResult, len(req.RepoCommits))
+	if s.GlobalBatchLogSemaphore == nil {
+		return protocol.BatchLogResponse{}, errors.New("s.GlobalBatchLogSemaphore not initialized")
+	}
 
-		if s.GlobalBatchLogSemaphore == nil {
-			return http.StatusInternalServerError, errors.New("s.GlobalBatchLogSemaphore not initialized")
+	for i, repoCommit := range req.RepoCommits {
+		// Avoid capture of loop variables
+		i, repoCommit := i, repoCommit
+
+		start := time.Now()
+		if err := s.GlobalBatchLogSemaphore.Acquire(ctx, 1); err != nil {
+			return protocol.BatchLogResponse{}, err
 		}
+		s.operations.batchLogSemaphoreWait.Observe(time.Since(start).Seconds())
 
-		for i, repoCommit := range req.RepoCommits {
-			// Avoid capture of loop variables
-			i, repoCommit := i, repoCommit
+		g.Go(func() error {
+			defer s.GlobalBatchLogSemaphore.Release(1)
 
-			start := time.Now()
-			if err := s.GlobalBatchLogSemaphore.Acquire(ctx, 1); err != nil {
-				return http.StatusInternalServerError, err
+			output, isRepoCloned, err := performGitLogCommand(ctx, repoCommit, req.Format)
+			if err == nil && !isRepoCloned {
+				err = errors.Newf("repo not found")
+			}
+			var errMessage string
+			if err != nil {
+				errMessage = err.Error()
 			}
-			s.operations.batchLogSemaphoreWait.Observe(time.Since(start).Seconds())
-
-			g.Go(func() error {
-				defer s.GlobalBatchLogSemaphore.Release(1)
-
-				output, isRepoCloned, err := performGitLogCommand(ctx, repoCommit, req.Format)
-				if err == nil && !isRepoCloned {
-					err = errors.Newf("repo not found")
-				}
-				var errMessage string
-				if err != nil {
-					errMessage = err.Error()
-				}
-
-				// Concurrently write results to shared slice. This slice is already properly
-				// sized, and each goroutine writes to a unique index exactly once. There should
-				// be no data race conditions possible here.
-
-				results[i] = protocol.BatchLogResult{
-					RepoCommit:    repoCommit,
-					CommandOutput: output,
-					CommandError:  errMessage,
-				}
-				return nil
-			})
-		}
 
-		if err := g.Wait(); err != nil {
-			return http.StatusInternalServerError, err
-		}
+			// Concurrently write results to shared slice. This slice is already properly
+			// sized, and each goroutine writes to a unique index exactly once. There should
+			// be no data race conditions possible here.
 
-		// Write payload to client: implicitly writes 200 OK
-		_ = json.NewEncoder(w).Encode(protocol.BatchLogResponse{Results: results})
-		return 0, nil
+			results[i] = protocol.BatchLogResult{
+				RepoCommit:    repoCommit,
+				CommandOutput: output,
+				CommandError:  errMessage,
+			}
+			return nil
+		})
 	}
 
-	// Handle unexpected error conditions. We expect the instrumented handler to not
-	// have written the status code or any of the body if this error value is non-nil.
-	if statusCodeOnError, err := instrumentedHandler(r.Context()); err != nil {
-		http.Error(w, err.Error(), statusCodeOnError)
-		return
+	if err := g.Wait(); err != nil {
+		return protocol.BatchLogResponse{}, err
 	}
+
+	return protocol.BatchLogResponse{Results: results}, nil
 }
 
 // ensureOperations returns the non-nil operations value supplied to this server
@@ -1590,19 +1642,77 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		defer fw.Close()
 	}
 
+	status, err := s.execGit(r.Context(), logger, req, r.UserAgent(), w, func() {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+
+		w.Header().Set("Trailer", "X-Exec-Error")
+		w.Header().Add("Trailer", "X-Exec-Exit-Status")
+		w.Header().Add("Trailer", "X-Exec-Stderr")
+		w.WriteHeader(http.StatusOK)
+	})
+	if err != nil {
+		var notFound *execNotFoundError
+		switch {
+		case errors.Is(err, errExecBlockedCommand):
+			logger.Warn("exec: bad command", log.String("RemoteAddr", r.RemoteAddr))
+
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid command"))
+		case errors.As(err, &notFound):
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(notFound.payload)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// write trailer
+	w.Header().Set("X-Exec-Error", errorString(status.err))
+	w.Header().Set("X-Exec-Exit-Status", strconv.Itoa(status.exitStatus))
+	w.Header().Set("X-Exec-Stderr", status.stderr)
+}
+
+// errExecBlockedCommand is returned by execGit for commands that are not in the
+// allowed list.
+var errExecBlockedCommand = errors.New("invalid command")
+
+// execNotFoundError is returned by execGit if the repository is not cloned.
+type execNotFoundError struct {
+	payload *protocol.NotFoundPayload
+}
+
+func (e *execNotFoundError) Error() string {
+	if e.payload.CloneInProgress {
+		return "repository clone in progress"
+	}
+	return "repository not found"
+}
+
+// execStatus describes how a command run by execGit terminated.
+type execStatus struct {
+	exitStatus int
+	stderr     string
+	err        error
+}
+
+// execGit runs the git command described by req and writes its standard output
+// to w. The given onStart function is invoked right before the first write to w.
+// It is shared by the HTTP and the gRPC API, which differ only in how they
+// frame the output and the returned status.
+//
+// A non-nil error is returned if the command was not run at all, in which case
+// nothing was written to w. Errors of the command itself are reported through
+// the returned execStatus.
+func (s *Server) execGit(ctx context.Context, logger log.Logger, req *protocol.ExecRequest, userAgent string, w io.Writer, onStart func()) (execStatus, error) {
 	// 🚨 SECURITY: Ensure that only commands in the allowed list are executed.
 	// See https://github.com/sourcegraph/security-issues/issues/213.
 	if !gitdomain.IsAllowedGitCmd(logger, req.Args) {
 		blockedCommandExecutedCounter.Inc()
-		logger.Warn("exec: bad command", log.String("RemoteAddr", r.RemoteAddr))
-
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("invalid command"))
-		return
+		return execStatus{}, errExecBlockedCommand
 	}
 
-	ctx := r.Context()
-
 	if !req.NoTimeout {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, shortGitCommandTimeout(req.Args))
@@ -1670,7 +1780,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 				ev.AddField("actor", act.UIDString())
 				ev.AddField("ensure_revision", req.EnsureRevision)
 				ev.AddField("ensure_revision_status", ensureRevisionStatus)
-				ev.AddField("client", r.UserAgent())
+				ev.AddField("client", userAgent)
 				ev.AddField("duration_ms", duration.Milliseconds())
 				ev.AddField("stdin_size", len(req.Stdin))
 				ev.AddField("stdout_size", stdoutN)
@@ -1713,9 +1823,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		} else {
 			status = "repo-not-found"
 		}
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(notFoundPayload)
-		return
+		return execStatus{}, &execNotFoundError{payload: notFoundPayload}
 	}
 
 	dir := s.dir(req.Repo)
@@ -1723,13 +1831,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		ensureRevisionStatus = "fetched"
 	}
 
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Cache-Control", "no-cache")
-
-	w.Header().Set("Trailer", "X-Exec-Error")
-	w.Header().Add("Trailer", "X-Exec-Exit-Status")
-	w.Header().Add("Trailer", "X-Exec-Stderr")
-	w.WriteHeader(http.StatusOK)
+	onStart()
 
 	// Special-case `git rev-parse HEAD` requests. These are invoked by search queries for every repo in scope.
 	// For searches over large repo sets (> 1k), this leads to too many child process execs, which can lead
@@ -1737,10 +1839,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	if len(req.Args) == 2 && req.Args[0] == "rev-parse" && req.Args[1] == "HEAD" {
 		if resolved, err := quickRevParseHead(dir); err == nil && isAbsoluteRevision(resolved) {
 			_, _ = w.Write([]byte(resolved))
-			w.Header().Set("X-Exec-Error", "")
-			w.Header().Set("X-Exec-Exit-Status", "0")
-			w.Header().Set("X-Exec-Stderr", "")
-			return
+			return execStatus{}, nil
 		}
 	}
 	// Special-case `git symbolic-ref HEAD` requests. These are invoked by resolvers determining the default branch of a repo.
@@ -1749,10 +1848,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	if len(req.Args) == 2 && req.Args[0] == "symbolic-ref" && req.Args[1] == "HEAD" {
 		if resolved, err := quickSymbolicRefHead(dir); err == nil {
 			_, _ = w.Write([]byte(resolved))
-			w.Header().Set("X-Exec-Error", "")
-			w.Header().Set("X-Exec-Exit-Status", "0")
-			w.Header().Set("X-Exec-Stderr", "")
-			return
+			return execStatus{}, nil
 		}
 	}
 
@@ -1776,10 +1872,11 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	stderr := stderrBuf.String()
 	s.logIfCorrupt(ctx, req.Repo, dir, stderr)
 
-	// write trailer
-	w.Header().Set("X-Exec-Error", errorString(execErr))
-	w.Header().Set("X-Exec-Exit-Status", status)
-	w.Header().Set("X-Exec-Stderr", stderr)
+	return execStatus{
+		exitStatus: exitStatus,
+		stderr:     stderr,
+		err:        execErr,
+	}, nil
 }
 
 func (s *Server) handleP4Exec(w http.ResponseWriter, r *http.Request) {
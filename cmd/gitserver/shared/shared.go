@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -43,6 +44,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/httpserver"
 	"github.com/sourcegraph/sourcegraph/internal/instrumentation"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
@@ -119,6 +121,7 @@ func Main(enterpriseInit EnterpriseInit) {
 		logger.Fatal("failed to initialize database stores", log.Error(err))
 	}
 	db := database.NewDB(logger, sqlDB)
+	audit.RegisterStore(db.AuditLogs())
 
 	repoStore := db.Repos()
 	dependenciesSvc := dependencies.NewService(observationCtx, db)
@@ -205,14 +208,33 @@ func Main(enterpriseInit EnterpriseInit) {
 		Addr:    addr,
 		Handler: handler,
 	}
+	grpcServer := server.NewGRPCServer(&gitserver)
+
+	// The gRPC API shares the port of the HTTP API. Clients switch between the
+	// two based on the experimentalFeatures.enableGRPC site setting.
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Fatal("failed to listen", log.String("addr", addr), log.Error(err))
+	}
+	mux := httpserver.NewGRPCListenerMux(listener)
 	logger.Info("git-server: listening", log.String("addr", srv.Addr))
 
 	go func() {
-		err := srv.ListenAndServe()
+		err := srv.Serve(mux.HTTPListener())
 		if err != http.ErrServerClosed {
 			logger.Fatal(err.Error())
 		}
 	}()
+	go func() {
+		if err := grpcServer.Serve(mux.GRPCListener()); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}()
+	go func() {
+		if err := mux.Serve(); err != nil && !errors.Is(err, net.ErrClosed) {
+			logger.Fatal(err.Error())
+		}
+	}()
 
 	// Listen for shutdown signals. When we receive one attempt to clean up,
 	// but do an insta-shutdown if we receive more than one signal.
@@ -235,6 +257,18 @@ func Main(enterpriseInit EnterpriseInit) {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("shutting down http server", log.Error(err))
 	}
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		// Cancel the gRPC requests that are still running.
+		grpcServer.Stop()
+	}
+	_ = mux.Close()
 
 	// The most important thing this does is kill all our clones. If we just
 	// shutdown they will be orphaned and continue running.
@@ -0,0 +1,888 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for a type qualified by a package name (e.g. `sub.T`)
+		if parent := node.Parent(); parent != nil && parent.Type() == "qualified_type" {
+			name := parent.ChildByFieldName("name")
+			pkg := parent.ChildByFieldName("package")
+			if name != nil && pkg != nil && nodeId(name) == nodeId(node.Node) {
+				return squirrel.getDefInPackageNamedGo(ctx, swapNode(node, pkg), ident)
+			}
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefGo: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.getDefInFileOrPackageGo(ctx, swapNode(node, cur), ident)
+
+			case "block":
+				// Declarations are only visible after they appear in a block
+				for blockChild := prev.PrevNamedSibling(); blockChild != nil; blockChild = blockChild.PrevNamedSibling() {
+					if found := findDeclGo(swapNode(node, blockChild), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "function_declaration":
+				fallthrough
+			case "method_declaration":
+				fallthrough
+			case "func_literal":
+				for _, field := range []string{"receiver", "type_parameters", "parameters", "result"} {
+					params := cur.ChildByFieldName(field)
+					if params == nil {
+						continue
+					}
+					if found := findParamGo(swapNode(node, params), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, child := range children(cur) {
+					switch child.Type() {
+					case "range_clause":
+						left := child.ChildByFieldName("left")
+						if left == nil {
+							continue
+						}
+						for _, name := range children(left) {
+							if name.Type() == "identifier" && name.Content(node.Contents) == ident {
+								return swapNodePtr(node, name), nil
+							}
+						}
+					case "for_clause":
+						initializer := child.ChildByFieldName("initializer")
+						if initializer == nil {
+							continue
+						}
+						if found := findDeclGo(swapNode(node, initializer), ident); found != nil {
+							return found, nil
+						}
+					}
+				}
+				continue
+
+			case "if_statement":
+				fallthrough
+			case "expression_switch_statement":
+				fallthrough
+			case "type_switch_statement":
+				if alias := cur.ChildByFieldName("alias"); alias != nil {
+					for _, name := range children(alias) {
+						if name.Type() == "identifier" && name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				if initializer := cur.ChildByFieldName("initializer"); initializer != nil {
+					if found := findDeclGo(swapNode(node, initializer), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "communication_case":
+				communication := cur.ChildByFieldName("communication")
+				if communication == nil || communication.Type() != "receive_statement" {
+					continue
+				}
+				left := communication.ChildByFieldName("left")
+				if left == nil {
+					continue
+				}
+				for _, name := range children(left) {
+					if name.Type() == "identifier" && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "field_identifier":
+		ident := node.Content(node.Contents)
+
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "selector_expression":
+			operand := parent.ChildByFieldName("operand")
+			if operand == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldGo(ctx, swapNode(node, operand), ident)
+
+		case "keyed_element":
+			// Find the type of the composite literal containing this key
+			cur := parent.Parent()
+			for cur != nil && cur.Type() != "composite_literal" {
+				cur = cur.Parent()
+			}
+			if cur == nil {
+				return nil, nil
+			}
+			ty := cur.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldGo(ctx, swapNode(node, ty), ident)
+
+		case "field_declaration":
+			fallthrough
+		case "method_declaration":
+			fallthrough
+		case "method_spec":
+			return &node, nil
+
+		default:
+			return nil, nil
+		}
+
+	case "package_identifier":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "qualified_type" {
+			return nil, nil
+		}
+		program := swapNode(node, getRoot(node.Node))
+		return squirrel.getDefInImportsGo(ctx, program, node.Content(node.Contents))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// findDeclGo returns the name declared by the given declaration or statement, if any.
+func findDeclGo(decl Node, ident string) *Node {
+	switch decl.Type() {
+	case "short_var_declaration":
+		left := decl.ChildByFieldName("left")
+		if left == nil {
+			return nil
+		}
+		for _, name := range children(left) {
+			if name.Type() == "identifier" && name.Content(decl.Contents) == ident {
+				return swapNodePtr(decl, name)
+			}
+		}
+	case "var_declaration":
+		fallthrough
+	case "const_declaration":
+		for _, spec := range children(decl.Node) {
+			if spec.Type() == "var_spec_list" {
+				if found := findDeclGo(swapNode(decl, spec), ident); found != nil {
+					return found
+				}
+				continue
+			}
+			if spec.Type() != "var_spec" && spec.Type() != "const_spec" {
+				continue
+			}
+			// Names are the only identifiers that are direct children of a spec
+			for _, name := range children(spec) {
+				if name.Type() == "identifier" && name.Content(decl.Contents) == ident {
+					return swapNodePtr(decl, name)
+				}
+			}
+		}
+	case "var_spec_list":
+		return findDeclGo(swapNode(decl, decl.Parent()), ident)
+	case "type_declaration":
+		for _, spec := range children(decl.Node) {
+			if spec.Type() != "type_spec" && spec.Type() != "type_alias" {
+				continue
+			}
+			name := spec.ChildByFieldName("name")
+			if name != nil && name.Content(decl.Contents) == ident {
+				return swapNodePtr(decl, name)
+			}
+		}
+	case "function_declaration":
+		name := decl.ChildByFieldName("name")
+		if name != nil && name.Content(decl.Contents) == ident {
+			return swapNodePtr(decl, name)
+		}
+	}
+
+	return nil
+}
+
+// findParamGo returns the parameter of the given parameter list with the given name, if any.
+func findParamGo(params Node, ident string) *Node {
+	for _, param := range children(params.Node) {
+		switch param.Type() {
+		case "parameter_declaration":
+			fallthrough
+		case "variadic_parameter_declaration":
+			fallthrough
+		case "type_parameter_declaration":
+			for _, name := range children(param) {
+				if name.Type() == "identifier" && name.Content(params.Contents) == ident {
+					return swapNodePtr(params, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getDefInFileOrPackageGo looks for a top-level declaration in the given file, then for a package
+// imported by the file, and finally for a declaration in another file of the same package.
+func (squirrel *SquirrelService) getDefInFileOrPackageGo(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(program.Node) {
+		if found := findDeclGo(swapNode(program, child), ident); found != nil {
+			return found, nil
+		}
+	}
+
+	found, err := squirrel.getDefInImportsGo(ctx, program, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	found, err = squirrel.symbolSearchOne(
+		ctx,
+		program.RepoCommitPath.Repo,
+		program.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(filepath.Dir(program.RepoCommitPath.Path))},
+		ident,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Check packages imported with a dot (e.g. `import . "foo"`)
+	for _, imp := range getImportsGo(program) {
+		if imp.name != "." {
+			continue
+		}
+		dir, err := squirrel.getImportDirGo(ctx, program, imp.path)
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			continue
+		}
+		found, err := squirrel.symbolSearchOne(
+			ctx,
+			program.RepoCommitPath.Repo,
+			program.RepoCommitPath.Commit,
+			[]string{packageFilesPatternGo(*dir)},
+			ident,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getDefInImportsGo returns the directory of the package imported under the given name, if it is
+// located in the same repository.
+func (squirrel *SquirrelService) getDefInImportsGo(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, imp := range getImportsGo(program) {
+		if imp.name != ident {
+			continue
+		}
+		dir, err := squirrel.getImportDirGo(ctx, program, imp.path)
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			return nil, nil
+		}
+		return &Node{
+			RepoCommitPath: types.RepoCommitPath{
+				Repo:   program.RepoCommitPath.Repo,
+				Commit: program.RepoCommitPath.Commit,
+				Path:   *dir,
+			},
+			Node:     nil,
+			Contents: program.Contents,
+			LangSpec: program.LangSpec,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// getDefInPackageNamedGo finds the declaration with the given name in the package imported under the
+// name of the given package identifier.
+func (squirrel *SquirrelService) getDefInPackageNamedGo(ctx context.Context, pkg Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(pkg, &Tuple{String(pkg.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	dir, err := squirrel.getDefInImportsGo(ctx, swapNode(pkg, getRoot(pkg.Node)), pkg.Content(pkg.Contents))
+	if err != nil {
+		return nil, err
+	}
+	if dir == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldGo(ctx, PkgTypeGo{dir: dir.RepoCommitPath.Path, noad: pkg}, ident)
+}
+
+type importGo struct {
+	name string
+	path string
+}
+
+// getImportsGo returns the imports of the given file along with the names they are imported under.
+func getImportsGo(program Node) []importGo {
+	imports := []importGo{}
+	walkFilter(program.Node, func(node *sitter.Node) bool {
+		switch node.Type() {
+		case "source_file", "import_declaration", "import_spec_list":
+			return true
+		case "import_spec":
+			path := node.ChildByFieldName("path")
+			if path == nil {
+				return false
+			}
+			importPath := strings.Trim(path.Content(program.Contents), "\"`")
+			name := defaultPackageNameGo(importPath)
+			if alias := node.ChildByFieldName("name"); alias != nil {
+				name = alias.Content(program.Contents)
+			}
+			imports = append(imports, importGo{name: name, path: importPath})
+			return false
+		default:
+			return false
+		}
+	})
+	return imports
+}
+
+var majorVersionRegexGo = regexp.MustCompile(`^v[0-9]+$`)
+
+// defaultPackageNameGo guesses the name of a package from its import path.
+func defaultPackageNameGo(importPath string) string {
+	components := strings.Split(importPath, "/")
+	name := components[len(components)-1]
+	if majorVersionRegexGo.MatchString(name) && len(components) > 1 {
+		name = components[len(components)-2]
+	}
+	return name
+}
+
+var moduleRegexGo = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+// getImportDirGo returns the directory of the given import path relative to the repository root,
+// if the package belongs to the module containing the given file.
+func (squirrel *SquirrelService) getImportDirGo(ctx context.Context, program Node, importPath string) (*string, error) {
+	dir := filepath.Dir(program.RepoCommitPath.Path)
+	for {
+		contents, err := squirrel.readFile(ctx, types.RepoCommitPath{
+			Repo:   program.RepoCommitPath.Repo,
+			Commit: program.RepoCommitPath.Commit,
+			Path:   filepath.Join(dir, "go.mod"),
+		})
+		if err == nil {
+			matches := moduleRegexGo.FindSubmatch(contents)
+			if matches == nil {
+				return nil, nil
+			}
+			module := string(matches[1])
+			if importPath != module && !strings.HasPrefix(importPath, module+"/") {
+				return nil, nil
+			}
+			importDir := filepath.Join(dir, strings.TrimPrefix(importPath, module))
+			return &importDir, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if dir == "." || dir == "/" {
+			return nil, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// packageFilesPatternGo returns a path pattern matching the files of the package in the given directory.
+func packageFilesPatternGo(dir string) string {
+	if dir == "." || dir == "" {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
+
+func (squirrel *SquirrelService) getFieldGo(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefGo(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldGo(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldGo(ctx context.Context, ty TypeGo, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case PkgTypeGo:
+		return squirrel.symbolSearchOne(
+			ctx,
+			ty2.noad.RepoCommitPath.Repo,
+			ty2.noad.RepoCommitPath.Commit,
+			[]string{packageFilesPatternGo(ty2.dir)},
+			field,
+		)
+	case NamedTypeGo:
+		name := ty2.def.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		typeName := name.Content(ty2.def.Contents)
+
+		// Check the fields and embedded types of structs and the methods of interfaces
+		embedded := []Node{}
+		if underlying := ty2.def.ChildByFieldName("type"); underlying != nil {
+			switch underlying.Type() {
+			case "struct_type":
+				for _, list := range children(underlying) {
+					for _, decl := range children(list) {
+						if decl.Type() != "field_declaration" {
+							continue
+						}
+						ty := decl.ChildByFieldName("type")
+						hasName := false
+						for _, child := range children(decl) {
+							if child.Type() != "field_identifier" {
+								continue
+							}
+							hasName = true
+							if child.Content(ty2.def.Contents) == field {
+								return swapNodePtr(ty2.def, child), nil
+							}
+						}
+						if !hasName && ty != nil {
+							if embeddedNameGo(swapNode(ty2.def, ty)) == field {
+								return swapNodePtr(ty2.def, ty), nil
+							}
+							embedded = append(embedded, swapNode(ty2.def, ty))
+						}
+					}
+				}
+			case "interface_type":
+				for _, list := range children(underlying) {
+					for _, spec := range children(list) {
+						if spec.Type() != "method_spec" {
+							continue
+						}
+						specName := spec.ChildByFieldName("name")
+						if specName != nil && specName.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, specName), nil
+						}
+					}
+				}
+			default:
+				// A defined type (e.g. `type T U`) has the fields of its underlying type
+				embedded = append(embedded, swapNode(ty2.def, underlying))
+			}
+		}
+
+		// Check the methods declared in the same file
+		query := `
+			(method_declaration
+				receiver: (parameter_list (parameter_declaration type: [
+					(type_identifier) @receiver
+					(pointer_type (type_identifier) @receiver)
+					(generic_type type: (type_identifier) @receiver)
+					(pointer_type (generic_type type: (type_identifier) @receiver))
+				]))
+				name: (field_identifier) @name)
+		`
+		var found *Node
+		forEachCapture(query, swapNode(ty2.def, getRoot(ty2.def.Node)), func(nameToNode map[string]Node) {
+			receiver, ok := nameToNode["receiver"]
+			if !ok || receiver.Content(receiver.Contents) != typeName {
+				return
+			}
+			method, ok := nameToNode["name"]
+			if !ok || method.Content(method.Contents) != field {
+				return
+			}
+			if found == nil {
+				found = &method
+			}
+		})
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the methods declared in other files of the same package
+		method, err := squirrel.symbolSearchOne(
+			ctx,
+			ty2.def.RepoCommitPath.Repo,
+			ty2.def.RepoCommitPath.Commit,
+			[]string{packageFilesPatternGo(filepath.Dir(ty2.def.RepoCommitPath.Path))},
+			field,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if method != nil && getReceiverNameGo(*method) == typeName {
+			return method, nil
+		}
+
+		// Check promoted fields and methods
+		for _, embeddedType := range embedded {
+			found, err := squirrel.getFieldGo(ctx, embeddedType, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		return nil, nil
+	case FnTypeGo:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// embeddedNameGo returns the name of the field of an embedded type (e.g. `T` for `*pkg.T`).
+func embeddedNameGo(ty Node) string {
+	switch ty.Type() {
+	case "pointer_type":
+		for _, child := range children(ty.Node) {
+			return embeddedNameGo(swapNode(ty, child))
+		}
+	case "qualified_type":
+		if name := ty.ChildByFieldName("name"); name != nil {
+			return name.Content(ty.Contents)
+		}
+	case "generic_type":
+		if name := ty.ChildByFieldName("type"); name != nil {
+			return embeddedNameGo(swapNode(ty, name))
+		}
+	case "type_identifier":
+		return ty.Content(ty.Contents)
+	}
+	return ""
+}
+
+// getReceiverNameGo returns the name of the receiver type of the method with the given name.
+func getReceiverNameGo(name Node) string {
+	method := name.Parent()
+	if method == nil || method.Type() != "method_declaration" {
+		return ""
+	}
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil {
+		return ""
+	}
+	for _, param := range children(receiver) {
+		if ty := param.ChildByFieldName("type"); ty != nil {
+			return embeddedNameGo(swapNode(name, ty))
+		}
+	}
+	return ""
+}
+
+func (squirrel *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret TypeGo, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeGoStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		found, err := squirrel.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if found.Node == nil {
+			return PkgTypeGo{dir: found.RepoCommitPath.Path, noad: node}, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, name))
+	case "selector_expression":
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, field))
+	case "composite_literal":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "generic_type":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "unary_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, operand))
+	case "pointer_type":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefGo(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefGo(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeGo:
+			return ty2.ret, nil
+		case NamedTypeGo:
+			// A conversion (e.g. `T(x)`)
+			return ty2, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefGo: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeGo(ctx context.Context, def Node) (TypeGo, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return (TypeGo)(NamedTypeGo{def: swapNode(def, parent)}), nil
+	case "type_alias":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "parameter_declaration":
+		fallthrough
+	case "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeGo: could not find type")
+			return nil, nil
+		}
+		if nodeId(ty) == nodeId(def.Node) {
+			// An embedded field is named after its type
+			return squirrel.getTypeDefGo(ctx, def)
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "pointer_type":
+		fallthrough
+	case "qualified_type":
+		// An embedded field is named after its type
+		return squirrel.getTypeDefGo(ctx, swapNode(def, parent))
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		names := []*sitter.Node{}
+		for _, child := range children(parent) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+		value := parent.ChildByFieldName("value")
+		return squirrel.getCorrespondingTypeDefGo(ctx, def, names, value)
+	case "expression_list":
+		grandparent := parent.Parent()
+		if grandparent == nil || grandparent.Type() != "short_var_declaration" {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeGo: unsupported declaration")
+			return nil, nil
+		}
+		value := grandparent.ChildByFieldName("right")
+		return squirrel.getCorrespondingTypeDefGo(ctx, def, children(parent), value)
+	case "function_declaration":
+		fallthrough
+	case "method_declaration":
+		fallthrough
+	case "method_spec":
+		result := parent.ChildByFieldName("result")
+		if result == nil {
+			return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		if result.Type() == "parameter_list" {
+			params := children(result)
+			if len(params) != 1 {
+				return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+			}
+			result = params[0].ChildByFieldName("type")
+			if result == nil {
+				return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+			}
+		}
+		retTy, err := squirrel.getTypeDefGo(ctx, swapNode(def, result))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeGo)(FnTypeGo{ret: retTy, noad: swapNode(def, parent)}), nil
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getCorrespondingTypeDefGo returns the type of the value assigned to the given name in a declaration
+// such as `x, y := a, b`.
+func (squirrel *SquirrelService) getCorrespondingTypeDefGo(ctx context.Context, def Node, names []*sitter.Node, value *sitter.Node) (TypeGo, error) {
+	if value == nil {
+		return nil, nil
+	}
+	values := children(value)
+	if len(values) != len(names) {
+		return nil, nil
+	}
+	for i, name := range names {
+		if nodeId(name) == nodeId(def.Node) {
+			return squirrel.getTypeDefGo(ctx, swapNode(def, values[i]))
+		}
+	}
+	return nil, nil
+}
+
+type TypeGo interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeGo struct {
+	ret  TypeGo
+	noad Node
+}
+
+func (t FnTypeGo) variant() string {
+	return "fn"
+}
+
+func (t FnTypeGo) node() Node {
+	return t.noad
+}
+
+type NamedTypeGo struct {
+	def Node
+}
+
+func (t NamedTypeGo) variant() string {
+	return "named"
+}
+
+func (t NamedTypeGo) node() Node {
+	return t.def
+}
+
+type PkgTypeGo struct {
+	dir  string
+	noad Node
+}
+
+func (t PkgTypeGo) variant() string {
+	return "pkg"
+}
+
+func (t PkgTypeGo) node() Node {
+	return t.noad
+}
+
+func lazyTypeGoStringer(ty *TypeGo) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -0,0 +1,1085 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		ident := node.Content(node.Contents)
+
+		if isInUseDeclarationRust(node.Node) {
+			return squirrel.getDefInUseTreeRust(ctx, node)
+		}
+
+		// Check for a path (e.g. `shapes::Circle`)
+		if parent := node.Parent(); parent != nil && (parent.Type() == "scoped_identifier" || parent.Type() == "scoped_type_identifier") {
+			name := parent.ChildByFieldName("name")
+			path := parent.ChildByFieldName("path")
+			if name != nil && nodeId(name) == nodeId(node.Node) {
+				if path == nil {
+					return nil, nil
+				}
+				return squirrel.getDefInPathRust(ctx, swapNode(node, path), ident)
+			}
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefRust: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.getDefInModuleRust(ctx, swapNode(node, cur), ident, true)
+
+			case "declaration_list":
+				if cur.Parent() != nil && cur.Parent().Type() == "mod_item" {
+					return squirrel.getDefInModuleRust(ctx, swapNode(node, cur), ident, true)
+				}
+				continue
+
+			case "block":
+				// Variables are only visible after they are declared, and may be shadowed
+				for blockChild := prev.PrevNamedSibling(); blockChild != nil; blockChild = blockChild.PrevNamedSibling() {
+					if blockChild.Type() != "let_declaration" {
+						continue
+					}
+					pattern := blockChild.ChildByFieldName("pattern")
+					if pattern == nil {
+						continue
+					}
+					for _, name := range patternIdentifiersRust(pattern) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				// Items are visible in the entire block
+				if found := findItemRust(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "function_item":
+				params := cur.ChildByFieldName("parameters")
+				if params != nil {
+					for _, param := range children(params) {
+						if param.Type() != "parameter" {
+							continue
+						}
+						pattern := param.ChildByFieldName("pattern")
+						if pattern == nil {
+							continue
+						}
+						for _, name := range patternIdentifiersRust(pattern) {
+							if name.Content(node.Contents) == ident {
+								return swapNodePtr(node, name), nil
+							}
+						}
+					}
+				}
+				if found := findTypeParamRust(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "closure_expression":
+				params := cur.ChildByFieldName("parameters")
+				if params == nil {
+					continue
+				}
+				for _, param := range children(params) {
+					pattern := param
+					if param.Type() == "parameter" {
+						pattern = param.ChildByFieldName("pattern")
+						if pattern == nil {
+							continue
+						}
+					}
+					for _, name := range patternIdentifiersRust(pattern) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "for_expression":
+				fallthrough
+			case "if_let_expression":
+				fallthrough
+			case "while_let_expression":
+				// The pattern is not visible in the value being matched
+				if value := cur.ChildByFieldName("value"); value != nil && nodeId(value) == nodeId(prev) {
+					continue
+				}
+				fallthrough
+			case "match_arm":
+				pattern := cur.ChildByFieldName("pattern")
+				if pattern == nil {
+					continue
+				}
+				for _, name := range patternIdentifiersRust(pattern) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "impl_item":
+				fallthrough
+			case "trait_item":
+				if ident == "Self" {
+					if cur.Type() == "trait_item" {
+						name := cur.ChildByFieldName("name")
+						if name == nil {
+							return nil, nil
+						}
+						return swapNodePtr(node, name), nil
+					}
+					ty := cur.ChildByFieldName("type")
+					if ty == nil {
+						return nil, nil
+					}
+					return squirrel.getDefRust(ctx, swapNode(node, typeNameRust(ty)))
+				}
+				fallthrough
+			case "struct_item":
+				fallthrough
+			case "enum_item":
+				fallthrough
+			case "union_item":
+				if found := findTypeParamRust(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "field_identifier":
+		ident := node.Content(node.Contents)
+
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "field_expression":
+			value := parent.ChildByFieldName("value")
+			if value == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldRust(ctx, swapNode(node, value), ident)
+
+		case "field_initializer":
+			// Find the type of the struct expression containing this field
+			structExpression := parent.Parent()
+			if structExpression != nil {
+				structExpression = structExpression.Parent()
+			}
+			if structExpression == nil || structExpression.Type() != "struct_expression" {
+				return nil, nil
+			}
+			name := structExpression.ChildByFieldName("name")
+			if name == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldRust(ctx, swapNode(node, name), ident)
+
+		case "field_declaration":
+			return &node, nil
+
+		default:
+			return nil, nil
+		}
+
+	case "self":
+		parent := node.Parent()
+		if parent == nil || parent.Type() == "self_parameter" {
+			return nil, nil
+		}
+		if parent.Type() == "scoped_identifier" || parent.Type() == "use_declaration" {
+			// A path such as `self::foo` refers to the current module
+			return nil, nil
+		}
+		for cur := parent; cur != nil; cur = cur.Parent() {
+			if cur.Type() != "function_item" {
+				continue
+			}
+			params := cur.ChildByFieldName("parameters")
+			if params == nil {
+				return nil, nil
+			}
+			for _, param := range children(params) {
+				if param.Type() != "self_parameter" {
+					continue
+				}
+				for _, self := range children(param) {
+					if self.Type() == "self" {
+						return swapNodePtr(node, self), nil
+					}
+				}
+			}
+			return nil, nil
+		}
+		return nil, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// patternIdentifiersRust returns the identifiers bound by the given pattern (e.g. `Some((x, y))`).
+func patternIdentifiersRust(pattern *sitter.Node) []*sitter.Node {
+	identifiers := []*sitter.Node{}
+	walkFilter(pattern, func(node *sitter.Node) bool {
+		switch node.Type() {
+		case "identifier", "shorthand_field_identifier":
+			identifiers = append(identifiers, node)
+			return false
+		case "tuple_struct_pattern", "struct_pattern":
+			// Skip the name of the struct or variant
+			ty := node.ChildByFieldName("type")
+			for _, child := range children(node) {
+				if ty == nil || nodeId(child) != nodeId(ty) {
+					identifiers = append(identifiers, patternIdentifiersRust(child)...)
+				}
+			}
+			return false
+		case "field_pattern":
+			if pattern := node.ChildByFieldName("pattern"); pattern != nil {
+				identifiers = append(identifiers, patternIdentifiersRust(pattern)...)
+				return false
+			}
+			return true
+		case "scoped_identifier", "scoped_type_identifier", "type_identifier", "field_identifier":
+			return false
+		default:
+			return true
+		}
+	})
+	return identifiers
+}
+
+// findTypeParamRust returns the type parameter of the given item with the given name, if any.
+func findTypeParamRust(item Node, ident string) *Node {
+	typeParams := item.ChildByFieldName("type_parameters")
+	if typeParams == nil {
+		return nil
+	}
+	for _, param := range children(typeParams) {
+		name := param
+		if param.Type() == "constrained_type_parameter" {
+			name = param.ChildByFieldName("left")
+		}
+		if name != nil && name.Type() == "type_identifier" && name.Content(item.Contents) == ident {
+			return swapNodePtr(item, name)
+		}
+	}
+	return nil
+}
+
+// findItemRust returns the name of the item with the given name declared directly in the given module
+// or block, if any.
+func findItemRust(container Node, ident string) *Node {
+	for _, item := range children(container.Node) {
+		switch item.Type() {
+		case "function_item", "struct_item", "enum_item", "union_item", "trait_item", "type_item",
+			"const_item", "static_item", "mod_item", "macro_definition":
+			name := item.ChildByFieldName("name")
+			if name != nil && name.Content(container.Contents) == ident {
+				return swapNodePtr(container, name)
+			}
+		}
+	}
+	return nil
+}
+
+// typeNameRust returns the node naming the given type (e.g. `Foo` for `Foo<T>`).
+func typeNameRust(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "generic_type":
+		if inner := ty.ChildByFieldName("type"); inner != nil {
+			return typeNameRust(inner)
+		}
+	case "scoped_identifier", "scoped_type_identifier":
+		if name := ty.ChildByFieldName("name"); name != nil {
+			return name
+		}
+	case "reference_type":
+		if inner := ty.ChildByFieldName("type"); inner != nil {
+			return typeNameRust(inner)
+		}
+	}
+	return ty
+}
+
+// getDefInModuleRust finds the item with the given name in the given module, optionally including the
+// names brought into scope by use declarations.
+func (squirrel *SquirrelService) getDefInModuleRust(ctx context.Context, module Node, ident string, includeUses bool) (ret *Node, err error) {
+	defer squirrel.onCall(module, &Tuple{String(module.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	if found := findItemRust(module, ident); found != nil {
+		if parent := found.Parent(); parent != nil && parent.Type() == "mod_item" && parent.ChildByFieldName("body") == nil {
+			// Modules declared with `mod foo;` are defined in their own file
+			file, err := squirrel.getModuleFileRust(ctx, swapNode(module, parent))
+			if err != nil {
+				return nil, err
+			}
+			if file != nil {
+				return file, nil
+			}
+		}
+		return found, nil
+	}
+
+	if !includeUses {
+		return nil, nil
+	}
+
+	for _, child := range children(module.Node) {
+		if child.Type() != "use_declaration" {
+			continue
+		}
+		argument := child.ChildByFieldName("argument")
+		if argument == nil {
+			continue
+		}
+		found, err := squirrel.getDefInUseRust(ctx, swapNode(module, argument), nil, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getDefInUseRust finds the definition of the given name if it is brought into scope by the given use
+// tree (e.g. `a::{b, c as d}`). The base is the resolved prefix of the use tree, or nil at the root.
+func (squirrel *SquirrelService) getDefInUseRust(ctx context.Context, tree Node, base *Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(tree, &Tuple{String(tree.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	switch tree.Type() {
+	case "identifier":
+		if tree.Content(tree.Contents) != ident {
+			return nil, nil
+		}
+		return squirrel.resolveUsePathRust(ctx, tree, base)
+	case "scoped_identifier":
+		name := tree.ChildByFieldName("name")
+		if name == nil || name.Content(tree.Contents) != ident {
+			return nil, nil
+		}
+		return squirrel.resolveUsePathRust(ctx, tree, base)
+	case "use_as_clause":
+		alias := tree.ChildByFieldName("alias")
+		path := tree.ChildByFieldName("path")
+		if alias == nil || path == nil || alias.Content(tree.Contents) != ident {
+			return nil, nil
+		}
+		return squirrel.resolveUsePathRust(ctx, swapNode(tree, path), base)
+	case "scoped_use_list":
+		list := tree.ChildByFieldName("list")
+		if list == nil {
+			return nil, nil
+		}
+		prefix := base
+		if path := tree.ChildByFieldName("path"); path != nil {
+			prefix, err = squirrel.resolveUsePathRust(ctx, swapNode(tree, path), base)
+			if err != nil {
+				return nil, err
+			}
+			if prefix == nil {
+				return nil, nil
+			}
+		}
+		for _, child := range children(list) {
+			found, err := squirrel.getDefInUseRust(ctx, swapNode(tree, child), prefix, ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case "use_wildcard":
+		for _, path := range children(tree.Node) {
+			prefix, err := squirrel.resolveUsePathRust(ctx, swapNode(tree, path), base)
+			if err != nil {
+				return nil, err
+			}
+			if prefix == nil {
+				return nil, nil
+			}
+			return squirrel.lookupPathRust(ctx, *prefix, ident)
+		}
+		return nil, nil
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInUseTreeRust finds the definition of an identifier that appears in a use declaration.
+func (squirrel *SquirrelService) getDefInUseTreeRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	path := node.Node
+	if parent := node.Parent(); parent != nil {
+		switch parent.Type() {
+		case "scoped_identifier":
+			if name := parent.ChildByFieldName("name"); name != nil && nodeId(name) == nodeId(node.Node) {
+				path = parent
+			}
+		case "use_as_clause":
+			if alias := parent.ChildByFieldName("alias"); alias != nil && nodeId(alias) == nodeId(node.Node) {
+				path = parent.ChildByFieldName("path")
+			}
+		}
+	}
+	if path == nil {
+		return nil, nil
+	}
+
+	base, err := squirrel.getUseBaseRust(ctx, swapNode(node, path))
+	if err != nil {
+		return nil, err
+	}
+	return squirrel.resolveUsePathRust(ctx, swapNode(node, path), base)
+}
+
+// getUseBaseRust resolves the prefix that the given part of a use tree is relative to (e.g. `a::b`
+// for `c` in `use a::b::{c, d}`), or returns nil if it is relative to the current module.
+func (squirrel *SquirrelService) getUseBaseRust(ctx context.Context, tree Node) (*Node, error) {
+	parent := tree.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "use_list":
+		scopedUseList := parent.Parent()
+		if scopedUseList == nil {
+			return nil, nil
+		}
+		base, err := squirrel.getUseBaseRust(ctx, swapNode(tree, scopedUseList))
+		if err != nil {
+			return nil, err
+		}
+		path := scopedUseList.ChildByFieldName("path")
+		if path == nil {
+			return base, nil
+		}
+		return squirrel.resolveUsePathRust(ctx, swapNode(tree, path), base)
+	case "scoped_identifier", "scoped_use_list", "use_as_clause", "use_wildcard":
+		return squirrel.getUseBaseRust(ctx, swapNode(tree, parent))
+	default:
+		return nil, nil
+	}
+}
+
+// resolveUsePathRust finds the definition of the given path in a use declaration, relative to the
+// given base or to the current module.
+func (squirrel *SquirrelService) resolveUsePathRust(ctx context.Context, path Node, base *Node) (ret *Node, err error) {
+	defer squirrel.onCall(path, String(path.Type()), lazyNodeStringer(&ret))()
+
+	switch path.Type() {
+	case "crate":
+		return squirrel.getCrateRootRust(ctx, path)
+	case "self":
+		if base != nil {
+			return base, nil
+		}
+		return getEnclosingModuleRust(path), nil
+	case "super":
+		module := getEnclosingModuleRust(path)
+		if base != nil {
+			module, err = squirrel.defToModuleRust(ctx, *base)
+			if err != nil {
+				return nil, err
+			}
+			if module == nil {
+				return nil, nil
+			}
+		}
+		return squirrel.getParentModuleRust(ctx, *module)
+	case "identifier":
+		ident := path.Content(path.Contents)
+		if base == nil {
+			// Uses are not considered to avoid resolving a use declaration in terms of itself
+			return squirrel.getDefInModuleRust(ctx, *getEnclosingModuleRust(path), ident, false)
+		}
+		return squirrel.lookupPathRust(ctx, *base, ident)
+	case "scoped_identifier":
+		name := path.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		prefix := base
+		if inner := path.ChildByFieldName("path"); inner != nil {
+			prefix, err = squirrel.resolveUsePathRust(ctx, swapNode(path, inner), base)
+			if err != nil {
+				return nil, err
+			}
+			if prefix == nil {
+				return nil, nil
+			}
+		}
+		if prefix == nil {
+			return nil, nil
+		}
+		return squirrel.lookupPathRust(ctx, *prefix, name.Content(path.Contents))
+	default:
+		squirrel.breadcrumb(path, fmt.Sprintf("resolveUsePathRust: unrecognized node type %q", path.Type()))
+		return nil, nil
+	}
+}
+
+// getDefInPathRust finds the definition of the given name in the module or type denoted by the given
+// path in an expression or type (e.g. `Circle::new`).
+func (squirrel *SquirrelService) getDefInPathRust(ctx context.Context, path Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(path, &Tuple{String(path.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	var prefix *Node
+	switch path.Type() {
+	case "crate", "self", "super":
+		prefix, err = squirrel.resolveUsePathRust(ctx, path, nil)
+	case "identifier", "type_identifier":
+		prefix, err = squirrel.getDefRust(ctx, path)
+	case "scoped_identifier", "scoped_type_identifier", "generic_type":
+		prefix, err = squirrel.getDefRust(ctx, swapNode(path, typeNameRust(path.Node)))
+	default:
+		squirrel.breadcrumb(path, fmt.Sprintf("getDefInPathRust: unrecognized node type %q", path.Type()))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if prefix == nil {
+		return nil, nil
+	}
+	return squirrel.lookupPathRust(ctx, *prefix, ident)
+}
+
+// lookupPathRust finds the item with the given name in the given module, or the associated item or
+// variant with the given name of the given type.
+func (squirrel *SquirrelService) lookupPathRust(ctx context.Context, def Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(def, &Tuple{String(def.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	module, err := squirrel.defToModuleRust(ctx, def)
+	if err != nil {
+		return nil, err
+	}
+	if module != nil {
+		return squirrel.getDefInModuleRust(ctx, *module, ident, true)
+	}
+
+	parent := def.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+	if parent.Type() == "enum_item" {
+		if body := parent.ChildByFieldName("body"); body != nil {
+			for _, variant := range children(body) {
+				name := variant.ChildByFieldName("name")
+				if variant.Type() == "enum_variant" && name != nil && name.Content(def.Contents) == ident {
+					return swapNodePtr(def, name), nil
+				}
+			}
+		}
+	}
+
+	ty, err := squirrel.defToTypeRust(ctx, def)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldRust(ctx, ty, ident)
+}
+
+// isInUseDeclarationRust returns true if the given node is part of a use declaration.
+func isInUseDeclarationRust(node *sitter.Node) bool {
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "use_declaration":
+			return true
+		case "scoped_identifier", "scoped_use_list", "use_list", "use_as_clause", "use_wildcard":
+			continue
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// getEnclosingModuleRust returns the file or inline module body containing the given node.
+func getEnclosingModuleRust(node Node) *Node {
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		if isModuleRust(cur) {
+			return swapNodePtr(node, cur)
+		}
+	}
+	return swapNodePtr(node, getRoot(node.Node))
+}
+
+// isModuleRust returns true if the given node is a file or the body of an inline module.
+func isModuleRust(node *sitter.Node) bool {
+	if node.Type() == "source_file" {
+		return true
+	}
+	return node.Type() == "declaration_list" && node.Parent() != nil && node.Parent().Type() == "mod_item"
+}
+
+// defToModuleRust returns the module denoted by the given definition, if it is one.
+func (squirrel *SquirrelService) defToModuleRust(ctx context.Context, def Node) (*Node, error) {
+	if isModuleRust(def.Node) {
+		return &def, nil
+	}
+	parent := def.Parent()
+	if parent == nil || parent.Type() != "mod_item" {
+		return nil, nil
+	}
+	if body := parent.ChildByFieldName("body"); body != nil {
+		return swapNodePtr(def, body), nil
+	}
+	return squirrel.getModuleFileRust(ctx, swapNode(def, parent))
+}
+
+// getModuleDirRust returns the directory containing the files of the submodules of the given module.
+func getModuleDirRust(module Node) string {
+	if module.Type() == "declaration_list" {
+		parentDir := getModuleDirRust(swapNode(module, getRoot(module.Node)))
+		if name := module.Parent().ChildByFieldName("name"); name != nil {
+			return filepath.Join(parentDir, name.Content(module.Contents))
+		}
+		return parentDir
+	}
+
+	path := module.RepoCommitPath.Path
+	switch filepath.Base(path) {
+	case "lib.rs", "main.rs", "mod.rs":
+		return filepath.Dir(path)
+	default:
+		return strings.TrimSuffix(path, ".rs")
+	}
+}
+
+// getModuleFileRust parses the file of a module declared with `mod foo;`.
+func (squirrel *SquirrelService) getModuleFileRust(ctx context.Context, modItem Node) (ret *Node, err error) {
+	defer squirrel.onCall(modItem, String(modItem.Type()), lazyNodeStringer(&ret))()
+
+	name := modItem.ChildByFieldName("name")
+	if name == nil {
+		return nil, nil
+	}
+	dir := getModuleDirRust(*getEnclosingModuleRust(swapNode(modItem, modItem.Parent())))
+	return squirrel.parseFirstRust(ctx, modItem, []string{
+		filepath.Join(dir, name.Content(modItem.Contents)+".rs"),
+		filepath.Join(dir, name.Content(modItem.Contents), "mod.rs"),
+	})
+}
+
+// getCrateRootRust parses the root file of the crate containing the given node. The crate root is
+// assumed to be lib.rs or main.rs in the nearest src directory.
+func (squirrel *SquirrelService) getCrateRootRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	srcDir := getSrcDirRust(node.RepoCommitPath.Path)
+	return squirrel.parseFirstRust(ctx, node, []string{
+		filepath.Join(srcDir, "lib.rs"),
+		filepath.Join(srcDir, "main.rs"),
+	})
+}
+
+// getParentModuleRust returns the parent of the given module.
+func (squirrel *SquirrelService) getParentModuleRust(ctx context.Context, module Node) (ret *Node, err error) {
+	defer squirrel.onCall(module, String(module.Type()), lazyNodeStringer(&ret))()
+
+	if module.Type() == "declaration_list" {
+		return getEnclosingModuleRust(swapNode(module, module.Parent().Parent())), nil
+	}
+
+	path := module.RepoCommitPath.Path
+	srcDir := getSrcDirRust(path)
+	parentDir := filepath.Dir(path)
+	switch filepath.Base(path) {
+	case "lib.rs", "main.rs":
+		if parentDir == srcDir {
+			// The crate root has no parent
+			return nil, nil
+		}
+	case "mod.rs":
+		parentDir = filepath.Dir(parentDir)
+	}
+	if parentDir == srcDir {
+		return squirrel.getCrateRootRust(ctx, module)
+	}
+	return squirrel.parseFirstRust(ctx, module, []string{
+		parentDir + ".rs",
+		filepath.Join(parentDir, "mod.rs"),
+	})
+}
+
+// getSrcDirRust returns the nearest src directory containing the given path, or the directory of the
+// path if there is none.
+func getSrcDirRust(path string) string {
+	components := strings.Split(filepath.Dir(path), "/")
+	for i := len(components) - 1; i >= 0; i-- {
+		if components[i] == "src" {
+			return filepath.Join(components[:i+1]...)
+		}
+	}
+	return filepath.Dir(path)
+}
+
+// parseFirstRust parses the first of the given paths that exists.
+func (squirrel *SquirrelService) parseFirstRust(ctx context.Context, node Node, paths []string) (*Node, error) {
+	for _, path := range paths {
+		file, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   node.RepoCommitPath.Repo,
+			Commit: node.RepoCommitPath.Commit,
+			Path:   path,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// The candidate does not exist
+			continue
+		}
+		return file, nil
+	}
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldRust(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefRust(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldRust(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldRust(ctx context.Context, ty TypeRust, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case StructTypeRust:
+		body := ty2.def.ChildByFieldName("body")
+		if body != nil {
+			for _, member := range children(body) {
+				switch member.Type() {
+				case "field_declaration", "function_item", "function_signature_item":
+					name := member.ChildByFieldName("name")
+					if name != nil && name.Content(ty2.def.Contents) == field {
+						return swapNodePtr(ty2.def, name), nil
+					}
+				}
+			}
+		}
+
+		name := ty2.def.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		typeName := name.Content(ty2.def.Contents)
+
+		// Check the methods and associated functions declared in the same file
+		query := `
+			(impl_item
+				type: [
+					(type_identifier) @type
+					(generic_type type: (type_identifier) @type)
+				]
+				body: (declaration_list (function_item name: (identifier) @name)))
+		`
+		var found *Node
+		forEachCapture(query, swapNode(ty2.def, getRoot(ty2.def.Node)), func(nameToNode map[string]Node) {
+			implType, ok := nameToNode["type"]
+			if !ok || implType.Content(implType.Contents) != typeName {
+				return
+			}
+			method, ok := nameToNode["name"]
+			if !ok || method.Content(method.Contents) != field {
+				return
+			}
+			if found == nil {
+				found = &method
+			}
+		})
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the methods declared in other files
+		method, err := squirrel.symbolSearchOne(
+			ctx,
+			ty2.def.RepoCommitPath.Repo,
+			ty2.def.RepoCommitPath.Commit,
+			[]string{`\.rs$`},
+			field,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if method != nil && getImplTypeNameRust(*method) == typeName {
+			return method, nil
+		}
+
+		return nil, nil
+	case FnTypeRust:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldRust: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldRust: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// getImplTypeNameRust returns the name of the type that the function with the given name is
+// implemented for.
+func getImplTypeNameRust(name Node) string {
+	fn := name.Parent()
+	if fn == nil || fn.Type() != "function_item" {
+		return ""
+	}
+	body := fn.Parent()
+	if body == nil || body.Type() != "declaration_list" {
+		return ""
+	}
+	impl := body.Parent()
+	if impl == nil || impl.Type() != "impl_item" {
+		return ""
+	}
+	ty := impl.ChildByFieldName("type")
+	if ty == nil {
+		return ""
+	}
+	return typeNameRust(ty).Content(name.Contents)
+}
+
+func (squirrel *SquirrelService) getTypeDefRust(ctx context.Context, node Node) (ret TypeRust, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeRustStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		fallthrough
+	case "self":
+		found, err := squirrel.getDefRust(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeRust(ctx, *found)
+	case "scoped_identifier":
+		fallthrough
+	case "scoped_type_identifier":
+		fallthrough
+	case "generic_type":
+		return squirrel.getTypeDefRust(ctx, swapNode(node, typeNameRust(node.Node)))
+	case "reference_type":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, ty))
+	case "reference_expression":
+		value := node.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, value))
+	case "field_expression":
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, field))
+	case "struct_expression":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(node, name))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefRust(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeRust:
+			return ty2.ret, nil
+		case StructTypeRust:
+			// A tuple struct constructor (e.g. `Meters(5)`)
+			return ty2, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefRust: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefRust(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefRust: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeRust(ctx context.Context, def Node) (TypeRust, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "struct_item":
+		fallthrough
+	case "enum_item":
+		fallthrough
+	case "union_item":
+		fallthrough
+	case "trait_item":
+		return (TypeRust)(StructTypeRust{def: swapNode(def, parent)}), nil
+	case "type_item":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+	case "let_declaration":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeRust: could not find type")
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(def, value))
+	case "parameter":
+		fallthrough
+	case "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeRust: could not find type")
+			return nil, nil
+		}
+		return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+	case "self_parameter":
+		for cur := parent.Parent(); cur != nil; cur = cur.Parent() {
+			if cur.Type() == "impl_item" {
+				ty := cur.ChildByFieldName("type")
+				if ty == nil {
+					return nil, nil
+				}
+				return squirrel.getTypeDefRust(ctx, swapNode(def, ty))
+			}
+		}
+		return nil, nil
+	case "function_item":
+		fallthrough
+	case "function_signature_item":
+		returnType := parent.ChildByFieldName("return_type")
+		if returnType == nil {
+			return (TypeRust)(FnTypeRust{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		retTy, err := squirrel.getTypeDefRust(ctx, swapNode(def, returnType))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeRust)(FnTypeRust{ret: retTy, noad: swapNode(def, parent)}), nil
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+type TypeRust interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeRust struct {
+	ret  TypeRust
+	noad Node
+}
+
+func (t FnTypeRust) variant() string {
+	return "fn"
+}
+
+func (t FnTypeRust) node() Node {
+	return t.noad
+}
+
+type StructTypeRust struct {
+	def Node
+}
+
+func (t StructTypeRust) variant() string {
+	return "struct"
+}
+
+func (t StructTypeRust) node() Node {
+	return t.def
+}
+
+func lazyTypeRustStringer(ty *TypeRust) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
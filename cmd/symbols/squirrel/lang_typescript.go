@@ -0,0 +1,898 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "shorthand_property_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for a type qualified by a namespace (e.g. `geom.Point`)
+		if parent := node.Parent(); parent != nil && parent.Type() == "nested_type_identifier" {
+			name := parent.ChildByFieldName("name")
+			module := parent.ChildByFieldName("module")
+			if name != nil && module != nil && nodeId(name) == nodeId(node.Node) {
+				return squirrel.getFieldTypeScript(ctx, swapNode(node, module), ident)
+			}
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefTypeScript: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "program":
+				for _, child := range children(cur) {
+					if found := findDeclTypeScript(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				return squirrel.getDefInImportsTypeScript(ctx, swapNode(node, cur), ident)
+
+			case "statement_block":
+				// Declarations are visible in the entire block
+				for _, child := range children(cur) {
+					if found := findDeclTypeScript(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "function":
+				fallthrough
+			case "function_declaration":
+				fallthrough
+			case "generator_function":
+				fallthrough
+			case "generator_function_declaration":
+				fallthrough
+			case "arrow_function":
+				fallthrough
+			case "method_definition":
+				if parameter := cur.ChildByFieldName("parameter"); parameter != nil && parameter.Content(node.Contents) == ident {
+					return swapNodePtr(node, parameter), nil
+				}
+				if params := cur.ChildByFieldName("parameters"); params != nil {
+					if found := findParamTypeScript(swapNode(node, params), ident); found != nil {
+						return found, nil
+					}
+				}
+				if found := findTypeParamTypeScript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				if cur.Type() == "function" || cur.Type() == "generator_function" {
+					// The name of a function expression is only visible inside of it
+					if name := cur.ChildByFieldName("name"); name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "class":
+				if name := cur.ChildByFieldName("name"); name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				fallthrough
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				fallthrough
+			case "interface_declaration":
+				fallthrough
+			case "type_alias_declaration":
+				if found := findTypeParamTypeScript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "for_statement":
+				initializer := cur.ChildByFieldName("initializer")
+				if initializer == nil {
+					continue
+				}
+				if found := findDeclTypeScript(swapNode(node, initializer), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "for_in_statement":
+				left := cur.ChildByFieldName("left")
+				if left == nil || nodeId(left) == nodeId(prev) {
+					continue
+				}
+				for _, name := range patternIdentifiersTypeScript(left) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "catch_clause":
+				parameter := cur.ChildByFieldName("parameter")
+				if parameter == nil {
+					continue
+				}
+				for _, name := range patternIdentifiersTypeScript(parameter) {
+					if name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "property_identifier":
+		ident := node.Content(node.Contents)
+
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "member_expression":
+			object := parent.ChildByFieldName("object")
+			if object == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldTypeScript(ctx, swapNode(node, object), ident)
+
+		case "public_field_definition":
+			fallthrough
+		case "method_definition":
+			fallthrough
+		case "property_signature":
+			fallthrough
+		case "method_signature":
+			fallthrough
+		case "abstract_method_signature":
+			return &node, nil
+
+		default:
+			return nil, nil
+		}
+
+	case "this":
+		for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+			switch cur.Type() {
+			case "class", "class_declaration", "abstract_class_declaration":
+				name := cur.ChildByFieldName("name")
+				if name == nil {
+					return nil, nil
+				}
+				return swapNodePtr(node, name), nil
+			case "function", "function_declaration", "generator_function", "generator_function_declaration":
+				// Functions have their own `this`
+				return nil, nil
+			}
+		}
+		return nil, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// findDeclTypeScript returns the name declared by the given statement, if any.
+func findDeclTypeScript(stmt Node, ident string) *Node {
+	switch stmt.Type() {
+	case "export_statement":
+		if declaration := stmt.ChildByFieldName("declaration"); declaration != nil {
+			return findDeclTypeScript(swapNode(stmt, declaration), ident)
+		}
+		if value := stmt.ChildByFieldName("value"); value != nil && (value.Type() == "class" || value.Type() == "function") {
+			name := value.ChildByFieldName("name")
+			if name != nil && name.Content(stmt.Contents) == ident {
+				return swapNodePtr(stmt, name)
+			}
+		}
+	case "lexical_declaration":
+		fallthrough
+	case "variable_declaration":
+		for _, declarator := range children(stmt.Node) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			name := declarator.ChildByFieldName("name")
+			if name == nil {
+				continue
+			}
+			for _, identifier := range patternIdentifiersTypeScript(name) {
+				if identifier.Content(stmt.Contents) == ident {
+					return swapNodePtr(stmt, identifier)
+				}
+			}
+		}
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "type_alias_declaration":
+		fallthrough
+	case "enum_declaration":
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+	}
+
+	return nil
+}
+
+// findParamTypeScript returns the parameter of the given formal parameters with the given name, if any.
+func findParamTypeScript(params Node, ident string) *Node {
+	for _, param := range children(params.Node) {
+		if param.Type() != "required_parameter" && param.Type() != "optional_parameter" {
+			continue
+		}
+		for _, child := range children(param) {
+			if child.Type() == "type_annotation" || child.Type() == "accessibility_modifier" {
+				continue
+			}
+			for _, name := range patternIdentifiersTypeScript(child) {
+				if name.Content(params.Contents) == ident {
+					return swapNodePtr(params, name)
+				}
+			}
+			// Only the first child that is not a modifier is the pattern
+			break
+		}
+	}
+	return nil
+}
+
+// findTypeParamTypeScript returns the type parameter of the given declaration with the given name, if any.
+func findTypeParamTypeScript(decl Node, ident string) *Node {
+	typeParams := decl.ChildByFieldName("type_parameters")
+	if typeParams == nil {
+		return nil
+	}
+	for _, param := range children(typeParams) {
+		for _, name := range children(param) {
+			if name.Type() == "type_identifier" && name.Content(decl.Contents) == ident {
+				return swapNodePtr(decl, name)
+			}
+		}
+	}
+	return nil
+}
+
+// patternIdentifiersTypeScript returns the identifiers bound by the given pattern (e.g. `{ x, y: [z] }`).
+func patternIdentifiersTypeScript(pattern *sitter.Node) []*sitter.Node {
+	identifiers := []*sitter.Node{}
+	walkFilter(pattern, func(node *sitter.Node) bool {
+		switch node.Type() {
+		case "identifier", "shorthand_property_identifier_pattern":
+			identifiers = append(identifiers, node)
+			return false
+		case "pair_pattern":
+			if value := node.ChildByFieldName("value"); value != nil {
+				identifiers = append(identifiers, patternIdentifiersTypeScript(value)...)
+			}
+			return false
+		case "assignment_pattern", "object_assignment_pattern":
+			if left := node.ChildByFieldName("left"); left != nil {
+				identifiers = append(identifiers, patternIdentifiersTypeScript(left)...)
+			}
+			return false
+		default:
+			return true
+		}
+	})
+	return identifiers
+}
+
+// getDefInImportsTypeScript finds the definition of a name imported by the given program.
+func (squirrel *SquirrelService) getDefInImportsTypeScript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "import_statement" {
+			continue
+		}
+		source := getImportSourceTypeScript(stmt)
+		if source == nil {
+			continue
+		}
+		for _, clause := range children(stmt) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				switch child.Type() {
+				case "identifier":
+					// import x from './x'
+					if child.Content(program.Contents) != ident {
+						continue
+					}
+					module, err := squirrel.resolveModuleTypeScript(ctx, program, source)
+					if err != nil {
+						return nil, err
+					}
+					if module == nil {
+						return nil, nil
+					}
+					return squirrel.findDefaultExportTypeScript(ctx, *module)
+				case "namespace_import":
+					// import * as x from './x'
+					for _, name := range children(child) {
+						if name.Type() == "identifier" && name.Content(program.Contents) == ident {
+							return swapNodePtr(program, name), nil
+						}
+					}
+				case "named_imports":
+					// import { x, y as z } from './x'
+					for _, specifier := range children(child) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						name := specifier.ChildByFieldName("name")
+						if name == nil {
+							continue
+						}
+						local := name
+						if alias := specifier.ChildByFieldName("alias"); alias != nil {
+							local = alias
+						}
+						if local.Content(program.Contents) != ident {
+							continue
+						}
+						module, err := squirrel.resolveModuleTypeScript(ctx, program, source)
+						if err != nil {
+							return nil, err
+						}
+						if module == nil {
+							return nil, nil
+						}
+						return squirrel.findExportTypeScript(ctx, *module, name.Content(program.Contents))
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// getImportSourceTypeScript returns the string that an import statement imports from. The source
+// field of import statements is not found by ChildByFieldName in the tsx grammar.
+func getImportSourceTypeScript(stmt *sitter.Node) *sitter.Node {
+	for _, child := range children(stmt) {
+		if child.Type() == "string" {
+			return child
+		}
+	}
+	return nil
+}
+
+// findExportTypeScript finds the declaration of the given name exported by the given module.
+func (squirrel *SquirrelService) findExportTypeScript(ctx context.Context, module Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(module, &Tuple{String(module.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		if found := findDeclTypeScript(swapNode(module, stmt), ident); found != nil {
+			return found, nil
+		}
+
+		// export { x, y as z } and export { x } from './x'
+		source := stmt.ChildByFieldName("source")
+		for _, clause := range children(stmt) {
+			if clause.Type() != "export_clause" {
+				continue
+			}
+			for _, specifier := range children(clause) {
+				if specifier.Type() != "export_specifier" {
+					continue
+				}
+				name := specifier.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				exported := name
+				if alias := specifier.ChildByFieldName("alias"); alias != nil {
+					exported = alias
+				}
+				if exported.Content(module.Contents) != ident {
+					continue
+				}
+				if source == nil {
+					return squirrel.getDefTypeScript(ctx, swapNode(module, name))
+				}
+				reexported, err := squirrel.resolveModuleTypeScript(ctx, module, source)
+				if err != nil {
+					return nil, err
+				}
+				if reexported == nil {
+					return nil, nil
+				}
+				return squirrel.findExportTypeScript(ctx, *reexported, name.Content(module.Contents))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// findDefaultExportTypeScript finds the declaration exported by default by the given module.
+func (squirrel *SquirrelService) findDefaultExportTypeScript(ctx context.Context, module Node) (ret *Node, err error) {
+	defer squirrel.onCall(module, String(module.Type()), lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" || !strings.HasPrefix(stmt.Content(module.Contents), "export default") {
+			continue
+		}
+		if declaration := stmt.ChildByFieldName("declaration"); declaration != nil {
+			if name := declaration.ChildByFieldName("name"); name != nil {
+				return swapNodePtr(module, name), nil
+			}
+		}
+		if value := stmt.ChildByFieldName("value"); value != nil {
+			switch value.Type() {
+			case "class", "function":
+				if name := value.ChildByFieldName("name"); name != nil {
+					return swapNodePtr(module, name), nil
+				}
+			case "identifier":
+				return squirrel.getDefTypeScript(ctx, swapNode(module, value))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveModuleTypeScript parses the module imported from the given source string by the given
+// program. Only relative imports are supported.
+func (squirrel *SquirrelService) resolveModuleTypeScript(ctx context.Context, program Node, source *sitter.Node) (*Node, error) {
+	specifier := strings.Trim(source.Content(program.Contents), "\"'`")
+	if !strings.HasPrefix(specifier, ".") {
+		return nil, nil
+	}
+
+	base := filepath.Join(filepath.Dir(program.RepoCommitPath.Path), specifier)
+	base = strings.TrimSuffix(base, ".js")
+
+	candidates := []string{
+		base + ".ts",
+		base + ".tsx",
+		base + ".d.ts",
+		filepath.Join(base, "index.ts"),
+		filepath.Join(base, "index.tsx"),
+	}
+	if strings.HasSuffix(base, ".ts") || strings.HasSuffix(base, ".tsx") {
+		candidates = append([]string{base}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		module, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   program.RepoCommitPath.Repo,
+			Commit: program.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// The candidate does not exist
+			continue
+		}
+		return module, nil
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldTypeScript(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty TypeTypeScript, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ModuleTypeTypeScript:
+		return squirrel.findExportTypeScript(ctx, ty2.module, field)
+	case ClassTypeTypeScript:
+		body := ty2.def.Node
+		if ty2.def.Type() != "object_type" {
+			body = ty2.def.ChildByFieldName("body")
+			if body == nil {
+				return nil, nil
+			}
+		}
+
+		for _, member := range children(body) {
+			switch member.Type() {
+			case "public_field_definition", "method_definition", "property_signature", "method_signature", "abstract_method_signature":
+				name := member.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				if name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+				if name.Content(ty2.def.Contents) != "constructor" {
+					continue
+				}
+				// Parameter properties (e.g. `constructor(private x: number)`) are fields too
+				params := member.ChildByFieldName("parameters")
+				if params == nil {
+					continue
+				}
+				for _, param := range children(params) {
+					isProperty := false
+					for _, child := range children(param) {
+						if child.Type() == "accessibility_modifier" || child.Type() == "readonly" {
+							isProperty = true
+							continue
+						}
+						if isProperty && child.Type() == "identifier" && child.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, child), nil
+						}
+					}
+				}
+			}
+		}
+
+		// Check super classes and extended interfaces
+		supers := []*sitter.Node{}
+		for _, child := range children(ty2.def.Node) {
+			switch child.Type() {
+			case "class_heritage":
+				for _, clause := range children(child) {
+					if clause.Type() == "extends_clause" {
+						supers = append(supers, children(clause)...)
+					}
+				}
+			case "extends_clause", "extends_type_clause":
+				supers = append(supers, children(child)...)
+			}
+		}
+		for _, super := range supers {
+			found, err := squirrel.getFieldTypeScript(ctx, swapNode(ty2.def, super), field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		return nil, nil
+	case FnTypeTypeScript, ArrayTypeTypeScript:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret TypeTypeScript, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeTypeScriptStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "property_identifier":
+		fallthrough
+	case "this":
+		found, err := squirrel.getDefTypeScript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypeScript(ctx, *found)
+	case "member_expression":
+		property := node.ChildByFieldName("property")
+		if property == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, property))
+	case "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefTypeScript(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeTypeScript:
+			return ty2.ret, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefTypeScript: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "object_type":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: node}), nil
+	case "array_type":
+		named := children(node.Node)
+		if len(named) == 0 {
+			return nil, nil
+		}
+		elem, err := squirrel.getTypeDefTypeScript(ctx, swapNode(node, named[0]))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeTypeScript)(ArrayTypeTypeScript{elem: elem, noad: node}), nil
+	case "union_type":
+		// Optional types (e.g. `T | undefined`) have the fields of the non-optional type
+		var nonOptional *sitter.Node
+		for _, member := range children(node.Node) {
+			switch member.Type() {
+			case "predefined_type", "literal_type":
+				continue
+			}
+			if nonOptional != nil {
+				return nil, nil
+			}
+			nonOptional = member
+		}
+		if nonOptional == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, nonOptional))
+	case "as_expression":
+		fallthrough
+	case "satisfies_expression":
+		// The type is the last child (e.g. `x as T`)
+		named := children(node.Node)
+		if len(named) == 0 {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, named[len(named)-1]))
+	case "type_annotation":
+		fallthrough
+	case "generic_type":
+		fallthrough
+	case "await_expression":
+		fallthrough
+	case "non_null_expression":
+		fallthrough
+	case "parenthesized_expression":
+		fallthrough
+	case "parenthesized_type":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (TypeTypeScript, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "namespace_import":
+		clause := parent.Parent()
+		if clause == nil {
+			return nil, nil
+		}
+		stmt := clause.Parent()
+		if stmt == nil {
+			return nil, nil
+		}
+		source := getImportSourceTypeScript(stmt)
+		if source == nil {
+			return nil, nil
+		}
+		module, err := squirrel.resolveModuleTypeScript(ctx, swapNode(def, getRoot(def.Node)), source)
+		if err != nil {
+			return nil, err
+		}
+		if module == nil {
+			return nil, nil
+		}
+		return (TypeTypeScript)(ModuleTypeTypeScript{module: *module}), nil
+	case "variable_declarator":
+		fallthrough
+	case "public_field_definition":
+		fallthrough
+	case "property_signature":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil || nodeId(value) == nodeId(def.Node) {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeTypeScript: could not find type")
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "for_in_statement":
+		right := parent.ChildByFieldName("right")
+		if right == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefTypeScript(ctx, swapNode(def, right))
+		if err != nil {
+			return nil, err
+		}
+		if array, ok := ty.(ArrayTypeTypeScript); ok {
+			return array.elem, nil
+		}
+		return nil, nil
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		for _, child := range children(parent) {
+			if child.Type() == "type_annotation" {
+				return squirrel.getTypeDefTypeScript(ctx, swapNode(def, child))
+			}
+		}
+		squirrel.breadcrumb(swapNode(def, parent), "defToTypeTypeScript: could not find type")
+		return nil, nil
+	case "function_declaration":
+		fallthrough
+	case "method_definition":
+		fallthrough
+	case "method_signature":
+		fallthrough
+	case "abstract_method_signature":
+		fallthrough
+	case "function_signature":
+		returnType := parent.ChildByFieldName("return_type")
+		if returnType == nil {
+			return (TypeTypeScript)(FnTypeTypeScript{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		retTy, err := squirrel.getTypeDefTypeScript(ctx, swapNode(def, returnType))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeTypeScript)(FnTypeTypeScript{ret: retTy, noad: swapNode(def, parent)}), nil
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+type TypeTypeScript interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeTypeScript struct {
+	ret  TypeTypeScript
+	noad Node
+}
+
+func (t FnTypeTypeScript) variant() string {
+	return "fn"
+}
+
+func (t FnTypeTypeScript) node() Node {
+	return t.noad
+}
+
+type ClassTypeTypeScript struct {
+	def Node
+}
+
+func (t ClassTypeTypeScript) variant() string {
+	return "class"
+}
+
+func (t ClassTypeTypeScript) node() Node {
+	return t.def
+}
+
+type ArrayTypeTypeScript struct {
+	elem TypeTypeScript
+	noad Node
+}
+
+func (t ArrayTypeTypeScript) variant() string {
+	return "array"
+}
+
+func (t ArrayTypeTypeScript) node() Node {
+	return t.noad
+}
+
+type ModuleTypeTypeScript struct {
+	module Node
+}
+
+func (t ModuleTypeTypeScript) variant() string {
+	return "module"
+}
+
+func (t ModuleTypeTypeScript) node() Node {
+	return t.module
+}
+
+func lazyTypeTypeScriptStringer(ty *TypeTypeScript) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/python"
 	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 )
 
@@ -124,6 +125,13 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration                name: (identifier)       @symbol))
+(source_file (method_declaration                  name: (field_identifier) @symbol))
+(source_file (type_declaration  (type_spec        name: (type_identifier)  @symbol)))
+(source_file (var_declaration   (var_spec         name: (identifier)       @symbol)))
+(source_file (const_declaration (const_spec       name: (identifier)       @symbol)))
 `,
 	},
 	"csharp": {
@@ -227,6 +235,59 @@ var langToLangSpec = map[string]LangSpec{
 (arrow_function parameter: (identifier) @definition)            ; x => ...
 (for_in_statement left: (identifier) @definition)               ; for (const x of xs) ...
 (catch_clause parameter: (identifier) @definition)              ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program                                     (function_declaration   name: (identifier)      @symbol))
+(program                                     (class_declaration      name: (type_identifier) @symbol))
+(program                                     (interface_declaration  name: (type_identifier) @symbol))
+(program                                     (type_alias_declaration name: (type_identifier) @symbol))
+(program                                     (enum_declaration       name: (identifier)      @symbol))
+(program                                     (lexical_declaration (variable_declarator name: (identifier) @symbol)))
+(program (export_statement declaration: (function_declaration   name: (identifier)      @symbol)))
+(program (export_statement declaration: (class_declaration      name: (type_identifier) @symbol)))
+(program (export_statement declaration: (interface_declaration  name: (type_identifier) @symbol)))
+(program (export_statement declaration: (type_alias_declaration name: (type_identifier) @symbol)))
+(program (export_statement declaration: (enum_declaration       name: (identifier)      @symbol)))
+(program (export_statement declaration: (lexical_declaration (variable_declarator name: (identifier) @symbol))))
+`,
+	},
+	"rust": {
+		name:     "rust",
+		language: rust.GetLanguage(),
+		commentStyle: CommentStyle{
+			nodeTypes:     []string{"line_comment", "block_comment"},
+			stripRegex:    regexp.MustCompile(`^//[/!]?|^\s*\*/?|^/\*[*!]?|\*/$`),
+			ignoreRegex:   javaStyleIgnoreRegex,
+			codeFenceName: "rust",
+			skipNodeTypes: []string{"attribute_item"},
+		},
+		localsQuery: `
+(block)                 @scope ; { ... }
+(function_item)         @scope ; fn f() { ... }
+(closure_expression)    @scope ; |x| ...
+(for_expression)        @scope ; for x in xs { ... }
+(if_let_expression)     @scope ; if let Some(x) = ... { ... }
+(while_let_expression)  @scope ; while let Some(x) = ... { ... }
+(match_arm)             @scope ; Some(x) => ...
+
+(let_declaration    pattern: (identifier) @definition)                   ; let x = ...;
+(let_declaration    pattern: (tuple_pattern (identifier) @definition))   ; let (x, y) = ...;
+(parameter          pattern: (identifier) @definition)                   ; fn f(x: i32) { ... }
+(closure_parameters (identifier) @definition)                            ; |x| ...
+(for_expression     pattern: (identifier) @definition)                   ; for x in xs { ... }
+(for_expression     pattern: (tuple_pattern (identifier) @definition))   ; for (i, x) in xs { ... }
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_item name: (identifier)      @symbol))
+(source_file (struct_item   name: (type_identifier) @symbol))
+(source_file (enum_item     name: (type_identifier) @symbol))
+(source_file (union_item    name: (type_identifier) @symbol))
+(source_file (trait_item    name: (type_identifier) @symbol))
+(source_file (type_item     name: (type_identifier) @symbol))
+(source_file (const_item    name: (identifier)      @symbol))
+(source_file (static_item   name: (identifier)      @symbol))
+(source_file (mod_item      name: (identifier)      @symbol))
+(source_file (impl_item body: (declaration_list (function_item name: (identifier) @symbol))))
 `,
 	},
 	"cpp": {
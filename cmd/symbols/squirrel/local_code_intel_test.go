@@ -334,6 +334,23 @@ def f(p1, p2 = 3, *p3, **p4)
 		puts e
 	end
 end
+`}, {
+		path: "test.rs",
+		contents: `
+fn f(p1: i32, mut p2: i32) { // < "p1" f.p1 def < "p1" f.p1 ref < "p2" f.p2 def < "p2" f.p2 ref
+	let x = p1 + p2; // < "x" f.x def < "x" f.x ref < "p1" f.p1 ref < "p2" f.p2 ref
+	let mut y = x; // < "y" f.y def < "y" f.y ref < "x" f.x ref
+	let (a, b) = (y, 2); // < "a" f.a def < "a" f.a ref < "b" f.b def < "b" f.b ref < "y" f.y ref
+
+	for i in 0..a { // < "i" f.i def < "i" f.i ref < "a" f.a ref
+		y += i; // < "y" f.y ref < "i" f.i ref
+	}
+
+	let c = |z| { // < "c" f.c def < "c" f.c ref < "z" f.z def < "z" f.z ref
+		z + b // < "z" f.z ref < "b" f.b ref
+	};
+	c(y); // < "c" f.c ref < "y" f.y ref
+}
 `},
 	}
 
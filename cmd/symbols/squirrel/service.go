@@ -148,11 +148,14 @@ func (squirrel *SquirrelService) getDef(ctx context.Context, node Node) (*Node,
 		return squirrel.getDefStarlark(ctx, node)
 	case "python":
 		return squirrel.getDefPython(ctx, node)
-	// case "go":
+	case "go":
+		return squirrel.getDefGo(ctx, node)
+	case "typescript":
+		return squirrel.getDefTypeScript(ctx, node)
+	case "rust":
+		return squirrel.getDefRust(ctx, node)
 	// case "csharp":
-	// case "python":
 	// case "javascript":
-	// case "typescript":
 	// case "cpp":
 	// case "ruby":
 	default:
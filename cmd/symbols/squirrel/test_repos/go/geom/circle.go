@@ -0,0 +1,8 @@
+package geom
+
+import "math"
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 { // < "Area" go.Circle.Area def < "Circle" go.Circle ref
+	return math.Pi * c.Radius * c.Radius // < "Radius" go.Circle.Radius ref
+}
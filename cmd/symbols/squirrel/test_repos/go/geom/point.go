@@ -0,0 +1,24 @@
+package geom
+
+import "math"
+
+// Point is a point in the plane.
+type Point struct { // < "Point" go.Point def
+	X float64 // < "X" go.Point.X def
+	Y float64 // < "Y" go.Point.Y def
+}
+
+// Origin is the point at (0, 0).
+var Origin = Point{X: 0, Y: 0} // < "Origin" go.Origin def < "X" go.Point.X ref
+
+// NewPoint returns the point at (x, y).
+func NewPoint(x, y float64) *Point { // < "NewPoint" go.NewPoint def < "x" go.NewPoint.x def
+	return &Point{X: x, Y: y} // < "Y" go.Point.Y ref < "x" go.NewPoint.x ref
+}
+
+// Dist returns the distance between two points.
+func (p Point) Dist(q Point) float64 { // < "Dist" go.Point.Dist def < "q" go.Dist.q def
+	dx := p.X - q.X                 // < "dx" go.Dist.dx def < "q" go.Dist.q ref
+	dy := p.Y - q.Y                 // < "Y" go.Point.Y ref
+	return math.Sqrt(dx*dx + dy*dy) // < "dx" go.Dist.dx ref
+}
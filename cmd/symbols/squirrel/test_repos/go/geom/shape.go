@@ -0,0 +1,12 @@
+package geom
+
+// Shape is anything with an area.
+type Shape interface { // < "Shape" go.Shape def
+	Area() float64 // < "Area" go.Shape.Area def
+}
+
+// Circle is a circle around a point.
+type Circle struct { // < "Circle" go.Circle def
+	Point          // < "Point" go.Circle.Point def < "Point" go.Point ref
+	Radius float64 // < "Radius" go.Circle.Radius def
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/shapes/geom"
+	g2 "example.com/shapes/geom"
+)
+
+type named struct { // < "named" go.named def
+	name  string     // < "name" go.named.name def
+	shape geom.Shape // < "shape" go.named.shape def < "Shape" go.Shape ref < "geom" geom path
+}
+
+func (n named) describe() string { // < "describe" go.named.describe def
+	return fmt.Sprintf("%s: %f", n.name, n.shape.Area()) // < "name" go.named.name ref < "Area" go.Shape.Area ref
+}
+
+func main() {
+	p := geom.NewPoint(1, 2)                // < "p" go.main.p def < "NewPoint" go.NewPoint ref < "geom" geom path
+	c := &geom.Circle{Point: *p, Radius: 3} // < "c" go.main.c def < "Circle" go.Circle ref < "Point" go.Circle.Point ref < "Radius" go.Circle.Radius ref
+	fmt.Println(p.Dist(geom.Origin))        // < "Dist" go.Point.Dist ref < "Origin" go.Origin ref
+	fmt.Println(c.X, c.Area())              // < "X" go.Point.X ref < "Area" go.Circle.Area ref
+
+	var s g2.Shape = c    // < "s" go.main.s def < "Shape" go.Shape ref < "g2" geom path
+	fmt.Println(s.Area()) // < "Area" go.Shape.Area ref
+
+	n := named{name: "circle", shape: c} // < "n" go.main.n def < "shape" go.named.shape ref
+	fmt.Println(n.describe())            // < "describe" go.named.describe ref
+
+	for idx, sh := range []geom.Shape{c, s} { // < "idx" go.main.idx def < "sh" go.main.sh def
+		fmt.Println(idx, sh) // < "idx" go.main.idx ref < "sh" go.main.sh ref
+	}
+
+	x := double(p) // < "x" go.main.x1 def < "double" go.double ref
+	if true {
+		x := 2         // < "x" go.main.x2 def
+		fmt.Println(x) // < "x" go.main.x2 ref
+	}
+	fmt.Println(x.Y) // < "x" go.main.x1 ref < "Y" go.Point.Y ref
+}
@@ -0,0 +1,8 @@
+package main
+
+import "example.com/shapes/geom"
+
+// double returns the point twice as far from the origin.
+func double(p *geom.Point) geom.Point { // < "double" go.double def < "p" go.double.p def
+	return geom.Point{X: 2 * p.X, Y: 2 * p.Y} // < "p" go.double.p ref < "Y" go.Point.Y ref
+}
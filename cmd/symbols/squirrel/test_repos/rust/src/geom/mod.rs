@@ -0,0 +1,5 @@
+//! Geometry. // < "//!" rs.mod.geom def
+
+pub mod point;
+
+pub use self::point::Point; // < "point" rs.mod.point ref < "Point" rs.Point ref
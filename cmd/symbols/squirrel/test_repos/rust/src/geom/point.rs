@@ -0,0 +1,22 @@
+//! Points in the plane. // < "//!" rs.mod.point def
+
+/// A point in the plane.
+#[derive(Clone, Copy, Debug)]
+pub struct Point { // < "Point" rs.Point def
+    pub x: f64, // < "x" rs.Point.x def
+    pub y: f64, // < "y" rs.Point.y def
+}
+
+impl Point { // < "Point" rs.Point ref
+    /// Creates the point at (x, y).
+    pub fn new(x: f64, y: f64) -> Self { // < "new" rs.Point.new def < "x" rs.new.x def
+        Point { x, y } // < "x" rs.new.x ref
+    }
+
+    /// Returns the distance to another point.
+    pub fn dist(&self, other: &Point) -> f64 { // < "dist" rs.Point.dist def < "other" rs.dist.other def
+        let h = self.x - other.x; // < "h" rs.dist.h def < "x" rs.Point.x ref < "other" rs.dist.other ref
+        let v = self.y - other.y; // < "y" rs.Point.y ref
+        (h * h + v * v).sqrt() // < "h" rs.dist.h ref
+    }
+}
@@ -0,0 +1,35 @@
+mod geom; // < "geom" rs.mod.geom ref
+mod shapes;
+
+use geom::Point; // < "Point" rs.Point ref
+use shapes::{kind_of, Circle, Kind, Shape}; // < "kind_of" rs.kind_of ref < "Circle" rs.Circle ref
+
+fn main() {
+    let origin = Point::new(0.0, 0.0); // < "origin" rs.main.origin def < "new" rs.Point.new ref
+    let circle = Circle { center: origin, radius: 2.0 }; // < "circle" rs.main.circle def < "origin" rs.main.origin ref < "radius" rs.Circle.radius ref
+    let size = circle.area(); // < "size" rs.main.size def < "circle" rs.main.circle ref < "area" rs.Circle.area ref
+    let far = circle.center.dist(&origin) > size; // < "far" rs.main.far def < "center" rs.Circle.center ref < "dist" rs.Point.dist ref < "size" rs.main.size ref
+
+    let u = shapes::Circle::unit(); // < "u" rs.main.u def < "shapes" rs.mod.shapes ref < "Circle" rs.Circle ref < "unit" rs.Circle.unit ref
+    match kind_of(&u) { // < "kind_of" rs.kind_of ref < "u" rs.main.u ref
+        Kind::Round => {} // < "Round" rs.Kind.Round ref
+        Kind::Angular => {}
+    }
+
+    let n = 1; // < "n" rs.main.n1 def
+    {
+        let n = 2; // < "n" rs.main.n2 def
+        let _ = n; // < "n" rs.main.n2 ref
+    }
+    let _ = n + 1; // < "n" rs.main.n1 ref
+
+    let limit = 3; // < "limit" rs.main.limit def
+    let double = |k: i32| k * 2; // < "double" rs.main.double def < "k" rs.main.k def
+    for i in 0..limit { // < "i" rs.main.i def < "limit" rs.main.limit ref
+        let _ = double(i); // < "double" rs.main.double ref < "i" rs.main.i ref
+    }
+
+    if let Some(p) = Some(far) { // < "p" rs.main.p def < "far" rs.main.far ref
+        let _ = p; // < "p" rs.main.p ref
+    }
+}
@@ -0,0 +1,42 @@
+use super::geom::Point; // < "use" rs.mod.shapes def < "geom" rs.mod.geom ref < "Point" rs.Point ref
+use crate::geom::point::{Point as Pt}; // < "Pt" rs.Point ref
+
+/// Anything with an area.
+pub trait Shape { // < "Shape" rs.Shape def
+    /// Returns the area of the shape.
+    fn area(&self) -> f64; // < "area" rs.Shape.area def
+}
+
+/// A circle around a point.
+pub struct Circle { // < "Circle" rs.Circle def
+    pub center: Point, // < "center" rs.Circle.center def < "Point" rs.Point ref
+    pub radius: f64, // < "radius" rs.Circle.radius def
+}
+
+impl Circle {
+    /// Returns the unit circle.
+    pub fn unit() -> Circle { // < "unit" rs.Circle.unit def
+        Circle { center: Pt::new(0.0, 0.0), radius: 1.0 } // < "center" rs.Circle.center ref < "Pt" rs.Point ref < "new" rs.Point.new ref
+    }
+}
+
+impl Shape for Circle { // < "Shape" rs.Shape ref < "Circle" rs.Circle ref
+    fn area(&self) -> f64 { // < "area" rs.Circle.area def
+        std::f64::consts::PI * self.radius * self.radius // < "radius" rs.Circle.radius ref
+    }
+}
+
+/// The kinds of shapes.
+pub enum Kind { // < "Kind" rs.Kind def
+    Round, // < "Round" rs.Kind.Round def
+    Angular,
+}
+
+/// Returns the kind of the given circle.
+pub fn kind_of(circle: &Circle) -> Kind { // < "kind_of" rs.kind_of def < "circle" rs.kind_of.circle def
+    if circle.radius > 0.0 { // < "circle" rs.kind_of.circle ref < "radius" rs.Circle.radius ref
+        Kind::Round // < "Round" rs.Kind.Round ref
+    } else {
+        Kind::Angular
+    }
+}
@@ -0,0 +1,13 @@
+/** A point in the plane. */
+export class Point { // < "Point" ts.Point def
+    constructor(public x: number, public y: number) {} // < "x" ts.Point.x def < "y" ts.Point.y def
+
+    /** Returns the distance to another point. */
+    dist(other: Point): number { // < "dist" ts.Point.dist def < "other" ts.dist.other def
+        const dh = this.x - other.x // < "dh" ts.dist.dh def < "x" ts.Point.x ref < "other" ts.dist.other ref
+        const dv = this.y - other.y // < "y" ts.Point.y ref
+        return Math.sqrt(dh * dh + dv * dv) // < "dh" ts.dist.dh ref
+    }
+}
+
+export const origin = new Point(0, 0) // < "origin" ts.origin def < "Point" ts.Point ref
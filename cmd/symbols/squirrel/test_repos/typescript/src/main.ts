@@ -0,0 +1,24 @@
+import totalArea, { Circle as Round, Shape } from './shapes' // < "totalArea" ts.totalArea ref < "Round" ts.Circle ref
+import * as geom from './geom' // < "geom" ts.geom def
+
+function largest(shapes: Shape[]): Shape | undefined { // < "largest" ts.largest def < "Shape" ts.Shape ref
+    let best: Shape | undefined // < "best" ts.largest.best def
+    for (const candidate of shapes) { // < "candidate" ts.largest.candidate def
+        if (!best || candidate.area() > best.area()) { // < "candidate" ts.largest.candidate ref < "area" ts.Shape.area ref
+            best = candidate // < "best" ts.largest.best ref
+        }
+    }
+    return best
+}
+
+function scale(factor: number): number { // < "scale" ts.scale def < "factor" ts.scale.factor def
+    const result = factor * 2 // < "result" ts.scale.result def < "factor" ts.scale.factor ref
+    const double = (k: number) => k * factor // < "double" ts.scale.double def < "k" ts.scale.k def < "factor" ts.scale.factor ref
+    return double(result) // < "double" ts.scale.double ref < "result" ts.scale.result ref
+}
+
+const circle = new Round(scale(2)) // < "circle" ts.main.circle def < "Round" ts.Circle ref < "scale" ts.scale ref
+console.log(circle.area(), circle.describe()) // < "circle" ts.main.circle ref < "area" ts.Circle.area ref < "describe" ts.Base.describe ref
+
+const p: geom.Point = geom.origin // < "p" ts.main.p def < "geom" ts.geom ref < "Point" ts.Point ref < "origin" ts.origin ref
+console.log(p.dist(geom.origin), totalArea([circle]), largest([circle])) // < "dist" ts.Point.dist ref < "totalArea" ts.totalArea ref < "largest" ts.largest ref
@@ -0,0 +1,35 @@
+import { Point, origin } from './geom' // < "Point" ts.Point ref
+
+/** Anything with an area. */
+export interface Shape { // < "Shape" ts.Shape def
+    area(): number // < "area" ts.Shape.area def
+    center: Point // < "center" ts.Shape.center def
+}
+
+export abstract class Base implements Shape { // < "Base" ts.Base def < "Shape" ts.Shape ref
+    center: Point = origin // < "center" ts.Base.center def < "origin" ts.origin ref
+    abstract area(): number
+
+    describe(): string { // < "describe" ts.Base.describe def
+        return this.center.toString() // < "center" ts.Base.center ref
+    }
+}
+
+export class Circle extends Base { // < "Circle" ts.Circle def < "Base" ts.Base ref
+    constructor(private radius: number) { // < "radius" ts.Circle.radius def
+        super()
+    }
+
+    area(): number { // < "area" ts.Circle.area def
+        return Math.PI * this.radius * this.radius // < "radius" ts.Circle.radius ref
+    }
+}
+
+/** Returns the total area of the given shapes. */
+export default function totalArea(shapes: Shape[]): number { // < "totalArea" ts.totalArea def < "shapes" ts.totalArea.shapes def
+    let total = 0 // < "total" ts.totalArea.total def
+    for (const item of shapes) { // < "item" ts.totalArea.item def < "shapes" ts.totalArea.shapes ref
+        total += item.area() // < "total" ts.totalArea.total ref < "item" ts.totalArea.item ref
+    }
+    return total
+}
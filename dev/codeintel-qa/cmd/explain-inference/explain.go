@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/dev/codeintel-qa/internal"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type jsonExplanation struct {
+	Commit                string
+	IndexJobs             string
+	Hints                 []jsonHint
+	RecognizerInvocations []jsonInvocation
+	DisabledRecognizers   []string
+	Error                 *string
+}
+
+type jsonHint struct {
+	Root       string
+	Indexer    string
+	Confidence string
+}
+
+type jsonInvocation struct {
+	Recognizer       string
+	Callback         string
+	Overridden       bool
+	Paths            []string
+	PathCount        int
+	PathsWithContent []string
+	SkipReason       *string
+	IndexJobs        string
+	Hints            []jsonHint
+	Error            *string
+}
+
+const repositoryIDQuery = `
+	query CodeIntelQA_ExplainInference_RepositoryID($name: String!) {
+		repository(name: $name) {
+			id
+		}
+	}
+`
+
+const explainInferenceQuery = `
+	query CodeIntelQA_ExplainInference($repository: ID!, $rev: String, $script: String) {
+		explainAutoIndexingInference(repository: $repository, rev: $rev, script: $script) {
+			commit
+			indexJobs
+			hints { root indexer confidence }
+			recognizerInvocations {
+				recognizer
+				callback
+				overridden
+				paths
+				pathCount
+				pathsWithContent
+				skipReason
+				indexJobs
+				hints { root indexer confidence }
+				error
+			}
+			disabledRecognizers
+			error
+		}
+	}
+`
+
+// explainInference runs the inference scripts against the given repository and revision on the
+// target instance and returns the resulting explanation.
+func explainInference(_ context.Context, name, rev string, script *string) (jsonExplanation, error) {
+	client := internal.GraphQLClient()
+
+	var repositoryPayload struct {
+		Data struct {
+			Repository *struct {
+				ID string
+			}
+		}
+	}
+	if err := client.GraphQL(internal.SourcegraphAccessToken, repositoryIDQuery, map[string]any{"name": name}, &repositoryPayload); err != nil {
+		return jsonExplanation{}, err
+	}
+	if repositoryPayload.Data.Repository == nil {
+		return jsonExplanation{}, errors.Newf("repository %q not found", name)
+	}
+
+	var payload struct {
+		Data struct {
+			ExplainAutoIndexingInference jsonExplanation
+		}
+	}
+	variables := map[string]any{
+		"repository": repositoryPayload.Data.Repository.ID,
+		"rev":        rev,
+		"script":     script,
+	}
+	if err := client.GraphQL(internal.SourcegraphAccessToken, explainInferenceQuery, variables, &payload); err != nil {
+		return jsonExplanation{}, err
+	}
+
+	return payload.Data.ExplainAutoIndexingInference, nil
+}
+
+// printExplanation prints the given explanation as a trace of recognizer invocations followed by
+// the inferred index jobs and hints.
+func printExplanation(explanation jsonExplanation) {
+	fmt.Printf("%s Inferred index jobs for %s@%s (%s)\n", internal.EmojiLightbulb, repositoryName, rev, explanation.Commit)
+
+	if len(explanation.DisabledRecognizers) > 0 {
+		fmt.Printf("\nDisabled recognizers: %s\n", strings.Join(explanation.DisabledRecognizers, ", "))
+	}
+
+	fmt.Printf("\nRecognizer invocations:\n")
+	for _, invocation := range explanation.RecognizerInvocations {
+		overridden := ""
+		if invocation.Overridden {
+			overridden = " (overridden)"
+		}
+		fmt.Printf("  %s %s%s\n", invocation.Callback, invocation.Recognizer, overridden)
+
+		if invocation.SkipReason != nil {
+			fmt.Printf("    skipped: %s\n", *invocation.SkipReason)
+			continue
+		}
+
+		fmt.Printf("    paths: %d, paths with content: %d\n", invocation.PathCount, len(invocation.PathsWithContent))
+		if verbose {
+			for _, path := range invocation.Paths {
+				fmt.Printf("      %s\n", path)
+			}
+			if len(invocation.Paths) < invocation.PathCount {
+				fmt.Printf("      ... and %d more\n", invocation.PathCount-len(invocation.Paths))
+			}
+		}
+		if invocation.Error != nil {
+			fmt.Printf("    %s error: %s\n", internal.EmojiFailure, *invocation.Error)
+		}
+		for _, hint := range invocation.Hints {
+			fmt.Printf("    hint: %s at %q (%s)\n", hint.Indexer, hint.Root, hint.Confidence)
+		}
+		if invocation.Callback == "generate" && invocation.Error == nil {
+			fmt.Printf("    index jobs: %s\n", strings.ReplaceAll(invocation.IndexJobs, "\n", "\n    "))
+		}
+	}
+
+	fmt.Printf("\nIndex jobs:\n%s\n", explanation.IndexJobs)
+
+	fmt.Printf("\nHints:\n")
+	for _, hint := range explanation.Hints {
+		fmt.Printf("  %s at %q (%s)\n", hint.Indexer, hint.Root, hint.Confidence)
+	}
+
+	if explanation.Error != nil {
+		fmt.Printf("\n%s Inference failed: %s\n", internal.EmojiFailure, *explanation.Error)
+	}
+}
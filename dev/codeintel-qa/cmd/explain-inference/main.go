@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/dev/codeintel-qa/internal"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	repositoryName string
+	rev            string
+	scriptPath     string
+	verbose        bool
+	timeout        time.Duration
+)
+
+func init() {
+	flag.StringVar(&repositoryName, "repo", "", "The name of the repository to run inference against")
+	flag.StringVar(&rev, "rev", "HEAD", "The revision to run inference against")
+	flag.StringVar(&scriptPath, "script", "", "The path to an inference script to use in place of the one set on the instance")
+	flag.BoolVar(&verbose, "verbose", false, "Display the paths passed to each recognizer")
+	flag.DurationVar(&timeout, "timeout", time.Minute, "The time it should take to run inference")
+}
+
+func main() {
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := mainErr(ctx); err != nil {
+		fmt.Printf("%s error: %s\n", internal.EmojiFailure, err.Error())
+		os.Exit(1)
+	}
+}
+
+func mainErr(ctx context.Context) error {
+	if repositoryName == "" {
+		return errors.New("no repository supplied (use -repo)")
+	}
+
+	var script *string
+	if scriptPath != "" {
+		contents, err := os.ReadFile(scriptPath)
+		if err != nil {
+			return err
+		}
+
+		s := string(contents)
+		script = &s
+	}
+
+	if err := internal.InitializeGraphQLClient(); err != nil {
+		return err
+	}
+
+	explanation, err := explainInference(ctx, repositoryName, rev, script)
+	if err != nil {
+		return err
+	}
+
+	printExplanation(explanation)
+	return nil
+}
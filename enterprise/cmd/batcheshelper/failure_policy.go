@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"text/template"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// stepStatus is written by the wrapper script of a step with a failure policy
+// and read in the post step to populate the step result.
+type stepStatus struct {
+	ExitCode int  `json:"exitCode"`
+	Attempts int  `json:"attempts"`
+	TimedOut bool `json:"timedOut"`
+}
+
+// killGracePeriodSeconds is the time a step that exceeded its timeout is given
+// to exit after receiving SIGTERM, before it is killed.
+const killGracePeriodSeconds = 10
+
+// wrapStepScript returns a POSIX shell script that runs the run script of the
+// step according to its timeout, retries and continueOnError settings. A step
+// with a timeout requires setsid in the container: the step is run in its own
+// process group, so that all of its processes are terminated when it times
+// out, and so is the watchdog enforcing the timeout, so that none of its
+// processes outlive the attempt. Before a step is retried, the repository is
+// reset to a copy taken before the first attempt, so that every attempt starts
+// from the same state. The outcome of the last attempt is written to the
+// status file of the step.
+func wrapStepScript(stepIdx int, step batcheslib.Step) (string, error) {
+	timeout, err := step.TimeoutDuration()
+	if err != nil {
+		return "", errors.Wrap(err, "parsing step timeout")
+	}
+
+	var out bytes.Buffer
+	err = wrapperScriptTemplate.Execute(&out, map[string]any{
+		"RunScript":       stepRunScriptFile(stepIdx),
+		"RepositoryDir":   repositoryDir,
+		"SnapshotDir":     stepSnapshotDir(stepIdx),
+		"StatusFile":      stepStatusFile(stepIdx),
+		"TimeoutFile":     stepTimeoutFile(stepIdx),
+		"Timeout":         step.Timeout,
+		"TimeoutSeconds":  int(math.Ceil(timeout.Seconds())),
+		"KillGracePeriod": killGracePeriodSeconds,
+		"Retries":         step.Retries,
+		"ContinueOnError": step.ContinueOnError,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "rendering step wrapper script")
+	}
+	return out.String(), nil
+}
+
+var wrapperScriptTemplate = template.Must(template.New("wrapper").Parse(`dir=$(cd "$(dirname "$0")" && pwd)
+run="$dir/{{.RunScript}}"
+timeout_file="$dir/{{.TimeoutFile}}"
+{{- if .TimeoutSeconds}}
+command -v setsid >/dev/null 2>&1 || { echo "setsid is required to run a step with a timeout" >&2; exit 1; }
+{{- end}}
+{{- if .Retries}}
+repo="$dir/{{.RepositoryDir}}"
+snapshot="$dir/{{.SnapshotDir}}"
+rm -rf "$snapshot"
+cp -R -p "$repo" "$snapshot" || { echo "failed to copy the repository before running the step" >&2; exit 1; }
+{{- end}}
+attempt=0
+while :; do
+  attempt=$((attempt + 1))
+  rm -f "$timeout_file"
+{{- if .TimeoutSeconds}}
+  setsid sh "$run" &
+  pid=$!
+  setsid sh -c 'sleep "$1" && touch "$2" && kill -TERM -"$3" && sleep "$4" && kill -KILL -"$3"' watchdog {{.TimeoutSeconds}} "$timeout_file" "$pid" {{.KillGracePeriod}} >/dev/null 2>&1 &
+  watchdog=$!
+  wait "$pid"
+  code=$?
+  # The watchdog may not have become a process group leader yet.
+  kill -KILL -"$watchdog" >/dev/null 2>&1 || kill -KILL "$watchdog" >/dev/null 2>&1
+{{- else}}
+  sh "$run"
+  code=$?
+{{- end}}
+  timed_out=false
+  if [ -e "$timeout_file" ]; then
+    timed_out=true
+    echo "step timed out after {{.Timeout}}" >&2
+    if [ "$code" -eq 0 ]; then code=124; fi
+  fi
+  if [ "$code" -eq 0 ] || [ "$attempt" -gt {{.Retries}} ]; then break; fi
+  echo "attempt $attempt failed with exit code $code, resetting the repository and retrying" >&2
+{{- if .Retries}}
+  (cd "$repo" && rm -rf ./* ./.[!.]* ./..?* && cp -R -p "$snapshot/." .) || { echo "failed to reset the repository" >&2; exit 1; }
+{{- end}}
+done
+{{- if .Retries}}
+rm -rf "$snapshot"
+{{- end}}
+printf '{"exitCode":%d,"attempts":%d,"timedOut":%s}\n' "$code" "$attempt" "$timed_out" > "$dir/{{.StatusFile}}"
+{{- if .ContinueOnError}}
+if [ "$code" -ne 0 ]; then
+  echo "step failed with exit code $code, continuing" >&2
+  exit 0
+fi
+{{- end}}
+exit "$code"
+`))
+
+// readStepStatus reads the status file written by the wrapper script of the
+// given step. It returns nil if the step wasn't wrapped.
+func readStepStatus(stepIdx int) (*stepStatus, error) {
+	c, err := os.ReadFile(stepStatusFile(stepIdx))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read step status file")
+	}
+
+	var status stepStatus
+	if err := json.Unmarshal(c, &status); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal step status file")
+	}
+	return &status, nil
+}
+
+func stepRunScriptFile(stepIdx int) string {
+	return fmt.Sprintf("step%d.run.sh", stepIdx)
+}
+
+func stepSnapshotDir(stepIdx int) string {
+	return fmt.Sprintf("step%d.snapshot", stepIdx)
+}
+
+func stepStatusFile(stepIdx int) string {
+	return fmt.Sprintf("step%d.status.json", stepIdx)
+}
+
+func stepTimeoutFile(stepIdx int) string {
+	return fmt.Sprintf("step%d.timeout", stepIdx)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestWrapStepScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	for name, tc := range map[string]struct {
+		step     batcheslib.Step
+		run      string
+		wantErr  bool
+		wantStat stepStatus
+		// wantFile is the expected content of file.txt in the repository.
+		wantFile string
+	}{
+		"success": {
+			step:     batcheslib.Step{Retries: 2},
+			run:      "exit 0",
+			wantStat: stepStatus{ExitCode: 0, Attempts: 1},
+		},
+		"retried until success": {
+			step: batcheslib.Step{Retries: 2},
+			// Fails on the first attempt only.
+			run:      `if [ ! -e "$(dirname "$0")/marker" ]; then touch "$(dirname "$0")/marker"; exit 3; fi`,
+			wantStat: stepStatus{ExitCode: 0, Attempts: 2},
+		},
+		"retries reset the repository": {
+			step: batcheslib.Step{Retries: 2},
+			// Changes the repository on every attempt and fails on the first
+			// attempt only.
+			run:      `echo changed >> file.txt; if [ ! -e "$(dirname "$0")/marker" ]; then touch "$(dirname "$0")/marker"; exit 3; fi`,
+			wantStat: stepStatus{ExitCode: 0, Attempts: 2},
+			wantFile: "original\nchanged\n",
+		},
+		"retries exhausted": {
+			step:     batcheslib.Step{Retries: 2},
+			run:      "exit 3",
+			wantErr:  true,
+			wantStat: stepStatus{ExitCode: 3, Attempts: 3},
+		},
+		"continue on error": {
+			step:     batcheslib.Step{ContinueOnError: true},
+			run:      "exit 3",
+			wantStat: stepStatus{ExitCode: 3, Attempts: 1},
+		},
+		"timeout": {
+			step:     batcheslib.Step{Timeout: "1s", ContinueOnError: true},
+			run:      "sleep 30",
+			wantStat: stepStatus{ExitCode: 143, Attempts: 1, TimedOut: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			wrapper, err := wrapStepScript(0, tc.step)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "step0.sh"), []byte(wrapper), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, stepRunScriptFile(0)), []byte(tc.run), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			repo := filepath.Join(dir, repositoryDir)
+			if err := os.Mkdir(repo, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(repo, "file.txt"), []byte("original\n"), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := exec.Command("sh", filepath.Join("..", "step0.sh"))
+			cmd.Dir = repo
+			err = cmd.Run()
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.wantFile != "" {
+				c, err := os.ReadFile(filepath.Join(repo, "file.txt"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(c) != tc.wantFile {
+					t.Errorf("unexpected repository file content %q, want %q", c, tc.wantFile)
+				}
+				if _, err := os.Stat(filepath.Join(dir, stepSnapshotDir(0))); !os.IsNotExist(err) {
+					t.Errorf("expected snapshot to be removed, got %v", err)
+				}
+			}
+
+			c, err := os.ReadFile(filepath.Join(dir, stepStatusFile(0)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var status stepStatus
+			if err := json.Unmarshal(c, &status); err != nil {
+				t.Fatalf("unmarshalling status %q: %s", c, err)
+			}
+			if diff := cmp.Diff(tc.wantStat, status); diff != "" {
+				t.Errorf("unexpected status (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWrapStepScriptTimeoutKillsProcessGroup(t *testing.T) {
+	for _, bin := range []string{"sh", "setsid"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not available", bin)
+		}
+	}
+
+	dir := t.TempDir()
+
+	wrapper, err := wrapStepScript(0, batcheslib.Step{Timeout: "1s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "step0.sh"), []byte(wrapper), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	// Starts a child process that would outlive the step if only the step itself was killed.
+	run := `sleep 30 & echo $! > "$(dirname "$0")/child"; wait`
+	if err := os.WriteFile(filepath.Join(dir, stepRunScriptFile(0)), []byte(run), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := exec.Command("sh", filepath.Join(dir, "step0.sh")).Run(); err == nil {
+		t.Fatal("expected the step to fail")
+	}
+
+	child, err := os.ReadFile(filepath.Join(dir, "child"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid := strings.TrimSpace(string(child))
+
+	// The child is terminated along with the step, but may take a moment to be reaped.
+	for attempt := 0; ; attempt++ {
+		if err := exec.Command("sh", "-c", "kill -0 "+pid).Run(); err != nil {
+			break
+		}
+		if attempt == 50 {
+			t.Fatalf("expected child process %s to be terminated", pid)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
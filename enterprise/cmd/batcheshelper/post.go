@@ -50,6 +50,17 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		Outputs: make(map[string]interface{}),
 	}
 
+	// Record the outcome of steps that were run by a wrapper script.
+	status, err := readStepStatus(stepIdx)
+	if err != nil {
+		return err
+	}
+	if status != nil {
+		stepResult.ExitCode = status.ExitCode
+		stepResult.Attempts = status.Attempts
+		stepResult.TimedOut = status.TimedOut
+	}
+
 	// Render the step outputs.
 	changes, err := git.ChangesInDiff(previousResult.Diff)
 	if err != nil {
@@ -114,23 +125,49 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to compute cache key")
 	}
 
+	// Report the outcome of the step, including the attempts and timeout of
+	// steps with a failure policy. A step that continued on error still
+	// succeeded from the point of view of the execution.
+	stepMetadata := &batcheslib.TaskStepMetadata{
+		Version:  2,
+		Step:     stepIdx + 1,
+		Diff:     diff,
+		Outputs:  stepResult.Outputs,
+		ExitCode: stepResult.ExitCode,
+		Attempts: stepResult.Attempts,
+		TimedOut: stepResult.TimedOut,
+	}
+	if stepResult.ExitCode != 0 {
+		stepMetadata.Error = fmt.Sprintf("step failed with exit code %d, continued on error", stepResult.ExitCode)
+	}
+	if err := writeLogEvent(batcheslib.LogEventOperationTaskStep, stepMetadata); err != nil {
+		return errors.Wrap(err, "failed to encode step event")
+	}
+
 	metadata := &batcheslib.CacheAfterStepResultMetadata{
 		Key:   k,
 		Value: stepResult,
 	}
-	e := batcheslib.LogEvent{Operation: batcheslib.LogEventOperationCacheAfterStepResult, Status: batcheslib.LogEventStatusSuccess, Metadata: metadata}
-	e.Timestamp = time.Now().UTC().Truncate(time.Millisecond)
-	err = json.NewEncoder(os.Stdout).Encode(e)
-	if err != nil {
+	if err := writeLogEvent(batcheslib.LogEventOperationCacheAfterStepResult, metadata); err != nil {
 		return errors.Wrap(err, "failed to encode after step result event")
 	}
 
 	return nil
 }
 
+func writeLogEvent(operation batcheslib.LogEventOperation, metadata any) error {
+	e := batcheslib.LogEvent{Operation: operation, Status: batcheslib.LogEventStatusSuccess, Metadata: metadata}
+	e.Timestamp = time.Now().UTC().Truncate(time.Millisecond)
+	return json.NewEncoder(os.Stdout).Encode(e)
+}
+
+// repositoryDir is the directory of the workspace the repository is checked
+// out in.
+const repositoryDir = "repository"
+
 func runGitCmd(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
-	cmd.Dir = "repository"
+	cmd.Dir = repositoryDir
 
 	return cmd.Output()
 }
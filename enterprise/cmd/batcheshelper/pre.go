@@ -114,6 +114,20 @@ func execPre(ctx context.Context, stepIdx int, executionInput batcheslib.Workspa
 
 	stepScriptPath := fmt.Sprintf("step%d.sh", stepIdx)
 	fullScript := []byte(envPreamble + fileMountsPreamble + runScript.String())
+
+	// Steps with a timeout, retries or that continue on error are run by a
+	// wrapper script that enforces the policy.
+	if cond && step.HasFailurePolicy() {
+		if err := os.WriteFile(stepRunScriptFile(stepIdx), fullScript, os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to write step run script file")
+		}
+		wrapper, err := wrapStepScript(stepIdx, step)
+		if err != nil {
+			return err
+		}
+		fullScript = []byte(wrapper)
+	}
+
 	if err := os.WriteFile(stepScriptPath, fullScript, os.ModePerm); err != nil {
 		return errors.Wrap(err, "failed to write step script file")
 	}
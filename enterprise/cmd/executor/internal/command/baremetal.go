@@ -0,0 +1,305 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// bareMetalRunner invokes commands directly on the host. Each command runs in a fresh set
+// of Linux namespaces and, if configured, within a cgroup v2 that enforces the CPU and
+// memory limits of the job. Within its mount namespace, a command only sees its own
+// processes in /proc, read-only copies of the system directories of the host, and the
+// workspace and scratch directory of its job. This runner is meant for hosts on which
+// neither docker nor KVM are available. Steps that specify an image are rejected, as
+// there is no container runtime to provide that image.
+type bareMetalRunner struct {
+	name    string
+	dir     string
+	logger  Logger
+	options Options
+	// tmpDir is the per-job scratch directory, which is used as HOME and TMPDIR of
+	// commands.
+	tmpDir string
+	// cgroupPath is the cgroup created for the job, or empty if none is used.
+	cgroupPath string
+}
+
+var _ Runner = &bareMetalRunner{}
+
+func (r *bareMetalRunner) Setup(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "executor-bare-metal-runner")
+	if err != nil {
+		return errors.Wrap(err, "failed to create tmp dir for bare-metal runner")
+	}
+	r.tmpDir = dir
+
+	for _, name := range []string{"home", "tmp", "mnt"} {
+		if err := os.Mkdir(filepath.Join(r.tmpDir, name), os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to create scratch dir for bare-metal runner")
+		}
+	}
+
+	if r.options.BareMetalOptions.CgroupParent == "" {
+		return nil
+	}
+
+	handle := r.logger.Log("setup.bare-metal.cgroup", nil)
+	defer handle.Close()
+
+	cgroupPath, err := setupCgroup(r.options.BareMetalOptions.CgroupParent, r.name, r.options.ResourceOptions)
+	if err != nil {
+		fmt.Fprintf(handle, "Failed to create cgroup: %s\n", err)
+		handle.Finalize(1)
+		return err
+	}
+	r.cgroupPath = cgroupPath
+
+	fmt.Fprintf(handle, "Created cgroup %s\n", cgroupPath)
+	handle.Finalize(0)
+	return nil
+}
+
+func (r *bareMetalRunner) Teardown(ctx context.Context) error {
+	var errs error
+	if r.cgroupPath != "" {
+		handle := r.logger.Log("teardown.bare-metal.cgroup", nil)
+		fmt.Fprintf(handle, "Removing cgroup %s\n", r.cgroupPath)
+		if err := teardownCgroup(r.cgroupPath); err != nil {
+			fmt.Fprintf(handle, "Operation failed: %s\n", err)
+			errs = errors.Append(errs, err)
+		}
+		// We always finish this with exit code 0 even if it errored, because cgroup
+		// cleanup doesn't fail the execution job.
+		handle.Finalize(0)
+		handle.Close()
+	}
+
+	if err := os.RemoveAll(r.tmpDir); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "failed to remove tmp dir for bare-metal runner"))
+	}
+
+	return errs
+}
+
+func (r *bareMetalRunner) Run(ctx context.Context, command CommandSpec) error {
+	if command.Image != "" {
+		return errors.Wrapf(ErrIllegalCommand, "bare-metal runner cannot run %s, which specifies the image %s", command.Key, command.Image)
+	}
+
+	return runCommand(ctx, formatBareMetalCommand(command, r.dir, r.tmpDir, r.cgroupPath), r.logger)
+}
+
+// formatBareMetalCommand constructs the command to run on the host in order to invoke
+// the given spec. The command of the spec is isolated in Linux namespaces and moved to
+// the given cgroup, if any.
+func formatBareMetalCommand(spec CommandSpec, dir, scratchDir, cgroupPath string) command {
+	// The scratch directory comes first so that the environment of the step takes
+	// precedence.
+	env := flatten(
+		"HOME="+filepath.Join(scratchDir, "home"),
+		"TMPDIR="+filepath.Join(scratchDir, "tmp"),
+		spec.Env,
+	)
+
+	return command{
+		Key:       spec.Key,
+		Command:   spec.Command,
+		Dir:       filepath.Join(dir, spec.Dir),
+		Env:       env,
+		Operation: spec.Operation,
+		Isolation: &isolation{
+			cgroupPath:   cgroupPath,
+			workspaceDir: dir,
+			scratchDir:   scratchDir,
+		},
+	}
+}
+
+// isolation describes how a command invoked on the host is confined.
+type isolation struct {
+	// cgroupPath is the cgroup the command is moved into before it starts executing.
+	// Empty if the command should remain in the cgroup of the executor.
+	cgroupPath string
+	// workspaceDir and scratchDir are the only writable directories of the command,
+	// which are visible at the same path as on the host.
+	workspaceDir string
+	scratchDir   string
+}
+
+// isolationScript is run with sh in the namespaces of the command, and sets them up
+// before running the command.
+//
+// It first holds the command back until a line can be read from file descriptor 3. The
+// executor writes that line once the process has been moved into its cgroup, so that no
+// part of the command ever runs unconstrained. If the executor closes the pipe without
+// writing, the command is not run at all.
+//
+// It then makes all mounts private, so that nothing propagates back to the host, and
+// assembles a fresh root file system on a tmpfs, staged in the mnt directory of the
+// scratch directory. The new root holds read-only bind mounts of the system directories
+// of the host (and of the target of /etc/resolv.conf, which may live elsewhere), /dev,
+// a /proc that only shows the processes of the command, and the workspace and scratch
+// directory of the job. Home directories, credentials and the workspaces of other jobs
+// are not part of it. After pivoting into the new root, the old root is detached, the
+// new root is made read-only, and the working directory is resolved again within the
+// new root.
+//
+// Finally, the command is run in nested user and mount namespaces. Mounts inherited by
+// a less privileged namespace are locked, so the command cannot undo any of the above.
+const isolationScript = `read -r _ <&3 || exit 126
+exec 3<&-
+set -e
+workspace=$1 scratch=$2
+shift 2
+root=$scratch/mnt
+mount --make-rprivate /
+mount -t tmpfs tmpfs "$root"
+for dir in /bin /sbin /lib /lib32 /lib64 /libx32 /usr /etc /opt; do
+  if [ -L "$dir" ]; then
+    ln -s "$(readlink "$dir")" "$root$dir"
+  elif [ -d "$dir" ]; then
+    mkdir "$root$dir"
+    mount --bind -o ro "$dir" "$root$dir"
+  fi
+done
+conf=$(readlink -f /etc/resolv.conf || true)
+if [ -f "$conf" ] && [ ! -e "$root$conf" ]; then
+  mkdir -p "$root${conf%/*}"
+  touch "$root$conf"
+  mount --bind -o ro "$conf" "$root$conf"
+fi
+mkdir "$root/dev" "$root/proc" "$root/.old"
+mount --rbind /dev "$root/dev"
+mount -t proc proc "$root/proc"
+for dir in "$workspace" "$scratch"; do
+  mkdir -p "$root$dir"
+  mount --bind "$dir" "$root$dir"
+done
+pivot_root "$root" "$root/.old"
+umount -l /.old
+rmdir /.old
+mount -o remount,ro /
+cd "$PWD"
+exec unshare --user --mount --map-root-user -- "$@"`
+
+// wrap returns the given command line prefixed by the isolation script.
+func (i *isolation) wrap(args []string) []string {
+	return flatten("sh", "-c", isolationScript, "sh", i.workspaceDir, i.scratchDir, args)
+}
+
+// confine configures the given (wrapped, but not yet started) command to run in new
+// namespaces and attaches the gate. The returned function must be called once starting
+// the command was attempted. It moves the process into the cgroup and releases it.
+func (i *isolation) confine(cmd *exec.Cmd) (func(cmd *exec.Cmd) error, error) {
+	if err := setNamespaces(cmd); err != nil {
+		return nil, err
+	}
+
+	gateReader, gateWriter, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating gate pipe")
+	}
+	// This becomes file descriptor 3 in the child, as there are no other extra files.
+	cmd.ExtraFiles = []*os.File{gateReader}
+
+	return func(cmd *exec.Cmd) error {
+		defer gateWriter.Close()
+		gateReader.Close()
+
+		if cmd.Process == nil {
+			return nil
+		}
+		if i.cgroupPath != "" {
+			if err := addToCgroup(i.cgroupPath, cmd.Process.Pid); err != nil {
+				return err
+			}
+		}
+
+		_, err := gateWriter.Write([]byte("\n"))
+		return err
+	}, nil
+}
+
+// cgroupCPUPeriod is the period in microseconds over which the CPU quota of a cgroup is
+// enforced.
+const cgroupCPUPeriod = 100000
+
+// setupCgroup creates a cgroup v2 with the given name below the given parent cgroup and
+// applies the CPU and memory limits from the given options. The cpu and memory controllers
+// must be available to the parent cgroup, which must be writable by the executor.
+func setupCgroup(parent, name string, options ResourceOptions) (string, error) {
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return "", errors.Wrap(err, "creating parent cgroup")
+	}
+	if err := writeCgroupFile(parent, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+		return "", errors.Wrap(err, "enabling cgroup controllers")
+	}
+
+	path := filepath.Join(parent, name)
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return "", errors.Wrap(err, "creating cgroup")
+	}
+
+	if options.NumCPUs != 0 {
+		quota := fmt.Sprintf("%d %d", options.NumCPUs*cgroupCPUPeriod, cgroupCPUPeriod)
+		if err := writeCgroupFile(path, "cpu.max", quota); err != nil {
+			return "", errors.Wrap(err, "setting cgroup cpu limit")
+		}
+	}
+
+	if options.Memory != "0" && options.Memory != "" {
+		memory, err := datasize.ParseString(options.Memory)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid memory limit %q", options.Memory)
+		}
+		if err := writeCgroupFile(path, "memory.max", strconv.FormatUint(memory.Bytes(), 10)); err != nil {
+			return "", errors.Wrap(err, "setting cgroup memory limit")
+		}
+	}
+
+	return path, nil
+}
+
+// addToCgroup moves the process with the given pid into the given cgroup.
+func addToCgroup(path string, pid int) error {
+	if err := writeCgroupFile(path, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+		return errors.Wrap(err, "adding process to cgroup")
+	}
+	return nil
+}
+
+// teardownCgroup kills all processes left in the given cgroup and removes it.
+func teardownCgroup(path string) error {
+	// cgroup.kill is only available from Linux 5.14 onwards. On older kernels,
+	// processes are still cleaned up as each command runs in its own PID namespace.
+	if f, err := os.OpenFile(filepath.Join(path, "cgroup.kill"), os.O_WRONLY, 0); err == nil {
+		_, err = f.WriteString("1")
+		f.Close()
+		if err != nil {
+			return errors.Wrap(err, "killing cgroup processes")
+		}
+	}
+
+	// Killed processes may take a moment to disappear, until then the cgroup is busy.
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		if err = os.RemoveAll(path); err == nil {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return errors.Wrap(err, "removing cgroup")
+}
+
+func writeCgroupFile(path, name, content string) error {
+	return os.WriteFile(filepath.Join(path, name), []byte(content), 0o644)
+}
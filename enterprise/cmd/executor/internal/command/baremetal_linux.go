@@ -0,0 +1,30 @@
+//go:build linux
+
+package command
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setNamespaces configures the given command to run in new user, mount, PID, IPC and
+// UTS namespaces. The network namespace is shared with the host, as jobs need to reach
+// the Sourcegraph instance and code hosts. The user of the executor is mapped to root
+// within the user namespace, which lets the isolation script set up the mount namespace.
+// Files written to the workspace are still owned by the user of the executor.
+func setNamespaces(cmd *exec.Cmd) error {
+	uid, gid := os.Getuid(), os.Getgid()
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER |
+			syscall.CLONE_NEWNS |
+			syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWIPC |
+			syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: uid, Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: gid, Size: 1}},
+		// Do not leave the command running if the executor goes away.
+		Pdeathsig: syscall.SIGKILL,
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+//go:build linux
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBareMetalRunnerIsolation(t *testing.T) {
+	if err := exec.Command("unshare", "--user", "--mount", "--map-root-user", "true").Run(); err != nil {
+		t.Skipf("user namespaces are not available: %s", err)
+	}
+
+	dir := t.TempDir()
+	otherWorkspace := t.TempDir()
+	// A directory of the host outside of its temporary and system directories, just like
+	// home directories.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostDir, err := os.MkdirTemp(wd, "host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hostDir)
+
+	script := strings.Join([]string{
+		"set -e",
+		// Only the processes of the command are visible.
+		`test ! -e "/proc/$EXECUTOR_PID"`,
+		// The workspaces of other jobs and the rest of the host are hidden.
+		`test ! -e "$OTHER_WORKSPACE"`,
+		`test ! -e "$HOST_DIR"`,
+		// The system directories of the host are visible but read-only.
+		`test -x /bin/sh`,
+		`! touch /usr/executor-test 2>/dev/null`,
+		`! mkdir /executor-test 2>/dev/null`,
+		// The workspace and the scratch directory are writable.
+		`echo workspace > out`,
+		`echo scratch > "$TMPDIR/out"`,
+		// Mounts cannot be undone by the command.
+		`! mount -o remount,rw /usr 2>/dev/null`,
+		`! touch /usr/executor-test 2>/dev/null`,
+	}, "\n")
+	if err := os.MkdirAll(filepath.Join(dir, ScriptsPath), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ScriptsPath, "0.sh"), []byte(script), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var output bytes.Buffer
+	entry := NewMockLogEntry()
+	entry.WriteFunc.SetDefaultHook(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return output.Write(p)
+	})
+	logger := NewMockLogger()
+	logger.LogFunc.SetDefaultReturn(entry)
+
+	runner := &bareMetalRunner{name: "test", dir: dir, logger: logger}
+	if err := runner.Setup(context.Background()); err != nil {
+		t.Fatalf("unexpected error setting up runner: %s", err)
+	}
+	defer runner.Teardown(context.Background())
+
+	// Only the allowed binaries can be run, none of which lets the test probe its
+	// environment.
+	defer func(binaries []string) { allowedBinaries = binaries }(allowedBinaries)
+	allowedBinaries = append(allowedBinaries, "sh")
+
+	err = runner.Run(context.Background(), CommandSpec{
+		Key:     "step.src.0",
+		Command: []string{"sh", filepath.Join(dir, ScriptsPath, "0.sh")},
+		Env: []string{
+			"EXECUTOR_PID=" + strconv.Itoa(os.Getpid()),
+			"HOST_DIR=" + hostDir,
+			"OTHER_WORKSPACE=" + otherWorkspace,
+		},
+		Operation: makeTestOperation(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running command: %s\noutput:\n%s", err, output.String())
+	}
+
+	if out, err := os.ReadFile(filepath.Join(dir, "out")); err != nil || string(out) != "workspace\n" {
+		t.Errorf("unexpected workspace output. err=%v have=%q", err, out)
+	}
+	if out, err := os.ReadFile(filepath.Join(runner.tmpDir, "tmp", "out")); err != nil || string(out) != "scratch\n" {
+		t.Errorf("unexpected scratch output. err=%v have=%q", err, out)
+	}
+}
+
+func TestPrepCommandIsolatedEnv(t *testing.T) {
+	t.Setenv("HOME", "/home/executor")
+
+	command := command{
+		Command:   []string{"git", "status"},
+		Env:       []string{"HOME=/tmp/scratch/home"},
+		Operation: makeTestOperation(),
+		Isolation: &isolation{workspaceDir: "/tmp/workspace", scratchDir: "/tmp/scratch"},
+	}
+	cmd, _, _, release, err := prepCommand(context.Background(), command)
+	if err != nil {
+		t.Fatalf("unexpected error preparing command: %s", err)
+	}
+	defer release(cmd)
+
+	// The variables explicitly set for an isolated command are not overridden by the host.
+	for _, kv := range cmd.Env {
+		if kv == "HOME=/home/executor" {
+			t.Errorf("unexpected HOME of the host in environment %q", cmd.Env)
+		}
+	}
+}
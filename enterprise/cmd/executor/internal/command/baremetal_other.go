@@ -0,0 +1,14 @@
+//go:build !linux
+
+package command
+
+import (
+	"os/exec"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// setNamespaces fails on hosts other than Linux, as namespaces are a Linux feature.
+func setNamespaces(cmd *exec.Cmd) error {
+	return errors.New("the bare-metal runner is only supported on linux hosts")
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFormatBareMetalCommand(t *testing.T) {
+	actual := formatBareMetalCommand(
+		CommandSpec{
+			Key:       "step.src.0",
+			Command:   []string{"src", "batch", "exec"},
+			Dir:       "subdir",
+			Env:       []string{"HOME=/custom"},
+			Operation: makeTestOperation(),
+		},
+		"/proj/src",
+		"/tmp/scratch",
+		"/sys/fs/cgroup/executor/job",
+	)
+
+	expected := command{
+		Key:     "step.src.0",
+		Command: []string{"src", "batch", "exec"},
+		Dir:     "/proj/src/subdir",
+		Env: []string{
+			"HOME=/tmp/scratch/home",
+			"TMPDIR=/tmp/scratch/tmp",
+			"HOME=/custom",
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+	expectedIsolation := &isolation{
+		cgroupPath:   "/sys/fs/cgroup/executor/job",
+		workspaceDir: "/proj/src",
+		scratchDir:   "/tmp/scratch",
+	}
+	if diff := cmp.Diff(expectedIsolation, actual.Isolation, cmp.AllowUnexported(isolation{})); diff != "" {
+		t.Errorf("unexpected isolation (-want +got):\n%s", diff)
+	}
+}
+
+func TestBareMetalRunnerRejectsImage(t *testing.T) {
+	runner := &bareMetalRunner{name: "test", dir: "/proj/src", logger: NewMockLogger()}
+
+	err := runner.Run(context.Background(), CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine",
+		ScriptPath: "0.sh",
+		Operation:  makeTestOperation(),
+	})
+	if !errors.Is(err, ErrIllegalCommand) {
+		t.Fatalf("unexpected error. want=%q have=%v", ErrIllegalCommand, err)
+	}
+}
+
+func TestIsolationWrap(t *testing.T) {
+	actual := (&isolation{workspaceDir: "/tmp/workspace", scratchDir: "/tmp/scratch"}).wrap([]string{"git", "status"})
+	expected := []string{"sh", "-c", isolationScript, "sh", "/tmp/workspace", "/tmp/scratch", "git", "status"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetupCgroup(t *testing.T) {
+	parent := filepath.Join(t.TempDir(), "executor")
+
+	path, err := setupCgroup(parent, "job", ResourceOptions{NumCPUs: 4, Memory: "12G"})
+	if err != nil {
+		t.Fatalf("unexpected error setting up cgroup: %s", err)
+	}
+	if want := filepath.Join(parent, "job"); path != want {
+		t.Errorf("unexpected cgroup path. want=%q have=%q", want, path)
+	}
+
+	for name, want := range map[string]string{
+		filepath.Join(parent, "cgroup.subtree_control"): "+cpu +memory",
+		filepath.Join(path, "cpu.max"):                  "400000 100000",
+		filepath.Join(path, "memory.max"):               "12884901888",
+	} {
+		have, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %s", name, err)
+		}
+		if string(have) != want {
+			t.Errorf("unexpected content of %s. want=%q have=%q", name, want, string(have))
+		}
+	}
+
+	if err := addToCgroup(path, 42); err != nil {
+		t.Fatalf("unexpected error adding process to cgroup: %s", err)
+	}
+	if have, _ := os.ReadFile(filepath.Join(path, "cgroup.procs")); string(have) != "42" {
+		t.Errorf("unexpected content of cgroup.procs. want=%q have=%q", "42", string(have))
+	}
+
+	if err := teardownCgroup(path); err != nil {
+		t.Fatalf("unexpected error tearing down cgroup: %s", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected cgroup to be removed. err=%v", err)
+	}
+}
+
+func TestSetupCgroupWithoutLimits(t *testing.T) {
+	parent := t.TempDir()
+
+	path, err := setupCgroup(parent, "job", ResourceOptions{Memory: "0"})
+	if err != nil {
+		t.Fatalf("unexpected error setting up cgroup: %s", err)
+	}
+	for _, name := range []string{"cpu.max", "memory.max"} {
+		if _, err := os.Stat(filepath.Join(path, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be written. err=%v", name, err)
+		}
+	}
+}
+
+func TestSetupCgroupInvalidMemory(t *testing.T) {
+	if _, err := setupCgroup(t.TempDir(), "job", ResourceOptions{Memory: "lots"}); err == nil {
+		t.Fatal("expected an error for an invalid memory limit")
+	}
+}
@@ -22,6 +22,9 @@ type command struct {
 	Dir       string
 	Env       []string
 	Operation *observation.Operation
+	// Isolation, if set, confines the command on the host. This is only
+	// set by the bare-metal runner.
+	Isolation *isolation
 }
 
 // runCommand invokes the given command on the host machine. The standard output and
@@ -43,7 +46,7 @@ func runCommand(ctx context.Context, command command, logger Logger) (err error)
 		return err
 	}
 
-	cmd, stdout, stderr, err := prepCommand(ctx, command)
+	cmd, stdout, stderr, release, err := prepCommand(ctx, command)
 	if err != nil {
 		return err
 	}
@@ -77,7 +80,7 @@ func runCommand(ctx context.Context, command command, logger Logger) (err error)
 	defer handle.Close()
 
 	pipeReaderWaitGroup := readProcessPipes(handle, stdout, stderr)
-	exitCode, err := monitorCommand(ctx, cmd, pipeReaderWaitGroup)
+	exitCode, err := monitorCommand(ctx, cmd, release, pipeReaderWaitGroup)
 	handle.Finalize(exitCode)
 	if err != nil {
 		return err
@@ -116,28 +119,59 @@ func validateCommand(command []string) error {
 	return ErrIllegalCommand
 }
 
-func prepCommand(ctx context.Context, command command) (cmd *exec.Cmd, stdout, stderr io.ReadCloser, err error) {
-	cmd = exec.CommandContext(ctx, command.Command[0], command.Command[1:]...)
+// prepCommand creates the command to invoke along with pipes attached to its output
+// streams. If the command is isolated, the returned release function is non-nil and
+// must be called after starting the command was attempted.
+func prepCommand(ctx context.Context, command command) (cmd *exec.Cmd, stdout, stderr io.ReadCloser, release func(cmd *exec.Cmd) error, err error) {
+	args := command.Command
+	if command.Isolation != nil {
+		args = command.Isolation.wrap(args)
+	}
+
+	cmd = exec.CommandContext(ctx, args[0], args[1:]...)
 	cmd.Dir = command.Dir
 
 	env := command.Env
 	for _, k := range forwardedHostEnvVars {
+		// Variables explicitly set for an isolated command take precedence. The bare-metal
+		// runner points HOME at the scratch directory of the job, as the home directory of
+		// the host is not visible to the command.
+		if command.Isolation != nil && hasEnvVar(env, k) {
+			continue
+		}
 		env = append(env, fmt.Sprintf("%s=%s", k, os.Getenv(k)))
 	}
 
 	cmd.Env = env
 
+	if command.Isolation != nil {
+		release, err = command.Isolation.confine(cmd)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
 	stdout, err = cmd.StdoutPipe()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	stderr, err = cmd.StderrPipe()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	return cmd, stdout, stderr, nil
+	return cmd, stdout, stderr, release, nil
+}
+
+// hasEnvVar returns true if the given list of `KEY=value` pairs sets the given key.
+func hasEnvVar(env []string, key string) bool {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			return true
+		}
+	}
+	return false
 }
 
 // forwardedHostEnvVars is a list of environment variable names that are inherited
@@ -175,11 +209,21 @@ func readProcessPipes(logWriter io.WriteCloser, stdout, stderr io.Reader) *errgr
 }
 
 // monitorCommand starts the given command and waits for the given errgroup to complete.
+// If given, the release function is called right after the command has been started.
 // This function returns a non-nil error only if there was a system issue - commands that
 // run but fail due to a non-zero exit code will return a nil error and the exit code.
-func monitorCommand(ctx context.Context, cmd *exec.Cmd, pipeReaderWaitGroup *errgroup.Group) (int, error) {
-	if err := cmd.Start(); err != nil {
-		return 0, errors.Wrap(err, "starting command")
+func monitorCommand(ctx context.Context, cmd *exec.Cmd, release func(cmd *exec.Cmd) error, pipeReaderWaitGroup *errgroup.Group) (int, error) {
+	startErr := cmd.Start()
+	if release != nil {
+		if err := release(cmd); err != nil && startErr == nil {
+			// The command is held back by the gate, so it did not do anything yet.
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return 0, errors.Wrap(err, "isolating command")
+		}
+	}
+	if startErr != nil {
+		return 0, errors.Wrap(startErr, "starting command")
 	}
 
 	select {
@@ -24,3 +24,22 @@ func TestRunCommandIllegalCommand(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
 	}
 }
+
+func TestPrepCommandForwardedHostEnv(t *testing.T) {
+	t.Setenv("HOME", "/home/executor")
+
+	command := command{
+		Command:   []string{"git", "status"},
+		Env:       []string{"HOME=/custom"},
+		Operation: makeTestOperation(),
+	}
+	cmd, _, _, _, err := prepCommand(context.Background(), command)
+	if err != nil {
+		t.Fatalf("unexpected error preparing command: %s", err)
+	}
+
+	// The variables of the host are appended, so they take precedence.
+	if have, want := cmd.Env[len(cmd.Env)-len(forwardedHostEnvVars)], "HOME=/home/executor"; have != want {
+		t.Errorf("unexpected HOME. want=%q have=%q", want, have)
+	}
+}
@@ -16,7 +16,7 @@ import (
 // Runner is the interface between an executor and the host on which commands
 // are invoked. Having this interface at this level allows us to use the same
 // code paths for local development (via shell + docker) as well as production
-// usage (via Firecracker).
+// usage (via Firecracker or, where virtualization is unavailable, bare-metal).
 type Runner interface {
 	// Setup prepares the runner to invoke a series of commands.
 	Setup(ctx context.Context) error
@@ -51,6 +51,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// BareMetalOptions configures the behavior of commands run directly on the host.
+	BareMetalOptions BareMetalOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -91,11 +94,22 @@ type FirecrackerOptions struct {
 	DockerRegistryMirrorURLs []string
 }
 
+type BareMetalOptions struct {
+	// Enabled determines if commands will be run directly on the host, isolated in
+	// Linux namespaces. Linux hosts only.
+	Enabled bool
+
+	// CgroupParent is the path of a cgroup v2 directory, under which a cgroup that
+	// enforces the resource limits is created for each job. The cgroup must be delegated
+	// to the user running the executor. If empty, no resource limits are enforced.
+	CgroupParent string
+}
+
 type ResourceOptions struct {
-	// NumCPUs is the number of virtual CPUs a container or VM can use.
+	// NumCPUs is the number of virtual CPUs a container, VM or bare-metal job can use.
 	NumCPUs int
 
-	// Memory is the maximum amount of memory a container or VM can use.
+	// Memory is the maximum amount of memory a container, VM or bare-metal job can use.
 	Memory string
 
 	// DiskSpace is the maximum amount of disk a container or VM can use.
@@ -119,6 +133,15 @@ type ResourceOptions struct {
 
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger Logger, options Options, operations *Operations) Runner {
+	if options.BareMetalOptions.Enabled {
+		return &bareMetalRunner{
+			name:    options.ExecutorName,
+			dir:     dir,
+			logger:  logger,
+			options: options,
+		}
+	}
+
 	if !options.FirecrackerOptions.Enabled {
 		return &dockerRunner{
 			dir:       dir,
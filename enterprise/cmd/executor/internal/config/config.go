@@ -31,6 +31,8 @@ type Config struct {
 	KeepWorkspaces                 bool
 	DockerHostMountPath            string
 	UseFirecracker                 bool
+	UseBareMetal                   bool
+	BareMetalCgroupParent          string
 	JobNumCPUs                     int
 	JobMemory                      string
 	FirecrackerDiskSpace           string
@@ -56,6 +58,8 @@ func (c *Config) Load() {
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.UseBareMetal = c.GetBool("EXECUTOR_USE_BARE_METAL", "false", "Whether to run commands directly on the host, isolated in Linux namespaces in which they only see their own processes, their workspace and read-only system directories. For hosts where neither docker nor KVM are available. Steps that specify an image are not supported. Requires EXECUTOR_USE_FIRECRACKER=false, unprivileged user namespaces, mount, pivot_root, umount and unshare. Linux hosts only.")
+	c.BareMetalCgroupParent = c.GetOptional("EXECUTOR_BARE_METAL_CGROUP_PARENT", "The path of a cgroup v2 directory delegated to the executor, under which a cgroup enforcing EXECUTOR_JOB_NUM_CPUS and EXECUTOR_JOB_MEMORY is created for each job. Resource limits are not enforced if unset.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -95,6 +99,20 @@ func (c *Config) Validate() error {
 		c.AddError(errors.Wrap(c.dockerAuthConfigUnmarshalError, "invalid EXECUTOR_DOCKER_AUTH_CONFIG, failed to parse"))
 	}
 
+	if c.UseBareMetal {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_BARE_METAL cannot be used together with EXECUTOR_USE_FIRECRACKER."))
+		}
+		if runtime.GOOS != "linux" {
+			c.AddError(errors.New("EXECUTOR_USE_BARE_METAL is only supported on linux hosts."))
+		}
+		if c.BareMetalCgroupParent != "" {
+			if _, err := datasize.ParseString(c.JobMemory); err != nil {
+				c.AddError(errors.Wrapf(err, "invalid memory size provided for EXECUTOR_JOB_MEMORY: %q", c.JobMemory))
+			}
+		}
+	}
+
 	if c.UseFirecracker {
 		// Validate that firecracker can work on this host.
 		if runtime.GOOS != "linux" {
@@ -72,6 +72,9 @@ var (
 	// RequiredCLIToolsFirecracker contains all the programs that are expected to
 	// exist in PATH when running the executor with firecracker enabled.
 	RequiredCLIToolsFirecracker = []string{"dmsetup", "losetup", "mkfs.ext4", "strings"}
+	// RequiredCLIToolsBareMetal contains all the programs that are expected to
+	// exist in PATH when running the executor with bare-metal execution enabled.
+	RequiredCLIToolsBareMetal = []string{"mount", "pivot_root", "umount", "unshare"}
 	// CNISubnetCIDR is the CIDR range of the VMs in firecracker. This is the ignite
 	// default and chosen so that it doesn't interfere with other common applications
 	// such as docker. It also provides room for a large number of VMs.
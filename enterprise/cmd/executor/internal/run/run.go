@@ -44,7 +44,7 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if cliCtx.Bool("verify") {
 		// Then, validate all tools that are required are installed.
-		if err := validateToolsRequired(cfg.UseFirecracker); err != nil {
+		if err := validateToolsRequired(cfg.UseFirecracker, cfg.UseBareMetal); err != nil {
 			return err
 		}
 
@@ -99,6 +99,7 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		WorkerOptions:      workerOptions(c),
 		DockerOptions:      dockerOptions(c),
 		FirecrackerOptions: firecrackerOptions(c),
+		BareMetalOptions:   bareMetalOptions(c),
 		ResourceOptions:    resourceOptions(c),
 		GitServicePath:     "/.executors/git",
 		QueueOptions:       queueOptions(c, queueTelemetryOptions),
@@ -154,6 +155,13 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+func bareMetalOptions(c *config.Config) command.BareMetalOptions {
+	return command.BareMetalOptions{
+		Enabled:      c.UseBareMetal,
+		CgroupParent: c.BareMetalCgroupParent,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
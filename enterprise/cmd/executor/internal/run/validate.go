@@ -25,7 +25,7 @@ func RunValidate(cliCtx *cli.Context, logger log.Logger, config *config.Config)
 	}
 
 	// Then, validate all tools that are required are installed.
-	if err := validateToolsRequired(config.UseFirecracker); err != nil {
+	if err := validateToolsRequired(config.UseFirecracker, config.UseBareMetal); err != nil {
 		return err
 	}
 
@@ -134,9 +134,13 @@ func latestSrcCLIVersion(ctx context.Context, client *apiclient.BaseClient, opti
 	return v.Version, nil
 }
 
-func validateToolsRequired(useFirecracker bool) error {
+func validateToolsRequired(useFirecracker, useBareMetal bool) error {
 	notFoundTools := []string{}
 	for tool := range config.RequiredCLITools {
+		// Bare-metal execution does not run steps in containers.
+		if useBareMetal && tool == "docker" {
+			continue
+		}
 		if found, err := existsPath(tool); err != nil {
 			return err
 		} else if !found {
@@ -152,12 +156,21 @@ func validateToolsRequired(useFirecracker bool) error {
 			}
 		}
 	}
+	if useBareMetal {
+		for _, tool := range config.RequiredCLIToolsBareMetal {
+			if found, err := existsPath(tool); err != nil {
+				return err
+			} else if !found {
+				notFoundTools = append(notFoundTools, tool)
+			}
+		}
+	}
 
 	if len(notFoundTools) > 0 {
 		var errs error
 		for _, tool := range notFoundTools {
 			helptext, ok := config.RequiredCLITools[tool]
-			// TODO: Help lines for config.RequiredCLIToolsFirecracker.
+			// TODO: Help lines for config.RequiredCLIToolsFirecracker and config.RequiredCLIToolsBareMetal.
 			helpLine := ""
 			if ok {
 				helpLine = fmt.Sprintf("\n%s", helptext)
@@ -36,6 +36,7 @@ func (h *handler) prepareWorkspace(
 		)
 	}
 
+	// Docker and bare-metal execution both operate on a workspace on the host.
 	return workspace.NewDockerWorkspace(
 		ctx,
 		h.filesStore,
@@ -0,0 +1,170 @@
+package bitbucketcloudoauth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseConfig(t *testing.T) {
+	logger := logtest.Scoped(t)
+	spew.Config.DisablePointerAddresses = true
+	spew.Config.SortKeys = true
+	spew.Config.SpewKeys = true
+
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	type args struct {
+		cfg *conf.Unified
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantProviders []Provider
+		wantProblems  []string
+	}{
+		{
+			name:          "No configs",
+			args:          args{cfg: &conf.Unified{}},
+			wantProviders: []Provider(nil),
+		},
+		{
+			name: "1 Bitbucket Cloud config",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				ExternalURL: "https://sourcegraph.example.com",
+				AuthProviders: []schema.AuthProviders{{
+					Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+						ClientKey:    "my-client-key",
+						ClientSecret: "my-client-secret",
+						DisplayName:  "Bitbucket Cloud",
+						Type:         extsvc.TypeBitbucketCloud,
+					},
+				}},
+			}}},
+			wantProviders: []Provider{
+				{
+					BitbucketCloudAuthProvider: &schema.BitbucketCloudAuthProvider{
+						ClientKey:    "my-client-key",
+						ClientSecret: "my-client-secret",
+						DisplayName:  "Bitbucket Cloud",
+						Type:         extsvc.TypeBitbucketCloud,
+					},
+					Provider: provider("https://bitbucket.org/", oauth2.Config{
+						RedirectURL:  "https://sourcegraph.example.com/.auth/bitbucketcloud/callback",
+						ClientID:     "my-client-key",
+						ClientSecret: "my-client-secret",
+						Endpoint: oauth2.Endpoint{
+							AuthURL:  "https://bitbucket.org/site/oauth2/authorize",
+							TokenURL: "https://bitbucket.org/site/oauth2/access_token",
+						},
+					}),
+				},
+			},
+		},
+		{
+			name: "2 Bitbucket Cloud configs with the same URL",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				ExternalURL: "https://sourcegraph.example.com",
+				AuthProviders: []schema.AuthProviders{{
+					Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+						ClientKey:    "my-client-key",
+						ClientSecret: "my-client-secret",
+						Type:         extsvc.TypeBitbucketCloud,
+						Url:          "https://bitbucket.org",
+					},
+				}, {
+					Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+						ClientKey:    "my-client-key-2",
+						ClientSecret: "my-client-secret-2",
+						Type:         extsvc.TypeBitbucketCloud,
+						Url:          "https://bitbucket.org/",
+					},
+				}},
+			}}},
+			wantProviders: []Provider{
+				{
+					BitbucketCloudAuthProvider: &schema.BitbucketCloudAuthProvider{
+						ClientKey:    "my-client-key",
+						ClientSecret: "my-client-secret",
+						Type:         extsvc.TypeBitbucketCloud,
+						Url:          "https://bitbucket.org",
+					},
+					Provider: provider("https://bitbucket.org/", oauth2.Config{
+						RedirectURL:  "https://sourcegraph.example.com/.auth/bitbucketcloud/callback",
+						ClientID:     "my-client-key",
+						ClientSecret: "my-client-secret",
+						Endpoint: oauth2.Endpoint{
+							AuthURL:  "https://bitbucket.org/site/oauth2/authorize",
+							TokenURL: "https://bitbucket.org/site/oauth2/access_token",
+						},
+					}),
+				},
+			},
+			wantProblems: []string{
+				`Cannot have more than one auth provider with url "https://bitbucket.org/", only the first one will be used`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProviders, gotProblems := parseConfig(logtest.Scoped(t), tt.args.cfg, db)
+			gotConfigs := make([]oauth2.Config, len(gotProviders))
+			for k, p := range gotProviders {
+				if p, ok := p.Provider.(*oauth.Provider); ok {
+					p.Login, p.Callback = nil, nil
+					gotConfigs[k] = p.OAuth2Config()
+					p.OAuth2Config = nil
+					p.ProviderOp.Login, p.ProviderOp.Callback = nil, nil
+				}
+			}
+			wantConfigs := make([]oauth2.Config, len(tt.wantProviders))
+			for k, p := range tt.wantProviders {
+				k := k
+				if q, ok := p.Provider.(*oauth.Provider); ok {
+					q.SourceConfig = schema.AuthProviders{Bitbucketcloud: p.BitbucketCloudAuthProvider}
+					wantConfigs[k] = q.OAuth2Config()
+					q.OAuth2Config = nil
+				}
+			}
+			if !reflect.DeepEqual(gotProviders, tt.wantProviders) {
+				dmp := diffmatchpatch.New()
+				t.Errorf("parseConfig() gotProviders != tt.wantProviders, diff:\n%s",
+					dmp.DiffPrettyText(dmp.DiffMain(spew.Sdump(tt.wantProviders), spew.Sdump(gotProviders), false)),
+				)
+			}
+			if !reflect.DeepEqual(gotProblems.Messages(), tt.wantProblems) {
+				t.Errorf("parseConfig() gotProblems = %v, want %v", gotProblems, tt.wantProblems)
+			}
+
+			if !reflect.DeepEqual(gotConfigs, wantConfigs) {
+				dmp := diffmatchpatch.New()
+				t.Errorf("parseConfig() gotConfigs != wantConfigs, diff:\n%s",
+					dmp.DiffPrettyText(dmp.DiffMain(spew.Sdump(gotConfigs), spew.Sdump(wantConfigs), false)),
+				)
+			}
+		})
+	}
+}
+
+func provider(serviceID string, oauth2Config oauth2.Config) *oauth.Provider {
+	op := oauth.ProviderOp{
+		AuthPrefix:   authPrefix,
+		OAuth2Config: func() oauth2.Config { return oauth2Config },
+		StateConfig:  getStateConfig(),
+		ServiceID:    serviceID,
+		ServiceType:  extsvc.TypeBitbucketCloud,
+	}
+	return &oauth.Provider{ProviderOp: op}
+}
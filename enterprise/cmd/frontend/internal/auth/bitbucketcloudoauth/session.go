@@ -0,0 +1,136 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot/hubspotutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	esauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type sessionIssuerHelper struct {
+	*extsvc.CodeHost
+	clientKey   string
+	db          database.DB
+	allowSignup *bool
+	client      bitbucketcloud.Client
+}
+
+func (s *sessionIssuerHelper) AuthSucceededEventName() database.SecurityEventName {
+	return database.SecurityEventBitbucketCloudAuthSucceeded
+}
+
+func (s *sessionIssuerHelper) AuthFailedEventName() database.SecurityEventName {
+	return database.SecurityEventBitbucketCloudAuthFailed
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL, lastSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	bbUser, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, "Could not read Bitbucket Cloud user from callback request.", errors.Wrap(err, "could not read user from context")
+	}
+
+	login, err := auth.NormalizeUsername(bbUser.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	// The user object of Bitbucket Cloud doesn't include any email addresses,
+	// so the primary email is requested separately. Only confirmed emails are
+	// used to resolve the user's identity.
+	client := s.client.WithAuthenticator(&esauth.OAuthBearerToken{Token: token.AccessToken})
+	email, err := primaryEmail(ctx, client)
+	if err != nil {
+		return nil, "Could not get the email addresses of the Bitbucket Cloud user.", err
+	}
+
+	// AllowSignup defaults to true when not set to preserve the existing behavior.
+	signupAllowed := s.allowSignup == nil || *s.allowSignup
+
+	var data extsvc.AccountData
+	if err := bitbucketcloud.SetExternalAccountData(&data, bbUser, token); err != nil {
+		return nil, "", err
+	}
+
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username:        login,
+			Email:           email,
+			EmailIsVerified: email != "",
+			DisplayName:     bbUser.DisplayName,
+			AvatarURL:       bbUser.Links["avatar"].Href,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: s.ServiceType,
+			ServiceID:   s.ServiceID,
+			ClientID:    s.clientKey,
+			AccountID:   bbUser.UUID,
+		},
+		ExternalAccountData: data,
+		CreateIfNotExist:    signupAllowed,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+
+	// There is no need to send record if we know email is empty as it's a primary property
+	if email != "" {
+		go hubspotutil.SyncUser(email, hubspotutil.SignupEventID, &hubspot.ContactProperties{
+			AnonymousUserID: anonymousUserID,
+			FirstSourceURL:  firstSourceURL,
+			LastSourceURL:   lastSourceURL,
+		})
+	}
+
+	return actor.FromUser(userID), "", nil
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := getStateConfig()
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.ServiceID,
+			Type: s.ServiceType,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
+
+// primaryEmail returns the primary email of the user authenticated by the
+// client, or an empty string if it isn't confirmed.
+func primaryEmail(ctx context.Context, client bitbucketcloud.Client) (string, error) {
+	var page *bitbucketcloud.PageToken
+	for {
+		emails, next, err := client.CurrentUserEmails(ctx, page)
+		if err != nil {
+			return "", errors.Wrap(err, "list user emails")
+		}
+		for _, e := range emails {
+			if e.IsPrimary && e.IsConfirmed {
+				return e.Email, nil
+			}
+		}
+		if !next.HasMore() {
+			return "", nil
+		}
+		page = next
+	}
+}
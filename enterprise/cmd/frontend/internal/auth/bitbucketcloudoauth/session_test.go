@@ -0,0 +1,263 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	authzbitbucketcloud "github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testToken = "dummy-token"
+
+// newTestServer returns a Bitbucket Cloud API server that only accepts
+// requests authenticated with testToken.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/2.0/user/emails":
+			_, _ = io.WriteString(w, `{"values": [
+  {"email": "old@example.com", "is_primary": false, "is_confirmed": true},
+  {"email": "dan@example.com", "is_primary": true, "is_confirmed": true}
+]}`)
+		case "/2.0/user/permissions/repositories":
+			_, _ = io.WriteString(w, `{"values": [
+  {"permission": "read", "repository": {"full_name": "ws/a", "uuid": "{a}"}},
+  {"permission": "admin", "repository": {"full_name": "ws/b", "uuid": "{b}"}}
+]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server) bitbucketcloud.Client {
+	t.Helper()
+
+	client, err := bitbucketcloud.NewClient(extsvc.URNBitbucketCloudOAuth, &schema.BitbucketCloudConnection{
+		Url:    "https://bitbucket.org",
+		ApiURL: srv.URL,
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestSessionIssuerHelper_GetOrCreateUser(t *testing.T) {
+	bbURL, _ := url.Parse("https://bitbucket.org")
+	codeHost := extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud)
+	clientKey := "client-key"
+	client := newTestClient(t, newTestServer(t))
+
+	authSaveableUsers := map[string]int32{
+		"dan": 4,
+	}
+
+	signupNotAllowed := new(bool)
+
+	bbUser := func(username, uuid string) *bitbucketcloud.User {
+		return &bitbucketcloud.User{Account: bitbucketcloud.Account{
+			Username:    username,
+			DisplayName: "Display " + username,
+			UUID:        uuid,
+			Links: bitbucketcloud.Links{
+				"avatar": {Href: "https://bitbucket.org/avatar/" + username},
+			},
+		}}
+	}
+
+	cases := []struct {
+		description   string
+		bbUser        *bitbucketcloud.User
+		token         string
+		allowSignup   *bool
+		expActor      *actor.Actor
+		expErr        bool
+		expAuthUserOp *auth.GetAndSaveUserOp
+	}{
+		{
+			description: "bbUser, allowSignup not set, defaults to true -> new user and session created",
+			bbUser:      bbUser("dan", "{dan}"),
+			token:       testToken,
+			expActor:    &actor.Actor{UID: 4},
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps: database.NewUser{
+					Username:        "dan",
+					Email:           "dan@example.com",
+					EmailIsVerified: true,
+					DisplayName:     "Display dan",
+					AvatarURL:       "https://bitbucket.org/avatar/dan",
+				},
+				ExternalAccount: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   "https://bitbucket.org/",
+					ClientID:    clientKey,
+					AccountID:   "{dan}",
+				},
+				CreateIfNotExist: true,
+			},
+		},
+		{
+			description: "bbUser, allowSignup set to false -> no new user nor session created",
+			bbUser:      bbUser("bob", "{bob}"),
+			token:       testToken,
+			allowSignup: signupNotAllowed,
+			expErr:      true,
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps: database.NewUser{
+					Username:        "bob",
+					Email:           "dan@example.com",
+					EmailIsVerified: true,
+					DisplayName:     "Display bob",
+					AvatarURL:       "https://bitbucket.org/avatar/bob",
+				},
+				ExternalAccount: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   "https://bitbucket.org/",
+					ClientID:    clientKey,
+					AccountID:   "{bob}",
+				},
+			},
+		},
+		{
+			description: "emails can't be listed -> no session created",
+			bbUser:      bbUser("dan", "{dan}"),
+			token:       "invalid-token",
+			expErr:      true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.description, func(t *testing.T) {
+			var gotAuthUserOp *auth.GetAndSaveUserOp
+			getAndSaveUserError := errors.New("auth.GetAndSaveUser error")
+
+			auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+				if gotAuthUserOp != nil {
+					t.Fatal("GetAndSaveUser called more than once")
+				}
+
+				op.ExternalAccountData = extsvc.AccountData{}
+				gotAuthUserOp = &op
+
+				if uid, ok := authSaveableUsers[op.UserProps.Username]; ok && op.CreateIfNotExist {
+					return uid, "", nil
+				}
+
+				return 0, "safeErr", getAndSaveUserError
+			}
+			defer func() { auth.MockGetAndSaveUser = nil }()
+
+			ctx := WithUser(context.Background(), c.bbUser)
+			s := &sessionIssuerHelper{
+				CodeHost:    codeHost,
+				clientKey:   clientKey,
+				allowSignup: c.allowSignup,
+				client:      client,
+			}
+
+			tok := &oauth2.Token{AccessToken: c.token}
+			actr, _, err := s.GetOrCreateUser(ctx, tok, "", "", "")
+
+			if got, exp := actr, c.expActor; !reflect.DeepEqual(got, exp) {
+				t.Errorf("expected actor %v, got %v", exp, got)
+			}
+
+			if c.expErr && err == nil {
+				t.Errorf("expected err %v, but was nil", c.expErr)
+			} else if !c.expErr && err != nil {
+				t.Errorf("expected no error, but was %v", err)
+			}
+
+			if got, exp := gotAuthUserOp, c.expAuthUserOp; !reflect.DeepEqual(got, exp) {
+				t.Error(cmp.Diff(exp, got))
+			}
+		})
+	}
+}
+
+// TestSessionIssuerHelper_LinkedAccountPermissions verifies that the account
+// linked by signing in can be used by the authz provider to fetch the
+// permissions of the user.
+func TestSessionIssuerHelper_LinkedAccountPermissions(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	bbURL, _ := url.Parse("https://bitbucket.org")
+	s := &sessionIssuerHelper{
+		CodeHost:  extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud),
+		clientKey: "client-key",
+		client:    client,
+	}
+
+	var account *extsvc.Account
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		account = &extsvc.Account{
+			ID:          1,
+			UserID:      4,
+			AccountSpec: op.ExternalAccount,
+			AccountData: op.ExternalAccountData,
+		}
+		return 4, "", nil
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	ctx := WithUser(context.Background(), &bitbucketcloud.User{Account: bitbucketcloud.Account{
+		Username: "dan",
+		UUID:     "{dan}",
+	}})
+	if _, _, err := s.GetOrCreateUser(ctx, &oauth2.Token{AccessToken: testToken}, "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if account == nil {
+		t.Fatal("no external account saved")
+	}
+
+	p, err := authzbitbucketcloud.NewProvider(&types.BitbucketCloudConnection{
+		URN: "extsvc:bitbucketcloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:    "https://bitbucket.org",
+			ApiURL: srv.URL,
+		},
+	}, authzbitbucketcloud.ProviderOptions{BitbucketCloudClient: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &authz.ExternalUserPermissions{
+		Exacts: []extsvc.RepoID{"{a}", "{b}"},
+	}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Fatalf("permissions mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -258,6 +258,9 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
 }
 
 type ChangesetSpec struct {
@@ -180,6 +180,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 							m, ok := e.Metadata.(*batcheslib.CacheAfterStepResultMetadata)
 							if ok {
 								resolver.cachedResult = &m.Value
+								resolver.result = &m.Value
 							}
 						}
 					}
@@ -88,6 +88,26 @@ func (r *batchSpecWorkspaceStepV1Resolver) ExitCode() *int32 {
 	return &code
 }
 
+func (r *batchSpecWorkspaceStepV1Resolver) Attempts() *int32 {
+	if r.stepInfo.ExitCode == nil {
+		return nil
+	}
+	// Steps without retries don't report the number of attempts.
+	attempts := int32(1)
+	if r.stepInfo.Attempts > 0 {
+		attempts = int32(r.stepInfo.Attempts)
+	}
+	return &attempts
+}
+
+func (r *batchSpecWorkspaceStepV1Resolver) TimedOut() bool {
+	return r.stepInfo.TimedOut
+}
+
+func (r *batchSpecWorkspaceStepV1Resolver) ContinuedOnError() bool {
+	return r.step.ContinueOnError && r.stepInfo.ExitCode != nil && *r.stepInfo.ExitCode != 0
+}
+
 func (r *batchSpecWorkspaceStepV1Resolver) Environment() ([]graphqlbackend.BatchSpecWorkspaceEnvironmentVariableResolver, error) {
 	// The environment is dependent on environment of the executor and template variables, that aren't
 	// known at the time when we resolve the workspace. If the step already started, src cli has logged
@@ -180,6 +200,10 @@ type batchSpecWorkspaceStepV2Resolver struct {
 
 	cachedResult      *execution.AfterStepResult
 	cachedResultFound bool
+
+	// result is the result of running the step in this execution. It is nil if
+	// the step hasn't finished yet, or if its result was taken from the cache.
+	result *execution.AfterStepResult
 }
 
 func (r *batchSpecWorkspaceStepV2Resolver) Number() int32 {
@@ -257,9 +281,34 @@ func (r *batchSpecWorkspaceStepV2Resolver) ExitCode() *int32 {
 		return nil
 	}
 	i32 := int32(*code)
+	// A step that continues on error exits successfully, so the exit code of its
+	// last attempt is only recorded in the step result.
+	if r.result != nil && r.result.ExitCode != 0 {
+		i32 = int32(r.result.ExitCode)
+	}
 	return &i32
 }
 
+func (r *batchSpecWorkspaceStepV2Resolver) Attempts() *int32 {
+	if r.result == nil {
+		return nil
+	}
+	// Steps without retries don't report the number of attempts.
+	attempts := int32(1)
+	if r.result.Attempts > 0 {
+		attempts = int32(r.result.Attempts)
+	}
+	return &attempts
+}
+
+func (r *batchSpecWorkspaceStepV2Resolver) TimedOut() bool {
+	return r.result != nil && r.result.TimedOut
+}
+
+func (r *batchSpecWorkspaceStepV2Resolver) ContinuedOnError() bool {
+	return r.result != nil && r.result.ExitCode != 0
+}
+
 func (r *batchSpecWorkspaceStepV2Resolver) Environment() ([]graphqlbackend.BatchSpecWorkspaceEnvironmentVariableResolver, error) {
 	// The environment is dependent on environment of the executor and template variables, that aren't
 	// known at the time when we resolve the workspace. If the step already started, src cli has logged
@@ -100,19 +100,20 @@ func (r *changesetApplyPreviewConnectionResolver) Nodes(ctx context.Context) ([]
 }
 
 type changesetApplyPreviewConnectionStatsResolver struct {
-	push         int32
-	update       int32
-	undraft      int32
-	publish      int32
-	publishDraft int32
-	sync         int32
-	_import      int32
-	close        int32
-	reopen       int32
-	sleep        int32
-	detach       int32
-	archive      int32
-	reattach     int32
+	push           int32
+	update         int32
+	undraft        int32
+	publish        int32
+	publishDraft   int32
+	sync           int32
+	_import        int32
+	close          int32
+	reopen         int32
+	sleep          int32
+	detach         int32
+	archive        int32
+	reattach       int32
+	updateMetadata int32
 
 	added    int32
 	modified int32
@@ -158,6 +159,9 @@ func (r *changesetApplyPreviewConnectionStatsResolver) Archive() int32 {
 func (r *changesetApplyPreviewConnectionStatsResolver) Reattach() int32 {
 	return r.reattach
 }
+func (r *changesetApplyPreviewConnectionStatsResolver) UpdateMetadata() int32 {
+	return r.updateMetadata
+}
 func (r *changesetApplyPreviewConnectionStatsResolver) Added() int32 {
 	return r.added
 }
@@ -235,6 +239,8 @@ func (r *changesetApplyPreviewConnectionResolver) Stats(ctx context.Context) (gr
 				stats.archive++
 			case string(btypes.ReconcilerOperationReattach):
 				stats.reattach++
+			case string(btypes.ReconcilerOperationUpdateMetadata):
+				stats.updateMetadata++
 			}
 		}
 	}
@@ -164,6 +164,10 @@ func (r *Resolver) UpdateCodeIntelligenceInferenceScript(ctx context.Context, ar
 	return r.autoIndexingRootResolver.UpdateCodeIntelligenceInferenceScript(ctx, args)
 }
 
+func (r *Resolver) ExplainAutoIndexingInference(ctx context.Context, args *resolverstubs.ExplainAutoIndexingInferenceArgs) (_ resolverstubs.AutoIndexingInferenceExplanationResolver, err error) {
+	return r.autoIndexingRootResolver.ExplainAutoIndexingInference(ctx, args)
+}
+
 func (r *Resolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *resolverstubs.PreviewGitObjectFilterArgs) (_ []resolverstubs.GitObjectFilterPreviewResolver, err error) {
 	return r.policiesRootResolver.PreviewGitObjectFilter(ctx, id, args)
 }
@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -278,7 +280,11 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				return err
 			}
 		case a.Webhook != nil:
-			_, err := r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL)
+			payload, err := webhookPayloadArgs(a.Webhook, "")
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL, payload)
 			if err != nil {
 				return err
 			}
@@ -290,15 +296,23 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or TeamsWebhook must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -313,8 +327,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or teams webhook")
 		}
 	}
 
@@ -330,6 +346,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -390,7 +410,12 @@ func (r *Resolver) TriggerTestWebhookAction(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
-	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, args.Webhook.URL); err != nil {
+	payload, err := webhookPayloadArgs(args.Webhook, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, args.Webhook.URL, payload); err != nil {
 		return nil, err
 	}
 
@@ -410,6 +435,23 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamsURL(args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -441,7 +483,11 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -451,6 +497,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -495,6 +544,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
 		}
 	}
 
@@ -579,8 +638,13 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or teams webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -621,7 +685,36 @@ func (r *Resolver) updateWebhookAction(ctx context.Context, args graphqlbackend.
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	// The signing secret and header values are never returned to clients, so
+	// the signing secret is kept unless it is explicitly changed, and headers
+	// set to the redacted placeholder keep their existing value.
+	var existing *edb.WebhookAction
+	if args.Update.SigningSecret == nil || hasRedactedHeader(args.Update.Headers) {
+		existing, err = r.db.CodeMonitors().GetWebhookAction(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	var existingSecret string
+	if existing != nil {
+		existingSecret = existing.SigningSecret
+	}
+	payload, err := webhookPayloadArgs(args.Update, existingSecret)
+	if err != nil {
+		return err
+	}
+	for name, value := range payload.Headers {
+		if value == types.RedactedSecret {
+			existingValue, ok := existing.Headers[name]
+			if !ok {
+				return errors.Errorf("header %q has no value to keep", name)
+			}
+			payload.Headers[name] = existingValue
+		}
+	}
+
+	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, payload)
 	return err
 }
 
@@ -636,6 +729,52 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func hasRedactedHeader(headers *[]*graphqlbackend.MonitorWebhookHeaderInput) bool {
+	if headers == nil {
+		return false
+	}
+	for _, h := range *headers {
+		if h.Value == types.RedactedSecret {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookPayloadArgs converts and validates the payload settings of a webhook
+// action. existingSecret is used if the input doesn't set a signing secret.
+func webhookPayloadArgs(args *graphqlbackend.CreateActionWebhookArgs, existingSecret string) (*edb.WebhookPayloadArgs, error) {
+	payload := &edb.WebhookPayloadArgs{SigningSecret: existingSecret}
+	if args.PayloadTemplate != nil {
+		payload.PayloadTemplate = *args.PayloadTemplate
+	}
+	if args.Headers != nil {
+		payload.Headers = make(map[string]string, len(*args.Headers))
+		for _, h := range *args.Headers {
+			payload.Headers[h.Name] = h.Value
+		}
+	}
+	if args.SigningSecret != nil {
+		payload.SigningSecret = *args.SigningSecret
+	}
+
+	if err := background.ValidateWebhookPayload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 func (r *Resolver) transact(ctx context.Context) (*Resolver, error) {
 	tx, err := r.db.Transact(ctx)
 	if err != nil {
@@ -721,9 +860,11 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind      = "CodeMonitorActionTeamsWebhook"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionTeamsWebhookEventKind = "CodeMonitorActionTeamsWebhookEvent"
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
@@ -816,7 +957,12 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -844,6 +990,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -972,6 +1127,7 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	count += m.TriggerJob.ContentChanges.Len()
 	return int32(count)
 }
 
@@ -1020,6 +1176,7 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook graphqlbackend.MonitorTeamsWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1030,6 +1187,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1047,6 +1206,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1176,6 +1339,24 @@ func (m *monitorWebhook) URL() string {
 	return m.WebhookAction.URL
 }
 
+func (m *monitorWebhook) PayloadTemplate() string {
+	return m.WebhookAction.PayloadTemplate
+}
+
+func (m *monitorWebhook) Headers() []graphqlbackend.MonitorWebhookHeaderResolver {
+	headers := make([]graphqlbackend.MonitorWebhookHeaderResolver, 0, len(m.WebhookAction.Headers))
+	// 🚨 SECURITY: header values may contain credentials, so they are redacted.
+	for name := range m.WebhookAction.Headers {
+		headers = append(headers, &monitorWebhookHeader{name: name, value: types.RedactedSecret})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name() < headers[j].Name() })
+	return headers
+}
+
+func (m *monitorWebhook) HasSigningSecret() bool {
+	return m.WebhookAction.SigningSecret != ""
+}
+
 func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1262,6 +1443,75 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTeamsWebhook struct {
+	*Resolver
+	*edb.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL() string {
+	return m.TeamsWebhookAction.URL
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		TeamsWebhookID: intPtr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorWebhookHeader struct {
+	name  string
+	value string
+}
+
+func (h *monitorWebhookHeader) Name() string {
+	return h.name
+}
+
+func (h *monitorWebhookHeader) Value() string {
+	return h.value
+}
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
@@ -1355,3 +1605,17 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Microsoft Teams webhook URLs are tenant specific, so we can only restrict
+	// them to HTTPS.
+	if u.Scheme != "https" {
+		return errors.New("teams webhook URL must begin with 'https://'")
+	}
+	return nil
+}
@@ -85,6 +85,36 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid teams webhook", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TeamsWebhook: &graphqlbackend.CreateActionTeamsWebhookArgs{
+					URL: "http://example.webhook.office.com",
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid webhook payload template", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		template := "{{ .Description }}"
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				Webhook: &graphqlbackend.CreateActionWebhookArgs{
+					URL:             "https://example.com/webhook",
+					PayloadTemplate: &template,
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("invalid query", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -1322,3 +1352,15 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestMonitorWebhookHeadersRedacted(t *testing.T) {
+	m := &monitorWebhook{WebhookAction: &edb.WebhookAction{
+		Headers: map[string]string{"X-Team": "search", "Authorization": "Bearer token"},
+	}}
+
+	var got []string
+	for _, h := range m.Headers() {
+		got = append(got, h.Name()+": "+h.Value())
+	}
+	require.Equal(t, []string{"Authorization: REDACTED", "X-Team: REDACTED"}, got)
+}
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/inconshreveable/log15"
@@ -161,6 +162,16 @@ func toComputeTextResolver(result *compute.Text, repository *gql.RepositoryResol
 	}
 }
 
+// toComputeTableResolver represents an aggregate table as JSON-encoded text,
+// as tables are not part of the ComputeResult union.
+func toComputeTableResolver(table *compute.Table) (gql.ComputeResultResolver, error) {
+	value, err := json.Marshal(table)
+	if err != nil {
+		return nil, err
+	}
+	return &computeResultResolver{result: &computeTextResolver{t: &compute.Text{Value: string(value), Kind: table.Kind}}}, nil
+}
+
 func toComputeResultResolver(result compute.Result, repoResolver *gql.RepositoryResolver, path, commit string) gql.ComputeResultResolver {
 	switch r := result.(type) {
 	case *compute.MatchContext:
@@ -200,6 +211,13 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 		return resolver
 	}
 
+	// Aggregate commands produce a partial table per match, which we merge
+	// into a single table for the whole result set.
+	var aggregator *compute.Aggregator
+	if _, ok := cmd.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(nil)
+	}
+
 	results := make([]gql.ComputeResultResolver, 0, len(matches))
 	for _, m := range matches {
 		computeResult, err := cmd.Run(ctx, db, m)
@@ -212,11 +230,25 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 			continue
 		}
 
+		if table, ok := computeResult.(*compute.Table); ok && aggregator != nil {
+			aggregator.Add(table)
+			continue
+		}
+
 		repoResolver := getRepoResolver(m.RepoName(), "")
 		path, commit := pathAndCommitFromResult(m)
 		result := toComputeResultResolver(computeResult, repoResolver, path, commit)
 		results = append(results, result)
 	}
+
+	if aggregator != nil {
+		table, _ := aggregator.Flush()
+		result, err := toComputeTableResolver(table)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
 	return results, nil
 }
 
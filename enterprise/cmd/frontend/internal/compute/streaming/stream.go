@@ -102,7 +102,22 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	matchesBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
 		return eventWriter.EventBytes("results", data)
 	})
+
+	// Aggregate commands produce a partial table per search result. Rather
+	// than streaming each partial table, we merge them and stream the
+	// aggregate whenever it changed, superseding any previous table.
+	var aggregator *compute.Aggregator
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(nil)
+	}
+
 	matchesFlush := func() {
+		if aggregator != nil {
+			if table, dirty := aggregator.Flush(); dirty {
+				_ = matchesBuf.Append(table)
+			}
+		}
+
 		if err := matchesBuf.Flush(); err != nil {
 			// EOF
 			return
@@ -124,6 +139,10 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if table, ok := result.(*compute.Table); ok && aggregator != nil {
+				aggregator.Add(table)
+				continue
+			}
 			_ = matchesBuf.Append(result)
 		}
 
@@ -85,6 +85,7 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
+		bitbucketCloudConnections    []*schema.BitbucketCloudConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
 		expSeriousProblems           []string
@@ -460,6 +461,54 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 			expAuthzAllowAccessByDefault: false,
 			expSeriousProblems:           []string{"The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled when \"bitbucketServer\" authorization providers are in use. Blocking access to all repositories until the conflict is resolved."},
 		},
+		{
+			description: "1 Bitbucket Cloud connection with authz enabled, 1 Bitbucket Cloud matching auth provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					AuthProviders: []schema.AuthProviders{{
+						Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+							ClientKey:    "clientKey",
+							ClientSecret: "clientSecret",
+							Type:         extsvc.TypeBitbucketCloud,
+						},
+					}},
+				},
+			},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				require.Len(t, have, 1)
+				assert.Equal(t, extsvc.TypeBitbucketCloud, have[0].ServiceType())
+				assert.Equal(t, "https://bitbucket.org/", have[0].ServiceID())
+			},
+		},
+		{
+			description: "1 Bitbucket Cloud connection with authz enabled, no Bitbucket Cloud auth provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					AuthProviders: []schema.AuthProviders{{
+						Builtin: &schema.BuiltinAuthProvider{Type: "builtin"},
+					}},
+				},
+			},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret",
+				},
+			},
+			expAuthzAllowAccessByDefault: false,
+			expSeriousProblems:           []string{"Did not find authentication provider matching \"https://bitbucket.org\". Check the [**site configuration**](/site-admin/configuration) to verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for https://bitbucket.org."},
+		},
 	}
 
 	for _, test := range tests {
@@ -489,6 +538,13 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbs)),
 							})
 						}
+					case extsvc.KindBitbucketCloud:
+						for _, bbc := range test.bitbucketCloudConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbc)),
+							})
+						}
 					case extsvc.KindGitHub, extsvc.KindPerforce:
 					default:
 						return nil, errors.Errorf("unexpected kind: %s", kind)
@@ -519,6 +575,7 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 		cfg                        conf.Unified
 		gitlabConnections          []*schema.GitLabConnection
 		bitbucketServerConnections []*schema.BitbucketServerConnection
+		bitbucketCloudConnections  []*schema.BitbucketCloudConnection
 		githubConnections          []*schema.GitHubConnection
 		perforceConnections        []*schema.PerforceConnection
 
@@ -600,6 +657,20 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 			expSeriousProblems:    []string{"failed"},
 			expInvalidConnections: []string{"bitbucketServer"},
 		},
+		{
+			description: "Bitbucket Cloud connection with authz enabled but missing license for ACLs",
+			cfg:         conf.Unified{},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					Username:      "admin",
+					AppPassword:   "secret-password",
+				},
+			},
+			expSeriousProblems:    []string{"failed"},
+			expInvalidConnections: []string{"bitbucketCloud"},
+		},
 		{
 			description: "Perforce connection with authz enabled but missing license for ACLs",
 			cfg:         conf.Unified{},
@@ -647,6 +718,13 @@ func TestAuthzProvidersEnabledACLsDisabled(t *testing.T) {
 								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbs)),
 							})
 						}
+					case extsvc.KindBitbucketCloud:
+						for _, bbc := range test.bitbucketCloudConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbc)),
+							})
+						}
 					case extsvc.KindGitHub:
 						for _, gh := range test.githubConnections {
 							svcs = append(svcs, &types.ExternalService{
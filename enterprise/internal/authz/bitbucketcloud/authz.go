@@ -0,0 +1,84 @@
+package bitbucketcloud
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(
+	db database.DB,
+	conns []*types.BitbucketCloudConnection,
+	authProviders []schema.AuthProviders,
+) (ps []authz.Provider, problems []string, warnings []string, invalidConnections []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(db, c, authProviders)
+		if err != nil {
+			invalidConnections = append(invalidConnections, extsvc.TypeBitbucketCloud)
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, problems, warnings, invalidConnections
+}
+
+func newAuthzProvider(db database.DB, c *types.BitbucketCloudConnection, ps []schema.AuthProviders) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if errLicense := licensing.Check(licensing.FeatureACLs); errLicense != nil {
+		return nil, errLicense
+	}
+
+	bbURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse URL for Bitbucket Cloud connection %q: %s", c.Url, err)
+	}
+
+	// The permissions of a user are fetched using the OAuth token of their
+	// Bitbucket Cloud account, which is only connected to their Sourcegraph
+	// account by signing in through a Bitbucket Cloud authentication provider.
+	foundAuthProvider := false
+	for _, authnProvider := range ps {
+		if authnProvider.Bitbucketcloud == nil {
+			continue
+		}
+		authnURL := authnProvider.Bitbucketcloud.Url
+		if authnURL == "" {
+			authnURL = "https://bitbucket.org"
+		}
+		authProviderURL, err := url.Parse(authnURL)
+		if err != nil {
+			// Ignore the error here, because the authn provider is responsible for its own validation
+			continue
+		}
+		if authProviderURL.Hostname() == bbURL.Hostname() {
+			foundAuthProvider = true
+			break
+		}
+	}
+	if !foundAuthProvider {
+		return nil, errors.Errorf("Did not find authentication provider matching %q. Check the [**site configuration**](/site-admin/configuration) to verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for %s.", c.Url, c.Url)
+	}
+
+	return NewProvider(c, ProviderOptions{DB: db})
+}
@@ -0,0 +1,203 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository
+// permissions as determined from Bitbucket Cloud. The permissions of a user
+// are fetched using the OAuth token of their Bitbucket Cloud account, while the
+// users with access to a repository are fetched using the credentials of the
+// connection.
+type Provider struct {
+	urn      string
+	client   bitbucketcloud.Client
+	codeHost *extsvc.CodeHost
+	db       database.DB
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+type ProviderOptions struct {
+	// If a BitbucketCloudClient is not provided, one is constructed from the
+	// connection.
+	BitbucketCloudClient bitbucketcloud.Client
+
+	// DB is used to store the OAuth tokens of user accounts when they are
+	// refreshed. If it is nil, tokens are not refreshed.
+	DB database.DB
+}
+
+// NewProvider returns a new Bitbucket Cloud authorization provider for the
+// given connection.
+func NewProvider(conn *types.BitbucketCloudConnection, opts ProviderOptions) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing URL for Bitbucket Cloud connection %q", conn.Url)
+	}
+
+	if opts.BitbucketCloudClient == nil {
+		opts.BitbucketCloudClient, err = bitbucketcloud.NewClient(conn.URN, conn.BitbucketCloudConnection, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		client:   opts.BitbucketCloudClient,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		db:       opts.DB,
+	}, nil
+}
+
+// ValidateConnection validates that the credentials of the connection can
+// access the Bitbucket Cloud API.
+func (p *Provider) ValidateConnection(ctx context.Context) (problems []string) {
+	if err := p.client.Ping(ctx); err != nil {
+		problems = append(problems, "Unable to connect to Bitbucket Cloud: "+err.Error())
+	}
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+// FetchAccount always returns nil, since Bitbucket Cloud accounts are only
+// connected to Sourcegraph users by signing in through the Bitbucket Cloud
+// authentication provider.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (mine *extsvc.Account, err error) {
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs (on code host) that the
+// given account has read access to. The repository UUID has the same value as
+// it would be used as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	_, tok, err := bitbucketcloud.GetExternalAccountData(ctx, &account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external account data")
+	} else if tok == nil {
+		return nil, errors.New("no token found in the external account data")
+	}
+
+	token := &auth.OAuthBearerToken{
+		Token:        tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		Expiry:       tok.Expiry,
+	}
+	// Bitbucket Cloud access tokens expire after two hours.
+	if p.db != nil {
+		if oauthCtx := bitbucketcloud.GetOAuthContext(p.codeHost.BaseURL.String()); oauthCtx != nil {
+			token.RefreshFunc = database.GetAccountRefreshAndStoreOAuthTokenFunc(p.db, account.ID, oauthCtx)
+			token.NeedsRefreshBuffer = 5
+		}
+	}
+	client := p.client.WithAuthenticator(token)
+
+	perms := &authz.ExternalUserPermissions{}
+	var page *bitbucketcloud.PageToken
+	for {
+		repoPerms, next, err := client.CurrentUserRepoPermissions(ctx, page)
+		if err != nil {
+			return perms, errors.Wrap(err, "list repository permissions")
+		}
+
+		for _, rp := range repoPerms {
+			if rp.Repo == nil || rp.Repo.UUID == "" {
+				continue
+			}
+			perms.Exacts = append(perms.Exacts, extsvc.RepoID(rp.Repo.UUID))
+		}
+
+		if !next.HasMore() {
+			return perms, nil
+		}
+		page = next
+	}
+}
+
+// FetchRepoPerms returns a list of user UUIDs (on code host) who have read
+// access to the given repository on the code host. The user UUID has the same
+// value as it would be used as extsvc.Account.AccountID. The returned list
+// includes both direct access and access inherited from group membership.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	namespace, slug, err := splitRepoURI(repo.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []extsvc.AccountID
+	var page *bitbucketcloud.PageToken
+	for {
+		repoPerms, next, err := p.client.RepoPermissions(ctx, page, namespace, slug)
+		if err != nil {
+			return userIDs, errors.Wrap(err, "list repository permissions")
+		}
+
+		for _, rp := range repoPerms {
+			if rp.User == nil || rp.User.UUID == "" {
+				continue
+			}
+			userIDs = append(userIDs, extsvc.AccountID(rp.User.UUID))
+		}
+
+		if !next.HasMore() {
+			return userIDs, nil
+		}
+		page = next
+	}
+}
+
+// splitRepoURI returns the workspace and slug of a repository from its URI,
+// which is of the form "bitbucket.org/workspace/slug".
+func splitRepoURI(uri string) (namespace, slug string, err error) {
+	parts := strings.Split(uri, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", errors.Errorf("malformed Bitbucket Cloud repository URI: %q", uri)
+	}
+	return parts[1], parts[2], nil
+}
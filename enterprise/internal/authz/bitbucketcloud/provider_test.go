@@ -0,0 +1,186 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	var gotAuth []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		if r.URL.Path != "/2.0/user/permissions/repositories" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			_, _ = io.WriteString(w, `{
+  "next": "`+srv.URL+`/2.0/user/permissions/repositories?page=2",
+  "values": [{"permission": "read", "repository": {"full_name": "ws/a", "uuid": "{a}"}}]
+}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"values": [{"permission": "write", "repository": {"full_name": "ws/b", "uuid": "{b}"}}]}`)
+	}))
+	defer srv.Close()
+
+	p := newTestProvider(t, srv)
+	ctx := context.Background()
+
+	t.Run("nil account", func(t *testing.T) {
+		if _, err := p.FetchUserPerms(ctx, nil, authz.FetchPermsOptions{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(ctx, &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, authz.FetchPermsOptions{})
+		want := `not a code host of the account: want "https://github.com/" but have "https://bitbucket.org/"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := p.FetchUserPerms(ctx, &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		gotAuth = nil
+
+		account := &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+				AccountID:   "{u}",
+			},
+		}
+		if err := bitbucketcloud.SetExternalAccountData(&account.AccountData, &bitbucketcloud.User{}, &oauth2.Token{AccessToken: "user-token"}); err != nil {
+			t.Fatal(err)
+		}
+
+		perms, err := p.FetchUserPerms(ctx, account, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]extsvc.RepoID{"{a}", "{b}"}, perms.Exacts); diff != "" {
+			t.Errorf("unexpected repo IDs (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"Bearer user-token", "Bearer user-token"}, gotAuth); diff != "" {
+			t.Errorf("unexpected authorization (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	var gotPath string
+	var gotUser string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, _, _ = r.BasicAuth()
+		_, _ = io.WriteString(w, `{"values": [
+  {"permission": "admin", "user": {"uuid": "{u1}"}},
+  {"permission": "read", "user": {"uuid": "{u2}"}}
+]}`)
+	}))
+	defer srv.Close()
+
+	p := newTestProvider(t, srv)
+	ctx := context.Background()
+
+	t.Run("nil repository", func(t *testing.T) {
+		if _, err := p.FetchRepoPerms(ctx, nil, authz.FetchPermsOptions{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not the code host of the repository", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(ctx, &extsvc.Repository{
+			URI: "github.com/ws/a",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, authz.FetchPermsOptions{})
+		want := `not a code host of the repository: want "https://github.com/" but have "https://bitbucket.org/"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		userIDs, err := p.FetchRepoPerms(ctx, &extsvc.Repository{
+			URI: "bitbucket.org/ws/a",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{a}",
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]extsvc.AccountID{"{u1}", "{u2}"}, userIDs); diff != "" {
+			t.Errorf("unexpected user IDs (-want +got):\n%s", diff)
+		}
+		if want := "/2.0/workspaces/ws/permissions/repositories/a"; gotPath != want {
+			t.Errorf("unexpected path: want %q, have %q", want, gotPath)
+		}
+		if gotUser != "admin" {
+			t.Errorf("expected the connection credentials to be used, got user %q", gotUser)
+		}
+	})
+}
+
+func newTestProvider(t *testing.T, srv *httptest.Server) *Provider {
+	t.Helper()
+
+	conn := &types.BitbucketCloudConnection{
+		URN: "extsvc:bitbucketcloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			ApiURL:      srv.URL,
+			Username:    "admin",
+			AppPassword: "secret",
+		},
+	}
+	cli, err := bitbucketcloud.NewClient(conn.URN, conn.BitbucketCloudConnection, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewProvider(conn, ProviderOptions{BitbucketCloudClient: cli})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,284 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AutoMergeChangeset evaluates the auto-merge policy of the batch change that
+// owns the given changeset and merges the changeset on the code host if the
+// policy is satisfied. It returns true if the changeset was merged, in which
+// case the caller is responsible for recomputing the derived state of the
+// changeset from its updated metadata.
+//
+// Every time the outcome of the evaluation changes, a decision is recorded in
+// the store, so that there is an audit trail of why each changeset was or
+// wasn't merged.
+func AutoMergeChangeset(ctx context.Context, tx AutoMergeStore, css sources.ChangesetSource, repo *types.Repo, ch *btypes.Changeset, events []*btypes.ChangesetEvent) (bool, error) {
+	// Imported changesets are never merged automatically.
+	if ch.OwnedByBatchChangeID == 0 || !ch.Published() {
+		return false, nil
+	}
+	if ch.ExternalState != btypes.ChangesetExternalStateOpen && ch.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false, nil
+	}
+
+	policy, err := loadAutoMergePolicy(ctx, tx, ch.OwnedByBatchChangeID)
+	if err != nil {
+		return false, err
+	}
+	if policy == nil {
+		return false, nil
+	}
+
+	decision := &btypes.ChangesetAutoMergeDecision{
+		ChangesetID:   ch.ID,
+		BatchChangeID: ch.OwnedByBatchChangeID,
+	}
+
+	ok, reason, err := evaluateAutoMergePolicy(policy, ch, events, tx.Clock()())
+	if err != nil {
+		return false, err
+	}
+	decision.Reason = reason
+
+	if ok {
+		remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, ch, nil)
+		if err != nil {
+			return false, errors.Wrap(err, "loading remote repo")
+		}
+
+		cs := &sources.Changeset{
+			Changeset:  ch,
+			TargetRepo: repo,
+			RemoteRepo: remoteRepo,
+		}
+		if err := css.MergeChangeset(ctx, cs, policy.Squash); err != nil {
+			// Sources return the not mergeable error both by value and by
+			// pointer.
+			if !errors.HasType(err, sources.ChangesetNotMergeableError{}) && !errors.HasType(err, &sources.ChangesetNotMergeableError{}) {
+				return false, err
+			}
+			// The code host has the final say on whether the changeset can be
+			// merged, so we record its reason and try again on the next sync.
+			decision.Reason = err.Error()
+		} else {
+			decision.Merged = true
+		}
+	}
+
+	if err := recordAutoMergeDecision(ctx, tx, decision); err != nil {
+		if !decision.Merged {
+			return false, err
+		}
+		// The changeset has already been merged on the code host, so the
+		// caller still needs to pick up its new state. Returning the error
+		// would make it retry the merge instead.
+		log15.Error("recording auto-merge decision", "changeset", ch.ID, "err", err)
+	}
+
+	return decision.Merged, nil
+}
+
+// AutoMergeStore is the subset of the store used to evaluate auto-merge
+// policies and record their decisions.
+type AutoMergeStore interface {
+	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
+	GetBatchSpec(ctx context.Context, opts store.GetBatchSpecOpts) (*btypes.BatchSpec, error)
+	GetLatestChangesetAutoMergeDecision(ctx context.Context, changesetID int64) (*btypes.ChangesetAutoMergeDecision, error)
+	CreateChangesetAutoMergeDecision(ctx context.Context, d *btypes.ChangesetAutoMergeDecision) error
+	Clock() func() time.Time
+}
+
+// loadAutoMergePolicy returns the auto-merge policy in the batch spec that is
+// currently applied to the given batch change, or nil if there is none.
+func loadAutoMergePolicy(ctx context.Context, tx AutoMergeStore, batchChangeID int64) (*batcheslib.AutoMerge, error) {
+	batchChange, err := loadBatchChange(ctx, tx, batchChangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving batch spec: %d", batchChange.BatchSpecID)
+	}
+
+	if batchSpec.Spec == nil || batchSpec.Spec.ChangesetTemplate == nil {
+		return nil, nil
+	}
+	return batchSpec.Spec.ChangesetTemplate.AutoMerge, nil
+}
+
+// evaluateAutoMergePolicy checks whether the given changeset satisfies the
+// auto-merge policy at the given time. Only approvals and checks of the current
+// head commit of the changeset count towards the policy. The returned reason
+// explains the outcome either way.
+func evaluateAutoMergePolicy(policy *batcheslib.AutoMerge, ch *btypes.Changeset, events []*btypes.ChangesetEvent, now time.Time) (bool, string, error) {
+	if ch.ExternalState == btypes.ChangesetExternalStateDraft {
+		return false, "changeset is a draft", nil
+	}
+
+	if ch.ExternalReviewState == btypes.ChangesetReviewStateChangesRequested {
+		return false, "changes have been requested", nil
+	}
+
+	required := policy.GetRequiredApprovals()
+	if approvals := headApprovals(ch, events); approvals < required {
+		return false, fmt.Sprintf("%d of %d required approvals", approvals, required), nil
+	}
+
+	if policy.GetChecksPassed() {
+		onHead, noChecks := headChecks(ch)
+		switch {
+		case !onHead:
+			return false, "checks have not been reported for the head commit", nil
+		case ch.ExternalCheckState == btypes.ChangesetCheckStatePassed:
+		case ch.ExternalCheckState == btypes.ChangesetCheckStateUnknown && noChecks:
+			// The repository doesn't run any checks on the changeset. If it
+			// requires checks that haven't been reported yet, the code host
+			// refuses to merge the changeset.
+		default:
+			return false, fmt.Sprintf("checks have not passed: %s", ch.ExternalCheckState), nil
+		}
+	}
+
+	if len(policy.Windows) > 0 {
+		cfg, err := autoMergeWindows(policy.Windows)
+		if err != nil {
+			return false, "", err
+		}
+		if !cfg.IsOpen(now) {
+			return false, "outside of the merge windows", nil
+		}
+	}
+
+	return true, "auto-merge policy satisfied", nil
+}
+
+// headApprovals returns the number of approvals of the changeset that were
+// given for its current head commit. GitLab and Bitbucket Cloud don't record
+// which commit was approved, so their approvals are counted as reported; they
+// can be configured to reset approvals when new commits are pushed.
+func headApprovals(ch *btypes.Changeset, events []*btypes.ChangesetEvent) int {
+	switch m := ch.Metadata.(type) {
+	case *github.PullRequest:
+		headEvents := make([]*btypes.ChangesetEvent, 0, len(events))
+		for _, e := range events {
+			if review, ok := e.Metadata.(*github.PullRequestReview); ok && review.Commit.OID != m.HeadRefOid {
+				continue
+			}
+			headEvents = append(headEvents, e)
+		}
+		return state.ComputeApprovals(ch, headEvents)
+
+	case *bitbucketserver.PullRequest:
+		n := 0
+		for _, r := range m.Reviewers {
+			if r.Status == "APPROVED" && r.LastReviewedCommit == m.FromRef.LatestCommit {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Gerrit votes are recorded on the current revision of the change.
+	return state.ComputeApprovals(ch, events)
+}
+
+// headChecks returns whether the check state of the changeset was reported for
+// its current head commit, and whether no checks were reported for it at all.
+func headChecks(ch *btypes.Changeset) (onHead, noChecks bool) {
+	switch m := ch.Metadata.(type) {
+	case *github.PullRequest:
+		if len(m.Commits.Nodes) == 0 {
+			return false, true
+		}
+		commit := m.Commits.Nodes[0].Commit
+		if commit.OID != m.HeadRefOid {
+			return false, false
+		}
+		return true, len(commit.Status.Contexts) == 0 && len(commit.CheckSuites.Nodes) == 0
+
+	case *gitlab.MergeRequest:
+		pipeline := m.HeadPipeline
+		for _, p := range m.Pipelines {
+			if pipeline == nil || pipeline.CreatedAt.Before(p.CreatedAt.Time) {
+				pipeline = p
+			}
+		}
+		if pipeline == nil {
+			return true, true
+		}
+		return pipeline.SHA == m.DiffRefs.HeadSHA, false
+
+	case *bitbucketserver.PullRequest:
+		for _, status := range m.CommitStatus {
+			if status.Commit != m.FromRef.LatestCommit {
+				return false, false
+			}
+		}
+		return true, len(m.CommitStatus) == 0
+
+	case *bbcs.AnnotatedPullRequest:
+		return true, len(m.Statuses) == 0
+
+	case *gerritcs.AnnotatedChange:
+		// The check state of a change is only unknown if it has no Verified
+		// label.
+		return true, ch.ExternalCheckState == btypes.ChangesetCheckStateUnknown
+	}
+	return false, false
+}
+
+// autoMergeWindows converts the merge windows of an auto-merge policy into a
+// window configuration. Merge windows don't limit the rate at which
+// changesets are merged.
+func autoMergeWindows(windows []batcheslib.AutoMergeWindow) (*window.Configuration, error) {
+	raw := make([]*schema.BatchChangeRolloutWindow, len(windows))
+	for i, w := range windows {
+		raw[i] = &schema.BatchChangeRolloutWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+			Rate:  "unlimited",
+		}
+	}
+
+	cfg, err := window.NewConfiguration(&raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing merge windows")
+	}
+	return cfg, nil
+}
+
+// recordAutoMergeDecision stores the given decision, unless it has the same
+// outcome as the last decision recorded for the changeset.
+func recordAutoMergeDecision(ctx context.Context, tx AutoMergeStore, d *btypes.ChangesetAutoMergeDecision) error {
+	last, err := tx.GetLatestChangesetAutoMergeDecision(ctx, d.ChangesetID)
+	if err != nil && err != store.ErrNoResults {
+		return err
+	}
+	if last != nil && last.Merged == d.Merged && last.Reason == d.Reason {
+		return nil
+	}
+
+	return tx.CreateChangesetAutoMergeDecision(ctx, d)
+}
@@ -0,0 +1,228 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestEvaluateAutoMergePolicy(t *testing.T) {
+	// A Monday.
+	now := time.Date(2023, 1, 16, 12, 0, 0, 0, time.UTC)
+
+	buildChangeset := func(externalState btypes.ChangesetExternalState, checkState btypes.ChangesetCheckState, approvals int) *btypes.Changeset {
+		pr := &bitbucketserver.PullRequest{}
+		pr.FromRef.LatestCommit = "head"
+		for i := 0; i < approvals; i++ {
+			pr.Reviewers = append(pr.Reviewers, bitbucketserver.Reviewer{Status: "APPROVED", LastReviewedCommit: "head"})
+		}
+		if checkState != btypes.ChangesetCheckStateUnknown {
+			pr.CommitStatus = append(pr.CommitStatus, &bitbucketserver.CommitStatus{Commit: "head"})
+		}
+		return &btypes.Changeset{
+			ExternalState:      externalState,
+			ExternalCheckState: checkState,
+			Metadata:           pr,
+		}
+	}
+
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+
+	for name, tc := range map[string]struct {
+		policy     *batcheslib.AutoMerge
+		changeset  *btypes.Changeset
+		wantMerge  bool
+		wantReason string
+	}{
+		"defaults satisfied": {
+			policy:     &batcheslib.AutoMerge{},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1),
+			wantMerge:  true,
+			wantReason: "auto-merge policy satisfied",
+		},
+		"draft": {
+			policy:     &batcheslib.AutoMerge{},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateDraft, btypes.ChangesetCheckStatePassed, 1),
+			wantReason: "changeset is a draft",
+		},
+		"changes requested": {
+			policy: &batcheslib.AutoMerge{},
+			changeset: func() *btypes.Changeset {
+				c := buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1)
+				c.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested
+				return c
+			}(),
+			wantReason: "changes have been requested",
+		},
+		"not enough approvals": {
+			policy:     &batcheslib.AutoMerge{RequiredApprovals: intPtr(2)},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1),
+			wantReason: "1 of 2 required approvals",
+		},
+		"no approvals required": {
+			policy:     &batcheslib.AutoMerge{RequiredApprovals: intPtr(0)},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 0),
+			wantMerge:  true,
+			wantReason: "auto-merge policy satisfied",
+		},
+		"checks pending": {
+			policy:     &batcheslib.AutoMerge{},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePending, 1),
+			wantReason: "checks have not passed: PENDING",
+		},
+		"no checks": {
+			policy:     &batcheslib.AutoMerge{},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateUnknown, 1),
+			wantMerge:  true,
+			wantReason: "auto-merge policy satisfied",
+		},
+		"approval of an earlier commit": {
+			policy: &batcheslib.AutoMerge{},
+			changeset: func() *btypes.Changeset {
+				c := buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1)
+				c.Metadata.(*bitbucketserver.PullRequest).Reviewers[0].LastReviewedCommit = "earlier"
+				return c
+			}(),
+			wantReason: "0 of 1 required approvals",
+		},
+		"checks of an earlier commit": {
+			policy: &batcheslib.AutoMerge{},
+			changeset: func() *btypes.Changeset {
+				c := buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1)
+				c.Metadata.(*bitbucketserver.PullRequest).CommitStatus[0].Commit = "earlier"
+				return c
+			}(),
+			wantReason: "checks have not been reported for the head commit",
+		},
+		"unknown check state with checks": {
+			policy: &batcheslib.AutoMerge{},
+			changeset: func() *btypes.Changeset {
+				c := buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1)
+				c.ExternalCheckState = btypes.ChangesetCheckStateUnknown
+				return c
+			}(),
+			wantReason: "checks have not passed: UNKNOWN",
+		},
+		"checks failed but not required": {
+			policy:     &batcheslib.AutoMerge{ChecksPassed: boolPtr(false)},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateFailed, 1),
+			wantMerge:  true,
+			wantReason: "auto-merge policy satisfied",
+		},
+		"inside merge window": {
+			policy: &batcheslib.AutoMerge{Windows: []batcheslib.AutoMergeWindow{
+				{Days: []string{"monday"}, Start: "10:00", End: "14:00"},
+			}},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1),
+			wantMerge:  true,
+			wantReason: "auto-merge policy satisfied",
+		},
+		"outside merge window": {
+			policy: &batcheslib.AutoMerge{Windows: []batcheslib.AutoMergeWindow{
+				{Days: []string{"saturday", "sunday"}},
+			}},
+			changeset:  buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1),
+			wantReason: "outside of the merge windows",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			merge, reason, err := evaluateAutoMergePolicy(tc.policy, tc.changeset, nil, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if merge != tc.wantMerge {
+				t.Errorf("wrong merge decision. have=%t, want=%t", merge, tc.wantMerge)
+			}
+			if reason != tc.wantReason {
+				t.Errorf("wrong reason. have=%q, want=%q", reason, tc.wantReason)
+			}
+		})
+	}
+
+	t.Run("invalid merge window", func(t *testing.T) {
+		policy := &batcheslib.AutoMerge{Windows: []batcheslib.AutoMergeWindow{
+			{Start: "14:00", End: "10:00"},
+		}}
+		changeset := buildChangeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, 1)
+		if _, _, err := evaluateAutoMergePolicy(policy, changeset, nil, now); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+	})
+}
+
+func TestAutoMergeChangesetRecordingFails(t *testing.T) {
+	pr := &bitbucketserver.PullRequest{}
+	pr.FromRef.LatestCommit = "head"
+	pr.Reviewers = []bitbucketserver.Reviewer{{Status: "APPROVED", LastReviewedCommit: "head"}}
+	ch := &btypes.Changeset{
+		ID:                   1,
+		OwnedByBatchChangeID: 2,
+		PublicationState:     btypes.ChangesetPublicationStatePublished,
+		ExternalState:        btypes.ChangesetExternalStateOpen,
+		ExternalCheckState:   btypes.ChangesetCheckStatePassed,
+		Metadata:             pr,
+	}
+
+	t.Run("merged", func(t *testing.T) {
+		tx := &fakeAutoMergeStore{createErr: errors.New("boom")}
+		css := &stesting.FakeChangesetSource{}
+
+		merged, err := AutoMergeChangeset(context.Background(), tx, css, &types.Repo{}, ch, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !merged || !css.MergeChangesetCalled {
+			t.Errorf("expected the changeset to be merged. merged=%t, called=%t", merged, css.MergeChangesetCalled)
+		}
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		tx := &fakeAutoMergeStore{createErr: errors.New("boom")}
+		css := &stesting.FakeChangesetSource{Err: sources.ChangesetNotMergeableError{ErrorMsg: "conflicts"}}
+
+		merged, err := AutoMergeChangeset(context.Background(), tx, css, &types.Repo{}, ch, nil)
+		if err == nil {
+			t.Fatal("expected the recording error to be returned")
+		}
+		if merged {
+			t.Error("expected the changeset not to be merged")
+		}
+	})
+}
+
+// fakeAutoMergeStore is an AutoMergeStore with an empty auto-merge policy.
+type fakeAutoMergeStore struct {
+	createErr error
+}
+
+func (s *fakeAutoMergeStore) GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error) {
+	return &btypes.BatchChange{ID: opts.ID, BatchSpecID: 3}, nil
+}
+
+func (s *fakeAutoMergeStore) GetBatchSpec(ctx context.Context, opts store.GetBatchSpecOpts) (*btypes.BatchSpec, error) {
+	return &btypes.BatchSpec{ID: opts.ID, Spec: &batcheslib.BatchSpec{
+		ChangesetTemplate: &batcheslib.ChangesetTemplate{AutoMerge: &batcheslib.AutoMerge{}},
+	}}, nil
+}
+
+func (s *fakeAutoMergeStore) GetLatestChangesetAutoMergeDecision(ctx context.Context, changesetID int64) (*btypes.ChangesetAutoMergeDecision, error) {
+	return nil, store.ErrNoResults
+}
+
+func (s *fakeAutoMergeStore) CreateChangesetAutoMergeDecision(ctx context.Context, d *btypes.ChangesetAutoMergeDecision) error {
+	return s.createErr
+}
+
+func (s *fakeAutoMergeStore) Clock() func() time.Time {
+	return func() time.Time { return time.Date(2023, 1, 16, 12, 0, 0, 0, time.UTC) }
+}
@@ -124,6 +124,15 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationReattach:
 			e.reattachChangeset()
 
+		case btypes.ReconcilerOperationUpdateMetadata:
+			// If the changeset is published in this run, none of the metadata
+			// of the previous spec has been applied to it yet.
+			previousSpec := plan.PreviousChangesetSpec
+			if publishesChangeset(plan.Ops) {
+				previousSpec = nil
+			}
+			err = e.updateChangesetMetadata(ctx, previousSpec)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -140,6 +149,14 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 	}
 	state.SetDerivedState(ctx, e.tx.Repos(), e.client, e.ch, events)
 
+	// Checks and approvals haven't been reported for commits pushed in this
+	// run yet, so the changeset is merged on a later sync at the earliest.
+	if e.ch.Published() && refreshesCodeHostState(plan.Ops) && !pushesChangeset(plan.Ops) {
+		if events, err = e.autoMergeChangeset(ctx, events); err != nil {
+			return err
+		}
+	}
+
 	if err := e.tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		log15.Error("UpsertChangesetEvents", "err", err)
 		return err
@@ -148,6 +165,71 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 	return e.tx.UpdateChangeset(ctx, e.ch)
 }
 
+// refreshesCodeHostState returns true if any of the given operations loads
+// the current state of the changeset from the code host.
+func refreshesCodeHostState(ops Operations) bool {
+	for _, op := range ops {
+		switch op {
+		case btypes.ReconcilerOperationSync,
+			btypes.ReconcilerOperationUpdate,
+			btypes.ReconcilerOperationUndraft,
+			btypes.ReconcilerOperationReopen:
+			return true
+		}
+	}
+	return false
+}
+
+// publishesChangeset returns true if any of the given operations publishes the
+// changeset.
+func publishesChangeset(ops Operations) bool {
+	for _, op := range ops {
+		if op == btypes.ReconcilerOperationPublish || op == btypes.ReconcilerOperationPublishDraft {
+			return true
+		}
+	}
+	return false
+}
+
+// pushesChangeset returns true if any of the given operations pushes a commit
+// to the changeset branch.
+func pushesChangeset(ops Operations) bool {
+	for _, op := range ops {
+		if op == btypes.ReconcilerOperationPush {
+			return true
+		}
+	}
+	return false
+}
+
+// autoMergeChangeset merges the changeset if the auto-merge policy of its batch
+// change is satisfied, and returns the changeset events reflecting the
+// changeset state afterwards.
+func (e *executor) autoMergeChangeset(ctx context.Context, events []*btypes.ChangesetEvent) ([]*btypes.ChangesetEvent, error) {
+	if e.ch.OwnedByBatchChangeID == 0 {
+		return events, nil
+	}
+
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := AutoMergeChangeset(ctx, e.tx, css, e.targetRepo, e.ch, events)
+	if err != nil || !merged {
+		return events, err
+	}
+
+	events, err = e.ch.Events()
+	if err != nil {
+		log15.Error("Events", "err", err)
+		return nil, errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, e.tx.Repos(), e.client, e.ch, events)
+
+	return events, nil
+}
+
 var errCannotPushToArchivedRepo = errcode.MakeNonRetryable(errors.New("cannot push to an archived repo"))
 
 // pushChangesetPatch creates the commits for the changeset on its codehost.
@@ -189,9 +271,35 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	}
 	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
 
+	mcss, isMagicRef := css.(sources.MagicRefChangesetSource)
+	if isMagicRef {
+		// Code hosts that create changesets from pushes to a magic ref derive
+		// the title and body from the commit message, so it needs the same
+		// decorated body as any other changeset.
+		body, err := e.decorateChangesetBody(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
+		}
+		cs := &sources.Changeset{
+			Title:      e.spec.Title,
+			Body:       body,
+			BaseRef:    e.spec.BaseRef,
+			HeadRef:    e.spec.HeadRef,
+			RemoteRepo: remoteRepo,
+			TargetRepo: e.targetRepo,
+			Changeset:  e.ch,
+		}
+		if err := mcss.PrepareCommit(cs, &opts); err != nil {
+			return errors.Wrap(err, "preparing commit")
+		}
+	}
+
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
+		if isMagicRef && mcss.IsNoNewChangesPushError(pce.CombinedOutput) {
+			return nil
+		}
 		if acss, ok := css.(sources.ArchivableChangesetSource); ok {
 			if acss.IsArchivedPushError(pce.CombinedOutput) {
 				if err := e.handleArchivedRepo(ctx); err != nil {
@@ -0,0 +1,189 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// updateChangesetMetadata requests reviewers, applies labels and sets assignees
+// on the changeset according to its ChangesetSpec. Reviewers, labels and
+// assignees of the given previous spec that are no longer in the current spec
+// are removed from the changeset. The reviewers derived from code owners are
+// stored on the current spec, so that the next spec can remove them again.
+func (e *executor) updateChangesetMetadata(ctx context.Context, previousSpec *btypes.ChangesetSpec) error {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+	mcss, ok := css.(sources.MetadataChangesetSource)
+	if !ok {
+		// Code hosts without support for any metadata are filtered out when
+		// determining the plan, so there is nothing to do here.
+		return nil
+	}
+
+	var codeOwners []string
+	if e.spec.ReviewersFromCodeOwners && btypes.ExternalServiceSupports(e.ch.ExternalServiceType, btypes.CodehostCapabilityReviewers) {
+		if codeOwners, err = e.codeOwners(ctx); err != nil {
+			return errors.Wrap(err, "loading code owners")
+		}
+	}
+
+	update := buildMetadataUpdate(previousSpec, e.spec, codeOwners, e.ch.ExternalServiceType)
+	if update.IsEmpty() {
+		return e.storeCodeOwnerReviewers(ctx, codeOwners)
+	}
+
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		Title:      e.spec.Title,
+		Body:       e.spec.Body,
+		BaseRef:    e.spec.BaseRef,
+		HeadRef:    e.spec.HeadRef,
+		RemoteRepo: remoteRepo,
+		TargetRepo: e.targetRepo,
+		Changeset:  e.ch,
+	}
+	if err := mcss.UpdateChangesetMetadata(ctx, cs, update); err != nil {
+		return errors.Wrap(err, "updating changeset metadata")
+	}
+	return e.storeCodeOwnerReviewers(ctx, codeOwners)
+}
+
+// storeCodeOwnerReviewers stores the given reviewers derived from code owners
+// on the current spec, unless they are already stored.
+func (e *executor) storeCodeOwnerReviewers(ctx context.Context, codeOwners []string) error {
+	if len(missingFrom(codeOwners, e.spec.CodeOwnerReviewers)) == 0 && len(missingFrom(e.spec.CodeOwnerReviewers, codeOwners)) == 0 {
+		return nil
+	}
+	if err := e.tx.UpdateChangesetSpecCodeOwnerReviewers(ctx, e.spec.ID, codeOwners); err != nil {
+		return errors.Wrap(err, "storing code owner reviewers")
+	}
+	e.spec.CodeOwnerReviewers = codeOwners
+	return nil
+}
+
+// codeOwners returns the handles of the owners of the files changed by the
+// changeset spec, according to the CODEOWNERS file of the repository at the
+// base revision. Owners only given by email are skipped, since they can't be
+// requested as reviewers.
+func (e *executor) codeOwners(ctx context.Context) ([]string, error) {
+	file, err := backend.NewOwnService(e.client).OwnersFile(ctx, e.targetRepo.Name, api.CommitID(e.spec.BaseRev))
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, nil
+	}
+
+	paths, err := changedPaths(e.spec.Diff)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := codeownerspb.NewRuleset(file)
+	var handles []string
+	seen := make(map[string]struct{})
+	for _, path := range paths {
+		for _, owner := range ruleset.FindOwners(path) {
+			handle := owner.GetHandle()
+			if handle == "" {
+				continue
+			}
+			if _, ok := seen[handle]; ok {
+				continue
+			}
+			seen[handle] = struct{}{}
+			handles = append(handles, handle)
+		}
+	}
+	return handles, nil
+}
+
+// changedPaths returns the paths of the files changed by the given diff.
+func changedPaths(rawDiff []byte) ([]string, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff(rawDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing diff")
+	}
+
+	paths := make([]string, 0, len(fileDiffs))
+	for _, fd := range fileDiffs {
+		name := fd.NewName
+		if name == "/dev/null" {
+			name = fd.OrigName
+		}
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "b/"), "a/")
+		paths = append(paths, name)
+	}
+	return paths, nil
+}
+
+// buildMetadataUpdate computes the metadata update that reconciles the
+// changeset from the previous to the current spec on the given code host.
+// Only reviewers, labels and assignees that weren't in the previous spec are
+// added, so that we don't request a review again from users that already
+// reviewed the changeset. Reviewers derived from code owners are handled like
+// the reviewers of the spec: the code owners requested for the previous spec
+// are compared against the given current code owners.
+func buildMetadataUpdate(previous, current *btypes.ChangesetSpec, codeOwners []string, externalServiceType string) *sources.ChangesetMetadataUpdate {
+	var prevReviewers, prevLabels, prevAssignees []string
+	if previous != nil {
+		prevReviewers = make([]string, 0, len(previous.Reviewers)+len(previous.CodeOwnerReviewers))
+		prevReviewers = append(prevReviewers, previous.Reviewers...)
+		prevReviewers = append(prevReviewers, previous.CodeOwnerReviewers...)
+		prevLabels, prevAssignees = previous.Labels, previous.Assignees
+	}
+
+	update := &sources.ChangesetMetadataUpdate{}
+	if btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityReviewers) {
+		wanted := make([]string, 0, len(current.Reviewers)+len(codeOwners))
+		wanted = append(wanted, current.Reviewers...)
+		wanted = append(wanted, codeOwners...)
+
+		update.AddReviewers = missingFrom(wanted, prevReviewers)
+		update.RemoveReviewers = missingFrom(prevReviewers, wanted)
+	}
+	if btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityLabels) {
+		update.AddLabels = missingFrom(current.Labels, prevLabels)
+		update.RemoveLabels = missingFrom(prevLabels, current.Labels)
+	}
+	if btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityAssignees) {
+		update.AddAssignees = missingFrom(current.Assignees, prevAssignees)
+		update.RemoveAssignees = missingFrom(prevAssignees, current.Assignees)
+	}
+	return update
+}
+
+// missingFrom returns the unique strings in a that are not in b, in the order
+// of a.
+func missingFrom(a, b []string) []string {
+	exclude := make(map[string]struct{}, len(a)+len(b))
+	for _, s := range b {
+		exclude[s] = struct{}{}
+	}
+
+	var result []string
+	for _, s := range a {
+		if _, ok := exclude[s]; ok {
+			continue
+		}
+		exclude[s] = struct{}{}
+		result = append(result, s)
+	}
+	return result
+}
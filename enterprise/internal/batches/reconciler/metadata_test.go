@@ -0,0 +1,148 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestBuildMetadataUpdate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		previous            *btypes.ChangesetSpec
+		current             *btypes.ChangesetSpec
+		codeOwners          []string
+		externalServiceType string
+		want                *sources.ChangesetMetadataUpdate
+	}{
+		"publishing": {
+			current: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice"},
+				Labels:    []string{"automated"},
+				Assignees: []string{"bob"},
+			},
+			codeOwners:          []string{"sourcegraph/batchers", "alice"},
+			externalServiceType: extsvc.TypeGitHub,
+			want: &sources.ChangesetMetadataUpdate{
+				AddReviewers: []string{"alice", "sourcegraph/batchers"},
+				AddLabels:    []string{"automated"},
+				AddAssignees: []string{"bob"},
+			},
+		},
+		"updating": {
+			previous: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice", "bob"},
+				Labels:    []string{"automated", "old"},
+				Assignees: []string{"bob"},
+			},
+			current: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice", "carol"},
+				Labels:    []string{"automated"},
+				Assignees: []string{"bob"},
+			},
+			externalServiceType: extsvc.TypeGitLab,
+			want: &sources.ChangesetMetadataUpdate{
+				AddReviewers:    []string{"carol"},
+				RemoveReviewers: []string{"bob"},
+				RemoveLabels:    []string{"old"},
+			},
+		},
+		"previous reviewer is now a code owner": {
+			previous: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice"},
+			},
+			current:             &btypes.ChangesetSpec{},
+			codeOwners:          []string{"alice"},
+			externalServiceType: extsvc.TypeGitHub,
+			want:                &sources.ChangesetMetadataUpdate{},
+		},
+		"code owners changed": {
+			previous: &btypes.ChangesetSpec{
+				ReviewersFromCodeOwners: true,
+				CodeOwnerReviewers:      []string{"sourcegraph/batchers", "sourcegraph/search"},
+			},
+			current: &btypes.ChangesetSpec{
+				ReviewersFromCodeOwners: true,
+			},
+			codeOwners:          []string{"sourcegraph/search", "sourcegraph/code-intel"},
+			externalServiceType: extsvc.TypeGitHub,
+			want: &sources.ChangesetMetadataUpdate{
+				AddReviewers:    []string{"sourcegraph/code-intel"},
+				RemoveReviewers: []string{"sourcegraph/batchers"},
+			},
+		},
+		"code owners unchanged": {
+			previous: &btypes.ChangesetSpec{
+				ReviewersFromCodeOwners: true,
+				CodeOwnerReviewers:      []string{"sourcegraph/batchers"},
+			},
+			current: &btypes.ChangesetSpec{
+				ReviewersFromCodeOwners: true,
+			},
+			codeOwners:          []string{"sourcegraph/batchers"},
+			externalServiceType: extsvc.TypeGitHub,
+			want:                &sources.ChangesetMetadataUpdate{},
+		},
+		"code owners disabled": {
+			previous: &btypes.ChangesetSpec{
+				Reviewers:               []string{"alice"},
+				ReviewersFromCodeOwners: true,
+				CodeOwnerReviewers:      []string{"sourcegraph/batchers"},
+			},
+			current: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice"},
+			},
+			externalServiceType: extsvc.TypeGitHub,
+			want: &sources.ChangesetMetadataUpdate{
+				RemoveReviewers: []string{"sourcegraph/batchers"},
+			},
+		},
+		"unsupported labels and assignees": {
+			current: &btypes.ChangesetSpec{
+				Reviewers: []string{"alice"},
+				Labels:    []string{"automated"},
+				Assignees: []string{"bob"},
+			},
+			externalServiceType: extsvc.TypeBitbucketServer,
+			want: &sources.ChangesetMetadataUpdate{
+				AddReviewers: []string{"alice"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := buildMetadataUpdate(tc.previous, tc.current, tc.codeOwners, tc.externalServiceType)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected update (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangedPaths(t *testing.T) {
+	rawDiff := []byte(`diff --git a/README.md b/README.md
+index 851b23a..140f333 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-# Hello
++# Hello World
+diff --git a/cmd/old.go b/cmd/old.go
+deleted file mode 100644
+index 3b18e51..0000000
+--- a/cmd/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package cmd
+`)
+
+	have, err := changedPaths(rawDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"README.md", "cmd/old.go"}, have); diff != "" {
+		t.Errorf("unexpected paths (-want +have):\n%s", diff)
+	}
+}
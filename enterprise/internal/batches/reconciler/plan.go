@@ -11,19 +11,20 @@ import (
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
-	btypes.ReconcilerOperationPush:         0,
-	btypes.ReconcilerOperationDetach:       0,
-	btypes.ReconcilerOperationArchive:      0,
-	btypes.ReconcilerOperationReattach:     0,
-	btypes.ReconcilerOperationImport:       1,
-	btypes.ReconcilerOperationPublish:      1,
-	btypes.ReconcilerOperationPublishDraft: 1,
-	btypes.ReconcilerOperationClose:        1,
-	btypes.ReconcilerOperationReopen:       2,
-	btypes.ReconcilerOperationUndraft:      3,
-	btypes.ReconcilerOperationUpdate:       4,
-	btypes.ReconcilerOperationSleep:        5,
-	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationPush:           0,
+	btypes.ReconcilerOperationDetach:         0,
+	btypes.ReconcilerOperationArchive:        0,
+	btypes.ReconcilerOperationReattach:       0,
+	btypes.ReconcilerOperationImport:         1,
+	btypes.ReconcilerOperationPublish:        1,
+	btypes.ReconcilerOperationPublishDraft:   1,
+	btypes.ReconcilerOperationClose:          1,
+	btypes.ReconcilerOperationReopen:         2,
+	btypes.ReconcilerOperationUndraft:        3,
+	btypes.ReconcilerOperationUpdate:         4,
+	btypes.ReconcilerOperationUpdateMetadata: 5,
+	btypes.ReconcilerOperationSleep:          6,
+	btypes.ReconcilerOperationSync:           7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -92,6 +93,11 @@ type Plan struct {
 	// The changeset spec that is used in this plan.
 	ChangesetSpec *btypes.ChangesetSpec
 
+	// The changeset spec that was previously applied to the changeset, if
+	// any. It is used to determine which reviewers, labels and assignees to
+	// remove from the changeset.
+	PreviousChangesetSpec *btypes.ChangesetSpec
+
 	// The operations that need to be done to reconcile the changeset.
 	Ops Operations
 
@@ -110,8 +116,9 @@ func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 // error.
 func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChangeset, wantedChangeset *btypes.Changeset) (*Plan, error) {
 	pl := &Plan{
-		Changeset:     wantedChangeset,
-		ChangesetSpec: currentSpec,
+		Changeset:             wantedChangeset,
+		ChangesetSpec:         currentSpec,
+		PreviousChangesetSpec: previousSpec,
 	}
 
 	wantDetach := false
@@ -185,11 +192,17 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		if calc.IsPublished() {
 			pl.SetOp(btypes.ReconcilerOperationPublish)
 			pl.AddOp(btypes.ReconcilerOperationPush)
+			if hasSupportedMetadata(currentSpec, wantedChangeset.ExternalServiceType) {
+				pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+			}
 		} else if calc.IsDraft() && wantedChangeset.SupportsDraft() {
 			// If configured to be opened as draft, and the changeset supports
 			// draft mode, publish as draft. Otherwise, take no action.
 			pl.SetOp(btypes.ReconcilerOperationPublishDraft)
 			pl.AddOp(btypes.ReconcilerOperationPush)
+			if hasSupportedMetadata(currentSpec, wantedChangeset.ExternalServiceType) {
+				pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+			}
 		}
 		// TODO: test for Published.Nil() and then plan based on the UI
 		// publication state. For now, we'll let it fall through and treat it
@@ -245,6 +258,10 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		if delta.NeedMetadataUpdate(wantedChangeset.ExternalServiceType) {
+			pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
@@ -252,6 +269,14 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 	return pl, nil
 }
 
+// hasSupportedMetadata returns true if the given changeset spec has reviewers,
+// labels or assignees that are supported by the given code host.
+func hasSupportedMetadata(spec *btypes.ChangesetSpec, externalServiceType string) bool {
+	return ((len(spec.Reviewers) > 0 || spec.ReviewersFromCodeOwners) && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityReviewers)) ||
+		(len(spec.Labels) > 0 && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityLabels)) ||
+		(len(spec.Assignees) > 0 && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityAssignees))
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed ||
 		ch.ExternalState == btypes.ChangesetExternalStateReadOnly
@@ -327,9 +352,44 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.AuthorEmailChanged = true
 	}
 
+	// Reviewers derived from code owners depend on the changed files, so
+	// they need to be requested again when the diff changes.
+	if !equalStringSets(previous.Reviewers, current.Reviewers) ||
+		previous.ReviewersFromCodeOwners != current.ReviewersFromCodeOwners ||
+		(current.ReviewersFromCodeOwners && delta.DiffChanged) {
+		delta.ReviewersChanged = true
+	}
+	if !equalStringSets(previous.Labels, current.Labels) {
+		delta.LabelsChanged = true
+	}
+	if !equalStringSets(previous.Assignees, current.Assignees) {
+		delta.AssigneesChanged = true
+	}
+
 	return delta
 }
 
+// equalStringSets returns true if a and b contain the same strings, ignoring
+// order and duplicates.
+func equalStringSets(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, s := range a {
+		set[s] = false
+	}
+	for _, s := range b {
+		if _, ok := set[s]; !ok {
+			return false
+		}
+		set[s] = true
+	}
+	for _, seen := range set {
+		if !seen {
+			return false
+		}
+	}
+	return true
+}
+
 type ChangesetSpecDelta struct {
 	TitleChanged         bool
 	BodyChanged          bool
@@ -339,6 +399,9 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -351,6 +414,14 @@ func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
 	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
 }
 
+// NeedMetadataUpdate returns true if the reviewers, labels or assignees of the
+// changeset need to be updated on the given code host.
+func (d *ChangesetSpecDelta) NeedMetadataUpdate(externalServiceType string) bool {
+	return (d.ReviewersChanged && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityReviewers)) ||
+		(d.LabelsChanged && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityLabels)) ||
+		(d.AssigneesChanged && btypes.ExternalServiceSupports(externalServiceType, btypes.CodehostCapabilityAssignees))
+}
+
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
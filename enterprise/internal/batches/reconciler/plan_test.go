@@ -407,6 +407,74 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				// Expect no operations.
 			},
 		},
+		{
+			name:        "publish with reviewers, labels and assignees",
+			currentSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}, Labels: []string{"automated"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:        "publish with unsupported labels",
+			currentSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"automated"}},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+			},
+		},
+		{
+			name:         "reviewers changed",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:         "labels reordered",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"a", "b"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"b", "a"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "diff changed with reviewers from code owners",
+			previousSpec: &bt.TestSpecOpts{Published: true, ReviewersFromCodeOwners: true, CommitDiff: []byte("old")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, ReviewersFromCodeOwners: true, CommitDiff: []byte("new")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:         "assignees changed on unsupported code host",
+			previousSpec: &bt.TestSpecOpts{Published: true, Assignees: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Assignees: []string{"bob"}},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name: "import changeset",
 			changeset: bt.TestChangesetOpts{
@@ -23,7 +23,10 @@ type BitbucketServerSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = BitbucketServerSource{}
+var (
+	_ ForkableChangesetSource = BitbucketServerSource{}
+	_ MetadataChangesetSource = BitbucketServerSource{}
+)
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -212,17 +215,29 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
 	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
 
+	updated, err := s.updatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// updatePullRequest updates the given pull request. If the version of the
+// pull request is outdated, the update is retried once with the newest
+// version.
+func (s BitbucketServerSource) updatePullRequest(ctx context.Context, pr *bitbucketserver.PullRequest, update *bitbucketserver.UpdatePullRequestInput) (*bitbucketserver.PullRequest, error) {
 	updated, err := s.client.UpdatePullRequest(ctx, update)
 	if err != nil {
 		if !bitbucketserver.IsPullRequestOutOfDate(err) {
-			return err
+			return nil, err
 		}
 
 		// If we have an outdated version of the pull request we extract the
 		// pull request that was returned with the error...
 		newestPR, err2 := bitbucketserver.ExtractPullRequest(err)
 		if err2 != nil {
-			return errors.Wrap(err, "failed to extract pull request after receiving error")
+			return nil, errors.Wrap(err, "failed to extract pull request after receiving error")
 		}
 
 		log15.Info("Updating Bitbucket Server PR failed because it's outdated. Retrying with newer version", "ID", pr.ID, "oldVersion", pr.Version, "newestVerssion", newestPR.Version)
@@ -232,10 +247,57 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 		updated, err = s.client.UpdatePullRequest(ctx, update)
 		if err != nil {
 			// If that didn't work, we bail out
-			return err
+			return nil, err
 		}
 	}
 
+	return updated, nil
+}
+
+// UpdateChangesetMetadata requests reviewers on the given *Changeset in the
+// code host. Bitbucket Server doesn't support labels and assignees.
+func (s BitbucketServerSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update *ChangesetMetadataUpdate) error {
+	if len(update.AddReviewers) == 0 && len(update.RemoveReviewers) == 0 {
+		return nil
+	}
+
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	current := make([]string, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			current = append(current, r.User.Name)
+		}
+	}
+
+	// Bitbucket Server only supports replacing the reviewers of a pull
+	// request, so we need to send the title, description and target ref
+	// along unchanged.
+	usernames := applyUsernames(current, update.AddReviewers, update.RemoveReviewers)
+	reviewers := make([]bitbucketserver.ReviewerInput, len(usernames))
+	for i, username := range usernames {
+		reviewers[i] = bitbucketserver.ReviewerInput{User: bitbucketserver.User{Name: username}}
+	}
+
+	input := &bitbucketserver.UpdatePullRequestInput{
+		PullRequestID: strconv.Itoa(pr.ID),
+		Title:         pr.Title,
+		Description:   pr.Description,
+		Version:       pr.Version,
+		Reviewers:     &reviewers,
+	}
+	input.ToRef.ID = pr.ToRef.ID
+	input.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
+	input.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
+
+	updated, err := s.updatePullRequest(ctx, pr, input)
+	if err != nil {
+		return err
+	}
+
 	return c.Changeset.SetMetadata(updated)
 }
 
@@ -48,6 +48,78 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A MagicRefChangesetSource creates and updates changesets by pushing commits
+// to a magic ref on the code host instead of to a branch, such as
+// `refs/for/<branch>` on Gerrit.
+type MagicRefChangesetSource interface {
+	ChangesetSource
+
+	// PrepareCommit updates the given commit options, so that pushing the
+	// commit creates or updates the given Changeset on the code host.
+	PrepareCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest) error
+	// IsNoNewChangesPushError parses the given error output from `git push` to
+	// detect whether the push was rejected, because the commit is already
+	// part of the changeset. Such errors are not failures.
+	IsNoNewChangesPushError(output string) bool
+}
+
+// A MetadataChangesetSource can request reviewers, apply labels and set
+// assignees on changesets. Code hosts that only support some of these report
+// the others through the capabilities in btypes.SupportedExternalServices, and
+// ignore them in the update.
+type MetadataChangesetSource interface {
+	ChangesetSource
+
+	// UpdateChangesetMetadata applies the given update to the Changeset on
+	// the code host and updates the Changeset with the result.
+	UpdateChangesetMetadata(context.Context, *Changeset, *ChangesetMetadataUpdate) error
+}
+
+// ChangesetMetadataUpdate describes the reviewers, labels and assignees to add
+// to and remove from a changeset. Users are identified by their username on
+// the code host. Metadata that was added by other means than the changeset
+// spec is left untouched.
+type ChangesetMetadataUpdate struct {
+	AddReviewers    []string
+	RemoveReviewers []string
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+}
+
+// IsEmpty returns true if the update doesn't change anything.
+func (u *ChangesetMetadataUpdate) IsEmpty() bool {
+	return len(u.AddReviewers) == 0 && len(u.RemoveReviewers) == 0 &&
+		len(u.AddLabels) == 0 && len(u.RemoveLabels) == 0 &&
+		len(u.AddAssignees) == 0 && len(u.RemoveAssignees) == 0
+}
+
+// applyUsernames returns current with the usernames in add appended and the
+// usernames in remove removed, preserving order and dropping duplicates.
+func applyUsernames(current, add, remove []string) []string {
+	removed := make(map[string]struct{}, len(remove))
+	for _, u := range remove {
+		removed[u] = struct{}{}
+	}
+
+	seen := make(map[string]struct{}, len(current)+len(add))
+	result := make([]string, 0, len(current)+len(add))
+	for _, list := range [][]string{current, add} {
+		for _, u := range list {
+			if _, ok := removed[u]; ok {
+				continue
+			}
+			if _, ok := seen[u]; ok {
+				continue
+			}
+			seen[u] = struct{}{}
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
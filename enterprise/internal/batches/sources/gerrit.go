@@ -0,0 +1,342 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Unlike other code hosts,
+// Gerrit has no concept of branches to review: a change is created or updated
+// by pushing a commit to the magic `refs/for/<branch>` ref, and changes are
+// identified by the Change-Id trailer of the commit message.
+type GerritSource struct {
+	client *gerrit.Client
+	au     auth.Authenticator
+}
+
+var (
+	_ DraftChangesetSource    = GerritSource{}
+	_ MagicRefChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{
+		client: client,
+		au:     &auth.BasicAuth{Username: c.Username, Password: c.Password},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// PrepareCommit sets the commit message and the remote ref of the commit
+// options, so that pushing the commit creates a new change, or a new patch
+// set of an existing change, for the given Changeset.
+func (s GerritSource) PrepareCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest) error {
+	if opts.Push == nil {
+		return errors.New("cannot prepare commit for Gerrit without push config")
+	}
+
+	opts.CommitInfo.Message = gerritCommitMessage(cs.Title, cs.Body, gerritChangeID(cs))
+	// The topic groups the change with the head ref it was created for, which
+	// is how the changeset is matched against its branch when syncing.
+	opts.Push.RemoteRef = "refs/for/" + gitdomain.AbbreviateRef(cs.BaseRef) + "%topic=" + gitdomain.AbbreviateRef(cs.HeadRef)
+	return nil
+}
+
+// IsNoNewChangesPushError returns true if Gerrit rejected the push because the
+// commit is already the current patch set of the change.
+func (s GerritSource) IsNoNewChangesPushError(output string) bool {
+	return strings.Contains(output, "no new changes")
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.loadPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if change.WorkInProgress {
+		if err := s.client.SetReadyForReview(ctx, strconv.Itoa(change.Number)); err != nil {
+			return false, errors.Wrap(err, "marking change as ready for review")
+		}
+		return true, s.reloadChangeset(ctx, change.Number, cs)
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// The change itself was already created by pushing the commit, so we
+	// can't tell if it existed before. We'll simply say it did, so that it goes
+	// through the IsOutdated check afterwards regardless.
+	return true, nil
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode, which is a work in progress change on Gerrit.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.loadPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if !change.WorkInProgress {
+		if err := s.client.SetWorkInProgress(ctx, strconv.Itoa(change.Number)); err != nil {
+			return false, errors.Wrap(err, "marking change as work in progress")
+		}
+	}
+
+	return true, s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+
+	if change.WorkInProgress {
+		if err := s.client.SetReadyForReview(ctx, strconv.Itoa(change.Number)); err != nil {
+			return errors.Wrap(err, "marking change as ready for review")
+		}
+	}
+
+	return s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+
+	if err := s.client.AbandonChange(ctx, strconv.Itoa(change.Number)); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+	id := strconv.Itoa(change.Number)
+
+	if base := gitdomain.AbbreviateRef(cs.BaseRef); base != change.Branch {
+		if err := s.client.MoveChange(ctx, id, base); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	// Gerrit rejects commit message updates that don't change anything, so
+	// we only update the message when the title or body actually differ.
+	message := gerritCommitMessage(cs.Title, cs.Body, change.ChangeID)
+	if rev, ok := change.Current(); !ok || rev.Commit == nil || strings.TrimSpace(rev.Commit.Message) != strings.TrimSpace(message) {
+		if err := s.client.SetCommitMessage(ctx, id, message); err != nil {
+			return errors.Wrap(err, "updating commit message")
+		}
+	}
+
+	return s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if err := s.client.RestoreChange(ctx, strconv.Itoa(change.Number)); err != nil {
+			return errors.Wrap(err, "restoring change")
+		}
+	}
+
+	return s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+
+	return s.client.WriteReviewComment(ctx, strconv.Itoa(change.Number), comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes consist of a single commit, so squash has no effect. If the
+// changeset cannot be merged, because it is in an unmergeable state,
+// ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	change := cs.Metadata.(*gerritcs.AnnotatedChange)
+
+	if err := s.client.SubmitChange(ctx, strconv.Itoa(change.Number)); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.reloadChangeset(ctx, change.Number, cs)
+}
+
+// loadPushedChange loads the change created by pushing the commit prepared by
+// PrepareCommit.
+func (s GerritSource) loadPushedChange(ctx context.Context, cs *Changeset) (*gerrit.Change, error) {
+	project, err := gerritProjectName(cs.TargetRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeTriplet(project, cs.BaseRef, gerritChangeID(cs)))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, errors.Wrap(err, "change not found, the commit has not been pushed")
+		}
+		return nil, errors.Wrap(err, "getting change")
+	}
+	return change, nil
+}
+
+func (s GerritSource) reloadChangeset(ctx context.Context, number int, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, strconv.Itoa(number))
+	if err != nil {
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritcs.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+// The ID of a project is its URL encoded name.
+func gerritProjectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("unexpected repo metadata type %T", repo.Metadata)
+	}
+	return url.PathUnescape(project.ID)
+}
+
+// gerritChangeID returns the Change-Id trailer value for the given Changeset.
+// It is derived from the target repo and head ref, so that pushing a new
+// commit for the same changeset creates a new patch set of the same change.
+func gerritChangeID(cs *Changeset) string {
+	h := sha1.New()
+	h.Write([]byte(cs.TargetRepo.ExternalRepo.ID))
+	h.Write([]byte{0})
+	h.Write([]byte(gitdomain.EnsureRefPrefix(cs.HeadRef)))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// gerritCommitMessage returns the commit message for a change. Gerrit derives
+// the subject of a change from the commit message, so it contains the title
+// and body of the changeset followed by the Change-Id trailer.
+func gerritCommitMessage(title, body, changeID string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(title))
+	b.WriteString("\n\n")
+	if body = strings.TrimSpace(body); body != "" {
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Change-Id: ")
+	b.WriteString(changeID)
+	b.WriteString("\n")
+	return b.String()
+}
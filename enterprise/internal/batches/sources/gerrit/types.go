@@ -0,0 +1,40 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	CodeHostURL string
+}
+
+// URL returns the web URL of the change.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// Body returns the commit message of the current revision of the change,
+// without the subject line and the Change-Id trailer.
+func (c *AnnotatedChange) Body() string {
+	rev, ok := c.Current()
+	if !ok || rev.Commit == nil {
+		return ""
+	}
+
+	_, body, _ := strings.Cut(rev.Commit.Message, "\n")
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "Change-Id: ") {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
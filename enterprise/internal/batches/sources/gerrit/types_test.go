@@ -0,0 +1,40 @@
+package gerrit
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+func TestAnnotatedChange(t *testing.T) {
+	change := &AnnotatedChange{
+		Change: &gerrit.Change{
+			Project:         "foo/bar",
+			Number:          42,
+			CurrentRevision: "deadbeef",
+			Revisions: map[string]gerrit.Revision{
+				"deadbeef": {Commit: &gerrit.Commit{
+					Message: "Fix all the things\n\nThis is the body.\n\nIt has two paragraphs.\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n",
+				}},
+			},
+		},
+		CodeHostURL: "https://gerrit.example.com/",
+	}
+
+	if have, want := change.URL(), "https://gerrit.example.com/c/foo/bar/+/42"; have != want {
+		t.Errorf("wrong URL. have=%q, want=%q", have, want)
+	}
+	if have, want := change.Body(), "This is the body.\n\nIt has two paragraphs."; have != want {
+		t.Errorf("wrong body. have=%q, want=%q", have, want)
+	}
+
+	change.Revisions["deadbeef"].Commit.Message = "Fix all the things\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n"
+	if have := change.Body(); have != "" {
+		t.Errorf("expected empty body, have %q", have)
+	}
+
+	change.CurrentRevision = ""
+	if have := change.Body(); have != "" {
+		t.Errorf("expected empty body without current revision, have %q", have)
+	}
+}
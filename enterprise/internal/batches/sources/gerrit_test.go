@@ -0,0 +1,374 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gerritcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"username": ["not a string"]}`,
+			"bad URN":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGerritSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGerritSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig(`{"url": "https://gerrit.example.com/", "username": "user", "password": "pass"}`),
+		}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+		assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, s.au)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s, _ := mockGerritSource(t, http.NotFoundHandler())
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.NotNil(t, err)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{Username: "user", Password: "pass"},
+			&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, err)
+				assert.Same(t, au, newSource.(*GerritSource).au)
+				assert.Equal(t, "user", newSource.(*GerritSource).client.Config.Username)
+			})
+		}
+	})
+}
+
+func TestGerritSource_PrepareCommit(t *testing.T) {
+	s, _ := mockGerritSource(t, http.NotFoundHandler())
+	cs := mockGerritChangeset()
+
+	t.Run("without push config", func(t *testing.T) {
+		opts := protocol.CreateCommitFromPatchRequest{}
+		assert.NotNil(t, s.PrepareCommit(cs, &opts))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		opts := protocol.CreateCommitFromPatchRequest{
+			CommitInfo: protocol.PatchCommitInfo{Message: "original message"},
+			Push:       &protocol.PushConfig{RemoteURL: "https://gerrit.example.com/foo/bar"},
+		}
+		assert.Nil(t, s.PrepareCommit(cs, &opts))
+
+		changeID := gerritChangeID(cs)
+		assert.Len(t, changeID, 41)
+		assert.True(t, strings.HasPrefix(changeID, "I"))
+		assert.Equal(t, "Title\n\nBody\n\nChange-Id: "+changeID+"\n", opts.CommitInfo.Message)
+		assert.Equal(t, "refs/for/main%topic=my-branch", opts.Push.RemoteRef)
+	})
+
+	t.Run("change ID is stable per head ref", func(t *testing.T) {
+		other := mockGerritChangeset()
+		assert.Equal(t, gerritChangeID(cs), gerritChangeID(other))
+
+		other.HeadRef = "refs/heads/other-branch"
+		assert.NotEqual(t, gerritChangeID(cs), gerritChangeID(other))
+	})
+}
+
+func TestGerritSource_IsNoNewChangesPushError(t *testing.T) {
+	s, _ := mockGerritSource(t, http.NotFoundHandler())
+
+	assert.True(t, s.IsNoNewChangesPushError(" ! [remote rejected] deadbeef -> refs/for/main%topic=my-branch (no new changes)"))
+	assert.False(t, s.IsNoNewChangesPushError(" ! [remote rejected] deadbeef -> refs/for/main (prohibited by Gerrit)"))
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		s, _ := mockGerritSource(t, http.NotFoundHandler())
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		target := ChangesetNotFoundError{}
+		assert.ErrorAs(t, err, &target)
+		assert.Same(t, target.Changeset, cs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+
+		assert.Nil(t, s.LoadChangeset(ctx, cs))
+		assert.Equal(t, []string{"GET /a/changes/42"}, *requests)
+		assertGerritChangesetMetadata(t, cs)
+	})
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not pushed", func(t *testing.T) {
+		s, _ := mockGerritSource(t, http.NotFoundHandler())
+		cs := mockGerritChangeset()
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.False(t, exists)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+		cs := mockGerritChangeset()
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.True(t, exists)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GET /a/changes/foo%2Fbar~main~" + gerritChangeID(cs)}, *requests)
+		assertGerritChangesetMetadata(t, cs)
+	})
+
+	t.Run("work in progress", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, true))
+		cs := mockGerritChangeset()
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.True(t, exists)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"GET /a/changes/foo%2Fbar~main~" + gerritChangeID(cs),
+			"POST /a/changes/42/ready",
+			"GET /a/changes/42",
+		}, *requests)
+	})
+}
+
+func TestGerritSource_CreateDraftChangeset(t *testing.T) {
+	s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+	cs := mockGerritChangeset()
+
+	exists, err := s.CreateDraftChangeset(context.Background(), cs)
+	assert.True(t, exists)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"GET /a/changes/foo%2Fbar~main~" + gerritChangeID(cs),
+		"POST /a/changes/42/wip",
+		"GET /a/changes/42",
+	}, *requests)
+}
+
+func TestGerritSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unchanged", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+		assert.Nil(t, s.LoadChangeset(ctx, cs))
+		*requests = nil
+
+		assert.Nil(t, s.UpdateChangeset(ctx, cs))
+		assert.Equal(t, []string{"GET /a/changes/42"}, *requests)
+	})
+
+	t.Run("new title and base", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+		assert.Nil(t, s.LoadChangeset(ctx, cs))
+		*requests = nil
+
+		cs.Title = "New title"
+		cs.BaseRef = "refs/heads/release"
+		assert.Nil(t, s.UpdateChangeset(ctx, cs))
+		assert.Equal(t, []string{
+			"POST /a/changes/42/move",
+			"PUT /a/changes/42/message",
+			"GET /a/changes/42",
+		}, *requests)
+	})
+}
+
+func TestGerritSource_ReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		status gerrit.ChangeStatus
+		want   []string
+	}{
+		"abandoned": {gerrit.ChangeStatusAbandoned, []string{"POST /a/changes/42/restore", "GET /a/changes/42"}},
+		"open":      {gerrit.ChangeStatusNew, []string{"GET /a/changes/42"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, requests := mockGerritSource(t, gerritChangeHandler(tc.status, false))
+			cs := mockGerritChangeset()
+			cs.ExternalID = "42"
+			assert.Nil(t, s.LoadChangeset(ctx, cs))
+			*requests = nil
+
+			assert.Nil(t, s.ReopenChangeset(ctx, cs))
+			assert.Equal(t, tc.want, *requests)
+		})
+	}
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not mergeable", func(t *testing.T) {
+		s, _ := mockGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/submit") {
+				http.Error(w, "change is not ready: needs Code-Review", http.StatusConflict)
+				return
+			}
+			gerritChangeHandler(gerrit.ChangeStatusNew, false).ServeHTTP(w, r)
+		}))
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+		assert.Nil(t, s.LoadChangeset(ctx, cs))
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.NotNil(t, err)
+		target := ChangesetNotMergeableError{}
+		assert.ErrorAs(t, err, &target)
+		assert.Contains(t, target.ErrorMsg, "needs Code-Review")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, requests := mockGerritSource(t, gerritChangeHandler(gerrit.ChangeStatusNew, false))
+		cs := mockGerritChangeset()
+		cs.ExternalID = "42"
+		assert.Nil(t, s.LoadChangeset(ctx, cs))
+		*requests = nil
+
+		assert.Nil(t, s.MergeChangeset(ctx, cs, true))
+		assert.Equal(t, []string{"POST /a/changes/42/submit", "GET /a/changes/42"}, *requests)
+	})
+}
+
+func mockGerritSource(t *testing.T, handler http.Handler) (*GerritSource, *[]string) {
+	t.Helper()
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := gerrit.NewClient("urn", &schema.GerritConnection{Url: srv.URL + "/"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &GerritSource{client: client, au: &auth.BasicAuth{}}, &requests
+}
+
+func mockGerritChangeset() *Changeset {
+	return &Changeset{
+		Title:   "Title",
+		Body:    "Body",
+		HeadRef: "refs/heads/my-branch",
+		BaseRef: "refs/heads/main",
+		TargetRepo: &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo%2Fbar",
+				ServiceType: extsvc.TypeGerrit,
+			},
+			Metadata: &gerrit.Project{ID: "foo%2Fbar"},
+		},
+		Changeset: &btypes.Changeset{},
+	}
+}
+
+// gerritChangeHandler serves change 42 in the given state on GET requests,
+// and accepts any other request.
+func gerritChangeHandler(status gerrit.ChangeStatus, wip bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `)]}'
+{
+  "project": "foo/bar",
+  "branch": "main",
+  "topic": "my-branch",
+  "change_id": "I0123456789abcdef0123456789abcdef01234567",
+  "subject": "Title",
+  "status": %q,
+  "work_in_progress": %t,
+  "created": "2022-10-11 12:13:14.000000000",
+  "updated": "2022-10-12 12:13:14.000000000",
+  "_number": 42,
+  "owner": {"_account_id": 1000, "username": "jane", "email": "jane@example.com"},
+  "current_revision": "deadbeef",
+  "revisions": {"deadbeef": {"_number": 1, "ref": "refs/changes/42/42/1", "commit": {"parents": [{"commit": "cafebabe"}], "subject": "Title", "message": "Title\n\nBody\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n"}}}
+}`, status, wip)
+	})
+}
+
+func assertGerritChangesetMetadata(t *testing.T, cs *Changeset) {
+	t.Helper()
+
+	meta, ok := cs.Metadata.(*gerritcs.AnnotatedChange)
+	if !ok {
+		t.Fatalf("unexpected metadata type %T", cs.Metadata)
+	}
+	assert.Equal(t, 42, meta.Number)
+	assert.Equal(t, "42", cs.ExternalID)
+	assert.Equal(t, extsvc.TypeGerrit, cs.ExternalServiceType)
+	assert.Equal(t, "refs/heads/my-branch", cs.ExternalBranch)
+
+	body, err := cs.Changeset.Body()
+	assert.Nil(t, err)
+	assert.Equal(t, "Body", body)
+
+	baseRefOid, err := cs.Changeset.BaseRefOid()
+	assert.Nil(t, err)
+	assert.Equal(t, "cafebabe", baseRefOid)
+}
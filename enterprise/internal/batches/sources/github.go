@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -21,10 +23,16 @@ import (
 
 type GithubSource struct {
 	client *github.V4Client
-	au     auth.Authenticator
+	// v3Client is used for the metadata operations that the GraphQL API
+	// doesn't support.
+	v3Client *github.V3Client
+	au       auth.Authenticator
 }
 
-var _ ForkableChangesetSource = GithubSource{}
+var (
+	_ ForkableChangesetSource = GithubSource{}
+	_ MetadataChangesetSource = GithubSource{}
+)
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -68,8 +76,9 @@ func newGithubSource(urn string, c *schema.GitHubConnection, cf *httpcli.Factory
 	}
 
 	return &GithubSource{
-		au:     authr,
-		client: github.NewV4Client(urn, apiURL, authr, cli),
+		au:       authr,
+		client:   github.NewV4Client(urn, apiURL, authr, cli),
+		v3Client: github.NewV3Client(log.Scoped("GithubSource", "GitHub changeset source"), urn, apiURL, authr, cli),
 	}, nil
 }
 
@@ -90,6 +99,7 @@ func (s GithubSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource,
 	sc := s
 	sc.au = a
 	sc.client = sc.client.WithAuthenticator(a)
+	sc.v3Client = sc.v3Client.WithAuthenticator(a)
 
 	return &sc, nil
 }
@@ -293,6 +303,75 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// UpdateChangesetMetadata requests reviewers, applies labels and sets
+// assignees on the given *Changeset in the code host. Reviewers given as
+// `organization/team` are requested as teams.
+func (s GithubSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update *ChangesetMetadataUpdate) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	if users, teams := splitGitHubReviewers(update.RemoveReviewers); len(users) > 0 || len(teams) > 0 {
+		if err := s.v3Client.RemoveRequestedReviewers(ctx, owner, name, pr.Number, users, teams); err != nil {
+			return errors.Wrap(err, "removing reviewers")
+		}
+	}
+	if users, teams := splitGitHubReviewers(update.AddReviewers); len(users) > 0 || len(teams) > 0 {
+		if err := s.v3Client.RequestReviewers(ctx, owner, name, pr.Number, users, teams); err != nil {
+			return errors.Wrap(err, "requesting reviewers")
+		}
+	}
+
+	for _, label := range update.RemoveLabels {
+		if err := s.v3Client.RemoveLabel(ctx, owner, name, pr.Number, label); err != nil {
+			return errors.Wrapf(err, "removing label %q", label)
+		}
+	}
+	if len(update.AddLabels) > 0 {
+		if err := s.v3Client.AddLabels(ctx, owner, name, pr.Number, update.AddLabels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+
+	if len(update.RemoveAssignees) > 0 {
+		if err := s.v3Client.RemoveAssignees(ctx, owner, name, pr.Number, update.RemoveAssignees); err != nil {
+			return errors.Wrap(err, "removing assignees")
+		}
+	}
+	if len(update.AddAssignees) > 0 {
+		if err := s.v3Client.AddAssignees(ctx, owner, name, pr.Number, update.AddAssignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+
+	// Reload the pull request, so that the changeset reflects the new labels
+	// and review requests.
+	if err := s.client.LoadPullRequest(ctx, pr); err != nil {
+		return err
+	}
+	return c.Changeset.SetMetadata(pr)
+}
+
+// splitGitHubReviewers splits the given reviewers into users and the slugs of
+// teams, which are given as `organization/team`.
+func splitGitHubReviewers(reviewers []string) (users, teams []string) {
+	for _, r := range reviewers {
+		if _, team, ok := strings.Cut(r, "/"); ok {
+			teams = append(teams, team)
+		} else {
+			users = append(users, r)
+		}
+	}
+	return users, teams
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
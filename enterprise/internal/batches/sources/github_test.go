@@ -27,6 +27,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+func TestSplitGitHubReviewers(t *testing.T) {
+	users, teams := splitGitHubReviewers([]string{"alice", "sourcegraph/batchers", "bob"})
+	assert.Equal(t, []string{"alice", "bob"}, users)
+	assert.Equal(t, []string{"batchers"}, teams)
+}
+
 func TestGithubSource_CreateChangeset(t *testing.T) {
 	// Repository used: sourcegraph/automation-testing
 	//
@@ -29,6 +29,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ MetadataChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -487,6 +488,76 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// UpdateChangesetMetadata requests reviewers, applies labels and sets
+// assignees on the given *Changeset in the code host.
+func (s *GitLabSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset, update *ChangesetMetadataUpdate) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	opts := gitlab.UpdateMergeRequestOpts{
+		AddLabels:    strings.Join(update.AddLabels, ","),
+		RemoveLabels: strings.Join(update.RemoveLabels, ","),
+	}
+
+	// GitLab only supports replacing the reviewers and assignees of a merge
+	// request, so we compute the new sets from the current ones.
+	if len(update.AddReviewers) > 0 || len(update.RemoveReviewers) > 0 {
+		ids, err := s.userIDs(ctx, mr.Reviewers, update.AddReviewers, update.RemoveReviewers)
+		if err != nil {
+			return errors.Wrap(err, "resolving reviewers")
+		}
+		opts.ReviewerIDs = &ids
+	}
+	if len(update.AddAssignees) > 0 || len(update.RemoveAssignees) > 0 {
+		ids, err := s.userIDs(ctx, mr.Assignees, update.AddAssignees, update.RemoveAssignees)
+		if err != nil {
+			return errors.Wrap(err, "resolving assignees")
+		}
+		opts.AssigneeIDs = &ids
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// userIDs returns the IDs of the given current users with the usernames in add
+// added and the usernames in remove removed.
+func (s *GitLabSource) userIDs(ctx context.Context, current []gitlab.User, add, remove []string) ([]int32, error) {
+	known := make(map[string]int32, len(current))
+	usernames := make([]string, 0, len(current))
+	for _, u := range current {
+		known[u.Username] = u.ID
+		usernames = append(usernames, u.Username)
+	}
+
+	usernames = applyUsernames(usernames, add, remove)
+	ids := make([]int32, 0, len(usernames))
+	for _, username := range usernames {
+		id, ok := known[username]
+		if !ok {
+			u, err := s.client.GetUserByUsername(ctx, username)
+			if err != nil {
+				return nil, err
+			}
+			id = u.ID
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -568,3 +568,36 @@ func newMockSourcer(css ChangesetSource) Sourcer {
 		return css, nil
 	})
 }
+
+func TestApplyUsernames(t *testing.T) {
+	for name, tc := range map[string]struct {
+		current, add, remove []string
+		want                 []string
+	}{
+		"empty": {
+			want: []string{},
+		},
+		"add": {
+			current: []string{"alice"},
+			add:     []string{"bob", "alice"},
+			want:    []string{"alice", "bob"},
+		},
+		"remove": {
+			current: []string{"alice", "bob"},
+			remove:  []string{"alice", "carol"},
+			want:    []string{"bob"},
+		},
+		"add and remove": {
+			current: []string{"alice", "bob"},
+			add:     []string{"carol"},
+			remove:  []string{"bob"},
+			want:    []string{"alice", "carol"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, applyUsernames(tc.current, tc.add, tc.remove)); diff != "" {
+				t.Errorf("unexpected usernames (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
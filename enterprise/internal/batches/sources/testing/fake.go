@@ -58,6 +58,7 @@ type FakeChangesetSource struct {
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
 	IsArchivedPushErrorCalled   bool
+	UpdateMetadataCalled        bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -98,6 +99,10 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*sources.Changeset
 
+	// MetadataUpdates contains the updates that were passed to
+	// UpdateChangesetMetadata
+	MetadataUpdates []*sources.ChangesetMetadataUpdate
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 
@@ -109,6 +114,7 @@ var (
 	_ sources.ChangesetSource           = &FakeChangesetSource{}
 	_ sources.ArchivableChangesetSource = &FakeChangesetSource{}
 	_ sources.DraftChangesetSource      = &FakeChangesetSource{}
+	_ sources.MetadataChangesetSource   = &FakeChangesetSource{}
 )
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
@@ -320,3 +326,14 @@ func (s *FakeChangesetSource) IsArchivedPushError(output string) bool {
 	s.IsArchivedPushErrorCalled = true
 	return s.IsArchivedPushErrorTrue
 }
+
+func (s *FakeChangesetSource) UpdateChangesetMetadata(ctx context.Context, c *sources.Changeset, update *sources.ChangesetMetadataUpdate) error {
+	s.UpdateMetadataCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.MetadataUpdates = append(s.MetadataUpdates, update)
+	return nil
+}
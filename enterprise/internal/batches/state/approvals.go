@@ -0,0 +1,96 @@
+package state
+
+import (
+	"sort"
+
+	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+)
+
+// gerritApprovalValue is the Code-Review vote that is considered an approval.
+const gerritApprovalValue = 2
+
+// ComputeApprovals returns the number of distinct reviewers that currently
+// approve the changeset.
+//
+// Bitbucket Server, Bitbucket Cloud and Gerrit report the current vote of each
+// reviewer on the changeset itself, so the metadata is used for those. For all
+// other code hosts the latest review of each author is derived from the
+// changeset events, in the same way the review state history is computed.
+func ComputeApprovals(c *btypes.Changeset, es []*btypes.ChangesetEvent) int {
+	switch m := c.Metadata.(type) {
+	case *bitbucketserver.PullRequest:
+		n := 0
+		for _, r := range m.Reviewers {
+			if r.Status == "APPROVED" {
+				n++
+			}
+		}
+		return n
+
+	case *bbcs.AnnotatedPullRequest:
+		n := 0
+		for _, p := range m.Participants {
+			if p.State == bitbucketcloud.ParticipantStateApproved {
+				n++
+			}
+		}
+		return n
+
+	case *gerritcs.AnnotatedChange:
+		n := 0
+		for _, a := range m.Labels[gerritCodeReviewLabel].All {
+			if a.Value >= gerritApprovalValue {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Copy so that we can sort without mutating the argument
+	events := make(ChangesetEvents, len(es))
+	copy(events, es)
+	sort.Sort(events)
+
+	lastReviewByAuthor := map[string]btypes.ChangesetReviewState{}
+	for _, e := range events {
+		author := e.ReviewAuthor()
+		// If the user has been deleted, skip their reviews, as they don't count
+		// towards the approvals anymore.
+		if author == "" {
+			continue
+		}
+
+		// Dismissed GitHub reviews are handled through the review state, since
+		// GitHub updates the original review when it is dismissed.
+		switch e.Type() {
+		case btypes.ChangesetEventKindGitHubReviewed,
+			btypes.ChangesetEventKindGitLabApproved:
+			s, err := e.ReviewState()
+			if err != nil {
+				continue
+			}
+
+			switch s {
+			case btypes.ChangesetReviewStateApproved, btypes.ChangesetReviewStateChangesRequested:
+				lastReviewByAuthor[author] = s
+			case btypes.ChangesetReviewStateDismissed:
+				delete(lastReviewByAuthor, author)
+			}
+
+		case btypes.ChangesetEventKindGitLabUnapproved:
+			delete(lastReviewByAuthor, author)
+		}
+	}
+
+	n := 0
+	for _, s := range lastReviewByAuthor {
+		if s == btypes.ChangesetReviewStateApproved {
+			n++
+		}
+	}
+	return n
+}
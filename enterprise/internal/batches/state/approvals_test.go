@@ -0,0 +1,91 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+func TestComputeApprovals(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 16, 12, 0, 0, 0, time.UTC)
+	minutesAgo := func(n int) time.Time { return now.Add(-time.Duration(n) * time.Minute) }
+
+	tests := []struct {
+		name      string
+		changeset *btypes.Changeset
+		events    []*btypes.ChangesetEvent
+		want      int
+	}{
+		{
+			name:      "github - no reviews",
+			changeset: ghChangeset(1, minutesAgo(10)),
+			want:      0,
+		},
+		{
+			name:      "github - approvals by distinct authors",
+			changeset: ghChangeset(1, minutesAgo(10)),
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, minutesAgo(5), "alice", "APPROVED"),
+				ghReview(1, minutesAgo(4), "bob", "APPROVED"),
+				ghReview(1, minutesAgo(3), "alice", "APPROVED"),
+			},
+			want: 2,
+		},
+		{
+			name:      "github - later review requests changes",
+			changeset: ghChangeset(1, minutesAgo(10)),
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, minutesAgo(5), "alice", "APPROVED"),
+				ghReview(1, minutesAgo(4), "bob", "APPROVED"),
+				ghReview(1, minutesAgo(3), "alice", "CHANGES_REQUESTED"),
+			},
+			want: 1,
+		},
+		{
+			name:      "github - dismissed review",
+			changeset: ghChangeset(1, minutesAgo(10)),
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, minutesAgo(5), "alice", "APPROVED"),
+				ghReview(1, minutesAgo(4), "alice", "DISMISSED"),
+			},
+			want: 0,
+		},
+		{
+			name: "bitbucketserver - reviewers",
+			changeset: &btypes.Changeset{
+				Metadata: &bitbucketserver.PullRequest{
+					Reviewers: []bitbucketserver.Reviewer{
+						{Status: "APPROVED"},
+						{Status: "NEEDS_WORK"},
+						{Status: "APPROVED"},
+					},
+				},
+			},
+			want: 2,
+		},
+		{
+			name: "gerrit - code review votes",
+			changeset: gerritChangeset(minutesAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {All: []gerrit.Approval{
+					{Account: gerrit.Account{ID: 1}, Value: 2},
+					{Account: gerrit.Account{ID: 2}, Value: 1},
+					{Account: gerrit.Account{ID: 3}, Value: 2},
+				}},
+			}),
+			want: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := ComputeApprovals(tc.changeset, tc.events); have != tc.want {
+				t.Errorf("wrong number of approvals. have=%d, want=%d", have, tc.want)
+			}
+		})
+	}
+}
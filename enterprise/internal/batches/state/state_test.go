@@ -7,9 +7,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	gerritcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +409,40 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritVerifiedState(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.ChangeLabel
+		want   btypes.ChangesetCheckState
+	}{
+		"no verified label": {
+			labels: map[string]gerrit.ChangeLabel{"Code-Review": {}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"verified": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {Approved: &gerrit.Account{ID: 1}}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"failed": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {Approved: &gerrit.Account{ID: 1}, Rejected: &gerrit.Account{ID: 2}}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			changeset := gerritChangeset(timeutil.Now(), gerrit.ChangeStatusNew, tc.labels)
+
+			if have := computeCheckState(changeset, nil); have != tc.want {
+				t.Errorf("wrong check state. have=%s, want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +572,44 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - recommended",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Recommended: &gerrit.Account{ID: 1}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Approved: &gerrit.Account{ID: 1}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - approved and disliked",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Approved: &gerrit.Account{ID: 1}, Disliked: &gerrit.Account{ID: 2}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gerrit - rejected",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Rejected: &gerrit.Account{ID: 1}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +818,30 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - no events, new",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - no events, abandoned",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit - no events, merged",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gerrit draft - no events",
+			changeset: setDraft(gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
 	}
 
 	for i, tc := range tests {
@@ -899,6 +997,19 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.ChangeLabel) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerritcs.AnnotatedChange{
+			Change: &gerrit.Change{
+				Status: status,
+				Labels: labels,
+			},
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
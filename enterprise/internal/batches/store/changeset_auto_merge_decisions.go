@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetAutoMergeDecisionColumns are used by the changeset auto-merge
+// decision related Store methods to query and create decisions.
+var changesetAutoMergeDecisionColumns = SQLColumns{
+	"changeset_auto_merge_decisions.id",
+	"changeset_auto_merge_decisions.changeset_id",
+	"changeset_auto_merge_decisions.batch_change_id",
+	"changeset_auto_merge_decisions.merged",
+	"changeset_auto_merge_decisions.reason",
+	"changeset_auto_merge_decisions.created_at",
+}
+
+// CreateChangesetAutoMergeDecision creates the given auto-merge decision.
+func (s *Store) CreateChangesetAutoMergeDecision(ctx context.Context, d *btypes.ChangesetAutoMergeDecision) (err error) {
+	ctx, _, endObservation := s.operations.createChangesetAutoMergeDecision.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(d.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = s.now()
+	}
+
+	q := createChangesetAutoMergeDecisionQuery(d)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMergeDecision(d, sc)
+	})
+}
+
+var createChangesetAutoMergeDecisionQueryFmtstr = `
+INSERT INTO changeset_auto_merge_decisions (
+	changeset_id,
+	batch_change_id,
+	merged,
+	reason,
+	created_at
+)
+VALUES
+	(%s, %s, %s, %s, %s)
+RETURNING
+	%s
+`
+
+func createChangesetAutoMergeDecisionQuery(d *btypes.ChangesetAutoMergeDecision) *sqlf.Query {
+	return sqlf.Sprintf(
+		createChangesetAutoMergeDecisionQueryFmtstr,
+		d.ChangesetID,
+		d.BatchChangeID,
+		d.Merged,
+		d.Reason,
+		d.CreatedAt,
+		sqlf.Join(changesetAutoMergeDecisionColumns.ToSqlf(), ", "),
+	)
+}
+
+// GetLatestChangesetAutoMergeDecision returns the most recent auto-merge
+// decision recorded for the given changeset. If no decision has been recorded
+// yet, ErrNoResults is returned.
+func (s *Store) GetLatestChangesetAutoMergeDecision(ctx context.Context, changesetID int64) (d *btypes.ChangesetAutoMergeDecision, err error) {
+	ctx, _, endObservation := s.operations.getLatestChangesetAutoMergeDecision.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getLatestChangesetAutoMergeDecisionQueryFmtstr,
+		sqlf.Join(changesetAutoMergeDecisionColumns.ToSqlf(), ", "),
+		changesetID,
+	)
+
+	var c btypes.ChangesetAutoMergeDecision
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMergeDecision(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getLatestChangesetAutoMergeDecisionQueryFmtstr = `
+SELECT %s FROM changeset_auto_merge_decisions
+WHERE changeset_auto_merge_decisions.changeset_id = %s
+ORDER BY changeset_auto_merge_decisions.id DESC
+LIMIT 1
+`
+
+// ListChangesetAutoMergeDecisionsOpts captures the query options needed for
+// listing auto-merge decisions.
+type ListChangesetAutoMergeDecisionsOpts struct {
+	LimitOpts
+	Cursor      int64
+	ChangesetID int64
+}
+
+// ListChangesetAutoMergeDecisions lists the auto-merge decisions matching the
+// given options, oldest first.
+func (s *Store) ListChangesetAutoMergeDecisions(ctx context.Context, opts ListChangesetAutoMergeDecisionsOpts) (ds []*btypes.ChangesetAutoMergeDecision, next int64, err error) {
+	ctx, _, endObservation := s.operations.listChangesetAutoMergeDecisions.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listChangesetAutoMergeDecisionsQuery(&opts)
+
+	ds = make([]*btypes.ChangesetAutoMergeDecision, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var d btypes.ChangesetAutoMergeDecision
+		if err := scanChangesetAutoMergeDecision(&d, sc); err != nil {
+			return err
+		}
+		ds = append(ds, &d)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ds) == opts.DBLimit() {
+		next = ds[len(ds)-1].ID
+		ds = ds[:len(ds)-1]
+	}
+
+	return ds, next, err
+}
+
+var listChangesetAutoMergeDecisionsQueryFmtstr = `
+SELECT %s FROM changeset_auto_merge_decisions
+WHERE %s
+ORDER BY changeset_auto_merge_decisions.id ASC
+`
+
+func listChangesetAutoMergeDecisionsQuery(opts *ListChangesetAutoMergeDecisionsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("changeset_auto_merge_decisions.id >= %s", opts.Cursor),
+	}
+
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merge_decisions.changeset_id = %s", opts.ChangesetID))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetAutoMergeDecisionsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(changesetAutoMergeDecisionColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanChangesetAutoMergeDecision(d *btypes.ChangesetAutoMergeDecision, s dbutil.Scanner) error {
+	return s.Scan(
+		&d.ID,
+		&d.ChangesetID,
+		&d.BatchChangeID,
+		&d.Merged,
+		&d.Reason,
+		&d.CreatedAt,
+	)
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetAutoMergeDecisions(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID})
+	otherChangeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID})
+	var batchChangeID int64 = 4321
+
+	decisions := []*btypes.ChangesetAutoMergeDecision{
+		{ChangesetID: changeset.ID, BatchChangeID: batchChangeID, Reason: "1 of 2 required approvals"},
+		{ChangesetID: otherChangeset.ID, BatchChangeID: batchChangeID, Reason: "checks have not passed"},
+		{ChangesetID: changeset.ID, BatchChangeID: batchChangeID, Merged: true, Reason: "policy satisfied"},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, d := range decisions {
+			if err := s.CreateChangesetAutoMergeDecision(ctx, d); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.ID == 0 {
+				t.Fatal("decision ID is 0")
+			}
+
+			if have, want := d.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("wrong created_at. have=%s, want=%s", have, want)
+			}
+		}
+	})
+
+	t.Run("GetLatest", func(t *testing.T) {
+		t.Run("ByChangesetID", func(t *testing.T) {
+			have, err := s.GetLatestChangesetAutoMergeDecision(ctx, changeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, decisions[2]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetLatestChangesetAutoMergeDecision(ctx, 0xdeadbeef)
+			if err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("All", func(t *testing.T) {
+			have, _, err := s.ListChangesetAutoMergeDecisions(ctx, ListChangesetAutoMergeDecisionsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, decisions); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ByChangesetID", func(t *testing.T) {
+			have, _, err := s.ListChangesetAutoMergeDecisions(ctx, ListChangesetAutoMergeDecisionsOpts{ChangesetID: changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []*btypes.ChangesetAutoMergeDecision{decisions[0], decisions[2]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			have, next, err := s.ListChangesetAutoMergeDecisions(ctx, ListChangesetAutoMergeDecisionsOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, decisions[:1]); diff != "" {
+				t.Fatal(diff)
+			}
+			if have, want := next, decisions[1].ID; have != want {
+				t.Fatalf("wrong cursor. have=%d, want=%d", have, want)
+			}
+		})
+	})
+}
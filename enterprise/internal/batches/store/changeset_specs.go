@@ -45,6 +45,11 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"reviewers",
+	"reviewers_from_code_owners",
+	"labels",
+	"assignees",
+	"code_owner_reviewers",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +77,11 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.reviewers",
+	"changeset_specs.reviewers_from_code_owners",
+	"changeset_specs.labels",
+	"changeset_specs.assignees",
+	"changeset_specs.code_owner_reviewers",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +147,11 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				pq.Array(nonNilStrings(c.Reviewers)),
+				c.ReviewersFromCodeOwners,
+				pq.Array(nonNilStrings(c.Labels)),
+				pq.Array(nonNilStrings(c.Assignees)),
+				pq.Array(nonNilStrings(c.CodeOwnerReviewers)),
 			); err != nil {
 				return err
 			}
@@ -187,6 +202,23 @@ func (s *Store) updateChangesetSpecQuery(cs []int64, batchSpec int64) *sqlf.Quer
 	)
 }
 
+// UpdateChangesetSpecCodeOwnerReviewers updates the reviewers derived from code
+// owners that were requested for the given ChangesetSpec.
+func (s *Store) UpdateChangesetSpecCodeOwnerReviewers(ctx context.Context, id int64, reviewers []string) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetSpecCodeOwnerReviewers.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(updateChangesetSpecCodeOwnerReviewersQueryFmtstr, pq.Array(nonNilStrings(reviewers)), id))
+}
+
+var updateChangesetSpecCodeOwnerReviewersQueryFmtstr = `
+UPDATE changeset_specs
+SET code_owner_reviewers = %s
+WHERE id = %s
+`
+
 // DeleteChangesetSpec deletes the ChangesetSpec with the given ID.
 func (s *Store) DeleteChangesetSpec(ctx context.Context, id int64) (err error) {
 	ctx, _, endObservation := s.operations.deleteChangesetSpec.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -562,6 +594,11 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		pq.Array(&c.Reviewers),
+		&c.ReviewersFromCodeOwners,
+		pq.Array(&c.Labels),
+		pq.Array(&c.Assignees),
+		pq.Array(&c.CodeOwnerReviewers),
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -569,6 +606,21 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 
 	c.Type = btypes.ChangesetSpecType(typ)
 
+	// Normalize empty arrays, so that specs read from the database compare
+	// equal to the specs they were created from.
+	if len(c.Reviewers) == 0 {
+		c.Reviewers = nil
+	}
+	if len(c.Labels) == 0 {
+		c.Labels = nil
+	}
+	if len(c.Assignees) == 0 {
+		c.Assignees = nil
+	}
+	if len(c.CodeOwnerReviewers) == 0 {
+		c.CodeOwnerReviewers = nil
+	}
+
 	if len(published) != 0 {
 		if err := json.Unmarshal(published, &c.Published); err != nil {
 			return err
@@ -578,6 +630,15 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 	return nil
 }
 
+// nonNilStrings returns an empty slice instead of nil, since the array columns
+// of changeset_specs are not nullable.
+func nonNilStrings(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
+
 type GetRewirerMappingsOpts struct {
 	BatchSpecID   int64
 	BatchChangeID int64
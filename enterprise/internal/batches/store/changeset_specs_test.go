@@ -77,6 +77,10 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 			c.CommitAuthorName = "name"
 			c.CommitAuthorEmail = "email"
 			c.Type = btypes.ChangesetSpecTypeBranch
+			c.Reviewers = []string{"alice", "sourcegraph/batchers"}
+			c.ReviewersFromCodeOwners = true
+			c.Labels = []string{"automated"}
+			c.Assignees = []string{"bob"}
 		} else {
 			c.ExternalID = "123456"
 			c.Type = btypes.ChangesetSpecTypeExisting
@@ -348,6 +352,24 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 		}
 	})
 
+	t.Run("UpdateChangesetSpecCodeOwnerReviewers", func(t *testing.T) {
+		c := changesetSpecs[0]
+		for _, reviewers := range [][]string{{"sourcegraph/batchers", "alice"}, nil} {
+			c.CodeOwnerReviewers = reviewers
+			want := c.Clone()
+			if err := s.UpdateChangesetSpecCodeOwnerReviewers(ctx, c.ID, reviewers); err != nil {
+				t.Fatal(err)
+			}
+			have, err := s.GetChangesetSpecByID(ctx, c.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		want := changesetSpecs[1]
 		tests := map[string]GetChangesetSpecOpts{
@@ -215,8 +215,13 @@ type operations struct {
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
+	createChangesetAutoMergeDecision    *observation.Operation
+	getLatestChangesetAutoMergeDecision *observation.Operation
+	listChangesetAutoMergeDecisions     *observation.Operation
+
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
+	updateChangesetSpecCodeOwnerReviewers    *observation.Operation
 	deleteChangesetSpec                      *observation.Operation
 	countChangesetSpecs                      *observation.Operation
 	getChangesetSpec                         *observation.Operation
@@ -359,8 +364,13 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
+			createChangesetAutoMergeDecision:    op("CreateChangesetAutoMergeDecision"),
+			getLatestChangesetAutoMergeDecision: op("GetLatestChangesetAutoMergeDecision"),
+			listChangesetAutoMergeDecisions:     op("ListChangesetAutoMergeDecisions"),
+
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
+			updateChangesetSpecCodeOwnerReviewers:    op("UpdateChangesetSpecCodeOwnerReviewers"),
 			deleteChangesetSpec:                      op("DeleteChangesetSpec"),
 			countChangesetSpecs:                      op("CountChangesetSpecs"),
 			getChangesetSpec:                         op("GetChangesetSpec"),
@@ -24,6 +24,10 @@ type MockSyncStore struct {
 	// ClockFunc is an instance of a mock function object controlling the
 	// behavior of the method Clock.
 	ClockFunc *SyncStoreClockFunc
+	// CreateChangesetAutoMergeDecisionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// CreateChangesetAutoMergeDecision.
+	CreateChangesetAutoMergeDecisionFunc *SyncStoreCreateChangesetAutoMergeDecisionFunc
 	// DatabaseDBFunc is an instance of a mock function object controlling
 	// the behavior of the method DatabaseDB.
 	DatabaseDBFunc *SyncStoreDatabaseDBFunc
@@ -33,12 +37,19 @@ type MockSyncStore struct {
 	// GetBatchChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBatchChange.
 	GetBatchChangeFunc *SyncStoreGetBatchChangeFunc
+	// GetBatchSpecFunc is an instance of a mock function object controlling
+	// the behavior of the method GetBatchSpec.
+	GetBatchSpecFunc *SyncStoreGetBatchSpecFunc
 	// GetChangesetFunc is an instance of a mock function object controlling
 	// the behavior of the method GetChangeset.
 	GetChangesetFunc *SyncStoreGetChangesetFunc
 	// GetExternalServiceIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetExternalServiceIDs.
 	GetExternalServiceIDsFunc *SyncStoreGetExternalServiceIDsFunc
+	// GetLatestChangesetAutoMergeDecisionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetLatestChangesetAutoMergeDecision.
+	GetLatestChangesetAutoMergeDecisionFunc *SyncStoreGetLatestChangesetAutoMergeDecisionFunc
 	// GetSiteCredentialFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSiteCredential.
 	GetSiteCredentialFunc *SyncStoreGetSiteCredentialFunc
@@ -78,6 +89,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		CreateChangesetAutoMergeDecisionFunc: &SyncStoreCreateChangesetAutoMergeDecisionFunc{
+			defaultHook: func(context.Context, *types.ChangesetAutoMergeDecision) (r0 error) {
+				return
+			},
+		},
 		DatabaseDBFunc: &SyncStoreDatabaseDBFunc{
 			defaultHook: func() (r0 database.DB) {
 				return
@@ -93,6 +109,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: func(context.Context, store.GetBatchSpecOpts) (r0 *types.BatchSpec, r1 error) {
+				return
+			},
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: func(context.Context, store.GetChangesetOpts) (r0 *types.Changeset, r1 error) {
 				return
@@ -103,6 +124,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		GetLatestChangesetAutoMergeDecisionFunc: &SyncStoreGetLatestChangesetAutoMergeDecisionFunc{
+			defaultHook: func(context.Context, int64) (r0 *types.ChangesetAutoMergeDecision, r1 error) {
+				return
+			},
+		},
 		GetSiteCredentialFunc: &SyncStoreGetSiteCredentialFunc{
 			defaultHook: func(context.Context, store.GetSiteCredentialOpts) (r0 *types.SiteCredential, r1 error) {
 				return
@@ -160,6 +186,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.Clock")
 			},
 		},
+		CreateChangesetAutoMergeDecisionFunc: &SyncStoreCreateChangesetAutoMergeDecisionFunc{
+			defaultHook: func(context.Context, *types.ChangesetAutoMergeDecision) error {
+				panic("unexpected invocation of MockSyncStore.CreateChangesetAutoMergeDecision")
+			},
+		},
 		DatabaseDBFunc: &SyncStoreDatabaseDBFunc{
 			defaultHook: func() database.DB {
 				panic("unexpected invocation of MockSyncStore.DatabaseDB")
@@ -175,6 +206,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.GetBatchChange")
 			},
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+				panic("unexpected invocation of MockSyncStore.GetBatchSpec")
+			},
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: func(context.Context, store.GetChangesetOpts) (*types.Changeset, error) {
 				panic("unexpected invocation of MockSyncStore.GetChangeset")
@@ -185,6 +221,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.GetExternalServiceIDs")
 			},
 		},
+		GetLatestChangesetAutoMergeDecisionFunc: &SyncStoreGetLatestChangesetAutoMergeDecisionFunc{
+			defaultHook: func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error) {
+				panic("unexpected invocation of MockSyncStore.GetLatestChangesetAutoMergeDecision")
+			},
+		},
 		GetSiteCredentialFunc: &SyncStoreGetSiteCredentialFunc{
 			defaultHook: func(context.Context, store.GetSiteCredentialOpts) (*types.SiteCredential, error) {
 				panic("unexpected invocation of MockSyncStore.GetSiteCredential")
@@ -240,6 +281,9 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		ClockFunc: &SyncStoreClockFunc{
 			defaultHook: i.Clock,
 		},
+		CreateChangesetAutoMergeDecisionFunc: &SyncStoreCreateChangesetAutoMergeDecisionFunc{
+			defaultHook: i.CreateChangesetAutoMergeDecision,
+		},
 		DatabaseDBFunc: &SyncStoreDatabaseDBFunc{
 			defaultHook: i.DatabaseDB,
 		},
@@ -249,12 +293,18 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		GetBatchChangeFunc: &SyncStoreGetBatchChangeFunc{
 			defaultHook: i.GetBatchChange,
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: i.GetBatchSpec,
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: i.GetChangeset,
 		},
 		GetExternalServiceIDsFunc: &SyncStoreGetExternalServiceIDsFunc{
 			defaultHook: i.GetExternalServiceIDs,
 		},
+		GetLatestChangesetAutoMergeDecisionFunc: &SyncStoreGetLatestChangesetAutoMergeDecisionFunc{
+			defaultHook: i.GetLatestChangesetAutoMergeDecision,
+		},
 		GetSiteCredentialFunc: &SyncStoreGetSiteCredentialFunc{
 			defaultHook: i.GetSiteCredential,
 		},
@@ -383,6 +433,115 @@ func (c SyncStoreClockFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SyncStoreCreateChangesetAutoMergeDecisionFunc describes the behavior when
+// the CreateChangesetAutoMergeDecision method of the parent MockSyncStore
+// instance is invoked.
+type SyncStoreCreateChangesetAutoMergeDecisionFunc struct {
+	defaultHook func(context.Context, *types.ChangesetAutoMergeDecision) error
+	hooks       []func(context.Context, *types.ChangesetAutoMergeDecision) error
+	history     []SyncStoreCreateChangesetAutoMergeDecisionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateChangesetAutoMergeDecision delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockSyncStore) CreateChangesetAutoMergeDecision(v0 context.Context, v1 *types.ChangesetAutoMergeDecision) error {
+	r0 := m.CreateChangesetAutoMergeDecisionFunc.nextHook()(v0, v1)
+	m.CreateChangesetAutoMergeDecisionFunc.appendCall(SyncStoreCreateChangesetAutoMergeDecisionFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateChangesetAutoMergeDecision method of the parent MockSyncStore
+// instance is invoked and the hook queue is empty.
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) SetDefaultHook(hook func(context.Context, *types.ChangesetAutoMergeDecision) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateChangesetAutoMergeDecision method of the parent MockSyncStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) PushHook(hook func(context.Context, *types.ChangesetAutoMergeDecision) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *types.ChangesetAutoMergeDecision) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *types.ChangesetAutoMergeDecision) error {
+		return r0
+	})
+}
+
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) nextHook() func(context.Context, *types.ChangesetAutoMergeDecision) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) appendCall(r0 SyncStoreCreateChangesetAutoMergeDecisionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SyncStoreCreateChangesetAutoMergeDecisionFuncCall objects describing the
+// invocations of this function.
+func (f *SyncStoreCreateChangesetAutoMergeDecisionFunc) History() []SyncStoreCreateChangesetAutoMergeDecisionFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreCreateChangesetAutoMergeDecisionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreCreateChangesetAutoMergeDecisionFuncCall is an object that
+// describes an invocation of method CreateChangesetAutoMergeDecision on an
+// instance of MockSyncStore.
+type SyncStoreCreateChangesetAutoMergeDecisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.ChangesetAutoMergeDecision
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreCreateChangesetAutoMergeDecisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreCreateChangesetAutoMergeDecisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // SyncStoreDatabaseDBFunc describes the behavior when the DatabaseDB method
 // of the parent MockSyncStore instance is invoked.
 type SyncStoreDatabaseDBFunc struct {
@@ -688,6 +847,114 @@ func (c SyncStoreGetBatchChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SyncStoreGetBatchSpecFunc describes the behavior when the GetBatchSpec
+// method of the parent MockSyncStore instance is invoked.
+type SyncStoreGetBatchSpecFunc struct {
+	defaultHook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)
+	hooks       []func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)
+	history     []SyncStoreGetBatchSpecFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBatchSpec delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSyncStore) GetBatchSpec(v0 context.Context, v1 store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+	r0, r1 := m.GetBatchSpecFunc.nextHook()(v0, v1)
+	m.GetBatchSpecFunc.appendCall(SyncStoreGetBatchSpecFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetBatchSpec method
+// of the parent MockSyncStore instance is invoked and the hook queue is
+// empty.
+func (f *SyncStoreGetBatchSpecFunc) SetDefaultHook(hook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBatchSpec method of the parent MockSyncStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SyncStoreGetBatchSpecFunc) PushHook(hook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreGetBatchSpecFunc) SetDefaultReturn(r0 *types.BatchSpec, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreGetBatchSpecFunc) PushReturn(r0 *types.BatchSpec, r1 error) {
+	f.PushHook(func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreGetBatchSpecFunc) nextHook() func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreGetBatchSpecFunc) appendCall(r0 SyncStoreGetBatchSpecFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SyncStoreGetBatchSpecFuncCall objects
+// describing the invocations of this function.
+func (f *SyncStoreGetBatchSpecFunc) History() []SyncStoreGetBatchSpecFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreGetBatchSpecFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreGetBatchSpecFuncCall is an object that describes an invocation
+// of method GetBatchSpec on an instance of MockSyncStore.
+type SyncStoreGetBatchSpecFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.GetBatchSpecOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.BatchSpec
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreGetBatchSpecFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreGetBatchSpecFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreGetChangesetFunc describes the behavior when the GetChangeset
 // method of the parent MockSyncStore instance is invoked.
 type SyncStoreGetChangesetFunc struct {
@@ -906,6 +1173,119 @@ func (c SyncStoreGetExternalServiceIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SyncStoreGetLatestChangesetAutoMergeDecisionFunc describes the behavior
+// when the GetLatestChangesetAutoMergeDecision method of the parent
+// MockSyncStore instance is invoked.
+type SyncStoreGetLatestChangesetAutoMergeDecisionFunc struct {
+	defaultHook func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error)
+	hooks       []func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error)
+	history     []SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLatestChangesetAutoMergeDecision delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockSyncStore) GetLatestChangesetAutoMergeDecision(v0 context.Context, v1 int64) (*types.ChangesetAutoMergeDecision, error) {
+	r0, r1 := m.GetLatestChangesetAutoMergeDecisionFunc.nextHook()(v0, v1)
+	m.GetLatestChangesetAutoMergeDecisionFunc.appendCall(SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetLatestChangesetAutoMergeDecision method of the parent MockSyncStore
+// instance is invoked and the hook queue is empty.
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) SetDefaultHook(hook func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLatestChangesetAutoMergeDecision method of the parent MockSyncStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) PushHook(hook func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) SetDefaultReturn(r0 *types.ChangesetAutoMergeDecision, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) PushReturn(r0 *types.ChangesetAutoMergeDecision, r1 error) {
+	f.PushHook(func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) nextHook() func(context.Context, int64) (*types.ChangesetAutoMergeDecision, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) appendCall(r0 SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall objects describing
+// the invocations of this function.
+func (f *SyncStoreGetLatestChangesetAutoMergeDecisionFunc) History() []SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall is an object that
+// describes an invocation of method GetLatestChangesetAutoMergeDecision on
+// an instance of MockSyncStore.
+type SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.ChangesetAutoMergeDecision
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreGetLatestChangesetAutoMergeDecisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreGetSiteCredentialFunc describes the behavior when the
 // GetSiteCredential method of the parent MockSyncStore instance is invoked.
 type SyncStoreGetSiteCredentialFunc struct {
@@ -25,4 +25,7 @@ type SyncStore interface {
 	GetExternalServiceIDs(ctx context.Context, opts store.GetExternalServiceIDsOpts) ([]int64, error)
 	UserCredentials() database.UserCredentialsStore
 	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
+	GetBatchSpec(ctx context.Context, opts store.GetBatchSpecOpts) (*btypes.BatchSpec, error)
+	GetLatestChangesetAutoMergeDecision(ctx context.Context, changesetID int64) (*btypes.ChangesetAutoMergeDecision, error)
+	CreateChangesetAutoMergeDecision(ctx context.Context, d *btypes.ChangesetAutoMergeDecision) error
 }
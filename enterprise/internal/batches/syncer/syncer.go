@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -524,17 +525,38 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	}
 	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
 
+	// Reset syncer error message state.
+	c.SyncErrorMessage = nil
+
+	if err := storeChangesetCodeHostState(ctx, syncStore, c, events); err != nil {
+		return err
+	}
+
+	// Merging calls the code host, so it happens after the synced state has
+	// been stored, outside of the transaction.
+	merged, err := reconciler.AutoMergeChangeset(ctx, syncStore, source, repo, c, events)
+	if err != nil || !merged {
+		return err
+	}
+
+	if events, err = c.Events(); err != nil {
+		return err
+	}
+	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
+
+	return storeChangesetCodeHostState(ctx, syncStore, c, events)
+}
+
+// storeChangesetCodeHostState updates the code host state and the events of
+// the given changeset in a single transaction.
+func storeChangesetCodeHostState(ctx context.Context, syncStore SyncStore, c *btypes.Changeset, events []*btypes.ChangesetEvent) (err error) {
 	tx, err := syncStore.Transact(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { err = tx.Done(err) }()
 
-	// Reset syncer error message state.
-	c.SyncErrorMessage = nil
-
-	err = tx.UpdateChangesetCodeHostState(ctx, c)
-	if err != nil {
+	if err := tx.UpdateChangesetCodeHostState(ctx, c); err != nil {
 		return err
 	}
 
@@ -39,6 +39,11 @@ type TestSpecOpts struct {
 	BaseRev string
 	BaseRef string
 
+	Reviewers               []string
+	ReviewersFromCodeOwners bool
+	Labels                  []string
+	Assignees               []string
+
 	Typ btypes.ChangesetSpecType
 }
 
@@ -75,6 +80,11 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+
+		Reviewers:               opts.Reviewers,
+		ReviewersFromCodeOwners: opts.ReviewersFromCodeOwners,
+		Labels:                  opts.Labels,
+		Assignees:               opts.Assignees,
 	}
 
 	return spec
@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+// ChangesetAutoMergeDecision records the outcome of evaluating the auto-merge
+// policy of a batch change against one of its changesets. Decisions are only
+// recorded when the outcome changes, so that the list of decisions of a
+// changeset forms an audit trail of why it was or wasn't merged.
+type ChangesetAutoMergeDecision struct {
+	ID            int64
+	ChangesetID   int64
+	BatchChangeID int64
+
+	// Merged is true if the changeset was merged as the result of this
+	// decision.
+	Merged bool
+	// Reason is a human readable explanation of the decision.
+	Reason string
+
+	CreatedAt time.Time
+}
@@ -35,6 +35,11 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+
+		Reviewers:               spec.Reviewers,
+		ReviewersFromCodeOwners: spec.ReviewersFromCodeOwners,
+		Labels:                  spec.Labels,
+		Assignees:               spec.Assignees,
 	}
 
 	if spec.IsImportingExisting() {
@@ -113,6 +118,18 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// Reviewers, Labels and Assignees are synced to the changeset on the code
+	// host. If ReviewersFromCodeOwners is set, the owners of the changed files
+	// are requested as reviewers in addition to Reviewers.
+	Reviewers               []string
+	ReviewersFromCodeOwners bool
+	Labels                  []string
+	Assignees               []string
+	// CodeOwnerReviewers are the reviewers derived from code owners that were
+	// requested on the changeset when the spec was applied. They are removed
+	// from the changeset again when they are no longer code owners.
+	CodeOwnerReviewers []string
+
 	ForkNamespace *string
 }
 
@@ -17,6 +17,8 @@ const (
 	ReconcilerOperationDetach       ReconcilerOperation = "DETACH"
 	ReconcilerOperationArchive      ReconcilerOperation = "ARCHIVE"
 	ReconcilerOperationReattach     ReconcilerOperation = "REATTACH"
+
+	ReconcilerOperationUpdateMetadata ReconcilerOperation = "UPDATE_METADATA"
 )
 
 // Valid returns true if the given ReconcilerOperation is valid.
@@ -34,7 +36,8 @@ func (r ReconcilerOperation) Valid() bool {
 		ReconcilerOperationSleep,
 		ReconcilerOperationDetach,
 		ReconcilerOperationArchive,
-		ReconcilerOperationReattach:
+		ReconcilerOperationReattach,
+		ReconcilerOperationUpdateMetadata:
 		return true
 	default:
 		return false
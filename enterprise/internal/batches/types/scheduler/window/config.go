@@ -82,6 +82,23 @@ func (cfg *Configuration) HasRolloutWindows() bool {
 	return len(cfg.windows) != 0
 }
 
+// IsOpen returns true if the given time falls within one of the windows, or if
+// no windows have been defined at all. The rate of the window is not taken
+// into account.
+func (cfg *Configuration) IsOpen(at time.Time) bool {
+	if !cfg.HasRolloutWindows() {
+		return true
+	}
+
+	at = at.UTC()
+	for i := range cfg.windows {
+		if cfg.windows[i].IsOpen(at) {
+			return true
+		}
+	}
+	return false
+}
+
 // Schedule returns the currently active schedule.
 func (cfg *Configuration) Schedule() *Schedule {
 	// If there are no rollout windows, then we return an unlimited schedule and
@@ -156,6 +156,50 @@ func TestConfiguration_Estimate(t *testing.T) {
 	})
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	t.Run("no windows", func(t *testing.T) {
+		cfg := &Configuration{}
+		if !cfg.IsOpen(time.Now()) {
+			t.Error("unexpected closed configuration")
+		}
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		cfg := &Configuration{
+			windows: []Window{
+				{
+					days:  newWeekdaySet(time.Monday),
+					start: timeOfDayPtr(10, 0),
+					end:   timeOfDayPtr(12, 0),
+					rate:  rate{n: 0},
+				},
+				{
+					days: newWeekdaySet(time.Wednesday),
+					rate: rate{n: -1},
+				},
+			},
+		}
+
+		for name, tc := range map[string]struct {
+			at   time.Time
+			want bool
+		}{
+			"before monday window": {at: time.Date(2021, 4, 5, 9, 59, 0, 0, time.UTC), want: false},
+			"in monday window":     {at: time.Date(2021, 4, 5, 11, 0, 0, 0, time.UTC), want: true},
+			"after monday window":  {at: time.Date(2021, 4, 5, 12, 1, 0, 0, time.UTC), want: false},
+			"tuesday":              {at: time.Date(2021, 4, 6, 11, 0, 0, 0, time.UTC), want: false},
+			"wednesday":            {at: time.Date(2021, 4, 7, 23, 0, 0, 0, time.UTC), want: true},
+			"non-UTC location":     {at: time.Date(2021, 4, 5, 13, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)), want: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if have := cfg.IsOpen(tc.at); have != tc.want {
+					t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+				}
+			})
+		}
+	})
+}
+
 func TestConfiguration_Schedule(t *testing.T) {
 	// We have other tests to test the actual implementation of scheduleAt();
 	// this is purely to ensure that we do the special case handling of not
@@ -45,6 +45,11 @@ type StepInfo struct {
 	DiffFound       bool
 	Diff            []byte
 	ExitCode        *int
+	// Attempts is the number of times the step was run, including retries.
+	Attempts int
+	// TimedOut is true if the last attempt to run the step exceeded its
+	// timeout.
+	TimedOut bool
 }
 
 // ParseLogLines looks at all given log lines and determines the derived *StepInfo
@@ -90,6 +95,8 @@ func ParseLogLines(entry workerutil.ExecutionLogEntry, lines []*batcheslib.LogEv
 				setSafe(m.Step, func(si *StepInfo) {
 					si.FinishedAt = l.Timestamp
 					si.ExitCode = &m.ExitCode
+					si.Attempts = m.Attempts
+					si.TimedOut = m.TimedOut
 					if l.Status == batcheslib.LogEventStatusSuccess {
 						outputs := m.Outputs
 						if outputs == nil {
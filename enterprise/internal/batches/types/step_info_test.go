@@ -329,6 +329,37 @@ func TestParseLogLines(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Timed out after retries",
+			lines: []*batcheslib.LogEvent{
+				{
+					Timestamp: time1,
+					Status:    batcheslib.LogEventStatusStarted,
+					Metadata: &batcheslib.TaskPreparingStepMetadata{
+						Step: 1,
+					},
+				},
+				{
+					Timestamp: time3,
+					Status:    batcheslib.LogEventStatusFailure,
+					Metadata: &batcheslib.TaskStepMetadata{
+						Step:     1,
+						ExitCode: nonZero,
+						Attempts: 3,
+						TimedOut: true,
+					},
+				},
+			},
+			want: map[int]*StepInfo{
+				1: {
+					StartedAt:  time1,
+					FinishedAt: time3,
+					ExitCode:   &nonZero,
+					Attempts:   3,
+					TimedOut:   true,
+				},
+			},
+		},
 		{
 			name: "Finished with success",
 			lines: []*batcheslib.LogEvent{
@@ -12,6 +12,8 @@ type CodehostCapability string
 const (
 	CodehostCapabilityLabels          CodehostCapability = "Labels"
 	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	CodehostCapabilityReviewers       CodehostCapability = "Reviewers"
+	CodehostCapabilityAssignees       CodehostCapability = "Assignees"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -21,10 +23,11 @@ type CodehostCapabilities map[CodehostCapability]bool
 // whose type is not in this list will simply be filtered out from the search
 // results.
 var SupportedExternalServices = map[string]CodehostCapabilities{
-	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketServer: {},
-	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityReviewers: true, CodehostCapabilityAssignees: true},
+	extsvc.TypeBitbucketServer: {CodehostCapabilityReviewers: true},
+	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityReviewers: true, CodehostCapabilityAssignees: true},
 	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeGerrit:          {CodehostCapabilityDraftChangesets: true},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -0,0 +1,244 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	otelog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/luatypes"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maximumExplainedPathCount is the maximum number of paths recorded for a single invocation of
+// a recognizer. Recognizers matching common file extensions may receive a huge number of paths.
+const maximumExplainedPathCount = 100
+
+// ExplainInference invokes the given script in the same way as InferIndexJobs and InferIndexJobHints,
+// and additionally records the decisions made by each recognizer along the way. No index jobs are
+// queued as a result of this call.
+//
+// Errors raised by the inference scripts are reported as part of the explanation rather than returned,
+// so that the decisions made up to the point of failure remain visible.
+func (s *Service) ExplainInference(ctx context.Context, repo api.RepoName, commit, overrideScript string) (_ *shared.InferenceExplanation, err error) {
+	ctx, _, endObservation := s.operations.explainInference.With(ctx, &err, observation.Args{LogFields: []otelog.Field{
+		otelog.String("repo", string(repo)),
+		otelog.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	explanation := &shared.InferenceExplanation{Commit: commit}
+	var inferenceErr error
+
+	generateRecorder := newExplanationRecorder("generate")
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, generatorFunctionTable, generateRecorder)
+	if err == nil {
+		explanation.IndexJobs, err = indexJobsFromJobOrHints(jobOrHints)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		inferenceErr = errors.Append(inferenceErr, errors.Wrap(err, "generate"))
+	}
+
+	hintsRecorder := newExplanationRecorder("hints")
+	jobOrHints, err = s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, hinterFunctionTable, hintsRecorder)
+	if err == nil {
+		explanation.Hints, err = indexJobHintsFromJobOrHints(jobOrHints)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		inferenceErr = errors.Append(inferenceErr, errors.Wrap(err, "hints"))
+	}
+
+	explanation.Invocations = append(generateRecorder.recordedInvocations(), hintsRecorder.recordedInvocations()...)
+	explanation.DisabledRecognizers = generateRecorder.disabled
+	if inferenceErr != nil {
+		explanation.Error = inferenceErr.Error()
+	}
+
+	return explanation, nil
+}
+
+// explanationRecorder records the decisions made while invoking the callbacks of recognizers.
+// All methods are no-ops on a nil recorder, which is used outside of ExplainInference.
+type explanationRecorder struct {
+	callback    string
+	names       map[*luatypes.Recognizer]string
+	overridden  map[*luatypes.Recognizer]bool
+	registered  map[string]int
+	current     *luatypes.Recognizer
+	invocations []*recordedInvocation
+	disabled    []string
+}
+
+type recordedInvocation struct {
+	shared.RecognizerInvocation
+}
+
+func newExplanationRecorder(callback string) *explanationRecorder {
+	return &explanationRecorder{
+		callback:   callback,
+		names:      map[*luatypes.Recognizer]string{},
+		overridden: map[*luatypes.Recognizer]bool{},
+		registered: map[string]int{},
+	}
+}
+
+// nameRecognizer records the name of a recognizer returned by the default or override script.
+func (r *explanationRecorder) nameRecognizer(recognizer *luatypes.Recognizer, name string) {
+	if r == nil {
+		return
+	}
+
+	r.names[recognizer] = name
+}
+
+// overrideRecognizer records that the given recognizer was supplied by the override script.
+func (r *explanationRecorder) overrideRecognizer(recognizer *luatypes.Recognizer) {
+	if r == nil {
+		return
+	}
+
+	r.overridden[recognizer] = true
+}
+
+// disableRecognizer records that the override script disabled the recognizer with the given name.
+func (r *explanationRecorder) disableRecognizer(name string) {
+	if r == nil {
+		return
+	}
+
+	r.disabled = append(r.disabled, name)
+	sort.Strings(r.disabled)
+}
+
+// registerRecognizer names a recognizer registered by the callback of the recognizer that is
+// currently being invoked after that recognizer.
+func (r *explanationRecorder) registerRecognizer(recognizer *luatypes.Recognizer) {
+	if r == nil {
+		return
+	}
+
+	parent := r.nameOf(r.current)
+	r.names[recognizer] = fmt.Sprintf("%s/registered[%d]", parent, r.registered[parent])
+	r.overridden[recognizer] = r.overridden[r.current]
+	r.registered[parent]++
+}
+
+// nameFallbackRecognizers names the unnamed recognizers in the linearized fallback chain of the
+// given recognizer after it.
+func (r *explanationRecorder) nameFallbackRecognizers(recognizer *luatypes.Recognizer, linearized []*luatypes.Recognizer) {
+	if r == nil {
+		return
+	}
+
+	for i, child := range linearized {
+		if _, ok := r.names[child]; !ok {
+			r.names[child] = fmt.Sprintf("%s[%d]", r.nameOf(recognizer), i)
+			r.overridden[child] = r.overridden[recognizer]
+		}
+	}
+}
+
+// skipFallbackRecognizers records that the given recognizers of a fallback chain were not invoked
+// because the given recognizer preceding them in the chain already produced results.
+func (r *explanationRecorder) skipFallbackRecognizers(recognizer *luatypes.Recognizer, skipped []*luatypes.Recognizer) {
+	if r == nil {
+		return
+	}
+
+	for _, child := range skipped {
+		r.skipRecognizer(child, fmt.Sprintf("recognizer %s earlier in the fallback chain produced results", r.nameOf(recognizer)))
+	}
+}
+
+// skipRecognizer records that the given recognizer was not invoked for the given reason.
+func (r *explanationRecorder) skipRecognizer(recognizer *luatypes.Recognizer, reason string) {
+	if r == nil {
+		return
+	}
+
+	invocation := r.newInvocation(recognizer)
+	invocation.SkipReason = reason
+}
+
+// startInvocation records the invocation of the given recognizer with the given arguments. The
+// returned value must be finished with the results of the invocation.
+func (r *explanationRecorder) startInvocation(recognizer *luatypes.Recognizer, paths []string, contentsByPath map[string]string) *recordedInvocation {
+	if r == nil {
+		return nil
+	}
+
+	r.current = recognizer
+
+	invocation := r.newInvocation(recognizer)
+	invocation.PathCount = len(paths)
+	if len(paths) > maximumExplainedPathCount {
+		paths = paths[:maximumExplainedPathCount]
+	}
+	invocation.Paths = append([]string(nil), paths...)
+
+	invocation.PathsWithContent = make([]string, 0, len(contentsByPath))
+	for path := range contentsByPath {
+		invocation.PathsWithContent = append(invocation.PathsWithContent, path)
+	}
+	sort.Strings(invocation.PathsWithContent)
+
+	return invocation
+}
+
+func (r *explanationRecorder) newInvocation(recognizer *luatypes.Recognizer) *recordedInvocation {
+	invocation := &recordedInvocation{shared.RecognizerInvocation{
+		Recognizer: r.nameOf(recognizer),
+		Callback:   r.callback,
+		Overridden: r.overridden[recognizer],
+	}}
+	r.invocations = append(r.invocations, invocation)
+	return invocation
+}
+
+func (r *explanationRecorder) nameOf(recognizer *luatypes.Recognizer) string {
+	if name, ok := r.names[recognizer]; ok {
+		return name
+	}
+
+	return "<unnamed>"
+}
+
+func (r *explanationRecorder) recordedInvocations() []shared.RecognizerInvocation {
+	invocations := make([]shared.RecognizerInvocation, 0, len(r.invocations))
+	for _, invocation := range r.invocations {
+		invocations = append(invocations, invocation.RecognizerInvocation)
+	}
+
+	return invocations
+}
+
+// finish records the results of the invocation.
+func (i *recordedInvocation) finish(jobOrHints []indexJobOrHint, err error) {
+	if i == nil {
+		return
+	}
+
+	if err != nil {
+		i.Error = err.Error()
+		return
+	}
+
+	for _, jobOrHint := range jobOrHints {
+		if jobOrHint.indexJob != nil {
+			i.IndexJobs = append(i.IndexJobs, *jobOrHint.indexJob)
+		}
+		if jobOrHint.indexJobHint != nil {
+			i.Hints = append(i.Hints, *jobOrHint.indexJobHint)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	// There is no default indexer for dotnet, so jobs are only inferred once an
+	// indexer is configured
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer (no match)",
+			repositoryContents: map[string]string{
+				"App.sln":            "",
+				"src/App/App.csproj": "",
+				"src/App/Program.cs": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "sourcegraph/scip-dotnet:configured"
+	mockIndexerMap(t, map[string]string{"dotnet": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution with projects",
+			repositoryContents: map[string]string{
+				"App.sln":                   "",
+				"src/App/App.csproj":        "",
+				"src/App/Program.cs":        "",
+				"src/Lib/Lib.vbproj":        "",
+				"src/Lib/Module.vb":         "",
+				"tests/App.Tests.csproj":    "",
+				"src/App/obj/Generated.cs":  "",
+				"src/App/bin/Debug/App.dll": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "projects without solution",
+			repositoryContents: map[string]string{
+				"a/A.csproj":   "",
+				"a/Program.cs": "",
+				"b/B.csproj":   "",
+				"b/B.vbproj":   "",
+				"c/C.sln":      "",
+				"c/d/D.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "a",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore A.csproj"},
+						},
+					},
+					Root:        "a",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "A.csproj"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "b",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore B.csproj", "dotnet restore B.vbproj"},
+						},
+					},
+					Root:        "b",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "B.csproj", "B.vbproj"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "c",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore C.sln"},
+						},
+					},
+					Root:        "c",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "C.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "source files without projects (no match)",
+			repositoryContents: map[string]string{
+				"src/Program.cs": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestDotnetHinter(t *testing.T) {
+	expectedIndexerImage := "sourcegraph/scip-dotnet:configured"
+	mockIndexerMap(t, map[string]string{"dotnet": expectedIndexerImage})
+
+	testHinters(t,
+		hinterTestCase{
+			description: "basic hints",
+			repositoryContents: map[string]string{
+				"App.sln":            "",
+				"src/App/App.csproj": "",
+				"src/App/Program.cs": "",
+				"scripts/Build.cs":   "",
+			},
+			expected: []config.IndexJobHint{
+				{
+					Root:           "",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "scripts",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+				{
+					Root:           "src/App",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+			},
+		},
+	)
+}
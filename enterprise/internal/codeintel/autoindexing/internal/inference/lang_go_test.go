@@ -58,6 +58,32 @@ func TestGoGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "go modules in excluded directories",
+			repositoryContents: map[string]string{
+				"go.mod":                            "",
+				"vendor/github.com/acme/lib/go.mod": "",
+				"internal/testdata/mod/go.mod":      "",
+				"examples/demo/go.mod":              "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{netrcString, "go mod download"},
+						},
+					},
+					LocalSteps:       []string{netrcString},
+					Root:             "",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"lsif-go", "--no-animation"},
+					Outfile:          "",
+					RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+				},
+			},
+		},
 		generatorTestCase{
 			description: "go files in root",
 			repositoryContents: map[string]string{
@@ -77,6 +103,15 @@ func TestGoGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "go modules only in excluded directories (no match)",
+			repositoryContents: map[string]string{
+				"vendor/github.com/acme/lib/go.mod": "",
+				"vendor/github.com/acme/lib/lib.go": "",
+				"testdata/main.go":                  "",
+			},
+			expected: []config.IndexJob{},
+		},
 		generatorTestCase{
 			description: "go files in non-root (no match)",
 			repositoryContents: map[string]string{
@@ -37,6 +37,58 @@ func TestJavaGenerator(t *testing.T) {
 			},
 			expected: []config.IndexJob{},
 		},
+		generatorTestCase{
+			description: "sbt and gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"scala/build.sbt":                     "",
+				"scala/core/build.sbt":                "",
+				"scala/core/src/main/scala/App.scala": "",
+				"kotlin/settings.gradle.kts":          "",
+				"kotlin/build.gradle.kts":             "",
+				"kotlin/app/build.gradle.kts":         "",
+				"kotlin/app/src/main/kotlin/App.kt":   "",
+				"groovy/build.gradle":                 "",
+				"groovy/kts/build.gradle.kts":         "",
+				"maven/pom.xml":                       "",
+				"test/fixtures/build.sbt":             "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "kotlin",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "scala",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "lsif-java.json takes precedence over build files",
+			repositoryContents: map[string]string{
+				"lsif-java.json":      "",
+				"build.sbt":           "",
+				"kt/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
 	)
 }
 
@@ -49,6 +101,7 @@ func TestJavaHinter(t *testing.T) {
 			repositoryContents: map[string]string{
 				"build.gradle":               "",
 				"kt/build.gradle.kts":        "",
+				"sbt/build.sbt":              "",
 				"maven/pom.xml":              "",
 				"subdir/src/java/App.java":   "",
 				"subdir/src/kotlin/App.kt":   "",
@@ -70,6 +123,11 @@ func TestJavaHinter(t *testing.T) {
 					Indexer:        expectedIndexerImage,
 					HintConfidence: config.HintConfidenceProjectStructureSupported,
 				},
+				{
+					Root:           "sbt",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
 				{
 					Root:           "subdir/src/java",
 					Indexer:        expectedIndexerImage,
@@ -0,0 +1,116 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	// There is no default indexer for php, so jobs are only inferred once an
+	// indexer is configured
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer (no match)",
+			repositoryContents: map[string]string{
+				"composer.json": "",
+				"src/App.php":   "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "davidrjenni/scip-php:configured"
+	mockIndexerMap(t, map[string]string{"php": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":                    "",
+				"src/App.php":                      "",
+				"packages/lib/composer.json":       "",
+				"packages/lib/src/Lib.php":         "",
+				"vendor/acme/widget/composer.json": "",
+				"tests/fixture/composer.json":      "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-scripts"},
+						},
+					},
+					Root:             "",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-php"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"COMPOSER_AUTH"},
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/lib",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-scripts"},
+						},
+					},
+					Root:             "packages/lib",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-php"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"COMPOSER_AUTH"},
+				},
+			},
+		},
+		generatorTestCase{
+			description: "php files without composer.json (no match)",
+			repositoryContents: map[string]string{
+				"index.php": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
+
+func TestPHPHinter(t *testing.T) {
+	expectedIndexerImage := "davidrjenni/scip-php:configured"
+	mockIndexerMap(t, map[string]string{"php": expectedIndexerImage})
+
+	testHinters(t,
+		hinterTestCase{
+			description: "basic hints",
+			repositoryContents: map[string]string{
+				"composer.json":       "",
+				"src/App.php":         "",
+				"legacy/index.php":    "",
+				"vendor/lib/a.php":    "",
+				"tools/composer.json": "",
+			},
+			expected: []config.IndexJobHint{
+				{
+					Root:           "",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+				{
+					Root:           "legacy",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+				{
+					Root:           "src",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceLanguageSupport,
+				},
+				{
+					Root:           "tools",
+					Indexer:        expectedIndexerImage,
+					HintConfidence: config.HintConfidenceProjectStructureSupported,
+				},
+			},
+		},
+	)
+}
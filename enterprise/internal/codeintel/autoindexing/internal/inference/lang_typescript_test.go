@@ -75,6 +75,27 @@ func TestTypeScriptGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "tsconfig in excluded directories",
+			repositoryContents: map[string]string{
+				"tsconfig.json":                           "",
+				"node_modules/lib/tsconfig.json":          "",
+				"test/fixtures/app/tsconfig.json":         "",
+				"examples/demo/tsconfig.json":             "",
+				"packages/a/node_modules/b/tsconfig.json": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:            nil,
+					LocalSteps:       []string{`if [ -n "${VM_MEM_MB:-}" ]; then export NODE_OPTIONS="--max-old-space-size=$VM_MEM_MB"; fi`},
+					Root:             "",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-typescript", "index"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"NPM_TOKEN"},
+				},
+			},
+		},
 		generatorTestCase{
 			description: "tsconfig in subdirectories",
 			repositoryContents: map[string]string{
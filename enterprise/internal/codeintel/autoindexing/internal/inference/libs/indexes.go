@@ -15,6 +15,10 @@ var Indexes = indexesAPI{}
 
 type indexesAPI struct{}
 
+// Default indexers must be pinned to a digest in defaultIndexerSHAs. Languages whose
+// indexer is not pinned yet (dotnet and php) have no default indexer, and index jobs
+// are only inferred for them when an image is configured in the site configuration
+// setting `codeIntelAutoIndexing.indexerMap`.
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
 	"go":         "sourcegraph/lsif-go",
@@ -5,19 +5,27 @@ set -u
 DOCKER_USER=${DOCKER_USER:?"No DOCKER_USER is set."}
 DOCKER_PASS=${DOCKER_PASS:?"No DOCKER_PASS is set."}
 
-for indexer in lsif-clang lsif-go lsif-rust scip-java scip-python scip-typescript scip-ruby; do
+for image in sourcegraph/lsif-clang sourcegraph/lsif-go sourcegraph/lsif-rust sourcegraph/scip-java sourcegraph/scip-python sourcegraph/scip-typescript sourcegraph/scip-ruby sourcegraph/scip-dotnet davidrjenni/scip-php; do
   tag="latest"
-  if [[ "${indexer}" = "scip-python" ]] || [[ "${indexer}" = "scip-typescript" || "${indexer}" = "scip-ruby" ]]; then
+  if [[ "${image}" = "sourcegraph/scip-python" ]] || [[ "${image}" = "sourcegraph/scip-typescript" || "${image}" = "sourcegraph/scip-ruby" ]]; then
     tag="autoindex"
   fi
 
-  sha=$(docker manifest inspect sourcegraph/${indexer}:${tag} -v | jq -s .[0].Descriptor.digest)
+  sha=$(docker manifest inspect ${image}:${tag} -v | jq -s .[0].Descriptor.digest)
 
-  sed -i.bak \
-    "s|\("'"'"sourcegraph/${indexer}"'"'":\).*|\1${sha},|g" \
-    indexes.go
+  if grep -q "\"${image}\":" indexes.go; then
+    sed -i.bak \
+      "s|\("'"'"${image}"'"'":\).*|\1${sha},|g" \
+      indexes.go
+  else
+    # Pin indexers that have no digest yet. They are only used by default once
+    # their language is also added to defaultIndexers.
+    sed -i.bak \
+      "s|^var defaultIndexerSHAs = map\[string\]string{$|&\n\t"'"'"${image}"'"'": ${sha},|" \
+      indexes.go
+  fi
 
-  echo "Updated tag for ${indexer}"
+  echo "Updated tag for ${image}"
   rm indexes.go.bak
 done
 
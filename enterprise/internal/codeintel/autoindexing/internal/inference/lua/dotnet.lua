@@ -0,0 +1,172 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+-- There is no default indexer for dotnet, as its image is not pinned to a digest yet.
+-- Index jobs are only inferred when an image is configured for the language in
+-- the site configuration setting `codeIntelAutoIndexing.indexerMap`.
+local has_indexer, indexer = pcall(require("sg.autoindex.indexes").get, "dotnet")
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+local is_solution_file = function(base)
+  return string.match(base, "%.sln$") ~= nil
+end
+
+local is_project_file = function(base)
+  return string.match(base, "%.csproj$") ~= nil or string.match(base, "%.vbproj$") ~= nil
+end
+
+local is_project_structure_supported = function(base)
+  return is_solution_file(base) or is_project_file(base)
+end
+
+-- Sort roots and files so that the generated jobs are stable
+local sorted_by_root = function(files_by_root)
+  local roots = {}
+  for root in pairs(files_by_root) do
+    table.insert(roots, root)
+  end
+  table.sort(roots)
+
+  local entries = {}
+  for _, root in ipairs(roots) do
+    local files = {}
+    for file in pairs(files_by_root[root]) do
+      table.insert(files, file)
+    end
+    table.sort(files)
+
+    table.insert(entries, { root = root, files = files })
+  end
+
+  return entries
+end
+
+local add_file = function(files_by_root, root, file)
+  if files_by_root[root] == nil then
+    files_by_root[root] = {}
+  end
+  files_by_root[root][file] = true
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "cs",
+    pattern.new_path_extension "vb",
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_extension "vbproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when C#, Visual Basic, solution or project files exist. Solutions are
+  -- indexed as a whole, and projects are only indexed on their own when no solution
+  -- exists in one of their ancestor directories.
+  generate = function(_, paths)
+    if not has_indexer then
+      return {}
+    end
+
+    local solutions_by_root = {}
+    for i = 1, #paths do
+      local base = path.basename(paths[i])
+      if is_solution_file(base) then
+        add_file(solutions_by_root, path.dirname(paths[i]), base)
+      end
+    end
+
+    local projects_by_root = {}
+    for i = 1, #paths do
+      local base = path.basename(paths[i])
+      if is_project_file(base) then
+        local covered = false
+        local ancestors = path.ancestors(paths[i])
+        for j = 1, #ancestors do
+          if solutions_by_root[ancestors[j]] ~= nil then
+            covered = true
+            break
+          end
+        end
+
+        if not covered then
+          add_file(projects_by_root, path.dirname(paths[i]), base)
+        end
+      end
+    end
+
+    local jobs = {}
+    for _, files_by_root in ipairs { solutions_by_root, projects_by_root } do
+      for _, entry in ipairs(sorted_by_root(files_by_root)) do
+        local restore_commands = {}
+        for _, file in ipairs(entry.files) do
+          table.insert(restore_commands, "dotnet restore " .. file)
+        end
+
+        table.insert(jobs, {
+          steps = {
+            {
+              root = entry.root,
+              image = indexer,
+              commands = restore_commands,
+            },
+          },
+          root = entry.root,
+          indexer = indexer,
+          indexer_args = { "scip-dotnet", "index", unpack(entry.files) },
+          outfile = outfile,
+        })
+      end
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when C#, Visual Basic, solution or project files exist
+  hints = function(_, paths)
+    if not has_indexer then
+      return {}
+    end
+
+    local hints = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if visited[dir] == nil and is_project_structure_supported(base) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "PROJECT_STRUCTURE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if visited[dir] == nil and not is_project_structure_supported(base) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "LANGUAGE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    return hints
+  end,
+}
@@ -2,11 +2,48 @@ local path = require "path"
 local recognizer = require "sg.autoindex.recognizer"
 local pattern = require "sg.autoindex.patterns"
 
+local shared = require "sg.autoindex.shared"
+
 local indexer = require("sg.autoindex.indexes").get "java"
 local outfile = "index.scip"
 
 local is_project_structure_supported = function(base)
-  return base == "pom.xml" or base == "build.gradle" or base == "build.gradle.kts"
+  return base == "pom.xml" or base == "build.gradle" or base == "build.gradle.kts" or base == "build.sbt"
+end
+
+-- Build tools for which scip-java can index a build without an lsif-java.json file,
+-- keyed by the basename of the build definition files of the root of the build.
+local build_tools = {
+  ["build.sbt"] = "sbt",
+  ["build.gradle.kts"] = "gradle",
+  ["settings.gradle.kts"] = "gradle",
+}
+
+-- Build definition files that mark their directory as part of a build, even when
+-- we do not infer jobs for the build itself.
+local is_build_file = function(base)
+  return build_tools[base] ~= nil or base == "build.gradle" or base == "settings.gradle" or base == "pom.xml"
+end
+
+-- Returns the build tool for the build rooted at the given directory, or nil if
+-- the directory belongs to a build rooted at one of its ancestors.
+local build_tool_for_root = function(root, build_files_by_dir)
+  local ancestors = path.ancestors(root)
+  for i = 1, #ancestors do
+    if ancestors[i] ~= root and build_files_by_dir[ancestors[i]] ~= nil then
+      return nil
+    end
+  end
+
+  local bases = build_files_by_dir[root]
+  table.sort(bases)
+  for _, base in ipairs(bases) do
+    if build_tools[base] ~= nil then
+      return build_tools[base]
+    end
+  end
+
+  return nil
 end
 
 return recognizer.new_path_recognizer {
@@ -17,6 +54,7 @@ return recognizer.new_path_recognizer {
     pattern.new_path_basename "pom.xml",
     pattern.new_path_basename "build.gradle",
     pattern.new_path_basename "build.gradle.kts",
+    pattern.new_path_basename "build.sbt",
   },
 
   -- Invoked when Java, Scala, Kotlin, or Gradle build files exist
@@ -24,17 +62,68 @@ return recognizer.new_path_recognizer {
     api:register(recognizer.new_path_recognizer {
       patterns = {
         pattern.new_path_literal "lsif-java.json",
+        pattern.new_path_basename "build.sbt",
+        pattern.new_path_basename "build.gradle",
+        pattern.new_path_basename "build.gradle.kts",
+        pattern.new_path_basename "settings.gradle",
+        pattern.new_path_basename "settings.gradle.kts",
+        pattern.new_path_basename "pom.xml",
+        pattern.new_path_exclude(shared.exclude_paths),
       },
 
-      -- Invoked when lsif-java.json exists in root of repository
-      generate = function(api, paths)
-        return {
-          steps = {},
-          root = "",
-          indexer = indexer,
-          indexer_args = { "scip-java", "index", "--build-tool=scip" },
-          outfile = outfile,
-        }
+      -- Invoked when lsif-java.json or build files exist
+      generate = function(_, paths)
+        -- An lsif-java.json file in the root of the repository describes the
+        -- entire build, and takes precedence over any other build definition
+        for i = 1, #paths do
+          if paths[i] == "lsif-java.json" then
+            return {
+              steps = {},
+              root = "",
+              indexer = indexer,
+              indexer_args = { "scip-java", "index", "--build-tool=scip" },
+              outfile = outfile,
+            }
+          end
+        end
+
+        local build_files_by_dir = {}
+        for i = 1, #paths do
+          local dir = path.dirname(paths[i])
+          local base = path.basename(paths[i])
+
+          if is_build_file(base) then
+            if build_files_by_dir[dir] == nil then
+              build_files_by_dir[dir] = {}
+            end
+            table.insert(build_files_by_dir[dir], base)
+          end
+        end
+
+        -- Sort roots so that the generated jobs are stable
+        local roots = {}
+        for root in pairs(build_files_by_dir) do
+          table.insert(roots, root)
+        end
+        table.sort(roots)
+
+        -- Index sbt builds and Gradle builds using the Kotlin DSL from the root
+        -- of the build, which also covers all of its subprojects
+        local jobs = {}
+        for _, root in ipairs(roots) do
+          local build_tool = build_tool_for_root(root, build_files_by_dir)
+          if build_tool ~= nil then
+            table.insert(jobs, {
+              steps = {},
+              root = root,
+              indexer = indexer,
+              indexer_args = { "scip-java", "index", "--build-tool=" .. build_tool },
+              outfile = outfile,
+            })
+          end
+        end
+
+        return jobs
       end,
     })
 
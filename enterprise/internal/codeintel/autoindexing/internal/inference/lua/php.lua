@@ -0,0 +1,102 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+-- There is no default indexer for php, as its image is not pinned to a digest yet.
+-- Index jobs are only inferred when an image is configured for the language in
+-- the site configuration setting `codeIntelAutoIndexing.indexerMap`.
+local has_indexer, indexer = pcall(require("sg.autoindex.indexes").get, "php")
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+local is_project_structure_supported = function(base)
+  return base == "composer.json"
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "php",
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when PHP or composer.json files exist
+  generate = function(_, paths)
+    if not has_indexer then
+      return {}
+    end
+
+    local jobs = {}
+    for i = 1, #paths do
+      if is_project_structure_supported(path.basename(paths[i])) then
+        local root = path.dirname(paths[i])
+
+        table.insert(jobs, {
+          steps = {
+            {
+              root = root,
+              image = indexer,
+              -- Dependencies are required to resolve symbols, but scripts are not
+              -- run as they may depend on an environment we cannot provide.
+              commands = { "composer install --no-interaction --no-scripts" },
+            },
+          },
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-php" },
+          outfile = outfile,
+          requested_envvars = { "COMPOSER_AUTH" },
+        })
+      end
+    end
+
+    return jobs
+  end,
+
+  -- Invoked when PHP or composer.json files exist
+  hints = function(_, paths)
+    if not has_indexer then
+      return {}
+    end
+
+    local hints = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if visited[dir] == nil and is_project_structure_supported(base) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "PROJECT_STRUCTURE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if visited[dir] == nil and not is_project_structure_supported(base) then
+        table.insert(hints, {
+          root = dir,
+          indexer = indexer,
+          confidence = "LANGUAGE_SUPPORTED",
+        })
+
+        visited[dir] = true
+      end
+    end
+
+    return hints
+  end,
+}
@@ -53,16 +53,24 @@ func FlattenPatterns(pathPatterns []*PathPattern, inverted bool) (patterns []str
 }
 
 // FlattenPattern returns the set of patterns matching the given inverted flag on this
-// path pattern or any of its descendants.
+// path pattern or any of its descendants. Descendants of an inverted pattern are also
+// inverted, so that combined patterns can be excluded as a whole.
 func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string) {
-	if pathPattern.invert == inverted {
-		if pathPattern.pattern != "" {
-			patterns = append(patterns, pathPattern.pattern)
-		}
-
-		for _, child := range pathPattern.children {
-			patterns = append(patterns, FlattenPattern(child, inverted)...)
-		}
+	return flattenPattern(pathPattern, inverted, false)
+}
+
+func flattenPattern(pathPattern *PathPattern, inverted, withinInverted bool) (patterns []string) {
+	invert := withinInverted || pathPattern.invert
+	if !inverted && invert {
+		return nil
+	}
+
+	if invert == inverted && pathPattern.pattern != "" {
+		patterns = append(patterns, pathPattern.pattern)
+	}
+
+	for _, child := range pathPattern.children {
+		patterns = append(patterns, flattenPattern(child, inverted, invert)...)
 	}
 
 	return
@@ -0,0 +1,37 @@
+package luatypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlattenPatterns(t *testing.T) {
+	excludePaths := NewCombinedPattern([]*PathPattern{
+		NewPattern("(^|/)vendor(/|$)"),
+		NewCombinedPattern([]*PathPattern{
+			NewPattern("(^|/)test(/|$)"),
+			NewPattern("(^|/)testdata(/|$)"),
+		}),
+	})
+
+	pathPatterns := []*PathPattern{
+		NewPattern("(^|/)go\\.mod$"),
+		NewExcludePattern([]*PathPattern{excludePaths}),
+	}
+
+	t.Run("included", func(t *testing.T) {
+		want := []string{"(^|/)go\\.mod$"}
+		if diff := cmp.Diff(want, FlattenPatterns(pathPatterns, false)); diff != "" {
+			t.Errorf("unexpected patterns (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		// Combined patterns nested in an exclude pattern are excluded as a whole
+		want := []string{"(^|/)vendor(/|$)", "(^|/)test(/|$)", "(^|/)testdata(/|$)"}
+		if diff := cmp.Diff(want, FlattenPatterns(pathPatterns, true)); diff != "" {
+			t.Errorf("unexpected patterns (-want +got):\n%s", diff)
+		}
+	})
+}
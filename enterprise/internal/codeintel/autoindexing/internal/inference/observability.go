@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	createSandbox              *observation.Operation
+	explainInference           *observation.Operation
 	inferIndexJobHints         *observation.Operation
 	inferIndexJobs             *observation.Operation
 	invokeLinearizedRecognizer *observation.Operation
@@ -40,6 +41,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		createSandbox:              op("createSandbox"),
+		explainInference:           op("ExplainInference"),
 		inferIndexJobHints:         op("InferIndexJobHints"),
 		inferIndexJobs:             op("InferIndexJobs"),
 		invokeLinearizedRecognizer: op("invokeLinearizedRecognizer"),
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -44,6 +45,7 @@ type invocationContext struct {
 	gitService GitService
 	repo       api.RepoName
 	commit     string
+	recorder   *explanationRecorder
 	invocationFunctionTable
 }
 
@@ -90,30 +92,34 @@ func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit,
 	}})
 	defer endObservation(1, observation.Args{})
 
-	functionTable := invocationFunctionTable{
-		linearize: luatypes.LinearizeGenerator,
-		callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Generator() },
-		scanLuaValue: func(value baselua.LValue) ([]indexJobOrHint, error) {
-			jobs, err := luatypes.IndexJobsFromTable(value)
-			if err != nil {
-				return nil, err
-			}
-
-			jobOrHints := make([]indexJobOrHint, 0, len(jobs))
-			for _, job := range jobs {
-				job := job // prevent loop capture
-				jobOrHints = append(jobOrHints, indexJobOrHint{indexJob: &job})
-			}
-
-			return jobOrHints, err
-		},
-	}
-
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, generatorFunctionTable, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return indexJobsFromJobOrHints(jobOrHints)
+}
+
+var generatorFunctionTable = invocationFunctionTable{
+	linearize: luatypes.LinearizeGenerator,
+	callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Generator() },
+	scanLuaValue: func(value baselua.LValue) ([]indexJobOrHint, error) {
+		jobs, err := luatypes.IndexJobsFromTable(value)
+		if err != nil {
+			return nil, err
+		}
+
+		jobOrHints := make([]indexJobOrHint, 0, len(jobs))
+		for _, job := range jobs {
+			job := job // prevent loop capture
+			jobOrHints = append(jobOrHints, indexJobOrHint{indexJob: &job})
+		}
+
+		return jobOrHints, err
+	},
+}
+
+func indexJobsFromJobOrHints(jobOrHints []indexJobOrHint) ([]config.IndexJob, error) {
 	jobs := make([]config.IndexJob, 0, len(jobOrHints))
 	for _, jobOrHint := range jobOrHints {
 		if jobOrHint.indexJob == nil {
@@ -137,30 +143,34 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 	}})
 	defer endObservation(1, observation.Args{})
 
-	functionTable := invocationFunctionTable{
-		linearize: luatypes.LinearizeHinter,
-		callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Hinter() },
-		scanLuaValue: func(value baselua.LValue) ([]indexJobOrHint, error) {
-			jobHints, err := luatypes.IndexJobHintsFromTable(value)
-			if err != nil {
-				return nil, err
-			}
-
-			jobOrHints := make([]indexJobOrHint, 0, len(jobHints))
-			for _, jobHint := range jobHints {
-				jobHint := jobHint // prevent loop capture
-				jobOrHints = append(jobOrHints, indexJobOrHint{indexJobHint: &jobHint})
-			}
-
-			return jobOrHints, err
-		},
-	}
-
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, hinterFunctionTable, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return indexJobHintsFromJobOrHints(jobOrHints)
+}
+
+var hinterFunctionTable = invocationFunctionTable{
+	linearize: luatypes.LinearizeHinter,
+	callback:  func(recognizer *luatypes.Recognizer) *baselua.LFunction { return recognizer.Hinter() },
+	scanLuaValue: func(value baselua.LValue) ([]indexJobOrHint, error) {
+		jobHints, err := luatypes.IndexJobHintsFromTable(value)
+		if err != nil {
+			return nil, err
+		}
+
+		jobOrHints := make([]indexJobOrHint, 0, len(jobHints))
+		for _, jobHint := range jobHints {
+			jobHint := jobHint // prevent loop capture
+			jobOrHints = append(jobOrHints, indexJobOrHint{indexJobHint: &jobHint})
+		}
+
+		return jobOrHints, err
+	},
+}
+
+func indexJobHintsFromJobOrHints(jobOrHints []indexJobOrHint) ([]config.IndexJobHint, error) {
 	jobHints := make([]config.IndexJobHint, 0, len(jobOrHints))
 	for _, jobOrHint := range jobOrHints {
 		if jobOrHint.indexJobHint == nil {
@@ -177,13 +187,15 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 // is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers will
 // overwrite them (to disable or change default behavior). Each recognizer's callback function is invoked
 // and the resulting values are combined into a flattened list. See InferIndexJobs and InferIndexJobHints
-// for concrete implementations of the given function table.
+// for concrete implementations of the given function table. If the given recorder is non-nil, the decisions
+// made during inference are recorded into it.
 func (s *Service) inferIndexJobOrHints(
 	ctx context.Context,
 	repo api.RepoName,
 	commit string,
 	overrideScript string,
 	invocationContextMethods invocationFunctionTable,
+	recorder *explanationRecorder,
 ) ([]indexJobOrHint, error) {
 	sandbox, err := s.createSandbox(ctx)
 	if err != nil {
@@ -191,7 +203,7 @@ func (s *Service) inferIndexJobOrHints(
 	}
 	defer sandbox.Close()
 
-	recognizers, err := s.setupRecognizers(ctx, sandbox, overrideScript)
+	recognizers, err := s.setupRecognizers(ctx, sandbox, overrideScript, recorder)
 	if err != nil || len(recognizers) == 0 {
 		return nil, err
 	}
@@ -201,6 +213,7 @@ func (s *Service) inferIndexJobOrHints(
 		gitService:              s.gitService,
 		repo:                    repo,
 		commit:                  commit,
+		recorder:                recorder,
 		invocationFunctionTable: invocationContextMethods,
 	}
 	return s.invokeRecognizers(ctx, invocationContext, recognizers)
@@ -232,8 +245,8 @@ func (s *Service) createSandbox(ctx context.Context) (_ *luasandbox.Sandbox, err
 }
 
 // setupRecognizers runs the given default and override scripts in the given sandbox and converts the
-// script return values to a list of recognizer instances.
-func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScript string) (_ []*luatypes.Recognizer, err error) {
+// script return values to a list of recognizer instances ordered by name.
+func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScript string, recorder *explanationRecorder) (_ []*luatypes.Recognizer, err error) {
 	ctx, _, endObservation := s.operations.setupRecognizers.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -266,15 +279,24 @@ func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sand
 		for name, recognizer := range overrideRecognizerMap {
 			if recognizer == nil {
 				delete(recognizerMap, name)
+				recorder.disableRecognizer(name)
 			} else {
 				recognizerMap[name] = recognizer
+				recorder.overrideRecognizer(recognizer)
 			}
 		}
 	}
 
+	names := make([]string, 0, len(recognizerMap))
+	for name := range recognizerMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	recognizers := make([]*luatypes.Recognizer, 0, len(recognizerMap))
-	for _, recognizer := range recognizerMap {
-		recognizers = append(recognizers, recognizer)
+	for _, name := range names {
+		recognizers = append(recognizers, recognizerMap[name])
+		recorder.nameRecognizer(recognizerMap[name], name)
 	}
 
 	return recognizers, nil
@@ -436,10 +458,12 @@ func (s *Service) resolveFileContents(
 
 type registrationAPI struct {
 	recognizers []*luatypes.Recognizer
+	recorder    *explanationRecorder
 }
 
 func (api *registrationAPI) Register(recognizer *luatypes.Recognizer) {
 	api.recognizers = append(api.recognizers, recognizer)
+	api.recorder.registerRecognizer(recognizer)
 }
 
 // invokeRecognizerChains invokes each of the given recognizer's callback function and combines
@@ -451,7 +475,7 @@ func (s *Service) invokeRecognizerChains(
 	paths []string,
 	contentsByPath map[string]string,
 ) (jobOrHints []indexJobOrHint, _ error) {
-	registrationAPI := &registrationAPI{}
+	registrationAPI := &registrationAPI{recorder: invocationContext.recorder}
 
 	// Invoke the recognizers and gather the resulting jobs or hints
 	for _, recognizer := range recognizers {
@@ -497,7 +521,10 @@ func (s *Service) invokeRecognizerChainUntilResults(
 	paths []string,
 	contentsByPath map[string]string,
 ) ([]indexJobOrHint, error) {
-	for _, recognizer := range invocationContext.linearize(recognizer) {
+	linearized := invocationContext.linearize(recognizer)
+	invocationContext.recorder.nameFallbackRecognizers(recognizer, linearized)
+
+	for i, recognizer := range linearized {
 		if jobOrHints, err := s.invokeLinearizedRecognizer(
 			ctx,
 			invocationContext,
@@ -506,6 +533,10 @@ func (s *Service) invokeRecognizerChainUntilResults(
 			paths,
 			contentsByPath,
 		); err != nil || len(jobOrHints) > 0 {
+			if err == nil {
+				invocationContext.recorder.skipFallbackRecognizers(recognizer, linearized[i+1:])
+			}
+
 			return jobOrHints, err
 		}
 	}
@@ -530,21 +561,27 @@ func (s *Service) invokeLinearizedRecognizer(
 		return nil, err
 	}
 	if len(callPaths) == 0 && len(callContentsByPath) == 0 {
+		invocationContext.recorder.skipRecognizer(recognizer, "no paths matched the patterns of the recognizer")
 		return nil, nil
 	}
 
+	invocation := invocationContext.recorder.startInvocation(recognizer, callPaths, callContentsByPath)
+
 	opts := luasandbox.RunOptions{}
 	args := []any{registrationAPI, callPaths, callContentsByPath}
 	value, err := invocationContext.sandbox.Call(ctx, opts, invocationContext.callback(recognizer), args...)
 	if err != nil {
+		invocation.finish(nil, err)
 		return nil, err
 	}
 
 	jobOrHints, err := invocationContext.scanLuaValue(value)
 	if err != nil {
+		invocation.finish(nil, err)
 		return nil, err
 	}
 
+	invocation.finish(jobOrHints, nil)
 	return jobOrHints, nil
 }
 
@@ -0,0 +1,184 @@
+package inference
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestExplainInference(t *testing.T) {
+	service := testService(t, map[string]string{
+		"go.mod":      "",
+		"main.go":     "",
+		"sg-test":     "",
+		"foo/sg-test": "",
+	})
+
+	overrideScript := `
+		local path = require("path")
+		local pattern = require("sg.autoindex.patterns")
+		local recognizer = require("sg.autoindex.recognizer")
+
+		local custom_recognizer = recognizer.new_path_recognizer {
+			patterns = { pattern.new_path_basename("sg-test") },
+
+			generate = function(api, paths)
+				api:register(recognizer.new_path_recognizer {
+					patterns = { pattern.new_path_literal("sg-test") },
+
+					generate = function(_, paths)
+						return {
+							steps = {},
+							root = "",
+							indexer = "test-override",
+							indexer_args = {},
+							outfile = "",
+						}
+					end,
+				})
+
+				return {}
+			end,
+		}
+
+		return require("sg.autoindex.config").new({
+			["sg.test"] = false,
+			["mycompany.test"] = custom_recognizer,
+		})
+	`
+
+	explanation, err := service.ExplainInference(context.Background(), api.RepoName("github.com/test/test"), "deadbeef", overrideScript)
+	if err != nil {
+		t.Fatalf("unexpected error explaining inference: %s", err)
+	}
+
+	goIndexer, _ := libs.DefaultIndexerForLang("go")
+	goJob := config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     "",
+				Image:    goIndexer,
+				Commands: []string{"if [ \"$NETRC_DATA\" ]; then\n  echo \"Writing netrc config to $HOME/.netrc\"\n  echo \"$NETRC_DATA\" > ~/.netrc\nelse\n  echo \"No netrc config set, continuing\"\nfi\n", "go mod download"},
+			},
+		},
+		LocalSteps:       []string{"if [ \"$NETRC_DATA\" ]; then\n  echo \"Writing netrc config to $HOME/.netrc\"\n  echo \"$NETRC_DATA\" > ~/.netrc\nelse\n  echo \"No netrc config set, continuing\"\nfi\n"},
+		Root:             "",
+		Indexer:          goIndexer,
+		IndexerArgs:      []string{"lsif-go", "--no-animation"},
+		RequestedEnvVars: []string{"GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA"},
+	}
+	overrideJob := config.IndexJob{Indexer: "test-override"}
+
+	if diff := cmp.Diff([]config.IndexJob{goJob, overrideJob}, sortIndexJobs(explanation.IndexJobs)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"sg.test"}, explanation.DisabledRecognizers); diff != "" {
+		t.Errorf("unexpected disabled recognizers (-want +got):\n%s", diff)
+	}
+	if explanation.Error != "" {
+		t.Errorf("unexpected error: %s", explanation.Error)
+	}
+
+	var invocations []shared.RecognizerInvocation
+	for _, invocation := range explanation.Invocations {
+		if invocation.Callback == "generate" && (strings.HasPrefix(invocation.Recognizer, "sg.go") || strings.HasPrefix(invocation.Recognizer, "mycompany.")) {
+			invocations = append(invocations, invocation)
+		}
+	}
+
+	expectedInvocations := []shared.RecognizerInvocation{
+		{
+			Recognizer:       "mycompany.test",
+			Callback:         "generate",
+			Overridden:       true,
+			Paths:            []string{"foo/sg-test", "sg-test"},
+			PathCount:        2,
+			PathsWithContent: []string{},
+		},
+		{
+			Recognizer:       "sg.go[0]",
+			Callback:         "generate",
+			Paths:            []string{"go.mod"},
+			PathCount:        1,
+			PathsWithContent: []string{},
+			IndexJobs:        []config.IndexJob{goJob},
+		},
+		{
+			Recognizer: "sg.go[1]",
+			Callback:   "generate",
+			SkipReason: "recognizer sg.go[0] earlier in the fallback chain produced results",
+		},
+		{
+			Recognizer:       "mycompany.test/registered[0]",
+			Callback:         "generate",
+			Overridden:       true,
+			Paths:            []string{"sg-test"},
+			PathCount:        1,
+			PathsWithContent: []string{},
+			IndexJobs:        []config.IndexJob{overrideJob},
+		},
+	}
+	if diff := cmp.Diff(expectedInvocations, sortPaths(invocations)); diff != "" {
+		t.Errorf("unexpected invocations (-want +got):\n%s", diff)
+	}
+}
+
+func TestExplainInferenceScriptError(t *testing.T) {
+	service := testService(t, map[string]string{
+		"sg-test": "",
+	})
+
+	overrideScript := `
+		local pattern = require("sg.autoindex.patterns")
+		local recognizer = require("sg.autoindex.recognizer")
+
+		return require("sg.autoindex.config").new({
+			["mycompany.test"] = recognizer.new_path_recognizer {
+				patterns = { pattern.new_path_basename("sg-test") },
+
+				generate = function(_, paths)
+					error("oops")
+				end,
+			},
+		})
+	`
+
+	explanation, err := service.ExplainInference(context.Background(), api.RepoName("github.com/test/test"), "deadbeef", overrideScript)
+	if err != nil {
+		t.Fatalf("unexpected error explaining inference: %s", err)
+	}
+	if !strings.Contains(explanation.Error, "oops") {
+		t.Errorf("expected script error to be explained, got %q", explanation.Error)
+	}
+
+	found := false
+	for _, invocation := range explanation.Invocations {
+		if invocation.Recognizer == "mycompany.test" && invocation.Callback == "generate" {
+			found = true
+
+			if !strings.Contains(invocation.Error, "oops") {
+				t.Errorf("expected script error to be attached to the invocation, got %q", invocation.Error)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected invocation of mycompany.test to be explained")
+	}
+}
+
+// sortPaths sorts the paths of the given invocations, as gitserver returns them in no particular order.
+func sortPaths(invocations []shared.RecognizerInvocation) []shared.RecognizerInvocation {
+	for _, invocation := range invocations {
+		sort.Strings(invocation.Paths)
+	}
+
+	return invocations
+}
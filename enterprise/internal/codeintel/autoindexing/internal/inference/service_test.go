@@ -10,11 +10,13 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/luasandbox"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/unpack/unpacktest"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func testService(t *testing.T, repositoryContents map[string]string) *Service {
@@ -45,3 +47,12 @@ func testService(t *testing.T, repositoryContents map[string]string) *Service {
 
 	return newService(&observation.TestContext, sandboxService, gitService, ratelimit.NewInstrumentedLimiter("TestInference", rate.NewLimiter(rate.Limit(100), 1)), 100, 1024*1024)
 }
+
+// mockIndexerMap configures the given indexer images in the site configuration for the
+// remainder of the test.
+func mockIndexerMap(t *testing.T, indexerMap map[string]string) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelAutoIndexingIndexerMap: indexerMap,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -86,6 +87,28 @@ func (s *JobSelector) InferIndexJobHintsFromRepositoryStructure(ctx context.Cont
 	return indexes, nil
 }
 
+// ExplainInference runs inference over the given commit and records the decisions made by each
+// recognizer. If no script is supplied, the script stored in the database (or the override script
+// supplied via the environment) is used, matching the behavior of InferIndexJobsFromRepositoryStructure.
+func (s *JobSelector) ExplainInference(ctx context.Context, repositoryID int, commit, script string) (*shared.InferenceExplanation, error) {
+	repoName, err := s.uploadSvc.GetRepoName(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if script == "" {
+		script, err = s.store.GetInferenceScript(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch inference script from database")
+		}
+		if script == "" {
+			script = overrideScript
+		}
+	}
+
+	return s.inferenceSvc.ExplainInference(ctx, api.RepoName(repoName), commit, script)
+}
+
 type configurationFactoryFunc func(ctx context.Context, repositoryID int, commit string, bypassLimit bool) ([]types.Index, bool, error)
 
 // GetIndexRecords determines the set of index records that should be enqueued for the given commit.
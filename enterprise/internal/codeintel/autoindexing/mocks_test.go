@@ -4907,6 +4907,9 @@ func (c GitserverClientResolveRevisionFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing)
 // used for unit testing.
 type MockInferenceService struct {
+	// ExplainInferenceFunc is an instance of a mock function object
+	// controlling the behavior of the method ExplainInference.
+	ExplainInferenceFunc *InferenceServiceExplainInferenceFunc
 	// InferIndexJobHintsFunc is an instance of a mock function object
 	// controlling the behavior of the method InferIndexJobHints.
 	InferIndexJobHintsFunc *InferenceServiceInferIndexJobHintsFunc
@@ -4920,6 +4923,11 @@ type MockInferenceService struct {
 // overwritten.
 func NewMockInferenceService() *MockInferenceService {
 	return &MockInferenceService{
+		ExplainInferenceFunc: &InferenceServiceExplainInferenceFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 *shared.InferenceExplanation, r1 error) {
+				return
+			},
+		},
 		InferIndexJobHintsFunc: &InferenceServiceInferIndexJobHintsFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) (r0 []config.IndexJobHint, r1 error) {
 				return
@@ -4937,6 +4945,11 @@ func NewMockInferenceService() *MockInferenceService {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockInferenceService() *MockInferenceService {
 	return &MockInferenceService{
+		ExplainInferenceFunc: &InferenceServiceExplainInferenceFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error) {
+				panic("unexpected invocation of MockInferenceService.ExplainInference")
+			},
+		},
 		InferIndexJobHintsFunc: &InferenceServiceInferIndexJobHintsFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error) {
 				panic("unexpected invocation of MockInferenceService.InferIndexJobHints")
@@ -4955,6 +4968,9 @@ func NewStrictMockInferenceService() *MockInferenceService {
 // implementation, unless overwritten.
 func NewMockInferenceServiceFrom(i InferenceService) *MockInferenceService {
 	return &MockInferenceService{
+		ExplainInferenceFunc: &InferenceServiceExplainInferenceFunc{
+			defaultHook: i.ExplainInference,
+		},
 		InferIndexJobHintsFunc: &InferenceServiceInferIndexJobHintsFunc{
 			defaultHook: i.InferIndexJobHints,
 		},
@@ -4964,6 +4980,123 @@ func NewMockInferenceServiceFrom(i InferenceService) *MockInferenceService {
 	}
 }
 
+// InferenceServiceExplainInferenceFunc describes the behavior when the
+// ExplainInference method of the parent MockInferenceService instance is
+// invoked.
+type InferenceServiceExplainInferenceFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error)
+	hooks       []func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error)
+	history     []InferenceServiceExplainInferenceFuncCall
+	mutex       sync.Mutex
+}
+
+// ExplainInference delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInferenceService) ExplainInference(v0 context.Context, v1 api.RepoName, v2 string, v3 string) (*shared.InferenceExplanation, error) {
+	r0, r1 := m.ExplainInferenceFunc.nextHook()(v0, v1, v2, v3)
+	m.ExplainInferenceFunc.appendCall(InferenceServiceExplainInferenceFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ExplainInference
+// method of the parent MockInferenceService instance is invoked and the
+// hook queue is empty.
+func (f *InferenceServiceExplainInferenceFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExplainInference method of the parent MockInferenceService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *InferenceServiceExplainInferenceFunc) PushHook(hook func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *InferenceServiceExplainInferenceFunc) SetDefaultReturn(r0 *shared.InferenceExplanation, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *InferenceServiceExplainInferenceFunc) PushReturn(r0 *shared.InferenceExplanation, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error) {
+		return r0, r1
+	})
+}
+
+func (f *InferenceServiceExplainInferenceFunc) nextHook() func(context.Context, api.RepoName, string, string) (*shared.InferenceExplanation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InferenceServiceExplainInferenceFunc) appendCall(r0 InferenceServiceExplainInferenceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InferenceServiceExplainInferenceFuncCall
+// objects describing the invocations of this function.
+func (f *InferenceServiceExplainInferenceFunc) History() []InferenceServiceExplainInferenceFuncCall {
+	f.mutex.Lock()
+	history := make([]InferenceServiceExplainInferenceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InferenceServiceExplainInferenceFuncCall is an object that describes an
+// invocation of method ExplainInference on an instance of
+// MockInferenceService.
+type InferenceServiceExplainInferenceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *shared.InferenceExplanation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InferenceServiceExplainInferenceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InferenceServiceExplainInferenceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // InferenceServiceInferIndexJobHintsFunc describes the behavior when the
 // InferIndexJobHints method of the parent MockInferenceService instance is
 // invoked.
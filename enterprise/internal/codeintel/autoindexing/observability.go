@@ -35,6 +35,7 @@ type operations struct {
 	inferIndexConfiguration                *observation.Operation
 	setInferenceScript                     *observation.Operation
 	getInferenceScript                     *observation.Operation
+	explainInference                       *observation.Operation
 
 	// Tags
 	getListTags *observation.Operation
@@ -92,6 +93,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		inferIndexConfiguration:                op("InferIndexConfiguration"),
 		getInferenceScript:                     op("GetInferenceScript"),
 		setInferenceScript:                     op("SetInferenceScript"),
+		explainInference:                       op("ExplainInference"),
 
 		// Tags
 		getListTags: op("GetListTags"),
@@ -301,3 +301,21 @@ func (s *Service) InferIndexJobsFromRepositoryStructure(ctx context.Context, rep
 func (s *Service) InferIndexJobHintsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string) ([]config.IndexJobHint, error) {
 	return s.jobSelector.InferIndexJobHintsFromRepositoryStructure(ctx, repositoryID, commit)
 }
+
+// ExplainInference resolves the given revision and runs inference over the resulting commit with the
+// given script, returning the inferred index jobs along with a trace of recognizer decisions. No index
+// jobs are queued as a result of this call.
+func (s *Service) ExplainInference(ctx context.Context, repositoryID int, rev, script string) (_ *shared.InferenceExplanation, err error) {
+	ctx, _, endObservation := s.operations.explainInference.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("repositoryID", repositoryID),
+		otlog.String("rev", rev),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	commit, err := s.gitserverClient.ResolveRevision(ctx, repositoryID, rev)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.ResolveRevision")
+	}
+
+	return s.jobSelector.ExplainInference(ctx, repositoryID, string(commit), script)
+}
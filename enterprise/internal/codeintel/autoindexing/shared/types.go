@@ -2,6 +2,7 @@ package shared
 
 import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
 type GetIndexesOptions struct {
@@ -42,3 +43,38 @@ type ReindexIndexesOptions struct {
 	Term         string
 	RepositoryID int
 }
+
+// InferenceExplanation describes how the auto-indexing inference scripts arrived at the
+// index jobs and hints for a repository at a particular commit.
+type InferenceExplanation struct {
+	Commit              string
+	IndexJobs           []config.IndexJob
+	Hints               []config.IndexJobHint
+	Invocations         []RecognizerInvocation
+	DisabledRecognizers []string
+	Error               string
+}
+
+// RecognizerInvocation describes a single (possibly skipped) invocation of the generate
+// or hints callback of a recognizer during inference.
+type RecognizerInvocation struct {
+	// Recognizer is the name of the recognizer. Recognizers registered by the callback
+	// of another recognizer and the children of fallback recognizers are named after
+	// their parent.
+	Recognizer string
+	// Callback is either "generate" or "hints".
+	Callback string
+	// Overridden is true if the recognizer was supplied by the override script.
+	Overridden bool
+	// Paths holds the paths passed to the callback, truncated to a reasonable amount.
+	Paths []string
+	// PathCount is the total number of paths passed to the callback.
+	PathCount int
+	// PathsWithContent holds the paths whose content was passed to the callback.
+	PathsWithContent []string
+	// SkipReason is non-empty if the callback was not invoked.
+	SkipReason string
+	IndexJobs  []config.IndexJob
+	Hints      []config.IndexJobHint
+	Error      string
+}
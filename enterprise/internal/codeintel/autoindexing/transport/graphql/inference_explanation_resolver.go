@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+type inferenceExplanationResolver struct {
+	explanation *shared.InferenceExplanation
+}
+
+func NewInferenceExplanationResolver(explanation *shared.InferenceExplanation) resolverstubs.AutoIndexingInferenceExplanationResolver {
+	return &inferenceExplanationResolver{
+		explanation: explanation,
+	}
+}
+
+func (r *inferenceExplanationResolver) Commit() string {
+	return r.explanation.Commit
+}
+
+func (r *inferenceExplanationResolver) IndexJobs() (string, error) {
+	return marshalIndexJobs(r.explanation.IndexJobs)
+}
+
+func (r *inferenceExplanationResolver) Hints() []resolverstubs.AutoIndexingInferenceHintResolver {
+	return newInferenceHintResolvers(r.explanation.Hints)
+}
+
+func (r *inferenceExplanationResolver) RecognizerInvocations() []resolverstubs.AutoIndexingRecognizerInvocationResolver {
+	resolvers := make([]resolverstubs.AutoIndexingRecognizerInvocationResolver, 0, len(r.explanation.Invocations))
+	for _, invocation := range r.explanation.Invocations {
+		resolvers = append(resolvers, &recognizerInvocationResolver{invocation: invocation})
+	}
+
+	return resolvers
+}
+
+func (r *inferenceExplanationResolver) DisabledRecognizers() []string {
+	if r.explanation.DisabledRecognizers == nil {
+		return []string{}
+	}
+
+	return r.explanation.DisabledRecognizers
+}
+
+func (r *inferenceExplanationResolver) Error() *string {
+	return strPtr(r.explanation.Error)
+}
+
+type recognizerInvocationResolver struct {
+	invocation shared.RecognizerInvocation
+}
+
+func (r *recognizerInvocationResolver) Recognizer() string {
+	return r.invocation.Recognizer
+}
+
+func (r *recognizerInvocationResolver) Callback() string {
+	return r.invocation.Callback
+}
+
+func (r *recognizerInvocationResolver) Overridden() bool {
+	return r.invocation.Overridden
+}
+
+func (r *recognizerInvocationResolver) Paths() []string {
+	if r.invocation.Paths == nil {
+		return []string{}
+	}
+
+	return r.invocation.Paths
+}
+
+func (r *recognizerInvocationResolver) PathCount() int32 {
+	return int32(r.invocation.PathCount)
+}
+
+func (r *recognizerInvocationResolver) PathsWithContent() []string {
+	if r.invocation.PathsWithContent == nil {
+		return []string{}
+	}
+
+	return r.invocation.PathsWithContent
+}
+
+func (r *recognizerInvocationResolver) SkipReason() *string {
+	return strPtr(r.invocation.SkipReason)
+}
+
+func (r *recognizerInvocationResolver) IndexJobs() (string, error) {
+	return marshalIndexJobs(r.invocation.IndexJobs)
+}
+
+func (r *recognizerInvocationResolver) Hints() []resolverstubs.AutoIndexingInferenceHintResolver {
+	return newInferenceHintResolvers(r.invocation.Hints)
+}
+
+func (r *recognizerInvocationResolver) Error() *string {
+	return strPtr(r.invocation.Error)
+}
+
+type inferenceHintResolver struct {
+	hint config.IndexJobHint
+}
+
+func newInferenceHintResolvers(hints []config.IndexJobHint) []resolverstubs.AutoIndexingInferenceHintResolver {
+	resolvers := make([]resolverstubs.AutoIndexingInferenceHintResolver, 0, len(hints))
+	for _, hint := range hints {
+		resolvers = append(resolvers, &inferenceHintResolver{hint: hint})
+	}
+
+	return resolvers
+}
+
+func (r *inferenceHintResolver) Root() string {
+	return r.hint.Root
+}
+
+func (r *inferenceHintResolver) Indexer() string {
+	return r.hint.Indexer
+}
+
+func (r *inferenceHintResolver) Confidence() string {
+	if r.hint.HintConfidence == config.HintConfidenceProjectStructureSupported {
+		return string(projectStructureSupported)
+	}
+
+	return string(languageSupport)
+}
+
+// marshalIndexJobs returns the given index jobs as an indented, JSON-encoded index configuration
+// in the same format as the inferred configuration of a repository.
+func marshalIndexJobs(indexJobs []config.IndexJob) (string, error) {
+	if indexJobs == nil {
+		indexJobs = []config.IndexJob{}
+	}
+
+	marshaled, err := config.MarshalJSON(config.IndexConfiguration{IndexJobs: indexJobs})
+	if err != nil {
+		return "", err
+	}
+
+	var indented bytes.Buffer
+	_ = json.Indent(&indented, marshaled, "", "\t")
+
+	return indented.String(), nil
+}
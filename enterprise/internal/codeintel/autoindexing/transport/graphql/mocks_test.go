@@ -32,6 +32,9 @@ type MockAutoIndexingService struct {
 	// DeleteIndexesFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexes.
 	DeleteIndexesFunc *AutoIndexingServiceDeleteIndexesFunc
+	// ExplainInferenceFunc is an instance of a mock function object
+	// controlling the behavior of the method ExplainInference.
+	ExplainInferenceFunc *AutoIndexingServiceExplainInferenceFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *AutoIndexingServiceGetIndexByIDFunc
@@ -118,6 +121,11 @@ func NewMockAutoIndexingService() *MockAutoIndexingService {
 				return
 			},
 		},
+		ExplainInferenceFunc: &AutoIndexingServiceExplainInferenceFunc{
+			defaultHook: func(context.Context, int, string, string) (r0 *shared.InferenceExplanation, r1 error) {
+				return
+			},
+		},
 		GetIndexByIDFunc: &AutoIndexingServiceGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (r0 types.Index, r1 bool, r2 error) {
 				return
@@ -241,6 +249,11 @@ func NewStrictMockAutoIndexingService() *MockAutoIndexingService {
 				panic("unexpected invocation of MockAutoIndexingService.DeleteIndexes")
 			},
 		},
+		ExplainInferenceFunc: &AutoIndexingServiceExplainInferenceFunc{
+			defaultHook: func(context.Context, int, string, string) (*shared.InferenceExplanation, error) {
+				panic("unexpected invocation of MockAutoIndexingService.ExplainInference")
+			},
+		},
 		GetIndexByIDFunc: &AutoIndexingServiceGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (types.Index, bool, error) {
 				panic("unexpected invocation of MockAutoIndexingService.GetIndexByID")
@@ -360,6 +373,9 @@ func NewMockAutoIndexingServiceFrom(i AutoIndexingService) *MockAutoIndexingServ
 		DeleteIndexesFunc: &AutoIndexingServiceDeleteIndexesFunc{
 			defaultHook: i.DeleteIndexes,
 		},
+		ExplainInferenceFunc: &AutoIndexingServiceExplainInferenceFunc{
+			defaultHook: i.ExplainInference,
+		},
 		GetIndexByIDFunc: &AutoIndexingServiceGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -645,6 +661,123 @@ func (c AutoIndexingServiceDeleteIndexesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// AutoIndexingServiceExplainInferenceFunc describes the behavior when the
+// ExplainInference method of the parent MockAutoIndexingService instance is
+// invoked.
+type AutoIndexingServiceExplainInferenceFunc struct {
+	defaultHook func(context.Context, int, string, string) (*shared.InferenceExplanation, error)
+	hooks       []func(context.Context, int, string, string) (*shared.InferenceExplanation, error)
+	history     []AutoIndexingServiceExplainInferenceFuncCall
+	mutex       sync.Mutex
+}
+
+// ExplainInference delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAutoIndexingService) ExplainInference(v0 context.Context, v1 int, v2 string, v3 string) (*shared.InferenceExplanation, error) {
+	r0, r1 := m.ExplainInferenceFunc.nextHook()(v0, v1, v2, v3)
+	m.ExplainInferenceFunc.appendCall(AutoIndexingServiceExplainInferenceFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ExplainInference
+// method of the parent MockAutoIndexingService instance is invoked and the
+// hook queue is empty.
+func (f *AutoIndexingServiceExplainInferenceFunc) SetDefaultHook(hook func(context.Context, int, string, string) (*shared.InferenceExplanation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExplainInference method of the parent MockAutoIndexingService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AutoIndexingServiceExplainInferenceFunc) PushHook(hook func(context.Context, int, string, string) (*shared.InferenceExplanation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AutoIndexingServiceExplainInferenceFunc) SetDefaultReturn(r0 *shared.InferenceExplanation, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) (*shared.InferenceExplanation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AutoIndexingServiceExplainInferenceFunc) PushReturn(r0 *shared.InferenceExplanation, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) (*shared.InferenceExplanation, error) {
+		return r0, r1
+	})
+}
+
+func (f *AutoIndexingServiceExplainInferenceFunc) nextHook() func(context.Context, int, string, string) (*shared.InferenceExplanation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AutoIndexingServiceExplainInferenceFunc) appendCall(r0 AutoIndexingServiceExplainInferenceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AutoIndexingServiceExplainInferenceFuncCall
+// objects describing the invocations of this function.
+func (f *AutoIndexingServiceExplainInferenceFunc) History() []AutoIndexingServiceExplainInferenceFuncCall {
+	f.mutex.Lock()
+	history := make([]AutoIndexingServiceExplainInferenceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AutoIndexingServiceExplainInferenceFuncCall is an object that describes
+// an invocation of method ExplainInference on an instance of
+// MockAutoIndexingService.
+type AutoIndexingServiceExplainInferenceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *shared.InferenceExplanation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AutoIndexingServiceExplainInferenceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AutoIndexingServiceExplainInferenceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AutoIndexingServiceGetIndexByIDFunc describes the behavior when the
 // GetIndexByID method of the parent MockAutoIndexingService instance is
 // invoked.
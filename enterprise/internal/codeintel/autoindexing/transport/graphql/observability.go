@@ -25,6 +25,7 @@ type operations struct {
 	// Index Configuration
 	inferedIndexConfiguration      *observation.Operation
 	inferedIndexConfigurationHints *observation.Operation
+	explainAutoIndexingInference   *observation.Operation
 
 	// Language Support
 	requestLanguageSupport    *observation.Operation
@@ -71,6 +72,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		// Index Configuration
 		inferedIndexConfiguration:      op("InferedIndexConfiguration"),
 		inferedIndexConfigurationHints: op("InferedIndexConfigurationHints"),
+		explainAutoIndexingInference:   op("ExplainAutoIndexingInference"),
 
 		// Language Support
 		requestLanguageSupport:    op("RequestLanguageSupport"),
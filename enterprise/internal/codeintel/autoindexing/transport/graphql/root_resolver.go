@@ -310,6 +310,43 @@ func (r *rootResolver) UpdateCodeIntelligenceInferenceScript(ctx context.Context
 	return &resolverstubs.EmptyResponse{}, r.autoindexSvc.SetInferenceScript(ctx, args.Script)
 }
 
+// 🚨 SECURITY: Only site admins may run inference scripts against arbitrary repositories
+func (r *rootResolver) ExplainAutoIndexingInference(ctx context.Context, args *resolverstubs.ExplainAutoIndexingInferenceArgs) (_ resolverstubs.AutoIndexingInferenceExplanationResolver, err error) {
+	ctx, _, endObservation := r.operations.explainAutoIndexingInference.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(args.Repository)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+		return nil, err
+	}
+	if !autoIndexingEnabled() {
+		return nil, errAutoIndexingNotEnabled
+	}
+
+	repositoryID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	rev := "HEAD"
+	if args.Rev != nil {
+		rev = *args.Rev
+	}
+
+	script := ""
+	if args.Script != nil {
+		script = *args.Script
+	}
+
+	explanation, err := r.autoindexSvc.ExplainInference(ctx, int(repositoryID), rev, script)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInferenceExplanationResolver(explanation), nil
+}
+
 func (r *rootResolver) GitBlobCodeIntelInfo(ctx context.Context, args *resolverstubs.GitTreeEntryCodeIntelInfoArgs) (_ resolverstubs.GitBlobCodeIntelSupportResolver, err error) {
 	ctx, errTracer, endObservation := r.operations.gitBlobCodeIntelInfo.WithErrors(ctx, &err, observation.Args{})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
@@ -9,7 +9,9 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -62,3 +64,69 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
 	}
 }
+
+func TestExplainAutoIndexingInference(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:42")))
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+	mockAutoIndexingService.ExplainInferenceFunc.SetDefaultReturn(&shared.InferenceExplanation{
+		Commit: "deadbeef",
+		Error:  "oops",
+	}, nil)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockAutoIndexingService, mockUploadsService, mockPolicyService)
+
+	script := "return {}"
+	resolver, err := rootResolver.ExplainAutoIndexingInference(context.Background(), &resolverstubs.ExplainAutoIndexingInferenceArgs{Repository: id, Script: &script})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if commit := resolver.Commit(); commit != "deadbeef" {
+		t.Errorf("unexpected commit. want=%q have=%q", "deadbeef", commit)
+	}
+	if explanationErr := resolver.Error(); explanationErr == nil || *explanationErr != "oops" {
+		t.Errorf("unexpected error. want=%q have=%v", "oops", explanationErr)
+	}
+
+	if len(mockAutoIndexingService.ExplainInferenceFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockAutoIndexingService.ExplainInferenceFunc.History()))
+	}
+	call := mockAutoIndexingService.ExplainInferenceFunc.History()[0]
+	if call.Arg1 != 42 {
+		t.Errorf("unexpected repository id. want=%d have=%d", 42, call.Arg1)
+	}
+	if call.Arg2 != "HEAD" {
+		t.Errorf("unexpected rev. want=%q have=%q", "HEAD", call.Arg2)
+	}
+	if call.Arg3 != script {
+		t.Errorf("unexpected script. want=%q have=%q", script, call.Arg3)
+	}
+}
+
+func TestExplainAutoIndexingInferenceUnauthenticated(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:42")))
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(&observation.TestContext, mockAutoIndexingService, mockUploadsService, mockPolicyService)
+
+	if _, err := rootResolver.ExplainAutoIndexingInference(context.Background(), &resolverstubs.ExplainAutoIndexingInferenceArgs{Repository: id}); err != auth.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
+	}
+	if len(mockAutoIndexingService.ExplainInferenceFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockAutoIndexingService.ExplainInferenceFunc.History()))
+	}
+}
@@ -24,6 +24,12 @@ type LsifStore interface {
 	// Definition
 	GetDefinitionLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
 
+	// Type definition
+	GetTypeDefinitionLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
+
+	// Prototypes
+	GetPrototypeLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
+
 	// Monikers
 	GetMonikersByPosition(ctx context.Context, uploadID int, path string, line, character int) (_ [][]precise.MonikerData, err error)
 	GetBulkMonikerLocations(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit, offset int) (_ []shared.Location, totalCount int, err error)
@@ -40,6 +46,10 @@ type LsifStore interface {
 	// Ranges
 	GetRanges(ctx context.Context, bundleID int, path string, startLine, endLine int) (_ []shared.CodeIntelligenceRange, err error)
 
+	// Call hierarchy
+	GetCallableDefinitions(ctx context.Context, bundleID int, path string) (_ []shared.SymbolRange, err error)
+	GetCallSites(ctx context.Context, bundleID int, path string, startLine, endLine int) (_ []shared.SymbolRange, err error)
+
 	// Paths
 	GetPathExists(ctx context.Context, bundleID int, path string) (_ bool, err error)
 }
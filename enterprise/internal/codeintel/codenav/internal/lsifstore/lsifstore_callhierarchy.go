@@ -0,0 +1,257 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetCallableDefinitions returns the definitions of callable symbols within the given document, ordered
+// by their start position. Each definition carries the enclosing range recorded by the indexer, which
+// spans the whole definition including its body. Definitions without an enclosing range are not returned,
+// and neither are definitions of LSIF data, which records neither enclosing ranges nor symbol kinds.
+func (s *store) GetCallableDefinitions(ctx context.Context, bundleID int, path string) (_ []shared.SymbolRange, err error) {
+	ctx, trace, endObservation := s.operations.getCallableDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		callHierarchyDocumentQuery,
+		bundleID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+
+	trace.AddEvent("SCIPData", attribute.Int("numOccurrences", len(documentData.SCIPData.Occurrences)))
+	return extractSCIPCallableDefinitions(documentData.SCIPData), nil
+}
+
+// GetCallSites returns the references to callable symbols that start within the given span of lines of
+// the given document, ordered by their start position. LSIF data does not distinguish callable symbols
+// from other symbols, so no call sites are returned for it.
+func (s *store) GetCallSites(ctx context.Context, bundleID int, path string, startLine, endLine int) (_ []shared.SymbolRange, err error) {
+	ctx, trace, endObservation := s.operations.getCallSites.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("startLine", startLine),
+		log.Int("endLine", endLine),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		callHierarchyDocumentQuery,
+		bundleID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+
+	trace.AddEvent("SCIPData", attribute.Int("numOccurrences", len(documentData.SCIPData.Occurrences)))
+	return extractSCIPCallSites(documentData.SCIPData, startLine, endLine), nil
+}
+
+const callHierarchyDocumentQuery = `
+SELECT
+	sd.id,
+	sid.document_path,
+	NULL AS data,
+	NULL AS ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	NULL AS diagnostics,
+	sd.raw_scip_payload AS scip_document
+FROM codeintel_scip_document_lookup sid
+JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
+WHERE
+	sid.upload_id = %s AND
+	sid.document_path = %s
+LIMIT 1
+`
+
+// extractSCIPCallableDefinitions returns the definition occurrences of callable symbols within the given
+// document that have an enclosing range, ordered by their start position.
+func extractSCIPCallableDefinitions(document *scip.Document) []shared.SymbolRange {
+	kinds := symbolKinds(document)
+
+	var definitions []shared.SymbolRange
+	for _, occurrence := range document.Occurrences {
+		if !isCallableSymbol(occurrence.Symbol, kinds) || !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		enclosingRange, ok := occurrenceEnclosingRange(occurrence)
+		if !ok {
+			continue
+		}
+
+		definitions = append(definitions, shared.SymbolRange{
+			Symbol:         occurrence.Symbol,
+			Range:          translateRange(scip.NewRange(occurrence.Range)),
+			EnclosingRange: translateRange(enclosingRange),
+		})
+	}
+	sortSymbolRanges(definitions)
+
+	return definitions
+}
+
+// extractSCIPCallSites returns the non-definition occurrences of callable symbols within the given
+// document that start within the given span of lines, ordered by their start position.
+func extractSCIPCallSites(document *scip.Document, startLine, endLine int) []shared.SymbolRange {
+	kinds := symbolKinds(document)
+
+	var callSites []shared.SymbolRange
+	for _, occurrence := range document.Occurrences {
+		if !isCallableSymbol(occurrence.Symbol, kinds) || scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		r := translateRange(scip.NewRange(occurrence.Range))
+		if r.Start.Line < startLine || r.Start.Line >= endLine {
+			continue
+		}
+
+		callSites = append(callSites, shared.SymbolRange{
+			Symbol: occurrence.Symbol,
+			Range:  r,
+		})
+	}
+	sortSymbolRanges(callSites)
+
+	return callSites
+}
+
+// isCallableSymbol returns true if the given SCIP symbol is a global symbol that can be called. If the
+// document records the kind of the symbol, the kind decides. Otherwise, the symbol is callable if its
+// final descriptor is a method (e.g., `pkg/Type#Method().` or `pkg/function(+1).`), as the symbol
+// grammar reserves this descriptor for methods and functions.
+func isCallableSymbol(symbol string, kinds map[string]int32) bool {
+	if symbol == "" || scip.IsLocalSymbol(symbol) {
+		return false
+	}
+	if kind, ok := kinds[symbol]; ok {
+		_, ok := callableSymbolKinds[kind]
+		return ok
+	}
+
+	return strings.HasSuffix(symbol, ").")
+}
+
+// The fields below were added to the SCIP schema after the version of the bindings used here. Indexers
+// that emit them still have them stored, as decoding and re-encoding a document retains unknown fields,
+// so they are read from the unknown fields of the decoded messages.
+const (
+	// occurrenceEnclosingRangeField is `Occurrence.enclosing_range`, the range of the nearest non-trivial
+	// enclosing AST node, encoded like `Occurrence.range`. For a definition of a function, this is the
+	// whole function including its body.
+	occurrenceEnclosingRangeField protowire.Number = 7
+	// symbolInformationKindField is `SymbolInformation.kind`.
+	symbolInformationKindField protowire.Number = 5
+)
+
+// callableSymbolKinds is the set of `SymbolInformation.Kind` values of symbols that have a body, which
+// may contain calls, and that can be called.
+var callableSymbolKinds = map[int32]struct{}{
+	9:  {}, // Constructor
+	17: {}, // Function
+	18: {}, // Getter
+	26: {}, // Method
+	45: {}, // Setter
+	76: {}, // SingletonMethod
+	80: {}, // StaticMethod
+}
+
+// symbolKinds returns the kinds recorded for the symbols of the given document, by symbol name.
+// Symbols without a kind are omitted.
+func symbolKinds(document *scip.Document) map[string]int32 {
+	kinds := map[string]int32{}
+	for _, symbol := range document.Symbols {
+		if values := decodeUnknownVarints(symbol.ProtoReflect().GetUnknown(), symbolInformationKindField); len(values) > 0 {
+			if kind := values[len(values)-1]; kind != 0 {
+				kinds[symbol.Symbol] = kind
+			}
+		}
+	}
+
+	return kinds
+}
+
+// occurrenceEnclosingRange returns the enclosing range of the given occurrence. A false-valued flag
+// is returned if the indexer did not record one.
+func occurrenceEnclosingRange(occurrence *scip.Occurrence) (*scip.Range, bool) {
+	values := decodeUnknownVarints(occurrence.ProtoReflect().GetUnknown(), occurrenceEnclosingRangeField)
+	if len(values) != 3 && len(values) != 4 {
+		return nil, false
+	}
+
+	return scip.NewRange(values), true
+}
+
+// decodeUnknownVarints returns the values of the (packed or unpacked) varint field with the given
+// number within the given unknown fields of a message. Malformed input yields no values.
+func decodeUnknownVarints(b []byte, field protowire.Number) []int32 {
+	var values []int32
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil
+		}
+		b = b[n:]
+
+		switch {
+		case num == field && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil
+			}
+			b = b[n:]
+			values = append(values, int32(v))
+
+		case num == field && typ == protowire.BytesType:
+			packed, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil
+			}
+			b = b[n:]
+			for len(packed) > 0 {
+				v, n := protowire.ConsumeVarint(packed)
+				if n < 0 {
+					return nil
+				}
+				packed = packed[n:]
+				values = append(values, int32(v))
+			}
+
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil
+			}
+			b = b[n:]
+		}
+	}
+
+	return values
+}
+
+// sortSymbolRanges sorts the given symbol ranges by their start position.
+func sortSymbolRanges(ranges []shared.SymbolRange) {
+	sort.Slice(ranges, func(i, j int) bool {
+		return compareBundleRanges(ranges[i].Range, ranges[j].Range)
+	})
+}
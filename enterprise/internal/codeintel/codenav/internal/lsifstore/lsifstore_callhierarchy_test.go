@@ -0,0 +1,92 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+)
+
+func TestExtractSCIPCallHierarchy(t *testing.T) {
+	withEnclosingRange := func(occurrence *scip.Occurrence, enclosingRange ...int32) *scip.Occurrence {
+		var packed []byte
+		for _, v := range enclosingRange {
+			packed = protowire.AppendVarint(packed, uint64(v))
+		}
+		b := protowire.AppendTag(nil, occurrenceEnclosingRangeField, protowire.BytesType)
+		occurrence.ProtoReflect().SetUnknown(protowire.AppendBytes(b, packed))
+		return occurrence
+	}
+	withKind := func(symbol *scip.SymbolInformation, kind int32) *scip.SymbolInformation {
+		b := protowire.AppendTag(nil, symbolInformationKindField, protowire.VarintType)
+		symbol.ProtoReflect().SetUnknown(protowire.AppendVarint(b, uint64(kind)))
+		return symbol
+	}
+
+	const (
+		function = "scip-go gomod pkg v1 `pkg`/f()."
+		method   = "scip-go gomod pkg v1 `pkg`/T#m()."
+		field    = "scip-go gomod pkg v1 `pkg`/T#x()."
+		noBody   = "scip-go gomod pkg v1 `pkg`/g()."
+		variable = "scip-go gomod pkg v1 `pkg`/v."
+		local    = "local 0"
+	)
+
+	document := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			withEnclosingRange(&scip.Occurrence{Range: []int32{10, 5, 6}, Symbol: function, SymbolRoles: int32(scip.SymbolRole_Definition)}, 10, 0, 20, 1),
+			withEnclosingRange(&scip.Occurrence{Range: []int32{30, 12, 13}, Symbol: method, SymbolRoles: int32(scip.SymbolRole_Definition)}, 30, 0, 40, 1),
+			withEnclosingRange(&scip.Occurrence{Range: []int32{50, 5, 6}, Symbol: field, SymbolRoles: int32(scip.SymbolRole_Definition)}, 50, 0, 50, 10),
+			&scip.Occurrence{Range: []int32{60, 5, 6}, Symbol: noBody, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			&scip.Occurrence{Range: []int32{12, 2, 3}, Symbol: method},
+			&scip.Occurrence{Range: []int32{14, 2, 3}, Symbol: noBody},
+			&scip.Occurrence{Range: []int32{15, 2, 3}, Symbol: field},
+			&scip.Occurrence{Range: []int32{16, 2, 3}, Symbol: variable},
+			&scip.Occurrence{Range: []int32{17, 2, 3}, Symbol: local},
+			&scip.Occurrence{Range: []int32{32, 2, 3}, Symbol: function},
+		},
+		Symbols: []*scip.SymbolInformation{
+			withKind(&scip.SymbolInformation{Symbol: method}, 26), // Method
+			withKind(&scip.SymbolInformation{Symbol: field}, 15),  // Field
+			{Symbol: function},
+		},
+	}
+
+	expectedDefinitions := []shared.SymbolRange{
+		{Symbol: function, Range: newRange(10, 5, 10, 6), EnclosingRange: newRange(10, 0, 20, 1)},
+		{Symbol: method, Range: newRange(30, 12, 30, 13), EnclosingRange: newRange(30, 0, 40, 1)},
+	}
+	if diff := cmp.Diff(expectedDefinitions, extractSCIPCallableDefinitions(document)); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	expectedCallSites := []shared.SymbolRange{
+		{Symbol: method, Range: newRange(12, 2, 12, 3)},
+		{Symbol: noBody, Range: newRange(14, 2, 14, 3)},
+	}
+	if diff := cmp.Diff(expectedCallSites, extractSCIPCallSites(document, 10, 21)); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeUnknownVarints(t *testing.T) {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, "ignored")
+	b = protowire.AppendTag(b, 7, protowire.VarintType)
+	b = protowire.AppendVarint(b, 3)
+	b = protowire.AppendTag(b, 7, protowire.VarintType)
+	b = protowire.AppendVarint(b, 4)
+	b = protowire.AppendTag(b, 2, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, 5)
+
+	if diff := cmp.Diff([]int32{3, 4}, decodeUnknownVarints(b, 7)); diff != "" {
+		t.Errorf("unexpected values (-want +got):\n%s", diff)
+	}
+	if values := decodeUnknownVarints(b[:len(b)-1], 7); values != nil {
+		t.Errorf("unexpected values for malformed input: %v", values)
+	}
+}
@@ -23,19 +23,36 @@ import (
 // GetDefinitionLocations returns the set of locations defining the symbol at the given position.
 func (s *store) GetDefinitionLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
 	extractor := func(r precise.RangeData) precise.ID { return r.DefinitionResultID }
-	return s.getLocations(ctx, extractor, "definition_ranges", extractDefinitionRanges, s.operations.getDefinitions, bundleID, path, line, character, limit, offset)
+	return s.getLocations(ctx, extractor, "definition_ranges", extractDefinitionRanges, extractOccurrenceSymbol, s.operations.getDefinitions, bundleID, path, line, character, limit, offset)
 }
 
 // GetReferenceLocations returns the set of locations referencing the symbol at the given position.
 func (s *store) GetReferenceLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
 	lsifExtractor := func(r precise.RangeData) precise.ID { return r.ReferenceResultID }
-	return s.getLocations(ctx, lsifExtractor, "reference_ranges", extractReferenceRanges, s.operations.getReferences, bundleID, path, line, character, limit, offset)
+	return s.getLocations(ctx, lsifExtractor, "reference_ranges", extractReferenceRanges, extractOccurrenceSymbol, s.operations.getReferences, bundleID, path, line, character, limit, offset)
 }
 
 // GetImplementationLocations returns the set of locations implementing the symbol at the given position.
 func (s *store) GetImplementationLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
 	extractor := func(r precise.RangeData) precise.ID { return r.ImplementationResultID }
-	return s.getLocations(ctx, extractor, "implementation_ranges", extractImplementationRanges, s.operations.getImplementations, bundleID, path, line, character, limit, offset)
+	return s.getLocations(ctx, extractor, "implementation_ranges", extractImplementationRanges, extractOccurrenceSymbol, s.operations.getImplementations, bundleID, path, line, character, limit, offset)
+}
+
+// GetTypeDefinitionLocations returns the set of locations defining the type of the symbol at the given
+// position. For LSIF data, these are the ranges of the typeDefinitionResult attached to the range.
+func (s *store) GetTypeDefinitionLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
+	extractor := func(r precise.RangeData) precise.ID { return r.TypeDefinitionResultID }
+	return s.getLocations(ctx, extractor, "definition_ranges", extractTypeDefinitionRanges, extractTypeDefinitionSymbols, s.operations.getTypeDefinitions, bundleID, path, line, character, limit, offset)
+}
+
+// GetPrototypeLocations returns the set of locations defining the symbols implemented by the symbol at
+// the given position (e.g., the interface method satisfied by a concrete method). This is the inverse of
+// GetImplementationLocations. LSIF has no result linking a range to the symbols it implements: answering
+// this from LSIF data would mean scanning every implementationResult of the upload for the range. Instead,
+// no locations are returned for LSIF data and the service resolves prototypes via implementation monikers.
+func (s *store) GetPrototypeLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
+	extractor := func(r precise.RangeData) precise.ID { return "" }
+	return s.getLocations(ctx, extractor, "definition_ranges", extractPrototypeRanges, extractPrototypeSymbols, s.operations.getPrototypes, bundleID, path, line, character, limit, offset)
 }
 
 func (s *store) getLocations(
@@ -43,6 +60,7 @@ func (s *store) getLocations(
 	lsifExtractor func(precise.RangeData) precise.ID,
 	scipFieldName string,
 	scipExtractor func(*scip.Document, *scip.Occurrence) []*scip.Range,
+	scipSymbolExtractor func(*scip.Document, *scip.Occurrence) []string,
 	operation *observation.Operation,
 	bundleID int,
 	path string,
@@ -78,10 +96,10 @@ func (s *store) getLocations(
 				locations = append(locations, convertSCIPRangesToLocations(ranges, bundleID, path)...)
 			}
 
-			if occurrence.Symbol != "" && !scip.IsLocalSymbol(occurrence.Symbol) {
+			if symbolNames := scipSymbolExtractor(documentData.SCIPData, occurrence); len(symbolNames) != 0 {
 				monikerLocations, err := s.scanQualifiedMonikerLocations(s.db.Query(ctx, sqlf.Sprintf(
 					locationsSymbolSearchQuery,
-					pq.Array([]string{occurrence.Symbol}),
+					pq.Array(symbolNames),
 					pq.Array([]int{bundleID}),
 					sqlf.Sprintf(scipFieldName),
 					bundleID,
@@ -599,10 +617,14 @@ func intsToString(vs []int) string {
 }
 
 type extractedOccurrenceData struct {
-	definitions     []*scip.Range
-	references      []*scip.Range
-	implementations []*scip.Range
-	hoverText       []string
+	definitions           []*scip.Range
+	references            []*scip.Range
+	implementations       []*scip.Range
+	typeDefinitions       []*scip.Range
+	prototypes            []*scip.Range
+	typeDefinitionSymbols []string
+	prototypeSymbols      []string
+	hoverText             []string
 }
 
 func extractDefinitionRanges(document *scip.Document, occurrence *scip.Occurrence) []*scip.Range {
@@ -617,6 +639,43 @@ func extractImplementationRanges(document *scip.Document, occurrence *scip.Occur
 	return extractOccurrenceData(document, occurrence).implementations
 }
 
+func extractTypeDefinitionRanges(document *scip.Document, occurrence *scip.Occurrence) []*scip.Range {
+	return extractOccurrenceData(document, occurrence).typeDefinitions
+}
+
+func extractPrototypeRanges(document *scip.Document, occurrence *scip.Occurrence) []*scip.Range {
+	return extractOccurrenceData(document, occurrence).prototypes
+}
+
+// extractOccurrenceSymbol returns the symbol name of the given occurrence if it can be
+// searched for in other documents of the same index.
+func extractOccurrenceSymbol(document *scip.Document, occurrence *scip.Occurrence) []string {
+	return filterGlobalSymbols([]string{occurrence.Symbol})
+}
+
+// extractTypeDefinitionSymbols returns the names of the global symbols defining the type of
+// the given occurrence's symbol.
+func extractTypeDefinitionSymbols(document *scip.Document, occurrence *scip.Occurrence) []string {
+	return filterGlobalSymbols(extractOccurrenceData(document, occurrence).typeDefinitionSymbols)
+}
+
+// extractPrototypeSymbols returns the names of the global symbols implemented by the given
+// occurrence's symbol.
+func extractPrototypeSymbols(document *scip.Document, occurrence *scip.Occurrence) []string {
+	return filterGlobalSymbols(extractOccurrenceData(document, occurrence).prototypeSymbols)
+}
+
+func filterGlobalSymbols(symbolNames []string) []string {
+	filtered := make([]string, 0, len(symbolNames))
+	for _, symbolName := range symbolNames {
+		if symbolName != "" && !scip.IsLocalSymbol(symbolName) {
+			filtered = append(filtered, symbolName)
+		}
+	}
+
+	return filtered
+}
+
 func extractHoverData(document *scip.Document, occurrence *scip.Occurrence) []string {
 	return extractOccurrenceData(document, occurrence).hoverText
 }
@@ -633,6 +692,10 @@ func extractOccurrenceData(document *scip.Document, occurrence *scip.Occurrence)
 		definitionSymbol        = occurrence.Symbol
 		referencesBySymbol      = map[string]struct{}{}
 		implementationsBySymbol = map[string]struct{}{}
+		typeDefinitionsBySymbol = map[string]struct{}{}
+		prototypesBySymbol      = map[string]struct{}{}
+		typeDefinitionSymbols   []string
+		prototypeSymbols        []string
 	)
 
 	// Extract hover text and relationship data from the symbol information that
@@ -652,12 +715,32 @@ func extractOccurrenceData(document *scip.Document, occurrence *scip.Occurrence)
 			if rel.IsImplementation {
 				implementationsBySymbol[rel.Symbol] = struct{}{}
 			}
+			if rel.IsTypeDefinition {
+				typeDefinitionsBySymbol[rel.Symbol] = struct{}{}
+				typeDefinitionSymbols = append(typeDefinitionSymbols, rel.Symbol)
+			}
+		}
+	}
+
+	// Extract the symbols that list this symbol as one of their implementations. These
+	// are the prototypes of this symbol: the symbols it implements.
+
+	for _, symbol := range document.Symbols {
+		for _, rel := range symbol.Relationships {
+			if rel.IsImplementation && rel.Symbol == occurrence.Symbol {
+				if _, ok := prototypesBySymbol[symbol.Symbol]; !ok {
+					prototypesBySymbol[symbol.Symbol] = struct{}{}
+					prototypeSymbols = append(prototypeSymbols, symbol.Symbol)
+				}
+			}
 		}
 	}
 
 	definitions := []*scip.Range{}
 	references := []*scip.Range{}
 	implementations := []*scip.Range{}
+	typeDefinitions := []*scip.Range{}
+	prototypes := []*scip.Range{}
 
 	// Include original symbol names for reference search below
 	referencesBySymbol[occurrence.Symbol] = struct{}{}
@@ -682,6 +765,16 @@ func extractOccurrenceData(document *scip.Document, occurrence *scip.Occurrence)
 		if _, ok := implementationsBySymbol[occ.Symbol]; ok && isDefinition {
 			implementations = append(implementations, scip.NewRange(occ.Range))
 		}
+
+		// This occurrence is a definition of a symbol implemented by this symbol
+		if _, ok := prototypesBySymbol[occ.Symbol]; ok && isDefinition {
+			prototypes = append(prototypes, scip.NewRange(occ.Range))
+		}
+
+		// This occurrence is a definition of a symbol with a type definition relationship
+		if _, ok := typeDefinitionsBySymbol[occ.Symbol]; ok && isDefinition {
+			typeDefinitions = append(typeDefinitions, scip.NewRange(occ.Range))
+		}
 	}
 
 	// Override symbol documentation with occurrence documentation, if it exists
@@ -690,9 +783,13 @@ func extractOccurrenceData(document *scip.Document, occurrence *scip.Occurrence)
 	}
 
 	return extractedOccurrenceData{
-		definitions:     definitions,
-		references:      references,
-		implementations: implementations,
-		hoverText:       hoverText,
+		definitions:           definitions,
+		references:            references,
+		implementations:       implementations,
+		typeDefinitions:       typeDefinitions,
+		prototypes:            prototypes,
+		typeDefinitionSymbols: typeDefinitionSymbols,
+		prototypeSymbols:      prototypeSymbols,
+		hoverText:             hoverText,
 	}
 }
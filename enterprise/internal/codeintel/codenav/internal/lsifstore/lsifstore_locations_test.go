@@ -532,4 +532,98 @@ func TestExtractOccurrenceData(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("type definitions", func(t *testing.T) {
+		document := &scip.Document{
+			Occurrences: []*scip.Occurrence{
+				{
+					Range:       []int32{1, 100, 1, 200},
+					Symbol:      "react 17.1 main.go value",
+					SymbolRoles: 1, // is definition
+				},
+				{
+					Range:       []int32{3, 300, 4, 400},
+					Symbol:      "react 17.1 main.go type",
+					SymbolRoles: 1, // is definition
+				},
+				{
+					Range:       []int32{5, 500, 5, 600},
+					Symbol:      "react 17.1 main.go type",
+					SymbolRoles: 0,
+				},
+			},
+			Symbols: []*scip.SymbolInformation{
+				{
+					Symbol: "react 17.1 main.go value",
+					Relationships: []*scip.Relationship{
+						{
+							Symbol:           "react 17.1 main.go type",
+							IsTypeDefinition: true,
+						},
+					},
+				},
+			},
+		}
+		occurrence := &scip.Occurrence{
+			Symbol:      "react 17.1 main.go value",
+			SymbolRoles: 0,
+		}
+
+		data := extractOccurrenceData(document, occurrence)
+		if diff := cmp.Diff([]*scip.Range{scip.NewRange([]int32{3, 300, 4, 400})}, data.typeDefinitions); diff != "" {
+			t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"react 17.1 main.go type"}, data.typeDefinitionSymbols); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("prototypes", func(t *testing.T) {
+		document := &scip.Document{
+			Occurrences: []*scip.Occurrence{
+				{
+					Range:       []int32{1, 100, 1, 200},
+					Symbol:      "react 17.1 main.go impl",
+					SymbolRoles: 1, // is definition
+				},
+				{
+					Range:       []int32{3, 300, 4, 400},
+					Symbol:      "react 17.1 main.go iface",
+					SymbolRoles: 1, // is definition
+				},
+			},
+			Symbols: []*scip.SymbolInformation{
+				{
+					Symbol: "react 17.1 main.go iface",
+					Relationships: []*scip.Relationship{
+						{
+							Symbol:           "react 17.1 main.go impl",
+							IsImplementation: true,
+						},
+					},
+				},
+			},
+		}
+
+		// The prototype of the implementation is the interface
+		data := extractOccurrenceData(document, &scip.Occurrence{Symbol: "react 17.1 main.go impl", SymbolRoles: 1})
+		if diff := cmp.Diff([]*scip.Range{scip.NewRange([]int32{3, 300, 4, 400})}, data.prototypes); diff != "" {
+			t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"react 17.1 main.go iface"}, data.prototypeSymbols); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]*scip.Range{}, data.implementations); diff != "" {
+			t.Errorf("unexpected implementation ranges (-want +got):\n%s", diff)
+		}
+
+		// The interface implements nothing
+		data = extractOccurrenceData(document, &scip.Occurrence{Symbol: "react 17.1 main.go iface", SymbolRoles: 1})
+		if diff := cmp.Diff([]*scip.Range{}, data.prototypes); diff != "" {
+			t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]*scip.Range{scip.NewRange([]int32{1, 100, 1, 200})}, data.implementations); diff != "" {
+			t.Errorf("unexpected implementation ranges (-want +got):\n%s", diff)
+		}
+	})
 }
@@ -12,6 +12,8 @@ type operations struct {
 	getImplementations     *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
+	getTypeDefinitions     *observation.Operation
+	getPrototypes          *observation.Operation
 	getDiagnostics         *observation.Operation
 	getRanges              *observation.Operation
 	getStencil             *observation.Operation
@@ -20,6 +22,8 @@ type operations struct {
 	getPackageInformation  *observation.Operation
 	getBulkMonikerResults  *observation.Operation
 	getLocationsWithinFile *observation.Operation
+	getCallableDefinitions *observation.Operation
+	getCallSites           *observation.Operation
 
 	locations *observation.Operation
 }
@@ -58,6 +62,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getImplementations:     op("GetImplementations"),
 		getHover:               op("GetHover"),
 		getDefinitions:         op("GetDefinitions"),
+		getTypeDefinitions:     op("GetTypeDefinitions"),
+		getPrototypes:          op("GetPrototypes"),
 		getDiagnostics:         op("GetDiagnostics"),
 		getRanges:              op("GetRanges"),
 		getStencil:             op("GetStencil"),
@@ -66,6 +72,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getPackageInformation:  op("GetPackageInformation"),
 		getBulkMonikerResults:  op("GetBulkMonikerResults"),
 		getLocationsWithinFile: op("GetLocationsWithinFile"),
+		getCallableDefinitions: op("GetCallableDefinitions"),
+		getCallSites:           op("GetCallSites"),
 
 		locations: subOp("locations"),
 	}
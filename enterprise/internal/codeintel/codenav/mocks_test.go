@@ -172,6 +172,12 @@ type MockLsifStore struct {
 	// GetBulkMonikerLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBulkMonikerLocations.
 	GetBulkMonikerLocationsFunc *LsifStoreGetBulkMonikerLocationsFunc
+	// GetCallSitesFunc is an instance of a mock function object controlling
+	// the behavior of the method GetCallSites.
+	GetCallSitesFunc *LsifStoreGetCallSitesFunc
+	// GetCallableDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCallableDefinitions.
+	GetCallableDefinitionsFunc *LsifStoreGetCallableDefinitionsFunc
 	// GetDefinitionLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDefinitionLocations.
 	GetDefinitionLocationsFunc *LsifStoreGetDefinitionLocationsFunc
@@ -194,6 +200,9 @@ type MockLsifStore struct {
 	// GetPathExistsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetPathExists.
 	GetPathExistsFunc *LsifStoreGetPathExistsFunc
+	// GetPrototypeLocationsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPrototypeLocations.
+	GetPrototypeLocationsFunc *LsifStoreGetPrototypeLocationsFunc
 	// GetRangesFunc is an instance of a mock function object controlling
 	// the behavior of the method GetRanges.
 	GetRangesFunc *LsifStoreGetRangesFunc
@@ -203,6 +212,10 @@ type MockLsifStore struct {
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *LsifStoreGetStencilFunc
+	// GetTypeDefinitionLocationsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetTypeDefinitionLocations.
+	GetTypeDefinitionLocationsFunc *LsifStoreGetTypeDefinitionLocationsFunc
 }
 
 // NewMockLsifStore creates a new mock of the LsifStore interface. All
@@ -214,6 +227,16 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: func(context.Context, int, string, int, int) (r0 []shared.SymbolRange, r1 error) {
+				return
+			},
+		},
+		GetCallableDefinitionsFunc: &LsifStoreGetCallableDefinitionsFunc{
+			defaultHook: func(context.Context, int, string) (r0 []shared.SymbolRange, r1 error) {
+				return
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
 				return
@@ -249,6 +272,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetPrototypeLocationsFunc: &LsifStoreGetPrototypeLocationsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
+				return
+			},
+		},
 		GetRangesFunc: &LsifStoreGetRangesFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 []shared.CodeIntelligenceRange, r1 error) {
 				return
@@ -264,6 +292,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetTypeDefinitionLocationsFunc: &LsifStoreGetTypeDefinitionLocationsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -276,6 +309,16 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetBulkMonikerLocations")
 			},
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]shared.SymbolRange, error) {
+				panic("unexpected invocation of MockLsifStore.GetCallSites")
+			},
+		},
+		GetCallableDefinitionsFunc: &LsifStoreGetCallableDefinitionsFunc{
+			defaultHook: func(context.Context, int, string) ([]shared.SymbolRange, error) {
+				panic("unexpected invocation of MockLsifStore.GetCallableDefinitions")
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetDefinitionLocations")
@@ -311,6 +354,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetPathExists")
 			},
 		},
+		GetPrototypeLocationsFunc: &LsifStoreGetPrototypeLocationsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+				panic("unexpected invocation of MockLsifStore.GetPrototypeLocations")
+			},
+		},
 		GetRangesFunc: &LsifStoreGetRangesFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([]shared.CodeIntelligenceRange, error) {
 				panic("unexpected invocation of MockLsifStore.GetRanges")
@@ -326,6 +374,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetStencil")
 			},
 		},
+		GetTypeDefinitionLocationsFunc: &LsifStoreGetTypeDefinitionLocationsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+				panic("unexpected invocation of MockLsifStore.GetTypeDefinitionLocations")
+			},
+		},
 	}
 }
 
@@ -336,6 +389,12 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetBulkMonikerLocationsFunc: &LsifStoreGetBulkMonikerLocationsFunc{
 			defaultHook: i.GetBulkMonikerLocations,
 		},
+		GetCallSitesFunc: &LsifStoreGetCallSitesFunc{
+			defaultHook: i.GetCallSites,
+		},
+		GetCallableDefinitionsFunc: &LsifStoreGetCallableDefinitionsFunc{
+			defaultHook: i.GetCallableDefinitions,
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: i.GetDefinitionLocations,
 		},
@@ -357,6 +416,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetPathExistsFunc: &LsifStoreGetPathExistsFunc{
 			defaultHook: i.GetPathExists,
 		},
+		GetPrototypeLocationsFunc: &LsifStoreGetPrototypeLocationsFunc{
+			defaultHook: i.GetPrototypeLocations,
+		},
 		GetRangesFunc: &LsifStoreGetRangesFunc{
 			defaultHook: i.GetRanges,
 		},
@@ -366,6 +428,9 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetStencilFunc: &LsifStoreGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
+		GetTypeDefinitionLocationsFunc: &LsifStoreGetTypeDefinitionLocationsFunc{
+			defaultHook: i.GetTypeDefinitionLocations,
+		},
 	}
 }
 
@@ -495,6 +560,237 @@ func (c LsifStoreGetBulkMonikerLocationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetCallSitesFunc describes the behavior when the GetCallSites
+// method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetCallSitesFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]shared.SymbolRange, error)
+	hooks       []func(context.Context, int, string, int, int) ([]shared.SymbolRange, error)
+	history     []LsifStoreGetCallSitesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCallSites delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLsifStore) GetCallSites(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]shared.SymbolRange, error) {
+	r0, r1 := m.GetCallSitesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetCallSitesFunc.appendCall(LsifStoreGetCallSitesFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetCallSites method
+// of the parent MockLsifStore instance is invoked and the hook queue is
+// empty.
+func (f *LsifStoreGetCallSitesFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]shared.SymbolRange, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCallSites method of the parent MockLsifStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LsifStoreGetCallSitesFunc) PushHook(hook func(context.Context, int, string, int, int) ([]shared.SymbolRange, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetCallSitesFunc) SetDefaultReturn(r0 []shared.SymbolRange, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]shared.SymbolRange, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetCallSitesFunc) PushReturn(r0 []shared.SymbolRange, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]shared.SymbolRange, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetCallSitesFunc) nextHook() func(context.Context, int, string, int, int) ([]shared.SymbolRange, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetCallSitesFunc) appendCall(r0 LsifStoreGetCallSitesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetCallSitesFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetCallSitesFunc) History() []LsifStoreGetCallSitesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetCallSitesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetCallSitesFuncCall is an object that describes an invocation
+// of method GetCallSites on an instance of MockLsifStore.
+type LsifStoreGetCallSitesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolRange
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetCallSitesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetCallSitesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetCallableDefinitionsFunc describes the behavior when the
+// GetCallableDefinitions method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetCallableDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string) ([]shared.SymbolRange, error)
+	hooks       []func(context.Context, int, string) ([]shared.SymbolRange, error)
+	history     []LsifStoreGetCallableDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCallableDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetCallableDefinitions(v0 context.Context, v1 int, v2 string) ([]shared.SymbolRange, error) {
+	r0, r1 := m.GetCallableDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.GetCallableDefinitionsFunc.appendCall(LsifStoreGetCallableDefinitionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetCallableDefinitions method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetCallableDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string) ([]shared.SymbolRange, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCallableDefinitions method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetCallableDefinitionsFunc) PushHook(hook func(context.Context, int, string) ([]shared.SymbolRange, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetCallableDefinitionsFunc) SetDefaultReturn(r0 []shared.SymbolRange, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]shared.SymbolRange, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetCallableDefinitionsFunc) PushReturn(r0 []shared.SymbolRange, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]shared.SymbolRange, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetCallableDefinitionsFunc) nextHook() func(context.Context, int, string) ([]shared.SymbolRange, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetCallableDefinitionsFunc) appendCall(r0 LsifStoreGetCallableDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetCallableDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetCallableDefinitionsFunc) History() []LsifStoreGetCallableDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetCallableDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetCallableDefinitionsFuncCall is an object that describes an
+// invocation of method GetCallableDefinitions on an instance of
+// MockLsifStore.
+type LsifStoreGetCallableDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolRange
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetCallableDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetCallableDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetDefinitionLocationsFunc describes the behavior when the
 // GetDefinitionLocations method of the parent MockLsifStore instance is
 // invoked.
@@ -1344,6 +1640,134 @@ func (c LsifStoreGetPathExistsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LsifStoreGetPrototypeLocationsFunc describes the behavior when the
+// GetPrototypeLocations method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetPrototypeLocationsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)
+	history     []LsifStoreGetPrototypeLocationsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPrototypeLocations delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetPrototypeLocations(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]shared.Location, int, error) {
+	r0, r1, r2 := m.GetPrototypeLocationsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.GetPrototypeLocationsFunc.appendCall(LsifStoreGetPrototypeLocationsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetPrototypeLocations method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetPrototypeLocationsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPrototypeLocations method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetPrototypeLocationsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetPrototypeLocationsFunc) SetDefaultReturn(r0 []shared.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetPrototypeLocationsFunc) PushReturn(r0 []shared.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetPrototypeLocationsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetPrototypeLocationsFunc) appendCall(r0 LsifStoreGetPrototypeLocationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetPrototypeLocationsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetPrototypeLocationsFunc) History() []LsifStoreGetPrototypeLocationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetPrototypeLocationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetPrototypeLocationsFuncCall is an object that describes an
+// invocation of method GetPrototypeLocations on an instance of
+// MockLsifStore.
+type LsifStoreGetPrototypeLocationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetPrototypeLocationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetPrototypeLocationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // LsifStoreGetRangesFunc describes the behavior when the GetRanges method
 // of the parent MockLsifStore instance is invoked.
 type LsifStoreGetRangesFunc struct {
@@ -1698,6 +2122,135 @@ func (c LsifStoreGetStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LsifStoreGetTypeDefinitionLocationsFunc describes the behavior when the
+// GetTypeDefinitionLocations method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetTypeDefinitionLocationsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)
+	history     []LsifStoreGetTypeDefinitionLocationsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTypeDefinitionLocations delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetTypeDefinitionLocations(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]shared.Location, int, error) {
+	r0, r1, r2 := m.GetTypeDefinitionLocationsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.GetTypeDefinitionLocationsFunc.appendCall(LsifStoreGetTypeDefinitionLocationsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTypeDefinitionLocations method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTypeDefinitionLocations method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) SetDefaultReturn(r0 []shared.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) PushReturn(r0 []shared.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) appendCall(r0 LsifStoreGetTypeDefinitionLocationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetTypeDefinitionLocationsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetTypeDefinitionLocationsFunc) History() []LsifStoreGetTypeDefinitionLocationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetTypeDefinitionLocationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetTypeDefinitionLocationsFuncCall is an object that describes
+// an invocation of method GetTypeDefinitionLocations on an instance of
+// MockLsifStore.
+type LsifStoreGetTypeDefinitionLocationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetTypeDefinitionLocationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetTypeDefinitionLocationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockGitTreeTranslator is a mock implementation of the GitTreeTranslator
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
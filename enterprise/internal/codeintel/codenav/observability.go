@@ -17,8 +17,12 @@ type operations struct {
 	getDiagnostics         *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
+	getTypeDefinitions     *observation.Operation
+	getPrototypes          *observation.Operation
 	getRanges              *observation.Operation
 	getStencil             *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
 	getDumpsByIDs          *observation.Operation
 	getClosestDumpsForBlob *observation.Operation
 }
@@ -49,8 +53,12 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getDiagnostics:         op("getDiagnostics"),
 		getHover:               op("getHover"),
 		getDefinitions:         op("getDefinitions"),
+		getTypeDefinitions:     op("getTypeDefinitions"),
+		getPrototypes:          op("getPrototypes"),
 		getRanges:              op("getRanges"),
 		getStencil:             op("getStencil"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
 		getDumpsByIDs:          op("GetDumpsByIDs"),
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 	}
@@ -189,6 +189,26 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	})
 	defer endObservation()
 
+	locations, cursor, err := s.getReferenceLocations(ctx, args, requestState, cursor, trace)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all references
+	// are occurring at the same commit they are looking at.
+	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferenceLocations", len(referenceLocations)))
+
+	return referenceLocations, cursor, nil
+}
+
+// getReferenceLocations returns the next page of (unadjusted) locations that reference the symbol at
+// the given position, along with the cursor used to resolve the following page.
+func (s *Service) getReferenceLocations(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.ReferencesCursor, trace observation.TraceLogger) ([]shared.Location, shared.ReferencesCursor, error) {
 	// Adjust the path and position for each visible upload based on its git difference to
 	// the target commit. This data may already be stashed in the cursor decoded above, in
 	// which case we don't need to hit the database.
@@ -279,16 +299,7 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all references
-	// are occurring at the same commit they are looking at.
-	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
-	if err != nil {
-		return nil, cursor, err
-	}
-	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferenceLocations", len(referenceLocations)))
-
-	return referenceLocations, cursor, nil
+	return locations, cursor, nil
 }
 
 // getUploadsWithDefinitionsForMonikers returns the set of uploads that provide any of the given monikers.
@@ -796,6 +807,28 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 		return nil, err
 	}
 
+	locations, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// getDefinitionLocations returns the set of (unadjusted) locations defining the symbol at the target
+// position of each of the given visible uploads. Local definitions are preferred; a moniker search over
+// the uploads defining an attached import moniker is performed only when no local definition exists.
+func (s *Service) getDefinitionLocations(ctx context.Context, visibleUploads []visibleUpload, requestState RequestState, trace observation.TraceLogger) ([]shared.Location, error) {
 	// Gather the "local" reference locations that are reachable via a referenceResult vertex.
 	// If the definition exists within the index, it should be reachable via an LSIF graph
 	// traversal and should not require an additional moniker search in the same index.
@@ -816,7 +849,7 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 		}
 		if len(locations) > 0 {
 			// If we have a local definition, we won't find a better one and can exit early
-			return s.getUploadLocations(ctx, args, requestState, locations, true)
+			return locations, nil
 		}
 	}
 
@@ -847,17 +880,471 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numXrepoLocations", len(locations)))
 
+	return locations, nil
+}
+
+// GetTypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+// The result set is paginated in the same way as GetImplementations.
+func (s *Service) GetTypeDefinitions(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.RelatedDefinitionsCursor) (_ []types.UploadLocation, _ shared.RelatedDefinitionsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getTypeDefinitions, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	// Type definitions in other indexes are found via the monikers of the types of the symbol.
+	return s.getRelatedDefinitions(ctx, args, requestState, cursor, s.lsifstore.GetTypeDefinitionLocations, precise.TypeDefinition, trace)
+}
+
+// GetPrototypes returns the set of locations defining the symbols implemented by the symbol at the given
+// position (e.g., the interface method satisfied by a concrete method). This is the inverse of
+// GetImplementations, and its result set is paginated in the same way.
+func (s *Service) GetPrototypes(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.RelatedDefinitionsCursor) (_ []types.UploadLocation, _ shared.RelatedDefinitionsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getPrototypes, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	// Prototypes in other indexes are found via the implementation monikers of the symbol, which
+	// name the symbols it implements.
+	return s.getRelatedDefinitions(ctx, args, requestState, cursor, s.lsifstore.GetPrototypeLocations, precise.Implementation, trace)
+}
+
+// getRelatedDefinitions returns a page of the adjusted locations returned by the given function for the
+// target position of each visible upload. If no upload yields a local result, the definitions of the
+// monikers of the given kind attached to the target position are searched in the uploads defining them,
+// in the same way GetDefinitions resolves definitions from other indexes. The given cursor is returned
+// modified to denote the offsets required to resolve the next page of results.
+func (s *Service) getRelatedDefinitions(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.RelatedDefinitionsCursor, getLocations getLocationsFn, monikerKind string, trace observation.TraceLogger) ([]types.UploadLocation, shared.RelatedDefinitionsCursor, error) {
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit. This data may already be stashed in the given cursor, in which
+	// case we don't need to hit the database.
+	visibleUploads, cursorsToVisibleUploads, err := s.getVisibleUploadsFromCursor(ctx, args.Line, args.Character, &cursor.CursorsToVisibleUploads, requestState)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Update the cursors with the updated visible uploads.
+	cursor.CursorsToVisibleUploads = cursorsToVisibleUploads
+
+	// Phase 1: Gather all "local" locations via LSIF graph traversal. We'll continue to request additional
+	// locations until we fill an entire page (the size of which is denoted by the given limit) or there are
+	// no more local results remaining.
+	var locations []shared.Location
+	if cursor.Phase == "local" {
+		for len(locations) < args.Limit {
+			localLocations, hasMore, err := s.getPageLocalLocations(ctx, getLocations, visibleUploads, &cursor.LocalCursor, args.Limit-len(locations), trace)
+			if err != nil {
+				return nil, cursor, err
+			}
+			locations = append(locations, localLocations...)
+
+			if len(localLocations) > 0 {
+				cursor.HasLocalLocations = true
+			}
+
+			if !hasMore {
+				// If we have a local definition, we won't find a better one in another index
+				if cursor.HasLocalLocations {
+					cursor.Phase = "done"
+				} else {
+					cursor.Phase = "dependencies"
+				}
+				break
+			}
+		}
+	}
+
+	// Phase 2: Gather all "remote" locations in the uploads defining one of the monikers of the given
+	// kind via moniker search. We only do this if there are no local results at all.
+	if cursor.Phase == "dependencies" {
+		// Gather all monikers of the given kind attached to the ranges enclosing the requested position.
+		// This data may already be stashed in the given cursor, in which case we don't need to hit the
+		// database.
+		if cursor.OrderedMonikers == nil {
+			if cursor.OrderedMonikers, err = s.getOrderedMonikers(ctx, visibleUploads, monikerKind); err != nil {
+				return nil, cursor, err
+			}
+		}
+		trace.AddEvent("TODO Domain Owner",
+			attribute.Int("numMonikers", len(cursor.OrderedMonikers)),
+			attribute.String("monikers", monikersToString(cursor.OrderedMonikers)))
+
+		uploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, cursor.OrderedMonikers, requestState)
+		if err != nil {
+			return nil, cursor, err
+		}
+		trace.AddEvent("TODO Domain Owner",
+			attribute.Int("numXrepoDefinitionUploads", len(uploads)),
+			attribute.String("xrepoDefinitionUploads", uploadIDsToString(uploads)))
+
+		remoteLocations, totalCount, err := s.getBulkMonikerLocations(ctx, uploads, cursor.OrderedMonikers, "definitions", args.Limit-len(locations), cursor.RemoteOffset)
+		if err != nil {
+			return nil, cursor, err
+		}
+		locations = append(locations, remoteLocations...)
+
+		cursor.RemoteOffset += len(remoteLocations)
+		if cursor.RemoteOffset >= totalCount {
+			cursor.Phase = "done"
+		}
+	}
+
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numLocations", len(locations)))
+
 	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all definitions
+	// locations within the repository the user is browsing so that it appears all locations
 	// are occurring at the same commit they are looking at.
 
 	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, cursor, nil
+}
+
+// DefaultCallHierarchyPageSize is the number of references (for incoming calls) or call sites (for
+// outgoing calls) resolved per page when no limit is supplied.
+const DefaultCallHierarchyPageSize = 100
+
+// GetIncomingCalls returns the callable symbols that reference the symbol at the given position, along
+// with the ranges of each such reference. The result set is paginated in the same way (and with the same
+// cross-repository moniker search) as GetReferences. A caller is determined by the innermost callable
+// definition whose enclosing range, as recorded by the indexer, contains the reference. References that
+// are not contained in such a definition (including all references in LSIF data) are skipped. Callers can
+// expand the hierarchy transitively by requesting the incoming calls of each returned item's location.
+// The args.Limit value defaults to DefaultCallHierarchyPageSize.
+func (s *Service) GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.IncomingCallsCursor) (_ []shared.CallHierarchyItem, _ shared.IncomingCallsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	if args.Limit <= 0 {
+		args.Limit = DefaultCallHierarchyPageSize
+	}
+
+	locations, referencesCursor, err := s.getReferenceLocations(ctx, args, requestState, cursor.ReferencesCursor, trace)
+	if err != nil {
+		return nil, cursor, err
+	}
+	cursor.ReferencesCursor = referencesCursor
+
+	// Group the reference locations by their containing document so that we only need to
+	// read the set of callable definitions once per document.
+	type documentKey struct {
+		dumpID int
+		path   string
+	}
+	var documentKeys []documentKey
+	locationsByDocument := map[documentKey][]shared.Location{}
+	for _, location := range locations {
+		key := documentKey{location.DumpID, location.Path}
+		if _, ok := locationsByDocument[key]; !ok {
+			documentKeys = append(documentKeys, key)
+		}
+		locationsByDocument[key] = append(locationsByDocument[key], location)
+	}
+
+	var edges []callEdge
+	for _, key := range documentKeys {
+		definitions, err := s.lsifstore.GetCallableDefinitions(ctx, key.dumpID, key.path)
+		if err != nil {
+			return nil, cursor, errors.Wrap(err, "lsifStore.GetCallableDefinitions")
+		}
+
+		for _, location := range locationsByDocument[key] {
+			definition, ok := enclosingDefinition(definitions, location.Range)
+			if !ok {
+				continue
+			}
+
+			edges = append(edges, callEdge{
+				symbol: definition.Symbol,
+				item:   shared.Location{DumpID: key.dumpID, Path: key.path, Range: definition.Range},
+				from:   location,
+			})
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numIncomingCalls", len(edges)))
+
+	items, err := s.getCallHierarchyItems(ctx, args, requestState, edges)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCallHierarchyItems", len(items)))
+
+	return items, cursor, nil
+}
+
+// GetOutgoingCalls returns the callable symbols referenced from within the body of the definition of the
+// symbol at the given position, along with the ranges of each such reference. The body of a definition
+// is its enclosing range as recorded by the indexer; definitions without one (including all definitions
+// in LSIF data) have no outgoing calls. Callees defined in another index are resolved via moniker search
+// in the same way as GetDefinitions. The args.Limit value bounds the number of call sites resolved per
+// page, and defaults to DefaultCallHierarchyPageSize.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.OutgoingCallsCursor) (_ []shared.CallHierarchyItem, _ shared.OutgoingCallsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	if args.Limit <= 0 {
+		args.Limit = DefaultCallHierarchyPageSize
+	}
+
+	visibleUploads, cursorsToVisibleUploads, err := s.getVisibleUploadsFromCursor(ctx, args.Line, args.Character, &cursor.CursorsToVisibleUploads, requestState)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Update the cursors with the updated visible uploads.
+	cursor.CursorsToVisibleUploads = cursorsToVisibleUploads
+
+	// Phase 1: Resolve the definitions of the symbol at the requested position. The body of each
+	// definition is scanned for call sites below. This data is stashed in the cursor so that it is
+	// not recalculated for subsequent pages.
+	if cursor.Phase == "definitions" {
+		definitions, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+		if err != nil {
+			return nil, cursor, err
+		}
+
+		cursor.Definitions = definitions
+		cursor.Phase = "calls"
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numDefinitions", len(cursor.Definitions)))
+
+	// Phase 2: Gather the call sites within the body of each definition until we fill an entire page
+	// or there are no more definitions remaining, then resolve the definition of each call site.
+	var edges []callEdge
+	if cursor.Phase == "calls" {
+		numCallSites := 0
+		for numCallSites < args.Limit && cursor.DefinitionOffset < len(cursor.Definitions) {
+			definition := cursor.Definitions[cursor.DefinitionOffset]
+
+			callSites, err := s.getCallSitesForDefinition(ctx, definition)
+			if err != nil {
+				return nil, cursor, err
+			}
+			totalCount := len(callSites)
+
+			if cursor.CallSiteOffset < len(callSites) {
+				callSites = callSites[cursor.CallSiteOffset:]
+			} else {
+				callSites = nil
+			}
+			if len(callSites) > args.Limit-numCallSites {
+				callSites = callSites[:args.Limit-numCallSites]
+			}
+			numCallSites += len(callSites)
+			cursor.CallSiteOffset += len(callSites)
+
+			if cursor.CallSiteOffset >= totalCount {
+				// Skip this definition on next request
+				cursor.CallSiteOffset = 0
+				cursor.DefinitionOffset++
+			}
+
+			calleeEdges, err := s.getCalleeEdges(ctx, definition, callSites, requestState, trace)
+			if err != nil {
+				return nil, cursor, err
+			}
+			edges = append(edges, calleeEdges...)
+		}
+
+		if cursor.DefinitionOffset >= len(cursor.Definitions) {
+			cursor.Phase = "done"
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numOutgoingCalls", len(edges)))
+
+	items, err := s.getCallHierarchyItems(ctx, args, requestState, edges)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCallHierarchyItems", len(items)))
+
+	return items, cursor, nil
+}
+
+// callEdge pairs the location of a call hierarchy item with the location of a single call to or from it.
+type callEdge struct {
+	symbol string
+	item   shared.Location
+	from   shared.Location
+}
+
+// getCallSitesForDefinition returns the call sites within the body of the given callable definition. If
+// the given location does not define a callable symbol, no call sites are returned.
+func (s *Service) getCallSitesForDefinition(ctx context.Context, definition shared.Location) ([]shared.SymbolRange, error) {
+	definitions, err := s.lsifstore.GetCallableDefinitions(ctx, definition.DumpID, definition.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetCallableDefinitions")
+	}
+
+	enclosingRange, ok := definitionEnclosingRange(definitions, definition.Range)
+	if !ok {
+		return nil, nil
+	}
+
+	callSites, err := s.lsifstore.GetCallSites(ctx, definition.DumpID, definition.Path, enclosingRange.Start.Line, enclosingRange.End.Line+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetCallSites")
+	}
+
+	filtered := callSites[:0]
+	for _, callSite := range callSites {
+		if rangeContains(enclosingRange, callSite.Range) {
+			filtered = append(filtered, callSite)
+		}
+	}
+
+	return filtered, nil
+}
+
+// getCalleeEdges resolves the definition of each of the given call sites, which occur within the body of
+// the given definition. The definitions are resolved once per distinct symbol of the call sites.
+func (s *Service) getCalleeEdges(ctx context.Context, definition shared.Location, callSites []shared.SymbolRange, requestState RequestState, trace observation.TraceLogger) ([]callEdge, error) {
+	if len(callSites) == 0 {
+		return nil, nil
+	}
+
+	uploads, err := s.getUploadsByIDs(ctx, []int{definition.DumpID}, requestState)
 	if err != nil {
 		return nil, err
 	}
-	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
+	if len(uploads) == 0 {
+		return nil, nil
+	}
+	upload := uploads[0]
 
-	return adjustedLocations, nil
+	// Group the call sites by their symbol so that we only need to resolve the definitions
+	// of each symbol once. All call sites are within the same document, so this also holds
+	// for document-local symbols.
+	var symbols []string
+	callSitesBySymbol := map[string][]shared.SymbolRange{}
+	for _, callSite := range callSites {
+		if _, ok := callSitesBySymbol[callSite.Symbol]; !ok {
+			symbols = append(symbols, callSite.Symbol)
+		}
+		callSitesBySymbol[callSite.Symbol] = append(callSitesBySymbol[callSite.Symbol], callSite)
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCalleeSymbols", len(symbols)))
+
+	var edges []callEdge
+	for _, symbol := range symbols {
+		symbolCallSites := callSitesBySymbol[symbol]
+
+		callSiteUpload := visibleUpload{
+			Upload:                upload,
+			TargetPath:            upload.Root + definition.Path,
+			TargetPosition:        symbolCallSites[0].Range.Start,
+			TargetPathWithoutRoot: definition.Path,
+		}
+
+		locations, err := s.getDefinitionLocations(ctx, []visibleUpload{callSiteUpload}, requestState, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, callSite := range symbolCallSites {
+			for _, location := range locations {
+				edges = append(edges, callEdge{
+					symbol: symbol,
+					item:   location,
+					from:   shared.Location{DumpID: definition.DumpID, Path: definition.Path, Range: callSite.Range},
+				})
+			}
+		}
+	}
+
+	return edges, nil
+}
+
+// getCallHierarchyItems groups the given call edges by their item location and translates each item and
+// call range into an equivalent location in the requested commit. Items (and call ranges) that cannot be
+// viewed by the current actor are dropped.
+func (s *Service) getCallHierarchyItems(ctx context.Context, args shared.RequestArgs, requestState RequestState, edges []callEdge) ([]shared.CallHierarchyItem, error) {
+	var itemLocations []shared.Location
+	edgesByItem := map[shared.Location][]callEdge{}
+	for _, edge := range edges {
+		if _, ok := edgesByItem[edge.item]; !ok {
+			itemLocations = append(itemLocations, edge.item)
+		}
+		edgesByItem[edge.item] = append(edgesByItem[edge.item], edge)
+	}
+
+	items := make([]shared.CallHierarchyItem, 0, len(itemLocations))
+	for _, itemLocation := range itemLocations {
+		adjustedItemLocations, err := s.getUploadLocations(ctx, args, requestState, []shared.Location{itemLocation}, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(adjustedItemLocations) == 0 {
+			continue
+		}
+
+		itemEdges := edgesByItem[itemLocation]
+		fromLocations := make([]shared.Location, 0, len(itemEdges))
+		for _, edge := range itemEdges {
+			fromLocations = append(fromLocations, edge.from)
+		}
+
+		adjustedFromLocations, err := s.getUploadLocations(ctx, args, requestState, fromLocations, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(adjustedFromLocations) == 0 {
+			continue
+		}
+
+		fromRanges := make([]types.Range, 0, len(adjustedFromLocations))
+		for _, location := range adjustedFromLocations {
+			fromRanges = append(fromRanges, location.TargetRange)
+		}
+
+		items = append(items, shared.CallHierarchyItem{
+			Symbol:     itemEdges[0].symbol,
+			Location:   adjustedItemLocations[0],
+			FromRanges: dedupeRanges(sortRanges(fromRanges)),
+		})
+	}
+
+	return items, nil
 }
 
 func (s *Service) GetDiagnostics(ctx context.Context, args shared.RequestArgs, requestState RequestState) (diagnosticsAtUploads []shared.DiagnosticAtUpload, _ int, err error) {
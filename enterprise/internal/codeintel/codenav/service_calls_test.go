@@ -0,0 +1,248 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testLineRange(line int) types.Range {
+	return types.Range{Start: types.Position{Line: line, Character: 5}, End: types.Position{Line: line, Character: 10}}
+}
+
+func testEnclosingRange(startLine, endLine int) types.Range {
+	return types.Range{Start: types.Position{Line: startLine, Character: 0}, End: types.Position{Line: endLine, Character: 1}}
+}
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Set up references to the target symbol
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: testLineRange(10)},
+		{DumpID: 51, Path: "a.go", Range: testLineRange(20)},
+		{DumpID: 51, Path: "a.go", Range: testLineRange(22)},
+		{DumpID: 51, Path: "a.go", Range: testLineRange(27)}, // between definitions
+		{DumpID: 51, Path: "a.go", Range: testLineRange(40)},
+		{DumpID: 51, Path: "b.go", Range: testLineRange(5)},
+	}
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	// Set up callable definitions enclosing the references
+	mockLsifStore.GetCallableDefinitionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string) ([]shared.SymbolRange, error) {
+		if path != "a.go" {
+			return nil, nil
+		}
+
+		return []shared.SymbolRange{
+			{Symbol: "pkg/f().", Range: testLineRange(10), EnclosingRange: testEnclosingRange(10, 25)},
+			{Symbol: "pkg/g().", Range: testLineRange(30), EnclosingRange: testEnclosingRange(30, 45)},
+		}, nil
+	})
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	items, cursor, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, shared.IncomingCallsCursor{ReferencesCursor: shared.ReferencesCursor{Phase: "local"}})
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedItems := []shared.CallHierarchyItem{
+		{
+			Symbol:     "pkg/f().",
+			Location:   types.UploadLocation{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testLineRange(10)},
+			FromRanges: []types.Range{testLineRange(20), testLineRange(22)},
+		},
+		{
+			Symbol:     "pkg/g().",
+			Location:   types.UploadLocation{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testLineRange(30)},
+			FromRanges: []types.Range{testLineRange(40)},
+		},
+	}
+	if diff := cmp.Diff(expectedItems, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	if cursor.ReferencesCursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.ReferencesCursor.Phase)
+	}
+
+	if history := mockLsifStore.GetCallableDefinitionsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of calls to GetCallableDefinitions. want=%d have=%d", 2, len(history))
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Definition of the target symbol, followed by the definitions of each symbol called
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 51, Path: "a.go", Range: testLineRange(10)}}, 1, nil)
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 51, Path: "b.go", Range: testLineRange(3)}}, 1, nil)
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 51, Path: "c.go", Range: testLineRange(7)}}, 1, nil)
+
+	mockLsifStore.GetCallableDefinitionsFunc.PushReturn([]shared.SymbolRange{
+		{Symbol: "pkg/f().", Range: testLineRange(10), EnclosingRange: testEnclosingRange(10, 25)},
+		{Symbol: "pkg/g().", Range: testLineRange(30), EnclosingRange: testEnclosingRange(30, 45)},
+	}, nil)
+	mockLsifStore.GetCallSitesFunc.PushReturn([]shared.SymbolRange{
+		{Symbol: "pkg/h().", Range: testLineRange(12)},
+		{Symbol: "pkg/h().", Range: testLineRange(14)},
+		{Symbol: "pkg/i().", Range: testLineRange(16)},
+		{Symbol: "pkg/j().", Range: testLineRange(26)}, // outside of the body of f
+	}, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	items, cursor, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, shared.OutgoingCallsCursor{Phase: "definitions"})
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedItems := []shared.CallHierarchyItem{
+		{
+			Symbol:     "pkg/h().",
+			Location:   types.UploadLocation{Dump: uploads[0], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testLineRange(3)},
+			FromRanges: []types.Range{testLineRange(12), testLineRange(14)},
+		},
+		{
+			Symbol:     "pkg/i().",
+			Location:   types.UploadLocation{Dump: uploads[0], Path: "sub2/c.go", TargetCommit: mockCommit, TargetRange: testLineRange(7)},
+			FromRanges: []types.Range{testLineRange(16)},
+		},
+	}
+	if diff := cmp.Diff(expectedItems, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+	}
+
+	if history := mockLsifStore.GetCallSitesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetCallSites. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg3 != 10 || history[0].Arg4 != 26 {
+		t.Errorf("unexpected call site span. want=%d-%d have=%d-%d", 10, 26, history[0].Arg3, history[0].Arg4)
+	}
+
+	// The definitions of h are resolved once for both of its call sites
+	if history := mockLsifStore.GetDefinitionLocationsFunc.History(); len(history) != 3 {
+		t.Errorf("unexpected number of calls to GetDefinitionLocations. want=%d have=%d", 3, len(history))
+	}
+}
+
+func TestOutgoingCallsDefaultLimit(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 51, Path: "a.go", Range: testLineRange(10)}}, 1, nil)
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 51, Path: "b.go", Range: testLineRange(3)}}, 1, nil)
+
+	mockLsifStore.GetCallableDefinitionsFunc.PushReturn([]shared.SymbolRange{
+		{Symbol: "pkg/f().", Range: testLineRange(10), EnclosingRange: testEnclosingRange(10, 25)},
+	}, nil)
+	mockLsifStore.GetCallSitesFunc.PushReturn([]shared.SymbolRange{
+		{Symbol: "pkg/h().", Range: testLineRange(12)},
+	}, nil)
+
+	// No limit is supplied
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+	}
+	items, cursor, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, shared.OutgoingCallsCursor{Phase: "definitions"})
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedItems := []shared.CallHierarchyItem{
+		{
+			Symbol:     "pkg/h().",
+			Location:   types.UploadLocation{Dump: uploads[0], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testLineRange(3)},
+			FromRanges: []types.Range{testLineRange(12)},
+		},
+	}
+	if diff := cmp.Diff(expectedItems, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+	}
+}
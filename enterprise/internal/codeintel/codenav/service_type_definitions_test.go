@@ -0,0 +1,240 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestTypeDefinitions(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// The first upload has no type definitions for the target position
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+	}
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn(nil, 0, nil)
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	adjustedLocations, cursor, err := svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState, shared.RelatedDefinitionsCursor{Phase: "local"})
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+	expectedLocations := []types.UploadLocation{
+		{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+	}
+
+	if history := mockLsifStore.GetTypeDefinitionLocationsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of calls to GetTypeDefinitionLocations. want=%d have=%d", 2, len(history))
+	}
+}
+
+func TestTypeDefinitionsPaged(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 50, Path: "a.go", Range: testRange1}}, 2, nil)
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn([]shared.Location{{DumpID: 50, Path: "b.go", Range: testRange2}}, 2, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        1,
+	}
+	adjustedLocations, cursor, err := svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState, shared.RelatedDefinitionsCursor{Phase: "local"})
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+	expectedLocations := []types.UploadLocation{
+		{Dump: uploads[0], Path: "sub1/a.go", TargetCommit: mockCommit, TargetRange: testRange1},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "local" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "local", cursor.Phase)
+	}
+
+	adjustedLocations, cursor, err = svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+	expectedLocations = []types.UploadLocation{
+		{Dump: uploads[0], Path: "sub1/b.go", TargetCommit: mockCommit, TargetRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+	}
+
+	if history := mockLsifStore.GetTypeDefinitionLocationsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of calls to GetTypeDefinitionLocations. want=%d have=%d", 2, len(history))
+	} else if history[1].Arg6 != 1 {
+		t.Errorf("unexpected offset. want=%d have=%d", 1, history[1].Arg6)
+	}
+	if history := mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected moniker search. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestTypeDefinitionsRemote(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{ID: 42}, mockCommit, mockPath, hunkCache)
+	mockRequestState.GitTreeTranslator = mockedGitTreeTranslator()
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	dumps := []types.Dump{
+		{ID: 151, Commit: "deadbeef1", Root: "sub2/"},
+	}
+	mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.PushReturn(dumps, nil)
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	// There are no local type definitions, only the typeDefinition moniker is searched for
+	monikers := []precise.MonikerData{
+		{Kind: "export", Scheme: "gomod", Identifier: "github.com/sourcegraph/example:reader", PackageInformationID: "51"},
+		{Kind: "typeDefinition", Scheme: "gomod", Identifier: "io:Reader", PackageInformationID: "52"},
+	}
+	mockLsifStore.GetMonikersByPositionFunc.PushReturn([][]precise.MonikerData{monikers}, nil)
+
+	packageInformation := precise.PackageInformationData{Name: "io", Version: "v1.0.0"}
+	mockLsifStore.GetPackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	locations := []shared.Location{
+		{DumpID: 151, Path: "a.go", Range: testRange1},
+	}
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	adjustedLocations, cursor, err := svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState, shared.RelatedDefinitionsCursor{Phase: "local"})
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+	expectedLocations := []types.UploadLocation{
+		{Dump: dumps[0], Path: "sub2/a.go", TargetCommit: "deadbeef1", TargetRange: testRange1},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+	}
+
+	if history := mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for GetDumpsWithDefinitionsForMonikers. want=%d have=%d", 1, len(history))
+	} else {
+		expectedMonikers := []precise.QualifiedMonikerData{
+			{MonikerData: monikers[1], PackageInformationData: packageInformation},
+		}
+		if diff := cmp.Diff(expectedMonikers, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+
+	if history := mockLsifStore.GetBulkMonikerLocationsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for GetBulkMonikerLocations. want=%d have=%d", 1, len(history))
+	} else {
+		if history[0].Arg1 != "definitions" {
+			t.Errorf("unexpected table. want=%q have=%q", "definitions", history[0].Arg1)
+		}
+		if diff := cmp.Diff([]int{151}, history[0].Arg2); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]precise.MonikerData{monikers[1]}, history[0].Arg3); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -59,6 +59,23 @@ type CodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// SymbolRange pairs a range within a document with the symbol occurring at that range. For the
+// definition of a callable symbol, EnclosingRange spans the whole definition including its body.
+type SymbolRange struct {
+	Symbol         string
+	Range          types.Range
+	EnclosingRange types.Range
+}
+
+// CallHierarchyItem pairs the definition of a callable symbol with the ranges at which it calls, or
+// is called by, the symbol at the requested position. The definition location and the call ranges
+// have been adjusted to fit the target (originally requested) commit.
+type CallHierarchyItem struct {
+	Symbol     string
+	Location   types.UploadLocation
+	FromRanges []types.Range
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
@@ -80,6 +97,34 @@ type ImplementationsCursor struct {
 	RemoteCursor                  RemoteCursor                   `json:"remoteCursor"`
 }
 
+// RelatedDefinitionsCursor stores (enough of) the state of a previous TypeDefinitions or Prototypes
+// request used to calculate the offset into the result set to be returned by the current request.
+type RelatedDefinitionsCursor struct {
+	CursorsToVisibleUploads []CursorToVisibleUpload        `json:"visibleUploads"`
+	OrderedMonikers         []precise.QualifiedMonikerData `json:"orderedMonikers"`
+	Phase                   string                         `json:"phase"`
+	LocalCursor             LocalCursor                    `json:"localCursor"`
+	HasLocalLocations       bool                           `json:"hasLocalLocations"`
+	RemoteOffset            int                            `json:"remoteOffset"`
+}
+
+// IncomingCallsCursor stores (enough of) the state of a previous IncomingCalls request used to
+// calculate the offset into the result set to be returned by the current request. Incoming calls
+// are derived from the references of the target symbol, so this wraps a references cursor.
+type IncomingCallsCursor struct {
+	ReferencesCursor ReferencesCursor `json:"referencesCursor"`
+}
+
+// OutgoingCallsCursor stores (enough of) the state of a previous OutgoingCalls request used to
+// calculate the offset into the result set to be returned by the current request.
+type OutgoingCallsCursor struct {
+	CursorsToVisibleUploads []CursorToVisibleUpload `json:"visibleUploads"`
+	Definitions             []Location              `json:"definitions"`
+	Phase                   string                  `json:"phase"`
+	DefinitionOffset        int                     `json:"definitionOffset"`
+	CallSiteOffset          int                     `json:"callSiteOffset"`
+}
+
 // cursorAdjustedUpload
 type CursorToVisibleUpload struct {
 	DumpID                int            `json:"dumpID"`
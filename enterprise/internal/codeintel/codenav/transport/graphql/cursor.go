@@ -52,3 +52,26 @@ func encodeReferencesCursor(cursor shared.ReferencesCursor) string {
 	rawEncoded, _ := json.Marshal(cursor)
 	return base64.RawURLEncoding.EncodeToString(rawEncoded)
 }
+
+// decodeRelatedDefinitionsCursor is the inverse of encodeRelatedDefinitionsCursor. If the given encoded
+// string is empty, then a fresh cursor is returned.
+func decodeRelatedDefinitionsCursor(rawEncoded string) (shared.RelatedDefinitionsCursor, error) {
+	if rawEncoded == "" {
+		return shared.RelatedDefinitionsCursor{Phase: "local"}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
+	if err != nil {
+		return shared.RelatedDefinitionsCursor{}, err
+	}
+
+	var cursor shared.RelatedDefinitionsCursor
+	err = json.Unmarshal(raw, &cursor)
+	return cursor, err
+}
+
+// encodeRelatedDefinitionsCursor returns an encoding of the given cursor suitable for a URL or a GraphQL token.
+func encodeRelatedDefinitionsCursor(cursor shared.RelatedDefinitionsCursor) string {
+	rawEncoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawEncoded)
+}
@@ -254,7 +254,107 @@ func (r *gitBlobLSIFDataResolver) Implementations(ctx context.Context, args *res
 	return NewLocationConnectionResolver(impls, strPtr(nextCursor), r.locationResolver), nil
 }
 
-// Hover returns the hover text and range for the symbol at the given position.
+// DefaultTypeDefinitionsPageSize is the type definition result page size when no limit is supplied.
+const DefaultTypeDefinitionsPageSize = 100
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the given position.
+func (r *gitBlobLSIFDataResolver) TypeDefinitions(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.LocationConnectionResolver, err error) {
+	limit := derefInt32(args.First, DefaultTypeDefinitionsPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := shared.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.typeDefinitions, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Decode cursor given from previous response or create a new one with default values.
+	// This cursor will be modified in-place to become the cursor used to fetch the subsequent
+	// page of results in this result set.
+	var nextCursor string
+	cursor, err := decodeRelatedDefinitionsCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	locations, locationsCursor, err := r.codeNavSvc.GetTypeDefinitions(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetTypeDefinitions")
+	}
+
+	if locationsCursor.Phase != "done" {
+		nextCursor = encodeRelatedDefinitionsCursor(locationsCursor)
+	}
+
+	if args.Filter != nil && *args.Filter != "" {
+		filtered := locations[:0]
+		for _, loc := range locations {
+			if strings.Contains(loc.Path, *args.Filter) {
+				filtered = append(filtered, loc)
+			}
+		}
+		locations = filtered
+	}
+
+	return NewLocationConnectionResolver(locations, strPtr(nextCursor), r.locationResolver), nil
+}
+
+// DefaultPrototypesPageSize is the prototype result page size when no limit is supplied.
+const DefaultPrototypesPageSize = 100
+
+// Prototypes returns the list of source locations that define the symbols implemented by the symbol at the
+// given position.
+func (r *gitBlobLSIFDataResolver) Prototypes(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.LocationConnectionResolver, err error) {
+	limit := derefInt32(args.First, DefaultPrototypesPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := shared.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.prototypes, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Decode cursor given from previous response or create a new one with default values.
+	// This cursor will be modified in-place to become the cursor used to fetch the subsequent
+	// page of results in this result set.
+	var nextCursor string
+	cursor, err := decodeRelatedDefinitionsCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	locations, locationsCursor, err := r.codeNavSvc.GetPrototypes(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetPrototypes")
+	}
+
+	if locationsCursor.Phase != "done" {
+		nextCursor = encodeRelatedDefinitionsCursor(locationsCursor)
+	}
+
+	if args.Filter != nil && *args.Filter != "" {
+		filtered := locations[:0]
+		for _, loc := range locations {
+			if strings.Contains(loc.Path, *args.Filter) {
+				filtered = append(filtered, loc)
+			}
+		}
+		locations = filtered
+	}
+
+	return NewLocationConnectionResolver(locations, strPtr(nextCursor), r.locationResolver), nil
+}
+
 func (r *gitBlobLSIFDataResolver) Hover(ctx context.Context, args *resolverstubs.LSIFQueryPositionArgs) (_ resolverstubs.HoverResolver, err error) {
 	requestArgs := shared.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character)}
 	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.hover, time.Second, getObservationArgs(requestArgs))
@@ -90,6 +90,120 @@ func TestDefinitions(t *testing.T) {
 	}
 }
 
+func TestTypeDefinitions(t *testing.T) {
+	mockAutoIndexingSvc := NewMockAutoIndexingService()
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := NewGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		mockAutoIndexingSvc,
+		mockUploadsService,
+		mockPolicyService,
+		mockRequestState,
+		observation.NewErrorCollector(),
+		mockOperations,
+	)
+
+	offset := int32(25)
+	mockRelatedDefinitionsCursor := shared.RelatedDefinitionsCursor{Phase: "local"}
+	encodedCursor := encodeRelatedDefinitionsCursor(mockRelatedDefinitionsCursor)
+	mockCursor := base64.StdEncoding.EncodeToString([]byte(encodedCursor))
+
+	args := &resolverstubs.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &mockCursor,
+	}
+
+	if _, err := resolver.TypeDefinitions(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockCodeNavService.GetTypeDefinitionsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetTypeDefinitionsFunc.History()))
+	}
+	if val := mockCodeNavService.GetTypeDefinitionsFunc.History()[0].Arg1; val.Line != 10 {
+		t.Fatalf("unexpected line. want=%v have=%v", 10, val)
+	}
+	if val := mockCodeNavService.GetTypeDefinitionsFunc.History()[0].Arg1; val.Character != 15 {
+		t.Fatalf("unexpected character. want=%d have=%v", 15, val)
+	}
+	if val := mockCodeNavService.GetTypeDefinitionsFunc.History()[0].Arg1; val.Limit != 25 {
+		t.Fatalf("unexpected limit. want=%d have=%v", 25, val)
+	}
+	if val := mockCodeNavService.GetTypeDefinitionsFunc.History()[0].Arg3; val.Phase != "local" {
+		t.Fatalf("unexpected cursor phase. want=%q have=%q", "local", val.Phase)
+	}
+}
+
+func TestPrototypes(t *testing.T) {
+	mockAutoIndexingSvc := NewMockAutoIndexingService()
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := NewGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		mockAutoIndexingSvc,
+		mockUploadsService,
+		mockPolicyService,
+		mockRequestState,
+		observation.NewErrorCollector(),
+		mockOperations,
+	)
+
+	offset := int32(25)
+	mockRelatedDefinitionsCursor := shared.RelatedDefinitionsCursor{Phase: "local"}
+	encodedCursor := encodeRelatedDefinitionsCursor(mockRelatedDefinitionsCursor)
+	mockCursor := base64.StdEncoding.EncodeToString([]byte(encodedCursor))
+
+	args := &resolverstubs.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &mockCursor,
+	}
+
+	if _, err := resolver.Prototypes(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockCodeNavService.GetPrototypesFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetPrototypesFunc.History()))
+	}
+	if val := mockCodeNavService.GetPrototypesFunc.History()[0].Arg1; val.Line != 10 {
+		t.Fatalf("unexpected line. want=%v have=%v", 10, val)
+	}
+	if val := mockCodeNavService.GetPrototypesFunc.History()[0].Arg1; val.Character != 15 {
+		t.Fatalf("unexpected character. want=%d have=%v", 15, val)
+	}
+	if val := mockCodeNavService.GetPrototypesFunc.History()[0].Arg1; val.Limit != 25 {
+		t.Fatalf("unexpected limit. want=%d have=%v", 25, val)
+	}
+	if val := mockCodeNavService.GetPrototypesFunc.History()[0].Arg3; val.Phase != "local" {
+		t.Fatalf("unexpected cursor phase. want=%q have=%q", "local", val.Phase)
+	}
+}
+
 func TestReferences(t *testing.T) {
 	mockAutoIndexingSvc := NewMockAutoIndexingService()
 	mockUploadsService := NewMockUploadsService()
@@ -851,6 +851,9 @@ type MockCodeNavService struct {
 	// GetImplementationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetImplementations.
 	GetImplementationsFunc *CodeNavServiceGetImplementationsFunc
+	// GetPrototypesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPrototypes.
+	GetPrototypesFunc *CodeNavServiceGetPrototypesFunc
 	// GetRangesFunc is an instance of a mock function object controlling
 	// the behavior of the method GetRanges.
 	GetRangesFunc *CodeNavServiceGetRangesFunc
@@ -860,6 +863,9 @@ type MockCodeNavService struct {
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *CodeNavServiceGetStencilFunc
+	// GetTypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTypeDefinitions.
+	GetTypeDefinitionsFunc *CodeNavServiceGetTypeDefinitionsFunc
 	// GetUnsafeDBFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUnsafeDB.
 	GetUnsafeDBFunc *CodeNavServiceGetUnsafeDBFunc
@@ -899,6 +905,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetPrototypesFunc: &CodeNavServiceGetPrototypesFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) (r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+				return
+			},
+		},
 		GetRangesFunc: &CodeNavServiceGetRangesFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, int, int) (r0 []shared1.AdjustedCodeIntelligenceRange, r1 error) {
 				return
@@ -914,6 +925,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetTypeDefinitionsFunc: &CodeNavServiceGetTypeDefinitionsFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) (r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+				return
+			},
+		},
 		GetUnsafeDBFunc: &CodeNavServiceGetUnsafeDBFunc{
 			defaultHook: func() (r0 database.DB) {
 				return
@@ -956,6 +972,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetImplementations")
 			},
 		},
+		GetPrototypesFunc: &CodeNavServiceGetPrototypesFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+				panic("unexpected invocation of MockCodeNavService.GetPrototypes")
+			},
+		},
 		GetRangesFunc: &CodeNavServiceGetRangesFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, int, int) ([]shared1.AdjustedCodeIntelligenceRange, error) {
 				panic("unexpected invocation of MockCodeNavService.GetRanges")
@@ -971,6 +992,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetStencil")
 			},
 		},
+		GetTypeDefinitionsFunc: &CodeNavServiceGetTypeDefinitionsFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+				panic("unexpected invocation of MockCodeNavService.GetTypeDefinitions")
+			},
+		},
 		GetUnsafeDBFunc: &CodeNavServiceGetUnsafeDBFunc{
 			defaultHook: func() database.DB {
 				panic("unexpected invocation of MockCodeNavService.GetUnsafeDB")
@@ -1002,6 +1028,9 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetImplementationsFunc: &CodeNavServiceGetImplementationsFunc{
 			defaultHook: i.GetImplementations,
 		},
+		GetPrototypesFunc: &CodeNavServiceGetPrototypesFunc{
+			defaultHook: i.GetPrototypes,
+		},
 		GetRangesFunc: &CodeNavServiceGetRangesFunc{
 			defaultHook: i.GetRanges,
 		},
@@ -1011,6 +1040,9 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
+		GetTypeDefinitionsFunc: &CodeNavServiceGetTypeDefinitionsFunc{
+			defaultHook: i.GetTypeDefinitions,
+		},
 		GetUnsafeDBFunc: &CodeNavServiceGetUnsafeDBFunc{
 			defaultHook: i.GetUnsafeDB,
 		},
@@ -1714,6 +1746,124 @@ func (c CodeNavServiceGetImplementationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// CodeNavServiceGetPrototypesFunc describes the behavior when the
+// GetPrototypes method of the parent MockCodeNavService instance is
+// invoked.
+type CodeNavServiceGetPrototypesFunc struct {
+	defaultHook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)
+	hooks       []func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)
+	history     []CodeNavServiceGetPrototypesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPrototypes delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetPrototypes(v0 context.Context, v1 shared1.RequestArgs, v2 codenav.RequestState, v3 shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+	r0, r1, r2 := m.GetPrototypesFunc.nextHook()(v0, v1, v2, v3)
+	m.GetPrototypesFunc.appendCall(CodeNavServiceGetPrototypesFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetPrototypes method
+// of the parent MockCodeNavService instance is invoked and the hook queue
+// is empty.
+func (f *CodeNavServiceGetPrototypesFunc) SetDefaultHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPrototypes method of the parent MockCodeNavService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeNavServiceGetPrototypesFunc) PushHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetPrototypesFunc) SetDefaultReturn(r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetPrototypesFunc) PushReturn(r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+	f.PushHook(func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CodeNavServiceGetPrototypesFunc) nextHook() func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetPrototypesFunc) appendCall(r0 CodeNavServiceGetPrototypesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeNavServiceGetPrototypesFuncCall objects
+// describing the invocations of this function.
+func (f *CodeNavServiceGetPrototypesFunc) History() []CodeNavServiceGetPrototypesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetPrototypesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetPrototypesFuncCall is an object that describes an
+// invocation of method GetPrototypes on an instance of MockCodeNavService.
+type CodeNavServiceGetPrototypesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.RequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 codenav.RequestState
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 shared1.RelatedDefinitionsCursor
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.UploadLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 shared1.RelatedDefinitionsCursor
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetPrototypesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetPrototypesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // CodeNavServiceGetRangesFunc describes the behavior when the GetRanges
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetRangesFunc struct {
@@ -2060,6 +2210,126 @@ func (c CodeNavServiceGetStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeNavServiceGetTypeDefinitionsFunc describes the behavior when the
+// GetTypeDefinitions method of the parent MockCodeNavService instance is
+// invoked.
+type CodeNavServiceGetTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)
+	hooks       []func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)
+	history     []CodeNavServiceGetTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetTypeDefinitions(v0 context.Context, v1 shared1.RequestArgs, v2 codenav.RequestState, v3 shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+	r0, r1, r2 := m.GetTypeDefinitionsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetTypeDefinitionsFunc.appendCall(CodeNavServiceGetTypeDefinitionsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetTypeDefinitions
+// method of the parent MockCodeNavService instance is invoked and the hook
+// queue is empty.
+func (f *CodeNavServiceGetTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTypeDefinitions method of the parent MockCodeNavService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeNavServiceGetTypeDefinitionsFunc) PushHook(hook func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetTypeDefinitionsFunc) SetDefaultReturn(r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetTypeDefinitionsFunc) PushReturn(r0 []types.UploadLocation, r1 shared1.RelatedDefinitionsCursor, r2 error) {
+	f.PushHook(func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CodeNavServiceGetTypeDefinitionsFunc) nextHook() func(context.Context, shared1.RequestArgs, codenav.RequestState, shared1.RelatedDefinitionsCursor) ([]types.UploadLocation, shared1.RelatedDefinitionsCursor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetTypeDefinitionsFunc) appendCall(r0 CodeNavServiceGetTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeNavServiceGetTypeDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeNavServiceGetTypeDefinitionsFunc) History() []CodeNavServiceGetTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetTypeDefinitionsFuncCall is an object that describes an
+// invocation of method GetTypeDefinitions on an instance of
+// MockCodeNavService.
+type CodeNavServiceGetTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.RequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 codenav.RequestState
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 shared1.RelatedDefinitionsCursor
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.UploadLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 shared1.RelatedDefinitionsCursor
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // CodeNavServiceGetUnsafeDBFunc describes the behavior when the GetUnsafeDB
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetUnsafeDBFunc struct {
@@ -131,3 +131,50 @@ func dedupeRanges(ranges []types.Range) []types.Range {
 	}
 	return dedup
 }
+
+// positionPrecedes returns true if the position a occurs strictly before the position b.
+func positionPrecedes(a, b types.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+// rangeContains returns true if the range inner lies entirely within the range outer.
+func rangeContains(outer, inner types.Range) bool {
+	return !positionPrecedes(inner.Start, outer.Start) && !positionPrecedes(outer.End, inner.End)
+}
+
+// enclosingDefinition returns the innermost callable definition (of the given list) whose enclosing
+// range contains the given range. A false-valued flag is returned if there is no such definition, or
+// if the given range is the definition itself.
+func enclosingDefinition(definitions []shared.SymbolRange, r types.Range) (shared.SymbolRange, bool) {
+	var enclosing shared.SymbolRange
+	found := false
+
+	for _, definition := range definitions {
+		if !rangeContains(definition.EnclosingRange, r) {
+			continue
+		}
+		if found && !rangeContains(enclosing.EnclosingRange, definition.EnclosingRange) {
+			continue
+		}
+
+		enclosing, found = definition, true
+	}
+
+	if !found || enclosing.Range == r {
+		return shared.SymbolRange{}, false
+	}
+
+	return enclosing, true
+}
+
+// definitionEnclosingRange returns the enclosing range of the callable definition (of the given list)
+// at the given range. A false-valued flag is returned if the given range is not one of the definitions.
+func definitionEnclosingRange(definitions []shared.SymbolRange, r types.Range) (types.Range, bool) {
+	for _, definition := range definitions {
+		if definition.Range == r {
+			return definition.EnclosingRange, true
+		}
+	}
+
+	return types.Range{}, false
+}
@@ -106,6 +106,60 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigAzure(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":            "Azure",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_NAME": "devstoreaccount1",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_KEY":  "account-key",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ENDPOINT":     "http://azurite:10000/devstoreaccount1",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.AzureAccountName != "devstoreaccount1" {
+		t.Errorf("unexpected value for Azure.AccountName. want=%s have=%s", "devstoreaccount1", config.AzureAccountName)
+	}
+	if config.AzureAccountKey != "account-key" {
+		t.Errorf("unexpected value for Azure.AccountKey. want=%s have=%s", "account-key", config.AzureAccountKey)
+	}
+	if config.AzureEndpoint != "http://azurite:10000/devstoreaccount1" {
+		t.Errorf("unexpected value for Azure.Endpoint. want=%s have=%s", "http://azurite:10000/devstoreaccount1", config.AzureEndpoint)
+	}
+}
+
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":         "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_ROOT": "/mnt/uploads",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.FilesystemRoot != "/mnt/uploads" {
+		t.Errorf("unexpected value for Filesystem.Root. want=%s have=%s", "/mnt/uploads", config.FilesystemRoot)
+	}
+
+	config = Config{}
+	config.SetMockGetter(mapGetter(map[string]string{"PRECISE_CODE_INTEL_UPLOAD_BACKEND": "Filesystem"}))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("expected a validation error for a missing filesystem root")
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
@@ -82,6 +82,14 @@ var (
 		Name: "scip-ruby",
 		URN:  "github.com/sourcegraph/scip-ruby",
 	}
+	scipDotnet = CodeIntelIndexer{
+		Name: "scip-dotnet",
+		URN:  "github.com/sourcegraph/scip-dotnet",
+	}
+	scipPHP = CodeIntelIndexer{
+		Name: "scip-php",
+		URN:  "github.com/davidrjenni/scip-php",
+	}
 )
 
 var AllIndexers = []CodeIntelIndexer{
@@ -104,6 +112,8 @@ var AllIndexers = []CodeIntelIndexer{
 	lsifTerraform,
 	lsifDotnet,
 	scipRuby,
+	scipDotnet,
+	scipPHP,
 }
 
 // A map of file extension to a list of indexers in order of recommendation
@@ -129,9 +139,9 @@ var LanguageToIndexer = map[string][]CodeIntelIndexer{
 	".py":      {scipPython},
 	".ml":      {lsifOcaml},
 	".rs":      {rustAnalyzer},
-	".php":     {lsifPHP},
+	".php":     {scipPHP, lsifPHP},
 	".tf":      {lsifTerraform},
-	".cs":      {lsifDotnet},
+	".cs":      {scipDotnet, lsifDotnet},
 	".rb":      {scipRuby},
 }
 
@@ -142,9 +152,11 @@ var ImageToIndexer = map[string]CodeIntelIndexer{
 	"sourcegraph/lsif-node":       lsifNode,
 	"sourcegraph/lsif-clang":      lsifClang,
 	"davidrjenni/lsif-php":        lsifPHP,
+	"davidrjenni/scip-php":        scipPHP,
 	"sourcegraph/lsif-rust":       rustAnalyzer,
 	"sourcegraph/scip-python":     scipPython,
 	"sourcegraph/scip-ruby":       scipRuby,
+	"sourcegraph/scip-dotnet":     scipDotnet,
 }
 
 var PreferredIndexers = map[string]CodeIntelIndexer{
@@ -167,4 +179,6 @@ var PreferredIndexers = map[string]CodeIntelIndexer{
 	"lsif-terraform":  lsifTerraform,
 	"lsif-dotnet":     lsifDotnet,
 	"scip-ruby":       scipRuby,
+	"scip-dotnet":     scipDotnet,
+	"scip-php":        scipPHP,
 }
@@ -78,11 +78,24 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
-
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+	var (
+		displayResults             []*DisplayResult
+		totalCount, truncatedCount int
+	)
+	if args.ContentChanges != nil {
+		var truncatedChanges []contentChange
+		truncatedChanges, totalCount, truncatedCount = truncateContentChanges(args.ContentChanges, 5)
+		displayResults = make([]*DisplayResult, len(truncatedChanges))
+		for i, change := range truncatedChanges {
+			displayResults[i] = contentChangeToDisplayResult(change, args.ExternalURL)
+		}
+	} else {
+		var truncatedResults []*result.CommitMatch
+		truncatedResults, totalCount, truncatedCount = truncateResults(args.Results, 5)
+		displayResults = make([]*DisplayResult, len(truncatedResults))
+		for i, result := range truncatedResults {
+			displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -114,7 +127,7 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 			ResultType: "Test",
 			RepoName:   "testorg/testrepo",
 			CommitID:   "0000000",
-			CommitURL:  "",
+			URL:        "",
 			Content:    "This is a test\nfor a code monitoring result.",
 		}},
 		DisplayMoreLink: false,
@@ -155,6 +168,15 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+// getFileURL returns the URL of the file at the given path, or of the
+// repository if the path is empty.
+func getFileURL(externalURL *url.URL, repoName, path, utmSource string) string {
+	if path == "" {
+		return sourcegraphURL(externalURL, repoName, "", utmSource)
+	}
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/blob/%s", repoName, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -199,10 +221,12 @@ func pluralize(word string, count int) string {
 
 type DisplayResult struct {
 	ResultType string
-	CommitURL  string
+	URL        string
 	RepoName   string
 	CommitID   string
-	Content    string
+	// Path is only set for matches of monitors over file content.
+	Path    string
+	Content string
 }
 
 func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayResult {
@@ -220,9 +244,19 @@ func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayR
 
 	return &DisplayResult{
 		ResultType: resultType,
-		CommitURL:  getCommitURL(externalURL, string(result.Repo.Name), string(result.Commit.ID), utmSourceEmail),
+		URL:        getCommitURL(externalURL, string(result.Repo.Name), string(result.Commit.ID), utmSourceEmail),
 		RepoName:   string(result.Repo.Name),
 		CommitID:   result.Commit.ID.Short(),
 		Content:    content,
 	}
 }
+
+func contentChangeToDisplayResult(change contentChange, externalURL *url.URL) *DisplayResult {
+	return &DisplayResult{
+		ResultType: change.resultType(),
+		URL:        getFileURL(externalURL, string(change.RepoName), change.Path, utmSourceEmail),
+		RepoName:   string(change.RepoName),
+		Path:       change.Path,
+		Content:    change.Preview,
+	}
+}
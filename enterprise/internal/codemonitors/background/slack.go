@@ -11,13 +11,14 @@ import (
 
 	"github.com/slack-go/slack"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func sendSlackNotification(ctx context.Context, url string, args actionArgs) error {
-	return postSlackWebhook(ctx, httpcli.ExternalDoer, url, slackPayload(args))
+	return PostSlackWebhook(ctx, httpcli.ExternalDoer, url, slackPayload(args))
 }
 
 func slackPayload(args actionArgs) *slack.WebhookMessage {
@@ -25,6 +26,10 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
 
+	if args.ContentChanges != nil {
+		return slackContentPayload(args)
+	}
+
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
 
 	blocks := []slack.Block{
@@ -81,6 +86,62 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
 }
 
+func slackContentPayload(args actionArgs) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	truncatedChanges, totalCount, truncatedCount := truncateContentChanges(args.ContentChanges, 5)
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, *%s*, detected *%d* changed matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, change := range truncatedChanges {
+			location := string(change.RepoName)
+			if change.Path != "" {
+				location += "/" + change.Path
+			}
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"%s match: <%s|%s>",
+				change.resultType(),
+				getFileURL(args.ExternalURL, string(change.RepoName), change.Path, args.UTMSource),
+				location,
+			)))
+			if change.Preview != "" {
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(change.Preview)))
+			}
+		}
+		if truncatedCount > 0 {
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"...and <%s|%d more matches>.",
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+				truncatedCount,
+			)))
+		}
+	} else {
+		blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+			"<%s|View results>",
+			getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		)))
+	}
+
+	blocks = append(blocks,
+		newMarkdownSection(fmt.Sprintf(
+			`If you are %s, you can <%s|edit your code monitor>`,
+			args.MonitorOwnerName,
+			getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		)),
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
 func formatCodeBlock(s string) string {
 	return fmt.Sprintf("```%s```", strings.ReplaceAll(s, "```", "\\`\\`\\`"))
 }
@@ -114,8 +175,42 @@ func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result
 	return output, totalCount, totalCount - outputCount
 }
 
+// contentChange is a content match that appeared or disappeared since the
+// previous run of a code monitor.
+type contentChange struct {
+	*edb.ContentMatch
+	Removed bool
+}
+
+func (c contentChange) resultType() string {
+	if c.Removed {
+		return "Removed"
+	}
+	return "New"
+}
+
+// truncateContentChanges returns at most maxResults changes, listing added
+// matches before removed ones.
+func truncateContentChanges(changes *edb.ContentMatchChanges, maxResults int) (_ []contentChange, totalCount, truncatedCount int) {
+	output := make([]contentChange, 0, changes.Len())
+	for _, m := range changes.Added {
+		output = append(output, contentChange{ContentMatch: m})
+	}
+	for _, m := range changes.Removed {
+		output = append(output, contentChange{ContentMatch: m, Removed: true})
+	}
+
+	totalCount = len(output)
+	if totalCount > maxResults {
+		output = output[:maxResults]
+	}
+	return output, totalCount, totalCount - len(output)
+}
+
+// PostSlackWebhook posts msg to the Slack incoming webhook at url.
+//
 // adapted from slack.PostWebhookCustomHTTPContext
-func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
+func PostSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
 	raw, err := json.Marshal(msg)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
@@ -161,5 +256,5 @@ func SendTestSlackWebhook(ctx context.Context, doer httpcli.Doer, description, u
 		),
 	}}}
 
-	return postSlackWebhook(ctx, doer, url, testMessage)
+	return PostSlackWebhook(ctx, doer, url, testMessage)
 }
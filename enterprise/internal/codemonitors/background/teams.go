@@ -0,0 +1,190 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// teamsMessage is a message posted to a Microsoft Teams incoming webhook. See
+// https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []teamsTextBlock     `json:"body"`
+	Actions []teamsOpenURLAction `json:"actions,omitempty"`
+}
+
+type teamsTextBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Wrap     bool   `json:"wrap"`
+	FontType string `json:"fontType,omitempty"`
+}
+
+type teamsOpenURLAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func newTeamsMessage(body []teamsTextBlock, actions ...teamsOpenURLAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+			},
+		}},
+	}
+}
+
+func newTeamsText(s string) teamsTextBlock {
+	return teamsTextBlock{Type: "TextBlock", Text: s, Wrap: true}
+}
+
+func newTeamsCode(s string) teamsTextBlock {
+	return teamsTextBlock{Type: "TextBlock", Text: s, Wrap: true, FontType: "Monospace"}
+}
+
+func newTeamsOpenURL(title, url string) teamsOpenURLAction {
+	return teamsOpenURLAction{Type: "Action.OpenUrl", Title: title, URL: url}
+}
+
+// escapeTeamsMarkdown escapes the characters that Teams interprets as
+// markdown in text blocks.
+func escapeTeamsMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postTeamsWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	if args.ContentChanges != nil {
+		return teamsContentPayload(args)
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	body := []teamsTextBlock{
+		newTeamsText(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			escapeTeamsMarkdown(args.MonitorOwnerName),
+			escapeTeamsMarkdown(args.MonitorDescription),
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"%s match: [%s@%s](%s)",
+				resultType,
+				escapeTeamsMarkdown(string(result.Repo.Name)),
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)))
+			if result.DiffPreview != nil {
+				body = append(body, newTeamsCode(truncateString(result.DiffPreview.Content, 10)))
+			} else {
+				body = append(body, newTeamsCode(truncateString(result.MessagePreview.Content, 10)))
+			}
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	return newTeamsMessage(body,
+		newTeamsOpenURL("View results", getSearchURL(args.ExternalURL, args.Query, args.UTMSource)),
+		newTeamsOpenURL("Edit code monitor", getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource)),
+	)
+}
+
+func teamsContentPayload(args actionArgs) *teamsMessage {
+	truncatedChanges, totalCount, truncatedCount := truncateContentChanges(args.ContentChanges, 5)
+
+	body := []teamsTextBlock{
+		newTeamsText(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** changed matches.",
+			escapeTeamsMarkdown(args.MonitorOwnerName),
+			escapeTeamsMarkdown(args.MonitorDescription),
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, change := range truncatedChanges {
+			location := string(change.RepoName)
+			if change.Path != "" {
+				location += "/" + change.Path
+			}
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"%s match: [%s](%s)",
+				change.resultType(),
+				escapeTeamsMarkdown(location),
+				getFileURL(args.ExternalURL, string(change.RepoName), change.Path, args.UTMSource),
+			)))
+			if change.Preview != "" {
+				body = append(body, newTeamsCode(change.Preview))
+			}
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	return newTeamsMessage(body,
+		newTeamsOpenURL("View results", getSearchURL(args.ExternalURL, args.Query, args.UTMSource)),
+		newTeamsOpenURL("Edit code monitor", getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource)),
+	)
+}
+
+func postTeamsWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *teamsMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	return PostWebhookBody(ctx, doer, url, raw, nil, "")
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]teamsTextBlock{
+		newTeamsText(fmt.Sprintf("Test message for Code Monitor '%s'", escapeTeamsMarkdown(description))),
+	})
+	return postTeamsWebhook(ctx, doer, url, testMessage)
+}
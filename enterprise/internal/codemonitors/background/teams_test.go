@@ -0,0 +1,109 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestTeamsWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My *test* monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	texts := func(msg *teamsMessage) []string {
+		var out []string
+		for _, block := range msg.Attachments[0].Content.Body {
+			out = append(out, block.Text)
+		}
+		return out
+	}
+
+	t.Run("without results", func(t *testing.T) {
+		msg := teamsPayload(action)
+		require.Equal(t, "message", msg.Type)
+		require.Len(t, msg.Attachments, 1)
+		require.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+		require.Equal(t, []string{
+			`Camden Cheek's Sourcegraph Code monitor, **My \*test\* monitor**, detected **3** new matches.`,
+		}, texts(msg))
+
+		actions := msg.Attachments[0].Content.Actions
+		require.Len(t, actions, 2)
+		require.Equal(t, "https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6NDI=?utm_source=", actions[1].URL)
+	})
+
+	t.Run("with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+
+		msg := teamsPayload(actionCopy)
+		body := msg.Attachments[0].Content.Body
+		// Header, then a link and a preview per result.
+		require.Len(t, body, 5)
+		require.Contains(t, body[1].Text, "Diff match: [github.com/test/test@7815187](")
+		require.Equal(t, "Monospace", body[2].FontType)
+		require.Contains(t, body[3].Text, "Message match: [github.com/test/test@7815187](")
+	})
+
+	t.Run("with content changes", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Results = nil
+		actionCopy.ContentChanges = &edb.ContentMatchChanges{
+			Added: []*edb.ContentMatch{{RepoName: "github.com/test/test", Path: "main.go", Preview: `import "banned"`}},
+		}
+
+		msg := teamsPayload(actionCopy)
+		require.Equal(t, []string{
+			`Camden Cheek's Sourcegraph Code monitor, **My \*test\* monitor**, detected **1** changed matches.`,
+			"New match: [github.com/test/test/main.go](https://sourcegraph.com/github.com/test/test/-/blob/main.go?utm_source=)",
+			`import "banned"`,
+		}, texts(msg))
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer s.Close()
+
+		err := postTeamsWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		require.Error(t, err)
+	})
+}
+
+func TestTriggerTestTeamsWebhookAction(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var msg teamsMessage
+		require.NoError(t, json.Unmarshal(b, &msg))
+		require.Equal(t, "Test message for Code Monitor 'My test monitor'", msg.Attachments[0].Content.Body[0].Text)
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	err := SendTestTeamsWebhook(context.Background(), s.Client(), "My test monitor", s.URL)
+	require.NoError(t, err)
+}
@@ -3,18 +3,32 @@ package background
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"text/template"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
-	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
+// webhookSignatureHeader is the header carrying the HMAC-SHA256 signature of
+// the request body for webhook actions with a signing secret.
+const webhookSignatureHeader = "X-Sourcegraph-Signature"
+
+func sendWebhookNotification(ctx context.Context, w *edb.WebhookAction, args actionArgs) error {
+	raw, err := renderWebhookPayload(w.PayloadTemplate, generateWebhookPayload(args))
+	if err != nil {
+		return err
+	}
+	return PostWebhookBody(ctx, httpcli.ExternalDoer, w.URL, raw, w.Headers, w.SigningSecret)
 }
 
 func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
@@ -22,12 +36,23 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
 	}
+	return PostWebhookBody(ctx, doer, url, raw, nil, "")
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+// PostWebhookBody posts the JSON body to url. The custom headers are added to
+// the request, and the body is signed if signingSecret is not empty.
+func PostWebhookBody(ctx context.Context, doer httpcli.Doer, url string, body []byte, headers map[string]string, signingSecret string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return errors.Wrap(err, "failed new request")
 	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 	req.Header.Set("Content-Type", "application/json")
+	if signingSecret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookBody(signingSecret, body))
+	}
 
 	resp, err := doer.Do(req)
 	if err != nil {
@@ -47,20 +72,131 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	return nil
 }
 
-func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string) error {
+// signWebhookBody returns the value of the signature header for body.
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string, payload *edb.WebhookPayloadArgs) error {
 	args := actionArgs{
 		ExternalURL:        &url.URL{},
 		MonitorDescription: description,
 		Query:              "test query",
 	}
-	return postWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
+	if payload == nil {
+		return postWebhook(ctx, doer, u, generateWebhookPayload(args))
+	}
+
+	raw, err := renderWebhookPayload(payload.PayloadTemplate, generateWebhookPayload(args))
+	if err != nil {
+		return err
+	}
+	return PostWebhookBody(ctx, doer, u, raw, payload.Headers, payload.SigningSecret)
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	// json renders a value as JSON, so that strings can be embedded in the
+	// payload without breaking it.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// renderWebhookPayload renders the body of a webhook request. If tmpl is
+// empty, the default payload is returned. Otherwise, tmpl is executed with
+// the default payload as data and must render valid JSON.
+func renderWebhookPayload(tmpl string, payload webhookPayload) ([]byte, error) {
+	if tmpl == "" {
+		raw, err := json.Marshal(payload)
+		return raw, errors.Wrap(err, "marshal failed")
+	}
+
+	t, err := template.New("payload").Funcs(webhookTemplateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing payload template")
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, payload); err != nil {
+		return nil, errors.Wrap(err, "executing payload template")
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("payload template must render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidateWebhookPayload checks that the payload template renders valid JSON
+// for both commit and content results, and that the header names are valid.
+func ValidateWebhookPayload(payload *edb.WebhookPayloadArgs) error {
+	if payload == nil {
+		return nil
+	}
+
+	for name := range payload.Headers {
+		if !validHeaderName(name) {
+			return errors.Errorf("invalid webhook header name %q", name)
+		}
+		switch http.CanonicalHeaderKey(name) {
+		case "Content-Type", webhookSignatureHeader:
+			return errors.Errorf("webhook header %q cannot be overridden", name)
+		}
+	}
+
+	if payload.PayloadTemplate == "" {
+		return nil
+	}
+	for _, p := range []webhookPayload{testWebhookPayload(false), testWebhookPayload(true)} {
+		if _, err := renderWebhookPayload(payload.PayloadTemplate, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testWebhookPayload returns a payload with placeholder results to validate
+// payload templates against.
+func testWebhookPayload(content bool) webhookPayload {
+	p := webhookPayload{
+		MonitorDescription: "test monitor",
+		MonitorURL:         "https://sourcegraph.example.com/code-monitoring/1",
+		Query:              "test query",
+	}
+	if content {
+		p.ContentChanges = []webhookContentChange{
+			{Repository: "github.com/test/test", Path: "main.go", Preview: "test"},
+			{Repository: "github.com/test/test", Path: "old.go", Preview: "test", Removed: true},
+		}
+	} else {
+		p.Results = []webhookResult{{
+			Repository:           "github.com/test/test",
+			Commit:               "4b1d7c2e9f0a6b3c8d5e1f7a2b9c4d6e8f0a1b3c",
+			Message:              "test",
+			MatchedMessageRanges: [][2]int{{0, 4}},
+		}}
+	}
+	return p
+}
+
+// validHeaderName returns whether name is a valid HTTP header field name.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	return strings.IndexFunc(name, func(r rune) bool {
+		// Token characters as defined by RFC 7230.
+		return r > 0x7e || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r)
+	}) < 0
 }
 
 type webhookPayload struct {
-	MonitorDescription string          `json:"monitorDescription"`
-	MonitorURL         string          `json:"monitorURL"`
-	Query              string          `json:"query"`
-	Results            []webhookResult `json:"results,omitempty"`
+	MonitorDescription string                 `json:"monitorDescription"`
+	MonitorURL         string                 `json:"monitorURL"`
+	Query              string                 `json:"query"`
+	Results            []webhookResult        `json:"results,omitempty"`
+	ContentChanges     []webhookContentChange `json:"contentChanges,omitempty"`
 }
 
 func generateWebhookPayload(args actionArgs) webhookPayload {
@@ -71,7 +207,11 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 	}
 
 	if args.IncludeResults {
-		p.Results = generateResults(args.Results)
+		if args.ContentChanges != nil {
+			p.ContentChanges = generateContentChanges(args.ContentChanges)
+		} else {
+			p.Results = generateResults(args.Results)
+		}
 	}
 
 	return p
@@ -106,6 +246,24 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+type webhookContentChange struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path,omitempty"`
+	Preview    string `json:"preview,omitempty"`
+	Removed    bool   `json:"removed"`
+}
+
+func generateContentChanges(in *edb.ContentMatchChanges) []webhookContentChange {
+	out := make([]webhookContentChange, 0, in.Len())
+	for _, m := range in.Added {
+		out = append(out, webhookContentChange{Repository: string(m.RepoName), Path: m.Path, Preview: m.Preview})
+	}
+	for _, m := range in.Removed {
+		out = append(out, webhookContentChange{Repository: string(m.RepoName), Path: m.Path, Preview: m.Preview, Removed: true})
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
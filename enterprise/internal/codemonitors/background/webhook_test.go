@@ -2,6 +2,9 @@ package background
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -12,6 +15,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -69,6 +73,30 @@ func TestWebhook(t *testing.T) {
 	})
 }
 
+func TestWebhookContentChanges(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              `import "banned"`,
+		ContentChanges: &edb.ContentMatchChanges{
+			Added:   []*edb.ContentMatch{{RepoName: "github.com/test/test", Path: "main.go", Preview: `import "banned"`}},
+			Removed: []*edb.ContentMatch{{RepoName: "github.com/test/test", Path: "old.go", Preview: `import "banned"`}},
+		},
+		IncludeResults: true,
+	}
+
+	payload := generateWebhookPayload(action)
+	require.Empty(t, payload.Results)
+	require.Equal(t, []webhookContentChange{
+		{Repository: "github.com/test/test", Path: "main.go", Preview: `import "banned"`},
+		{Repository: "github.com/test/test", Path: "old.go", Preview: `import "banned"`, Removed: true},
+	}, payload.ContentChanges)
+}
+
 func TestTriggerTestWebhookAction(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
@@ -79,6 +107,102 @@ func TestTriggerTestWebhookAction(t *testing.T) {
 	defer s.Close()
 
 	client := s.Client()
-	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL)
+	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL, nil)
+	require.NoError(t, err)
+}
+
+func TestRenderWebhookPayload(t *testing.T) {
+	payload := webhookPayload{
+		MonitorDescription: `My "test" monitor`,
+		MonitorURL:         "https://sourcegraph.com/code-monitoring/42",
+		Query:              "test query",
+		Results:            []webhookResult{{Repository: "github.com/test/test", Commit: "abc"}},
+	}
+
+	t.Run("default payload", func(t *testing.T) {
+		raw, err := renderWebhookPayload("", payload)
+		require.NoError(t, err)
+
+		want, err := json.Marshal(payload)
+		require.NoError(t, err)
+		require.Equal(t, want, raw)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		tmpl := `{"text": {{ json .MonitorDescription }}, "repos": [{{ range $i, $r := .Results }}{{ if $i }},{{ end }}{{ json $r.Repository }}{{ end }}]}`
+		raw, err := renderWebhookPayload(tmpl, payload)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"text": "My \"test\" monitor", "repos": ["github.com/test/test"]}`, string(raw))
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := renderWebhookPayload(`{"text": "{{ .MonitorDescription }}"}`, payload)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := renderWebhookPayload(`{"text": {{ .Unknown }}}`, payload)
+		require.Error(t, err)
+	})
+}
+
+func TestValidateWebhookPayload(t *testing.T) {
+	for name, tc := range map[string]struct {
+		payload *edb.WebhookPayloadArgs
+		wantErr bool
+	}{
+		"nil": {
+			payload: nil,
+		},
+		"template over results and content changes": {
+			payload: &edb.WebhookPayloadArgs{
+				PayloadTemplate: `{"count": {{ len .Results }}, "changes": {{ len .ContentChanges }}}`,
+			},
+		},
+		"template rendering invalid JSON": {
+			payload: &edb.WebhookPayloadArgs{PayloadTemplate: `{{ .Query }}`},
+			wantErr: true,
+		},
+		"custom header": {
+			payload: &edb.WebhookPayloadArgs{Headers: map[string]string{"Authorization": "Bearer token"}},
+		},
+		"invalid header name": {
+			payload: &edb.WebhookPayloadArgs{Headers: map[string]string{"Bad Header": "value"}},
+			wantErr: true,
+		},
+		"reserved header name": {
+			payload: &edb.WebhookPayloadArgs{Headers: map[string]string{"x-sourcegraph-signature": "value"}},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateWebhookPayload(tc.payload)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostWebhookBodyHeadersAndSignature(t *testing.T) {
+	body := []byte(`{"text": "test"}`)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, b)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(b)
+		require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(webhookSignatureHeader))
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	err := PostWebhookBody(context.Background(), s.Client(), s.URL, body, map[string]string{"Authorization": "Bearer token"}, "secret")
 	require.NoError(t, err)
 }
@@ -18,7 +18,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
-	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -198,13 +197,21 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		return errors.Wrap(searchErr, "execute search")
 	}
 
-	// Log the actual query we ran and whether we got any new results.
-	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results)
-	if err != nil {
-		return errors.Wrap(err, "UpdateTriggerJobWithResults")
+	if results.ContentChanges != nil {
+		// Log the query we ran and how the matches changed since the previous run.
+		err = s.UpdateTriggerJobWithContentChanges(ctx, triggerJob.ID, query, results.ContentChanges)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithContentChanges")
+		}
+	} else {
+		// Log the actual query we ran and whether we got any new results.
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, results.Commits)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithResults")
+		}
 	}
 
-	if len(results) > 0 {
+	if len(results.Commits) > 0 || results.ContentChanges.Len() > 0 {
 		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -232,8 +239,10 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *edb.Act
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.TeamsWebhook != nil:
+		return r.handleTeamsWebhook(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, or teams webhook")
 	}
 }
 
@@ -272,6 +281,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentChanges:     m.ContentChanges,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -325,10 +335,11 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentChanges:     m.ContentChanges,
 		IncludeResults:     w.IncludeResults,
 	}
 
-	return sendWebhookNotification(ctx, w.URL, args)
+	return sendWebhookNotification(ctx, w, args)
 }
 
 func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob) error {
@@ -361,12 +372,50 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentChanges:     m.ContentChanges,
 		IncludeResults:     w.IncludeResults,
 	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *edb.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		ContentChanges:     m.ContentChanges,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -396,8 +445,8 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
-	if searchErr != nil || len(results) == 0 {
+func latestResultTime(previousLastResult *time.Time, results *codemonitors.SearchResults, searchErr error) time.Time {
+	if searchErr != nil || (len(results.Commits) == 0 && results.ContentChanges.Len() == 0) {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
@@ -406,8 +455,8 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	if len(results.Commits) > 0 && results.Commits[0].Commit.Committer != nil {
+		return results.Commits[0].Commit.Committer.Date
 	}
 	return time.Now()
 }
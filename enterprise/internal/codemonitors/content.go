@@ -0,0 +1,144 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// isContentJob returns whether the job searches file content, symbols, paths
+// or repositories rather than commits and diffs.
+func isContentJob(j job.Job) bool {
+	return !job.HasDescendent[*commit.SearchJob](j)
+}
+
+// searchContent runs the job and compares its matches to the matches stored
+// by the previous run of the code monitor. On the first run, the matches are
+// only stored, so that a new monitor doesn't trigger on all existing matches.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) (*edb.ContentMatchChanges, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	current := toContentMatches(agg.Results)
+
+	previous, found, err := cm.GetContentMatches(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return &edb.ContentMatchChanges{}, cm.UpsertContentMatches(ctx, monitorID, current)
+	}
+
+	complete := !agg.Stats.IsLimitHit
+	changes := DiffContentMatches(previous, current, complete)
+	if !complete {
+		// We don't know whether the matches we didn't get still exist, so we
+		// keep them around until a complete run tells us otherwise.
+		current = append(previous, changes.Added...)
+	}
+	if err := cm.UpsertContentMatches(ctx, monitorID, current); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// DiffContentMatches returns the matches of current that are not in previous
+// and the matches of previous that are not in current. If complete is false,
+// current only contains some of the matches of the query, so no matches are
+// reported as removed.
+func DiffContentMatches(previous, current []*edb.ContentMatch, complete bool) *edb.ContentMatchChanges {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, m := range previous {
+		previousSet[m.Fingerprint] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, m := range current {
+		currentSet[m.Fingerprint] = struct{}{}
+	}
+
+	changes := &edb.ContentMatchChanges{}
+	for _, m := range current {
+		if _, ok := previousSet[m.Fingerprint]; !ok {
+			changes.Added = append(changes.Added, m)
+		}
+	}
+	if complete {
+		for _, m := range previous {
+			if _, ok := currentSet[m.Fingerprint]; !ok {
+				changes.Removed = append(changes.Removed, m)
+			}
+		}
+	}
+	return changes
+}
+
+// toContentMatches converts search results to content matches. Every matched
+// line and symbol of a file is a separate match. Matches with the same
+// fingerprint are only included once.
+func toContentMatches(matches result.Matches) []*edb.ContentMatch {
+	var out []*edb.ContentMatch
+	seen := make(map[string]struct{})
+	add := func(repo api.RepoID, repoName api.RepoName, path, preview string, lineNumber int) {
+		fingerprint := contentFingerprint(repo, path, preview)
+		if _, ok := seen[fingerprint]; ok {
+			return
+		}
+		seen[fingerprint] = struct{}{}
+		out = append(out, &edb.ContentMatch{
+			Fingerprint: fingerprint,
+			RepoID:      repo,
+			RepoName:    repoName,
+			Path:        path,
+			Preview:     preview,
+			LineNumber:  lineNumber,
+		})
+	}
+
+	for _, match := range matches {
+		switch m := match.(type) {
+		case *result.FileMatch:
+			if m.IsPathMatch() {
+				add(m.Repo.ID, m.Repo.Name, m.Path, "", 0)
+				continue
+			}
+			for _, lm := range m.ChunkMatches.AsLineMatches() {
+				if len(lm.OffsetAndLengths) == 0 {
+					// Chunks can contain lines without any matches.
+					continue
+				}
+				add(m.Repo.ID, m.Repo.Name, m.Path, lm.Preview, int(lm.LineNumber))
+			}
+			for _, sm := range m.Symbols {
+				add(m.Repo.ID, m.Repo.Name, m.Path, sm.Symbol.Name+" ("+sm.Symbol.Kind+")", sm.Symbol.Line-1)
+			}
+		case *result.RepoMatch:
+			add(m.ID, m.Name, "", "", 0)
+		}
+	}
+	return out
+}
+
+// contentFingerprint identifies a content match independently of its line
+// number, so that matches moving around in a file are not reported as changed.
+func contentFingerprint(repo api.RepoID, path, preview string) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(int(repo))))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(preview))
+	return hex.EncodeToString(h.Sum(nil))
+}
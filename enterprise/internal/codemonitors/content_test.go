@@ -0,0 +1,74 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiffContentMatches(t *testing.T) {
+	a := &edb.ContentMatch{Fingerprint: "a"}
+	b := &edb.ContentMatch{Fingerprint: "b"}
+	c := &edb.ContentMatch{Fingerprint: "c"}
+
+	t.Run("complete", func(t *testing.T) {
+		changes := DiffContentMatches([]*edb.ContentMatch{a, b}, []*edb.ContentMatch{b, c}, true)
+		require.Equal(t, &edb.ContentMatchChanges{
+			Added:   []*edb.ContentMatch{c},
+			Removed: []*edb.ContentMatch{a},
+		}, changes)
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		changes := DiffContentMatches([]*edb.ContentMatch{a, b}, []*edb.ContentMatch{b, c}, false)
+		require.Equal(t, &edb.ContentMatchChanges{
+			Added: []*edb.ContentMatch{c},
+		}, changes)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		changes := DiffContentMatches([]*edb.ContentMatch{a, b}, []*edb.ContentMatch{b, a}, true)
+		require.Equal(t, 0, changes.Len())
+	})
+}
+
+func TestToContentMatches(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/test/test"}
+	file := func(path string, lineNumber int) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{Repo: repo, Path: path},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      `import "banned"`,
+				ContentStart: result.Location{Line: lineNumber},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: lineNumber, Column: 8},
+					End:   result.Location{Line: lineNumber, Column: 14},
+				}},
+			}},
+		}
+	}
+
+	matches := toContentMatches(result.Matches{
+		file("a.go", 3),
+		file("b.go", 3),
+		&result.FileMatch{File: result.File{Repo: repo, Path: "banned.go"}},
+		&result.RepoMatch{ID: repo.ID, Name: repo.Name},
+	})
+	require.Len(t, matches, 4)
+	require.Equal(t, "a.go", matches[0].Path)
+	require.Equal(t, `import "banned"`, matches[0].Preview)
+	require.Equal(t, 3, matches[0].LineNumber)
+	require.Equal(t, "banned.go", matches[2].Path)
+	require.Empty(t, matches[2].Preview)
+	require.Empty(t, matches[3].Path)
+
+	t.Run("fingerprint ignores line numbers", func(t *testing.T) {
+		moved := toContentMatches(result.Matches{file("a.go", 10)})
+		require.Equal(t, matches[0].Fingerprint, moved[0].Fingerprint)
+		require.NotEqual(t, matches[0].Fingerprint, matches[1].Fingerprint)
+	})
+}
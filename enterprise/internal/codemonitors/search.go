@@ -107,7 +107,20 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
+// SearchResults are the results of running the query of a code monitor.
+type SearchResults struct {
+	// Commits are the new commit and diff matches of a monitor over commits.
+	Commits []*result.CommitMatch
+	// ContentChanges are the changes to the matches of a monitor over file
+	// content since its previous run. It is nil for monitors over commits.
+	ContentChanges *edb.ContentMatchChanges
+}
+
+// Search runs the query of the given code monitor. Queries over commits and
+// diffs only search commits that weren't searched by a previous run. Any
+// other query is run over file content, and its matches are compared to the
+// matches of the previous run.
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ *SearchResults, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -130,6 +143,14 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isContentJob(planJob) {
+		changes, err := searchContent(ctx, db, clients, planJob, monitorID)
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResults{ContentChanges: changes}, nil
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, logger, gs, monitorID, repoID, args, doSearch)
@@ -181,12 +202,13 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		results[i] = cm
 	}
 
-	return results, nil
+	return &SearchResults{Commits: results}, nil
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For queries over file content, the stored matches are discarded
+// instead, so that the next run records the current matches without triggering the monitor.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -209,6 +231,10 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if isContentJob(planJob) {
+		return edb.NewEnterpriseDB(db).CodeMonitors().DeleteContentMatches(ctx, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
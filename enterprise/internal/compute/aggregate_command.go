@@ -0,0 +1,110 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// AggregateFunc is the function used to roll up the values of all matches that
+// fall into the same group of an Aggregate command.
+type AggregateFunc string
+
+const (
+	// AggregateCount counts the number of matches in each group.
+	AggregateCount AggregateFunc = "count"
+
+	// AggregateSum sums the numeric value of each match in a group. Matches for
+	// which the value template does not produce a number are ignored.
+	AggregateSum AggregateFunc = "sum"
+)
+
+// Aggregate groups the matches of a search pattern by one or more templates,
+// and rolls up each group with an AggregateFunc. Templates may refer to
+// capture groups of the search pattern (e.g., $1) as well as to the variables
+// of the MetaEnvironment of a match (e.g., $repo, $path, $author).
+type Aggregate struct {
+	SearchPattern MatchPattern
+	Func          AggregateFunc
+
+	// GroupBy are the templates which produce the group key of a match, one
+	// per column of the resulting table.
+	GroupBy []string
+
+	// ValuePattern is the template producing the numeric value of a match.
+	// It is only used by AggregateSum.
+	ValuePattern string
+
+	TypeValue string
+	Kind      string
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Aggregate) String() string {
+	if c.Func == AggregateSum {
+		return fmt.Sprintf("Aggregate %s: (%s) -> (%s) by (%s)", c.Func, c.SearchPattern.String(), c.ValuePattern, strings.Join(c.GroupBy, ", "))
+	}
+	return fmt.Sprintf("Aggregate %s: (%s) by (%s)", c.Func, c.SearchPattern.String(), strings.Join(c.GroupBy, ", "))
+}
+
+// columns returns the column names of tables produced by this command.
+func (c *Aggregate) columns() []string {
+	return append(append([]string{}, c.GroupBy...), string(c.Func))
+}
+
+func (c *Aggregate) Run(_ context.Context, _ database.DB, r result.Match) (Result, error) {
+	onlyPath := c.TypeValue == "path" // don't read file contents for file matches when we only want type:path
+	chunks := resultChunks(r, c.Kind, onlyPath)
+
+	aggregator := NewAggregator(c.columns())
+	for _, content := range chunks {
+		env := NewMetaEnvironment(r, content)
+
+		// Substitute metavariables once per chunk so that only capture
+		// groups remain to be expanded for every match.
+		groupBy := make([]string, 0, len(c.GroupBy))
+		for _, pattern := range c.GroupBy {
+			substituted, err := substituteMetaVariables(pattern, env)
+			if err != nil {
+				return nil, err
+			}
+			groupBy = append(groupBy, substituted)
+		}
+		valuePattern, err := substituteMetaVariables(c.ValuePattern, env)
+		if err != nil {
+			return nil, err
+		}
+
+		switch match := c.SearchPattern.(type) {
+		case *Regexp:
+			for _, submatches := range match.Value.FindAllStringSubmatchIndex(content, -1) {
+				groups := make([]string, 0, len(groupBy))
+				for _, pattern := range groupBy {
+					groups = append(groups, string(match.Value.ExpandString(nil, pattern, content, submatches)))
+				}
+
+				value := 1.0
+				if c.Func == AggregateSum {
+					expanded := string(match.Value.ExpandString(nil, valuePattern, content, submatches))
+					value, err = strconv.ParseFloat(strings.TrimSpace(expanded), 64)
+					if err != nil {
+						// Not a number, so there is nothing to sum.
+						continue
+					}
+				}
+
+				aggregator.add(groups, value)
+			}
+		}
+	}
+
+	table, _ := aggregator.Flush()
+	return table, nil
+}
@@ -0,0 +1,87 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestAggregate(t *testing.T) {
+	test := func(q string, matches ...result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+
+		aggregator := NewAggregator(nil)
+		for _, m := range matches {
+			res, err := computeQuery.Command.Run(context.Background(), database.NewMockDB(), m)
+			if err != nil {
+				return err.Error()
+			}
+			table, ok := res.(*Table)
+			if !ok {
+				return "Error, unrecognized result type returned"
+			}
+			aggregator.Add(table)
+		}
+
+		table, _ := aggregator.Flush()
+		result, _ := json.Marshal(table)
+		return string(result)
+	}
+
+	autogold.Want(
+		"count by capture group",
+		`{"columns":["$1","count"],"rows":[{"groups":["b"],"value":2},{"groups":["a"],"value":1}],"kind":"aggregate"}`).
+		Equal(t, test(`content:aggregate(deprecated\.(\w) -> $1)`, fileMatch("deprecated.a deprecated.b", "deprecated.b")))
+
+	autogold.Want(
+		"count across results by capture group and metavariable",
+		`{"columns":["$1","$author","count"],"rows":[{"groups":["a","bob"],"value":2},{"groups":["a",""],"value":1}],"kind":"aggregate"}`).
+		Equal(t, test(`content:aggregate.count(deprecated\.(\w) -> $1, $author)`, commitMatch("deprecated.a deprecated.a"), fileMatch("deprecated.a")))
+
+	autogold.Want(
+		"sum numeric captures by repo",
+		`{"columns":["$repo","sum"],"rows":[{"groups":["my/awesome/repo"],"value":4.5}],"kind":"aggregate"}`).
+		Equal(t, test(`content:aggregate.sum(cost=(\S+) -> $1 by $repo)`, fileMatch("cost=1 cost=3.5 cost=oops")))
+
+	autogold.Want(
+		"sum requires a group",
+		"invalid aggregate.sum statement, expected `<value> by <group>` on the right hand side of `->`").
+		Equal(t, test(`content:aggregate.sum(cost=(\d+) -> $1)`))
+
+	autogold.Want(
+		"empty group",
+		"aggregate command expects nonempty templates to group by").
+		Equal(t, test(`content:aggregate(foo -> $repo,)`))
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator([]string{"$1", "count"})
+	if _, dirty := aggregator.Flush(); dirty {
+		t.Fatalf("expected empty aggregator not to be dirty")
+	}
+
+	aggregator.Add(&Table{Rows: []Row{{Groups: []string{"a"}, Value: 1}}})
+	aggregator.Add(&Table{Rows: []Row{{Groups: []string{"b"}, Value: 1}, {Groups: []string{"a"}, Value: 2}}})
+
+	table, dirty := aggregator.Flush()
+	if !dirty {
+		t.Fatalf("expected aggregator to be dirty after adding tables")
+	}
+	result, _ := json.Marshal(table)
+	autogold.Want(
+		"merged table",
+		`{"columns":["$1","count"],"rows":[{"groups":["a"],"value":3},{"groups":["b"],"value":1}],"kind":"aggregate"}`).
+		Equal(t, string(result))
+
+	if _, dirty := aggregator.Flush(); dirty {
+		t.Fatalf("expected aggregator not to be dirty after flush")
+	}
+}
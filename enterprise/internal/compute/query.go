@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -104,6 +105,9 @@ var ComputePredicateRegistry = query.PredicateRegistry{
 		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
 		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
 		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate":          func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.count":    func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.sum":      func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -217,6 +221,85 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+var bySyntax = lazyregexp.New(`\s+by\s+`)
+
+// parseGroupBy splits a comma-separated list of group templates.
+func parseGroupBy(args string) ([]string, error) {
+	var groupBy []string
+	for _, pattern := range strings.Split(args, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return nil, errors.New("aggregate command expects nonempty templates to group by")
+		}
+		groupBy = append(groupBy, pattern)
+	}
+	return groupBy, nil
+}
+
+// parseAggregate parses the aggregate commands. Counts are expressed as
+// `aggregate.count(<pattern> -> <group>, ...)`, where `aggregate` is shorthand
+// for `aggregate.count`. Sums are expressed as `aggregate.sum(<pattern> ->
+// <value> by <group>, ...)`.
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var fn AggregateFunc
+	switch name {
+	case "aggregate", "aggregate.count":
+		fn = AggregateCount
+	case "aggregate.sum":
+		fn = AggregateSum
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+	matchPattern, err := toRegexpPattern(left)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "aggregate command")
+	}
+
+	var valuePattern string
+	if fn == AggregateSum {
+		parts := bySyntax.Split(right, 2)
+		if len(parts) != 2 {
+			return nil, false, errors.New("invalid aggregate.sum statement, expected `<value> by <group>` on the right hand side of `->`")
+		}
+		valuePattern, right = parts[0], parts[1]
+	}
+
+	groupBy, err := parseGroupBy(right)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var typeValue string
+	query.VisitField(q.ToParseTree(), query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		typeValue = value
+	})
+
+	return &Aggregate{
+		SearchPattern: matchPattern,
+		Func:          fn,
+		GroupBy:       groupBy,
+		ValuePattern:  valuePattern,
+		TypeValue:     typeValue,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +343,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseAggregate,
 	parseMatchOnly,
 )
 
@@ -50,6 +50,14 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("aggregate",
+		"Command: `Aggregate count: (deprecated\\.(\\w+)) by ($1, $repo)`").
+		Equal(t, test(`content:aggregate(deprecated\.(\w+) -> $1, $repo)`))
+
+	autogold.Want("aggregate sum",
+		"Command: `Aggregate sum: (cost=(\\d+)) -> ($1) by ($author)`").
+		Equal(t, test(`content:aggregate.sum(cost=(\d+) -> $1 by $author)`))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -0,0 +1,100 @@
+package compute
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Table is the result of an Aggregate command. A table produced for a single
+// search result is partial: streaming clients receive tables that aggregate
+// all results seen so far, where each table supersedes the previous one.
+type Table struct {
+	Columns []string `json:"columns"`
+	Rows    []Row    `json:"rows"`
+	Kind    string   `json:"kind"`
+}
+
+// Row is a single group of a Table. Groups holds the value of each group
+// column, and Value the aggregated value of the group.
+type Row struct {
+	Groups []string `json:"groups"`
+	Value  float64  `json:"value"`
+}
+
+// Aggregator incrementally merges the tables produced by an Aggregate command.
+// It is safe for concurrent use.
+type Aggregator struct {
+	mu      sync.Mutex
+	columns []string
+	rows    []Row
+	index   map[string]int
+	dirty   bool
+}
+
+// NewAggregator returns an empty aggregator. If columns is nil, the columns of
+// the first non-empty table added are used.
+func NewAggregator(columns []string) *Aggregator {
+	return &Aggregator{
+		columns: columns,
+		index:   map[string]int{},
+	}
+}
+
+// Add merges the rows of the given table into the aggregate.
+func (a *Aggregator) Add(t *Table) {
+	if t == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.columns == nil {
+		a.columns = t.Columns
+	}
+	for _, row := range t.Rows {
+		a.addLocked(row.Groups, row.Value)
+	}
+}
+
+func (a *Aggregator) add(groups []string, value float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.addLocked(groups, value)
+}
+
+func (a *Aggregator) addLocked(groups []string, value float64) {
+	// Both counts and sums are rolled up by addition.
+	key := strings.Join(groups, "\x00")
+	if i, ok := a.index[key]; ok {
+		a.rows[i].Value += value
+	} else {
+		a.index[key] = len(a.rows)
+		a.rows = append(a.rows, Row{Groups: groups, Value: value})
+	}
+	a.dirty = true
+}
+
+// Flush returns a snapshot of the aggregate, with rows ordered by descending
+// value. The returned boolean reports whether the aggregate changed since the
+// previous call to Flush.
+func (a *Aggregator) Flush() (*Table, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rows := make([]Row, len(a.rows))
+	copy(rows, a.rows)
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Value != rows[j].Value {
+			return rows[i].Value > rows[j].Value
+		}
+		return strings.Join(rows[i].Groups, "\x00") < strings.Join(rows[j].Groups, "\x00")
+	})
+
+	dirty := a.dirty
+	a.dirty = false
+
+	return &Table{Columns: a.columns, Rows: rows, Kind: "aggregate"}, dirty
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ContentMatch is a match of a code monitor over file content. Matches are
+// compared between runs by their fingerprint, which doesn't depend on the
+// position of the match in the file, so that moving a match around doesn't
+// trigger the monitor.
+type ContentMatch struct {
+	Fingerprint string       `json:"fingerprint"`
+	RepoID      api.RepoID   `json:"repoID"`
+	RepoName    api.RepoName `json:"repoName"`
+	// Path is the path of the file the match is in. It is empty for
+	// repository matches.
+	Path string `json:"path,omitempty"`
+	// Preview is the matched line or symbol. It is empty for path matches.
+	Preview string `json:"preview,omitempty"`
+	// LineNumber is the 0-based line number of the match, if any.
+	LineNumber int `json:"lineNumber,omitempty"`
+}
+
+// ContentMatchChanges are the content matches of a code monitor that appeared
+// and disappeared since its previous run.
+type ContentMatchChanges struct {
+	Added   []*ContentMatch `json:"added,omitempty"`
+	Removed []*ContentMatch `json:"removed,omitempty"`
+}
+
+// Len returns the total number of changed matches.
+func (c *ContentMatchChanges) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.Added) + len(c.Removed)
+}
+
+// GetContentMatches returns the content matches found by the last run of the
+// given code monitor. The boolean return value is false if the monitor has
+// not been run over file content yet.
+func (s *codeMonitorStore) GetContentMatches(ctx context.Context, monitorID int64) ([]*ContentMatch, bool, error) {
+	rawQuery := `
+	SELECT matches
+	FROM cm_content_matches
+	WHERE monitor_id = %s
+	`
+
+	var matchesJSON []byte
+	err := s.QueryRow(ctx, sqlf.Sprintf(rawQuery, monitorID)).Scan(&matchesJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var matches []*ContentMatch
+	if err := json.Unmarshal(matchesJSON, &matches); err != nil {
+		return nil, false, err
+	}
+	return matches, true, nil
+}
+
+// UpsertContentMatches stores the content matches found by the latest run of
+// the given code monitor.
+func (s *codeMonitorStore) UpsertContentMatches(ctx context.Context, monitorID int64, matches []*ContentMatch) error {
+	rawQuery := `
+	INSERT INTO cm_content_matches (monitor_id, matches, updated_at)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id) DO UPDATE
+	SET matches = EXCLUDED.matches,
+		updated_at = EXCLUDED.updated_at
+	`
+
+	// Appease non-null constraint on column
+	if matches == nil {
+		matches = []*ContentMatch{}
+	}
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return err
+	}
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID, matchesJSON, s.Now()))
+}
+
+// DeleteContentMatches deletes the stored content matches of the given code
+// monitor, so that the next run records a new baseline without triggering
+// the monitor.
+func (s *codeMonitorStore) DeleteContentMatches(ctx context.Context, monitorID int64) error {
+	rawQuery := `
+	DELETE FROM cm_content_matches
+	WHERE monitor_id = %s
+	`
+
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID))
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreContentMatches(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("upsert get delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Get before the first run
+		matches, found, err := cm.GetContentMatches(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Empty(t, matches)
+
+		// Insert
+		insertMatches := []*ContentMatch{{
+			Fingerprint: "a",
+			RepoID:      fixtures.Repo.ID,
+			RepoName:    fixtures.Repo.Name,
+			Path:        "main.go",
+			Preview:     `import "banned"`,
+			LineNumber:  2,
+		}}
+		err = cm.UpsertContentMatches(ctx, fixtures.Monitor.ID, insertMatches)
+		require.NoError(t, err)
+
+		matches, found, err = cm.GetContentMatches(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, insertMatches, matches)
+
+		// Update with no matches
+		err = cm.UpsertContentMatches(ctx, fixtures.Monitor.ID, nil)
+		require.NoError(t, err)
+
+		matches, found, err = cm.GetContentMatches(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Empty(t, matches)
+
+		// Delete
+		err = cm.DeleteContentMatches(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+
+		_, found, err = cm.GetContentMatches(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
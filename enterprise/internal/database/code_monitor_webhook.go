@@ -3,12 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type WebhookAction struct {
@@ -18,17 +22,74 @@ type WebhookAction struct {
 	URL            string
 	IncludeResults bool
 
+	// PayloadTemplate is a Go template rendering the body of the request. If
+	// empty, the default payload is sent.
+	PayloadTemplate string
+	Headers         map[string]string
+	// SigningSecret is used to sign the body of the request. If empty, the
+	// request is not signed.
+	SigningSecret string
+
 	CreatedBy int32
 	CreatedAt time.Time
 	ChangedBy int32
 	ChangedAt time.Time
 }
 
+// WebhookPayloadArgs customizes the request sent by a webhook action. A nil
+// *WebhookPayloadArgs sends the default payload without additional headers.
+type WebhookPayloadArgs struct {
+	PayloadTemplate string
+	Headers         map[string]string
+	SigningSecret   string
+}
+
+// queryArgs returns the column values of the payload settings. The headers and
+// the signing secret are credentials, so they are encrypted with key.
+func (a *WebhookPayloadArgs) queryArgs(ctx context.Context, key encryption.Key) (payloadTemplate, headers, signingSecret, keyID string, err error) {
+	var rawHeaders, rawSecret string
+	if a != nil {
+		payloadTemplate, rawSecret = a.PayloadTemplate, a.SigningSecret
+		if len(a.Headers) > 0 {
+			raw, err := json.Marshal(a.Headers)
+			if err != nil {
+				return "", "", "", "", err
+			}
+			rawHeaders = string(raw)
+		}
+	}
+
+	encrypted, err := encryptCredentials(ctx, key, rawHeaders, rawSecret)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return payloadTemplate, encrypted.Values[0], encrypted.Values[1], encrypted.KeyID, nil
+}
+
+// encryptCredentials encrypts each value with key. All values share the returned
+// key ID, which is empty if key is nil.
+func encryptCredentials(ctx context.Context, key encryption.Key, values ...string) (e database.Encrypted, err error) {
+	e.Values = make([]string, 0, len(values))
+	for _, v := range values {
+		ev, keyID, err := encryption.MaybeEncrypt(ctx, key, v)
+		if err != nil {
+			return database.Encrypted{}, errors.Wrap(err, "encrypting webhook credentials")
+		}
+		e.Values = append(e.Values, ev)
+		e.KeyID = keyID
+	}
+	return e, nil
+}
+
 const updateWebhookActionQuery = `
 UPDATE cm_webhooks
 SET enabled = %s,
     include_results = %s,
 	url = %s,
+	payload_template = %s,
+	headers = %s,
+	signing_secret = %s,
+	encryption_key_id = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +102,22 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string, payload *WebhookPayloadArgs) (*WebhookAction, error) {
+	payloadTemplate, headers, signingSecret, keyID, err := payload.queryArgs(ctx, s.getEncryptionKey())
+	if err != nil {
+		return nil, err
+	}
+
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		payloadTemplate,
+		headers,
+		signingSecret,
+		keyID,
 		a.UID,
 		s.Now(),
 		id,
@@ -56,17 +126,22 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(ctx, s.getEncryptionKey(), row)
 }
 
 const createWebhookActionQuery = `
 INSERT INTO cm_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, payload_template, headers, signing_secret, encryption_key_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, payload *WebhookPayloadArgs) (*WebhookAction, error) {
+	payloadTemplate, headers, signingSecret, keyID, err := payload.queryArgs(ctx, s.getEncryptionKey())
+	if err != nil {
+		return nil, err
+	}
+
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -75,6 +150,10 @@ func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID in
 		enabled,
 		includeResults,
 		url,
+		payloadTemplate,
+		headers,
+		signingSecret,
+		keyID,
 		a.UID,
 		now,
 		a.UID,
@@ -83,7 +162,7 @@ func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID in
 	)
 
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(ctx, s.getEncryptionKey(), row)
 }
 
 const deleteWebhookActionQuery = `
@@ -135,7 +214,7 @@ func (s *codeMonitorStore) GetWebhookAction(ctx context.Context, webhookID int64
 		webhookID,
 	)
 	row := s.QueryRow(ctx, q)
-	return scanWebhookAction(row)
+	return scanWebhookAction(ctx, s.getEncryptionKey(), row)
 }
 
 const listWebhookActionsQuery = `
@@ -158,7 +237,7 @@ func (s *codeMonitorStore) ListWebhookActions(ctx context.Context, opts ListActi
 		return nil, err
 	}
 	defer rows.Close()
-	return scanWebhookActions(rows)
+	return scanWebhookActions(ctx, s.getEncryptionKey(), rows)
 }
 
 // webhookActionColumns is the set of columns in the cm_webhooks table
@@ -169,16 +248,20 @@ var webhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_webhooks.enabled"),
 	sqlf.Sprintf("cm_webhooks.url"),
 	sqlf.Sprintf("cm_webhooks.include_results"),
+	sqlf.Sprintf("cm_webhooks.payload_template"),
+	sqlf.Sprintf("cm_webhooks.headers"),
+	sqlf.Sprintf("cm_webhooks.signing_secret"),
+	sqlf.Sprintf("cm_webhooks.encryption_key_id"),
 	sqlf.Sprintf("cm_webhooks.created_by"),
 	sqlf.Sprintf("cm_webhooks.created_at"),
 	sqlf.Sprintf("cm_webhooks.changed_by"),
 	sqlf.Sprintf("cm_webhooks.changed_at"),
 }
 
-func scanWebhookActions(rows *sql.Rows) ([]*WebhookAction, error) {
+func scanWebhookActions(ctx context.Context, key encryption.Key, rows *sql.Rows) ([]*WebhookAction, error) {
 	var ws []*WebhookAction
 	for rows.Next() {
-		w, err := scanWebhookAction(rows)
+		w, err := scanWebhookAction(ctx, key, rows)
 		if err != nil {
 			return nil, err
 		}
@@ -187,20 +270,46 @@ func scanWebhookActions(rows *sql.Rows) ([]*WebhookAction, error) {
 	return ws, rows.Err()
 }
 
-// scanWebhookAction scans a WebhookAction from a *sql.Row or *sql.Rows.
+// scanWebhookAction scans a WebhookAction from a *sql.Row or *sql.Rows and
+// decrypts its credentials with key.
 // It must be kept in sync with webhookActionColumns.
-func scanWebhookAction(scanner dbutil.Scanner) (*WebhookAction, error) {
-	var w WebhookAction
+func scanWebhookAction(ctx context.Context, key encryption.Key, scanner dbutil.Scanner) (*WebhookAction, error) {
+	var (
+		w             WebhookAction
+		rawHeaders    string
+		rawSecret     string
+		encryptionKey string
+	)
 	err := scanner.Scan(
 		&w.ID,
 		&w.Monitor,
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&w.PayloadTemplate,
+		&rawHeaders,
+		&rawSecret,
+		&encryptionKey,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
 		&w.ChangedAt,
 	)
-	return &w, err
+	if err != nil {
+		return &w, err
+	}
+
+	headers, err := encryption.MaybeDecrypt(ctx, key, rawHeaders, encryptionKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting webhook headers")
+	}
+	if headers != "" {
+		if err := json.Unmarshal([]byte(headers), &w.Headers); err != nil {
+			return nil, err
+		}
+	}
+	if w.SigningSecret, err = encryption.MaybeDecrypt(ctx, key, rawSecret, encryptionKey); err != nil {
+		return nil, errors.Wrap(err, "decrypting webhook signing secret")
+	}
+	return &w, nil
 }
@@ -18,6 +18,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
@@ -50,6 +52,7 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithContentChanges(ctx context.Context, triggerJobID int32, queryString string, changes *ContentMatchChanges) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -58,8 +61,8 @@ type CodeMonitorStore interface {
 	GetEmailAction(ctx context.Context, emailID int64) (*EmailAction, error)
 	ListEmailActions(context.Context, ListActionsOpts) ([]*EmailAction, error)
 
-	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error)
-	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error)
+	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string, _ *WebhookPayloadArgs) (*WebhookAction, error)
+	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, _ *WebhookPayloadArgs) (*WebhookAction, error)
 	DeleteWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetWebhookAction(ctx context.Context, id int64) (*WebhookAction, error)
@@ -72,6 +75,13 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTeamsWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error)
+	ListTeamsWebhookActions(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -90,6 +100,10 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	GetContentMatches(ctx context.Context, monitorID int64) ([]*ContentMatch, bool, error)
+	UpsertContentMatches(ctx context.Context, monitorID int64, matches []*ContentMatch) error
+	DeleteContentMatches(ctx context.Context, monitorID int64) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -97,6 +111,10 @@ type CodeMonitorStore interface {
 type codeMonitorStore struct {
 	*basestore.Store
 	now func() time.Time
+
+	// key encrypts the credentials of webhook actions. If nil, the webhook key
+	// of the default keyring is used.
+	key encryption.Key
 }
 
 var _ CodeMonitorStore = (*codeMonitorStore)(nil)
@@ -121,6 +139,13 @@ func (s *codeMonitorStore) Now() time.Time {
 	return s.now()
 }
 
+func (s *codeMonitorStore) getEncryptionKey() encryption.Key {
+	if s.key != nil {
+		return s.key
+	}
+	return keyring.Default().WebhookKey
+}
+
 // Transact creates a new transaction.
 // It's required to implement this method and wrap the Transact method of the
 // underlying basestore.Store.
@@ -129,7 +154,7 @@ func (s *codeMonitorStore) Transact(ctx context.Context) (CodeMonitorStore, erro
 	if err != nil {
 		return nil, err
 	}
-	return &codeMonitorStore{Store: txBase, now: s.now}, nil
+	return &codeMonitorStore{Store: txBase, now: s.now, key: s.key}, nil
 }
 
 type JobTable int
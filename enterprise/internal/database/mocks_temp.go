@@ -46,6 +46,9 @@ type MockCodeMonitorStore struct {
 	// CountSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSlackWebhookActions.
 	CountSlackWebhookActionsFunc *CodeMonitorStoreCountSlackWebhookActionsFunc
+	// CountTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountTeamsWebhookActions.
+	CountTeamsWebhookActionsFunc *CodeMonitorStoreCountTeamsWebhookActionsFunc
 	// CountWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountWebhookActions.
 	CountWebhookActionsFunc *CodeMonitorStoreCountWebhookActionsFunc
@@ -64,9 +67,15 @@ type MockCodeMonitorStore struct {
 	// CreateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateSlackWebhookAction.
 	CreateSlackWebhookActionFunc *CodeMonitorStoreCreateSlackWebhookActionFunc
+	// CreateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateTeamsWebhookAction.
+	CreateTeamsWebhookActionFunc *CodeMonitorStoreCreateTeamsWebhookActionFunc
 	// CreateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateWebhookAction.
 	CreateWebhookActionFunc *CodeMonitorStoreCreateWebhookActionFunc
+	// DeleteContentMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteContentMatches.
+	DeleteContentMatchesFunc *CodeMonitorStoreDeleteContentMatchesFunc
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
@@ -83,6 +92,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteTeamsWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTeamsWebhookActions.
+	DeleteTeamsWebhookActionsFunc *CodeMonitorStoreDeleteTeamsWebhookActionsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -105,6 +118,9 @@ type MockCodeMonitorStore struct {
 	// GetActionJobMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetActionJobMetadata.
 	GetActionJobMetadataFunc *CodeMonitorStoreGetActionJobMetadataFunc
+	// GetContentMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetContentMatches.
+	GetContentMatchesFunc *CodeMonitorStoreGetContentMatchesFunc
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
@@ -124,6 +140,9 @@ type MockCodeMonitorStore struct {
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
+	// GetTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTeamsWebhookAction.
+	GetTeamsWebhookActionFunc *CodeMonitorStoreGetTeamsWebhookActionFunc
 	// GetWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetWebhookAction.
 	GetWebhookActionFunc *CodeMonitorStoreGetWebhookActionFunc
@@ -151,6 +170,9 @@ type MockCodeMonitorStore struct {
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
+	// ListTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListTeamsWebhookActions.
+	ListTeamsWebhookActionsFunc *CodeMonitorStoreListTeamsWebhookActionsFunc
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
@@ -182,6 +204,13 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateTeamsWebhookAction.
+	UpdateTeamsWebhookActionFunc *CodeMonitorStoreUpdateTeamsWebhookActionFunc
+	// UpdateTriggerJobWithContentChangesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpdateTriggerJobWithContentChanges.
+	UpdateTriggerJobWithContentChangesFunc *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -189,6 +218,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertContentMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertContentMatches.
+	UpsertContentMatchesFunc *CodeMonitorStoreUpsertContentMatchesFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -229,6 +261,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
 				return
@@ -259,8 +296,18 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (r0 *WebhookAction, r1 error) {
+				return
+			},
+		},
+		DeleteContentMatchesFunc: &CodeMonitorStoreDeleteContentMatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
 				return
 			},
 		},
@@ -289,6 +336,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -324,6 +376,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetContentMatchesFunc: &CodeMonitorStoreGetContentMatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 []*ContentMatch, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *EmailAction, r1 error) {
 				return
@@ -354,6 +411,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *WebhookAction, r1 error) {
 				return
@@ -399,6 +461,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) (r0 []*WebhookAction, r1 error) {
 				return
@@ -449,13 +516,28 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
+		UpdateTriggerJobWithContentChangesFunc: &CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc{
+			defaultHook: func(context.Context, int32, string, *ContentMatchChanges) (r0 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (r0 *WebhookAction, r1 error) {
+				return
+			},
+		},
+		UpsertContentMatchesFunc: &CodeMonitorStoreUpsertContentMatchesFunc{
+			defaultHook: func(context.Context, int64, []*ContentMatch) (r0 error) {
 				return
 			},
 		},
@@ -501,6 +583,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountSlackWebhookActions")
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTeamsWebhookActions")
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountWebhookActions")
@@ -531,11 +618,21 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTeamsWebhookAction")
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
 			},
 		},
+		DeleteContentMatchesFunc: &CodeMonitorStoreDeleteContentMatchesFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteContentMatches")
+			},
+		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: func(context.Context, []int64, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
@@ -561,6 +658,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTeamsWebhookActions")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -596,6 +698,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetActionJobMetadata")
 			},
 		},
+		GetContentMatchesFunc: &CodeMonitorStoreGetContentMatchesFunc{
+			defaultHook: func(context.Context, int64) ([]*ContentMatch, bool, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetContentMatches")
+			},
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: func(context.Context, int64) (*EmailAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
@@ -626,6 +733,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTeamsWebhookAction")
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetWebhookAction")
@@ -671,6 +783,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTeamsWebhookActions")
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) ([]*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
@@ -721,16 +838,31 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTeamsWebhookAction")
+			},
+		},
+		UpdateTriggerJobWithContentChangesFunc: &CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc{
+			defaultHook: func(context.Context, int32, string, *ContentMatchChanges) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithContentChanges")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertContentMatchesFunc: &CodeMonitorStoreUpsertContentMatchesFunc{
+			defaultHook: func(context.Context, int64, []*ContentMatch) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertContentMatches")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -762,6 +894,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountSlackWebhookActionsFunc: &CodeMonitorStoreCountSlackWebhookActionsFunc{
 			defaultHook: i.CountSlackWebhookActions,
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: i.CountTeamsWebhookActions,
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: i.CountWebhookActions,
 		},
@@ -780,9 +915,15 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
 			defaultHook: i.CreateSlackWebhookAction,
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: i.CreateTeamsWebhookAction,
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: i.CreateWebhookAction,
 		},
+		DeleteContentMatchesFunc: &CodeMonitorStoreDeleteContentMatchesFunc{
+			defaultHook: i.DeleteContentMatches,
+		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
@@ -798,6 +939,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: i.DeleteTeamsWebhookActions,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -819,6 +963,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetActionJobMetadataFunc: &CodeMonitorStoreGetActionJobMetadataFunc{
 			defaultHook: i.GetActionJobMetadata,
 		},
+		GetContentMatchesFunc: &CodeMonitorStoreGetContentMatchesFunc{
+			defaultHook: i.GetContentMatches,
+		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
@@ -837,6 +984,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: i.GetTeamsWebhookAction,
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: i.GetWebhookAction,
 		},
@@ -864,6 +1014,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: i.ListTeamsWebhookActions,
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
@@ -894,12 +1047,21 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: i.UpdateTeamsWebhookAction,
+		},
+		UpdateTriggerJobWithContentChangesFunc: &CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc{
+			defaultHook: i.UpdateTriggerJobWithContentChanges,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertContentMatchesFunc: &CodeMonitorStoreUpsertContentMatchesFunc{
+			defaultHook: i.UpsertContentMatches,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -1561,6 +1723,118 @@ func (c CodeMonitorStoreCountSlackWebhookActionsFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountTeamsWebhookActionsFunc describes the behavior when
+// the CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCountTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTeamsWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreCountTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) History() []CodeMonitorStoreCountTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCountTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
 // CountWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2248,37 +2522,37 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreCreateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	history     []CodeMonitorStoreCreateWebhookActionFuncCall
+// CodeMonitorStoreCreateTeamsWebhookActionFunc describes the behavior when
+// the CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCreateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreCreateTeamsWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+// CreateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*TeamsWebhookAction, error) {
+	r0, r1 := m.CreateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreCreateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+// SetDefaultHook sets function that is called when the
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2286,20 +2560,20 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2312,27 +2586,28 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) History() []CodeMonitorStoreCreateTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCreateTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
-// an invocation of method CreateWebhookAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreCreateWebhookActionFuncCall struct {
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2350,7 +2625,7 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *WebhookAction
+	Result0 *TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2358,47 +2633,47 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
-// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreDeleteEmailActionsFunc struct {
-	defaultHook func(context.Context, []int64, int64) error
-	hooks       []func(context.Context, []int64, int64) error
-	history     []CodeMonitorStoreDeleteEmailActionsFuncCall
+type CodeMonitorStoreCreateWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)
+	history     []CodeMonitorStoreCreateWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteEmailActions delegates to the next hook function in the queue and
+// CreateWebhookAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteEmailActions(v0 context.Context, v1 []int64, v2 int64) error {
-	r0 := m.DeleteEmailActionsFunc.nextHook()(v0, v1, v2)
-	m.DeleteEmailActionsFunc.appendCall(CodeMonitorStoreDeleteEmailActionsFuncCall{v0, v1, v2, r0})
-	return r0
+func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *WebhookPayloadArgs) (*WebhookAction, error) {
+	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the DeleteEmailActions
+// SetDefaultHook sets function that is called when the CreateWebhookAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) SetDefaultHook(hook func(context.Context, []int64, int64) error) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteEmailActions method of the parent MockCodeMonitorStore instance
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) PushHook(hook func(context.Context, []int64, int64) error) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2406,20 +2681,20 @@ func (f *CodeMonitorStoreDeleteEmailActionsFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, []int64, int64) error {
-		return r0
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, []int64, int64) error {
-		return r0
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
+		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) nextHook() func(context.Context, []int64, int64) error {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2432,41 +2707,273 @@ func (f *CodeMonitorStoreDeleteEmailActionsFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) appendCall(r0 CodeMonitorStoreDeleteEmailActionsFuncCall) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreDeleteEmailActionsFuncCall
+// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreDeleteEmailActionsFunc) History() []CodeMonitorStoreDeleteEmailActionsFuncCall {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreDeleteEmailActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreDeleteEmailActionsFuncCall is an object that describes an
-// invocation of method DeleteEmailActions on an instance of
+// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
+// an invocation of method CreateWebhookAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreDeleteEmailActionsFuncCall struct {
+type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []int64
+	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int64
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *WebhookPayloadArgs
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
-}
-
+	Result0 *WebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreDeleteContentMatchesFunc describes the behavior when the
+// DeleteContentMatches method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreDeleteContentMatchesFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteContentMatches delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteContentMatches(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteContentMatchesFunc.nextHook()(v0, v1)
+	m.DeleteContentMatchesFunc.appendCall(CodeMonitorStoreDeleteContentMatchesFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteContentMatches
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteContentMatches method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) appendCall(r0 CodeMonitorStoreDeleteContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteContentMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteContentMatchesFunc) History() []CodeMonitorStoreDeleteContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteContentMatchesFuncCall is an object that describes
+// an invocation of method DeleteContentMatches on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
+// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteEmailActionsFunc struct {
+	defaultHook func(context.Context, []int64, int64) error
+	hooks       []func(context.Context, []int64, int64) error
+	history     []CodeMonitorStoreDeleteEmailActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteEmailActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteEmailActions(v0 context.Context, v1 []int64, v2 int64) error {
+	r0 := m.DeleteEmailActionsFunc.nextHook()(v0, v1, v2)
+	m.DeleteEmailActionsFunc.appendCall(CodeMonitorStoreDeleteEmailActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteEmailActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) SetDefaultHook(hook func(context.Context, []int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteEmailActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) PushHook(hook func(context.Context, []int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int64, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) nextHook() func(context.Context, []int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) appendCall(r0 CodeMonitorStoreDeleteEmailActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteEmailActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteEmailActionsFunc) History() []CodeMonitorStoreDeleteEmailActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteEmailActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteEmailActionsFuncCall is an object that describes an
+// invocation of method DeleteEmailActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteEmailActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Args() []interface{} {
@@ -2922,6 +3429,125 @@ func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteTeamsWebhookActionsFunc describes the behavior when
+// the DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteTeamsWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteTeamsWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteTeamsWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) History() []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTeamsWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteWebhookActionsFunc describes the behavior when the
 // DeleteWebhookActions method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -3535,26 +4161,138 @@ func (f *CodeMonitorStoreGetActionJobFunc) nextHook() func(context.Context, int3
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobFunc) appendCall(r0 CodeMonitorStoreGetActionJobFuncCall) {
+func (f *CodeMonitorStoreGetActionJobFunc) appendCall(r0 CodeMonitorStoreGetActionJobFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetActionJobFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetActionJobFunc) History() []CodeMonitorStoreGetActionJobFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetActionJobFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobFuncCall is an object that describes an
+// invocation of method GetActionJob on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetActionJobMetadataFunc struct {
+	defaultHook func(context.Context, int32) (*ActionJobMetadata, error)
+	hooks       []func(context.Context, int32) (*ActionJobMetadata, error)
+	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+	mutex       sync.Mutex
+}
+
+// GetActionJobMetadata delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*ActionJobMetadata, error) {
+	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
+	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetActionJobMetadata
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
+	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetActionJobFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetActionJobFunc) History() []CodeMonitorStoreGetActionJobFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobFuncCall is an object that describes an
-// invocation of method GetActionJob on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobFuncCall struct {
+// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
+// an invocation of method GetActionJobMetadata on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3563,7 +4301,7 @@ type CodeMonitorStoreGetActionJobFuncCall struct {
 	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *ActionJob
+	Result0 *ActionJobMetadata
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3571,47 +4309,47 @@ type CodeMonitorStoreGetActionJobFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreGetActionJobMetadataFunc struct {
-	defaultHook func(context.Context, int32) (*ActionJobMetadata, error)
-	hooks       []func(context.Context, int32) (*ActionJobMetadata, error)
-	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+// CodeMonitorStoreGetContentMatchesFunc describes the behavior when the
+// GetContentMatches method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetContentMatchesFunc struct {
+	defaultHook func(context.Context, int64) ([]*ContentMatch, bool, error)
+	hooks       []func(context.Context, int64) ([]*ContentMatch, bool, error)
+	history     []CodeMonitorStoreGetContentMatchesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetActionJobMetadata delegates to the next hook function in the queue and
+// GetContentMatches delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*ActionJobMetadata, error) {
-	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
-	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockCodeMonitorStore) GetContentMatches(v0 context.Context, v1 int64) ([]*ContentMatch, bool, error) {
+	r0, r1, r2 := m.GetContentMatchesFunc.nextHook()(v0, v1)
+	m.GetContentMatchesFunc.appendCall(CodeMonitorStoreGetContentMatchesFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetActionJobMetadata
+// SetDefaultHook sets function that is called when the GetContentMatches
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+func (f *CodeMonitorStoreGetContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64) ([]*ContentMatch, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// GetContentMatches method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+func (f *CodeMonitorStoreGetContentMatchesFunc) PushHook(hook func(context.Context, int64) ([]*ContentMatch, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3619,20 +4357,20 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
-		return r0, r1
+func (f *CodeMonitorStoreGetContentMatchesFunc) SetDefaultReturn(r0 []*ContentMatch, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*ContentMatch, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
-	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
-		return r0, r1
+func (f *CodeMonitorStoreGetContentMatchesFunc) PushReturn(r0 []*ContentMatch, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int64) ([]*ContentMatch, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetContentMatchesFunc) nextHook() func(context.Context, int64) ([]*ContentMatch, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3645,52 +4383,54 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+func (f *CodeMonitorStoreGetContentMatchesFunc) appendCall(r0 CodeMonitorStoreGetContentMatchesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
-// invocations of this function.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+// History returns a sequence of CodeMonitorStoreGetContentMatchesFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetContentMatchesFunc) History() []CodeMonitorStoreGetContentMatchesFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetContentMatchesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
-// an invocation of method GetActionJobMetadata on an instance of
+// CodeMonitorStoreGetContentMatchesFuncCall is an object that describes an
+// invocation of method GetContentMatches on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+type CodeMonitorStoreGetContentMatchesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *ActionJobMetadata
+	Result0 []*ContentMatch
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetContentMatchesFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c CodeMonitorStoreGetContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // CodeMonitorStoreGetEmailActionFunc describes the behavior when the
@@ -4361,6 +5101,118 @@ func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetTeamsWebhookActionFunc describes the behavior when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreGetTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetTeamsWebhookAction(v0 context.Context, v1 int64) (*TeamsWebhookAction, error) {
+	r0, r1 := m.GetTeamsWebhookActionFunc.nextHook()(v0, v1)
+	m.GetTeamsWebhookActionFunc.appendCall(CodeMonitorStoreGetTeamsWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64) (*TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) nextHook() func(context.Context, int64) (*TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) History() []CodeMonitorStoreGetTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall is an object that describes
+// an invocation of method GetTeamsWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetWebhookActionFunc describes the behavior when the
 // GetWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -5213,10 +6065,122 @@ type CodeMonitorStoreListRecipientsFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListRecipientsOpts
+	Arg1 ListRecipientsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*Recipient
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListRecipientsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListRecipientsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListSlackWebhookActionsFunc describes the behavior when
+// the ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreListSlackWebhookActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
+	history     []CodeMonitorStoreListSlackWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListSlackWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListSlackWebhookActions(v0 context.Context, v1 ListActionsOpts) ([]*SlackWebhookAction, error) {
+	r0, r1 := m.ListSlackWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListSlackWebhookActionsFunc.appendCall(CodeMonitorStoreListSlackWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) SetDefaultReturn(r0 []*SlackWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) PushReturn(r0 []*SlackWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListSlackWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListSlackWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListSlackWebhookActionsFunc) History() []CodeMonitorStoreListSlackWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListSlackWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListSlackWebhookActionsFuncCall is an object that
+// describes an invocation of method ListSlackWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreListSlackWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*Recipient
+	Result0 []*SlackWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5224,47 +6188,47 @@ type CodeMonitorStoreListRecipientsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListRecipientsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListRecipientsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListSlackWebhookActionsFunc describes the behavior when
-// the ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// CodeMonitorStoreListTeamsWebhookActionsFunc describes the behavior when
+// the ListTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance is invoked.
-type CodeMonitorStoreListSlackWebhookActionsFunc struct {
-	defaultHook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
-	hooks       []func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
-	history     []CodeMonitorStoreListSlackWebhookActionsFuncCall
+type CodeMonitorStoreListTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreListTeamsWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListSlackWebhookActions delegates to the next hook function in the queue
+// ListTeamsWebhookActions delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListSlackWebhookActions(v0 context.Context, v1 ListActionsOpts) ([]*SlackWebhookAction, error) {
-	r0, r1 := m.ListSlackWebhookActionsFunc.nextHook()(v0, v1)
-	m.ListSlackWebhookActionsFunc.appendCall(CodeMonitorStoreListSlackWebhookActionsFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) ListTeamsWebhookActions(v0 context.Context, v1 ListActionsOpts) ([]*TeamsWebhookAction, error) {
+	r0, r1 := m.ListTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreListTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListSlackWebhookActions method of the parent MockCodeMonitorStore
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5272,20 +6236,20 @@ func (f *CodeMonitorStoreListSlackWebhookActionsFunc) PushHook(hook func(context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) SetDefaultReturn(r0 []*SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultReturn(r0 []*TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) PushReturn(r0 []*SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushReturn(r0 []*TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5298,28 +6262,28 @@ func (f *CodeMonitorStoreListSlackWebhookActionsFunc) nextHook() func(context.Co
 	return hook
 }
 
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListSlackWebhookActionsFuncCall) {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTeamsWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreListSlackWebhookActionsFuncCall objects describing the
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreListSlackWebhookActionsFunc) History() []CodeMonitorStoreListSlackWebhookActionsFuncCall {
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) History() []CodeMonitorStoreListTeamsWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListSlackWebhookActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListTeamsWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListSlackWebhookActionsFuncCall is an object that
-// describes an invocation of method ListSlackWebhookActions on an instance
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTeamsWebhookActions on an instance
 // of MockCodeMonitorStore.
-type CodeMonitorStoreListSlackWebhookActionsFuncCall struct {
+type CodeMonitorStoreListTeamsWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -5328,7 +6292,7 @@ type CodeMonitorStoreListSlackWebhookActionsFuncCall struct {
 	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*SlackWebhookAction
+	Result0 []*TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5336,13 +6300,13 @@ type CodeMonitorStoreListSlackWebhookActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6278,7 +7242,239 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushReturn(r0 error) {
 	})
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) appendCall(r0 CodeMonitorStoreUpdateQueryTriggerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateQueryTriggerFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) History() []CodeMonitorStoreUpdateQueryTriggerFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateQueryTriggerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateQueryTriggerFuncCall is an object that describes an
+// invocation of method UpdateQueryTrigger on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreUpdateSlackWebhookActionFunc describes the behavior when
+// the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSlackWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
+	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateSlackWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateSlackWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) History() []CodeMonitorStoreUpdateSlackWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateSlackWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateSlackWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateSlackWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *SlackWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreUpdateTeamsWebhookActionFunc describes the behavior when
+// the UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpdateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*TeamsWebhookAction, error) {
+	r0, r1 := m.UpdateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushReturn(r0 *TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6291,27 +7487,28 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) appendCall(r0 CodeMonitorStoreUpdateQueryTriggerFuncCall) {
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreUpdateQueryTriggerFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) History() []CodeMonitorStoreUpdateQueryTriggerFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) History() []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateQueryTriggerFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateQueryTriggerFuncCall is an object that describes an
-// invocation of method UpdateQueryTrigger on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6320,55 +7517,64 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateSlackWebhookActionFunc describes the behavior when
-// the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
-// instance is invoked.
-type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
+// CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc describes the
+// behavior when the UpdateTriggerJobWithContentChanges method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc struct {
+	defaultHook func(context.Context, int32, string, *ContentMatchChanges) error
+	hooks       []func(context.Context, int32, string, *ContentMatchChanges) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateSlackWebhookAction delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
-	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
-	return r0, r1
+// UpdateTriggerJobWithContentChanges delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithContentChanges(v0 context.Context, v1 int32, v2 string, v3 *ContentMatchChanges) error {
+	r0 := m.UpdateTriggerJobWithContentChangesFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithContentChangesFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
-// instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+// UpdateTriggerJobWithContentChanges method of the parent
+// MockCodeMonitorStore instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) SetDefaultHook(hook func(context.Context, int32, string, *ContentMatchChanges) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+// UpdateTriggerJobWithContentChanges method of the parent
+// MockCodeMonitorStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) PushHook(hook func(context.Context, int32, string, *ContentMatchChanges) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6376,20 +7582,20 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
-		return r0, r1
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, *ContentMatchChanges) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
-		return r0, r1
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, *ContentMatchChanges) error {
+		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) nextHook() func(context.Context, int32, string, *ContentMatchChanges) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6402,61 +7608,55 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.C
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateSlackWebhookActionFuncCall) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreUpdateSlackWebhookActionFuncCall objects describing the
-// invocations of this function.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) History() []CodeMonitorStoreUpdateSlackWebhookActionFuncCall {
+// CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentChangesFunc) History() []CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateSlackWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateSlackWebhookActionFuncCall is an object that
-// describes an invocation of method UpdateSlackWebhookAction on an instance
-// of MockCodeMonitorStore.
-type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
+// CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall is an object
+// that describes an invocation of method UpdateTriggerJobWithContentChanges
+// on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 int32
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 bool
+	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 bool
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 string
+	Arg3 *ContentMatchChanges
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *SlackWebhookAction
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+func (c CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c CodeMonitorStoreUpdateTriggerJobWithContentChangesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
@@ -6578,24 +7778,24 @@ func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interfa
 // UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreUpdateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)
 	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateWebhookAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *WebhookPayloadArgs) (*WebhookAction, error) {
+	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the UpdateWebhookAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -6604,7 +7804,7 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(conte
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6613,19 +7813,19 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *WebhookPayloadArgs) (*WebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6674,6 +7874,9 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *WebhookPayloadArgs
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *WebhookAction
@@ -6685,7 +7888,7 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -6694,6 +7897,118 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertContentMatchesFunc describes the behavior when the
+// UpsertContentMatches method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreUpsertContentMatchesFunc struct {
+	defaultHook func(context.Context, int64, []*ContentMatch) error
+	hooks       []func(context.Context, int64, []*ContentMatch) error
+	history     []CodeMonitorStoreUpsertContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertContentMatches delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertContentMatches(v0 context.Context, v1 int64, v2 []*ContentMatch) error {
+	r0 := m.UpsertContentMatchesFunc.nextHook()(v0, v1, v2)
+	m.UpsertContentMatchesFunc.appendCall(CodeMonitorStoreUpsertContentMatchesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpsertContentMatches
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64, []*ContentMatch) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertContentMatches method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) PushHook(hook func(context.Context, int64, []*ContentMatch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*ContentMatch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*ContentMatch) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) nextHook() func(context.Context, int64, []*ContentMatch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) appendCall(r0 CodeMonitorStoreUpsertContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertContentMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertContentMatchesFunc) History() []CodeMonitorStoreUpsertContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertContentMatchesFuncCall is an object that describes
+// an invocation of method UpsertContentMatches on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpsertContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*ContentMatch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6816,6 +8131,9 @@ type MockEnterpriseDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *EnterpriseDBAccessTokensFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *EnterpriseDBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *EnterpriseDBAuthzFunc
@@ -6966,6 +8284,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() (r0 database.AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() (r0 database.AuthzStore) {
 				return
@@ -7208,6 +8531,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.AccessTokens")
 			},
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() database.AuditLogStore {
+				panic("unexpected invocation of MockEnterpriseDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() database.AuthzStore {
 				panic("unexpected invocation of MockEnterpriseDB.Authz")
@@ -7449,6 +8777,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		AccessTokensFunc: &EnterpriseDBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -7689,6 +9020,105 @@ func (c EnterpriseDBAccessTokensFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBAuditLogsFunc describes the behavior when the AuditLogs
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBAuditLogsFunc struct {
+	defaultHook func() database.AuditLogStore
+	hooks       []func() database.AuditLogStore
+	history     []EnterpriseDBAuditLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// AuditLogs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) AuditLogs() database.AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(EnterpriseDBAuditLogsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultHook(hook func() database.AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AuditLogs method of the parent MockEnterpriseDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnterpriseDBAuditLogsFunc) PushHook(hook func() database.AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultReturn(r0 database.AuditLogStore) {
+	f.SetDefaultHook(func() database.AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBAuditLogsFunc) PushReturn(r0 database.AuditLogStore) {
+	f.PushHook(func() database.AuditLogStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBAuditLogsFunc) nextHook() func() database.AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBAuditLogsFunc) appendCall(r0 EnterpriseDBAuditLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBAuditLogsFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBAuditLogsFunc) History() []EnterpriseDBAuditLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBAuditLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBAuditLogsFuncCall is an object that describes an invocation
+// of method AuditLogs on an instance of MockEnterpriseDB.
+type EnterpriseDBAuditLogsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBAuditLogsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBAuditLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBAuthzFunc describes the behavior when the Authz method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBAuthzFunc struct {
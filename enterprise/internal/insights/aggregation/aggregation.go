@@ -2,6 +2,7 @@ package aggregation
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
@@ -86,6 +88,74 @@ func countPath(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// countDirectoryFunc returns a count function grouping file matches by their directory,
+// truncated to the first depth path components. Files at the root of a repository are
+// not counted.
+func countDirectoryFunc(depth int) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+		dir := directoryAtDepth(match.Path, depth)
+		if dir == "" {
+			return nil, nil
+		}
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  dir,
+		}: r.ResultCount()}, nil
+	}
+}
+
+// directoryAtDepth returns the directory of path truncated to at most depth components,
+// with a trailing slash. It returns an empty string for files at the root.
+func directoryAtDepth(path string, depth int) string {
+	components := strings.Split(strings.Trim(path, "/"), "/")
+	// The last component is the file name.
+	components = components[:len(components)-1]
+	if len(components) > depth {
+		components = components[:depth]
+	}
+	if len(components) == 0 {
+		return ""
+	}
+	return strings.Join(components, "/") + "/"
+}
+
+// countOwnersFunc returns a count function grouping file matches by the owners of the file,
+// as per the CODEOWNERS file at the revision of the match. A file with several owners counts
+// towards each of them, and files without an owner are not counted.
+func countOwnersFunc(ctx context.Context, rules *codeownership.RulesCache) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, match.Repo.Name, match.CommitID)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetFromCacheOrFetch")
+		}
+		owners := ruleset.FindOwners(match.Path)
+		if len(owners) == 0 {
+			return nil, nil
+		}
+		matches := make(map[MatchKey]int, len(owners))
+		for _, owner := range owners {
+			label := owner.GetEmail()
+			if handle := owner.GetHandle(); handle != "" {
+				label = "@" + handle
+			}
+			if label == "" {
+				continue
+			}
+			matches[MatchKey{Repo: string(r.RepoName().Name), RepoID: int32(r.RepoName().ID), Group: label}] += r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
 func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	var author string
 	switch match := r.(type) {
@@ -166,19 +236,40 @@ func matchContent(event result.Match) []string {
 	}
 }
 
-func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
+// CountFuncArgs are the inputs of the count functions that need more than the search
+// results.
+type CountFuncArgs struct {
+	// DirectoryDepth is the number of leading path components directory aggregations
+	// group by.
+	DirectoryDepth int
+	// OwnerRules resolves the CODEOWNERS files used by owner aggregations.
+	OwnerRules *codeownership.RulesCache
+}
+
+func GetCountFuncForMode(ctx context.Context, query, patternType string, mode types.SearchAggregationMode, args CountFuncArgs) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
 		types.REPO_AGGREGATION_MODE:   countRepo,
 		types.PATH_AGGREGATION_MODE:   countPath,
 		types.AUTHOR_AGGREGATION_MODE: countAuthor,
 	}
 
-	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
+	switch mode {
+	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		captureGroupsCount, err := countCaptureGroupsFunc(query)
 		if err != nil {
 			return nil, err
 		}
 		modeCountTypes[types.CAPTURE_GROUP_AGGREGATION_MODE] = captureGroupsCount
+	case types.DIRECTORY_AGGREGATION_MODE:
+		if args.DirectoryDepth < 1 {
+			return nil, errors.Newf("invalid directory depth %d, must be at least 1", args.DirectoryDepth)
+		}
+		modeCountTypes[types.DIRECTORY_AGGREGATION_MODE] = countDirectoryFunc(args.DirectoryDepth)
+	case types.OWNER_AGGREGATION_MODE:
+		if args.OwnerRules == nil {
+			return nil, errors.New("owner aggregation requires CODEOWNERS rules")
+		}
+		modeCountTypes[types.OWNER_AGGREGATION_MODE] = countOwnersFunc(ctx, args.OwnerRules)
 	}
 
 	modeCountFunc, ok := modeCountTypes[mode]
@@ -242,12 +333,12 @@ func (r *searchAggregationResults) Send(event streaming.SearchEvent) {
 			return
 		default:
 			groups, err := r.countFunc(match)
-			for groupKey, count := range groups {
+			if err != nil {
 				// delegate error handling to the passed in tabulator
-				if err != nil {
-					r.tabulator(nil, err)
-					continue
-				}
+				r.tabulator(nil, err)
+				continue
+			}
+			for groupKey, count := range groups {
 				current := combined[groupKey]
 				combined[groupKey] = current + count
 			}
@@ -2,6 +2,7 @@ package aggregation
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,9 +11,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
 )
 
 func newTestSearchResultsAggregator(ctx context.Context, tabulator AggregationTabulator, countFunc AggregationCountFunc) SearchResultsAggregator {
@@ -240,7 +246,7 @@ func TestRepoAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, CountFuncArgs{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -302,7 +308,7 @@ func TestAuthorAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, CountFuncArgs{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -399,7 +405,7 @@ func TestPathAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, CountFuncArgs{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -407,6 +413,160 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestDirectoryAggregation(t *testing.T) {
+	testCases := []struct {
+		depth       int
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{1, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("no directory for repo or commit match", map[string]int{}),
+		},
+		{
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "README.md", 1, "a"),
+					contentMatch("myRepo", "cmd/frontend/main.go", 1, "a", "b"),
+					pathMatch("myRepo", "cmd/gitserver/main.go", 1),
+					symbolMatch("myRepo2", "internal/search/job.go", 2, "a", "b"),
+				},
+			},
+			autogold.Want("Count top level directories", map[string]int{"cmd/": 3, "internal/": 2}),
+		},
+		{
+			2,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/main.go", 1, "a"),
+					contentMatch("myRepo", "cmd/frontend/main.go", 1, "a", "b"),
+					contentMatch("myRepo", "cmd/frontend/internal/app.go", 1, "a"),
+				},
+			},
+			autogold.Want("Count nested directories", map[string]int{"cmd/": 1, "cmd/frontend/": 3}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode(context.Background(), "", "", types.DIRECTORY_AGGREGATION_MODE, CountFuncArgs{DirectoryDepth: tc.depth})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	t.Run("invalid depth", func(t *testing.T) {
+		if _, err := GetCountFuncForMode(context.Background(), "", "", types.DIRECTORY_AGGREGATION_MODE, CountFuncArgs{}); err == nil {
+			t.Fatal("expected error for depth 0")
+		}
+	})
+}
+
+type fakeOwnService struct {
+	files map[api.RepoName]string
+	err   error
+}
+
+func (s *fakeOwnService) OwnersFile(_ context.Context, repoName api.RepoName, _ api.CommitID) (*codeownerspb.File, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	content, ok := s.files[repoName]
+	if !ok {
+		return nil, nil
+	}
+	return codeowners.Parse(strings.NewReader(content))
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	ownService := &fakeOwnService{files: map[api.RepoName]string{
+		"myRepo": `
+/cmd/ @sourcegraph/devx
+/cmd/frontend/ @sourcegraph/frontend alice@example.com
+/internal/ @sourcegraph/search
+`,
+	}}
+
+	testCases := []struct {
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					commitMatch("myRepo", "Author A", sampleDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("no owner for repo or commit match", map[string]int{}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "README.md", 1, "a"),
+					contentMatch("myRepo2", "cmd/main.go", 2, "a"),
+				},
+			},
+			autogold.Want("unowned files are not counted", map[string]int{}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/gitserver/main.go", 1, "a", "b"),
+					contentMatch("myRepo", "cmd/frontend/main.go", 1, "a"),
+					symbolMatch("myRepo", "internal/search/job.go", 1, "a", "b"),
+				},
+			},
+			autogold.Want("Count owners", map[string]int{
+				"@sourcegraph/devx":     2,
+				"@sourcegraph/frontend": 1,
+				"alice@example.com":     1,
+				"@sourcegraph/search":   2,
+			}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, CountFuncArgs{OwnerRules: codeownership.NewRulesCache(ownService)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	t.Run("fetch error", func(t *testing.T) {
+		aggregator := testAggregator{results: make(map[string]int)}
+		countFunc, err := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, CountFuncArgs{
+			OwnerRules: codeownership.NewRulesCache(&fakeOwnService{err: errors.New("gitserver unavailable")}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+		sra.Send(streaming.SearchEvent{Results: []result.Match{contentMatch("myRepo", "cmd/main.go", 1, "a")}})
+		if len(aggregator.errors) != 1 {
+			t.Errorf("expected fetch error to be reported, got %v", aggregator.errors)
+		}
+	})
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
@@ -588,7 +748,7 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, CountFuncArgs{})
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -624,7 +784,7 @@ func TestAggregationCancelation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, CountFuncArgs{})
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -0,0 +1,113 @@
+package alerts
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Alerter evaluates the alert rules of insight series after new points are recorded, and
+// delivers the alerts that fire.
+type Alerter struct {
+	alertStore  *store.AlertStore
+	seriesStore store.Interface
+	userEmails  database.UserEmailsStore
+	doer        httpcli.Doer
+	externalURL func() string
+	logger      log.Logger
+}
+
+// NewAlerter returns an Alerter that reads series points and alert rules from the insights
+// database, and looks up email recipients in the main database.
+func NewAlerter(logger log.Logger, mainAppDB database.DB, insightsDB edb.InsightsDB) *Alerter {
+	return &Alerter{
+		alertStore:  store.NewAlertStore(insightsDB),
+		seriesStore: store.New(insightsDB, store.NewInsightPermissionStore(mainAppDB)),
+		userEmails:  mainAppDB.UserEmails(),
+		doer:        httpcli.ExternalDoer,
+		externalURL: conf.ExternalURL,
+		logger:      logger,
+	}
+}
+
+// EvaluateSeries evaluates the enabled alert rules of the series against its latest point. Alerts
+// are recorded in the history of the series before they are delivered, so that an alert is
+// delivered at most once even if the series is evaluated again.
+func (a *Alerter) EvaluateSeries(ctx context.Context, series *types.InsightSeries) error {
+	alerts, err := a.alertStore.GetAlerts(ctx, store.AlertQueryArgs{SeriesID: &series.ID, EnabledOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "GetAlerts")
+	}
+
+	var errs errors.MultiError
+	for _, alert := range alerts {
+		if err := a.evaluate(ctx, series, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert %d", alert.ID))
+		}
+	}
+	return errs
+}
+
+func (a *Alerter) evaluate(ctx context.Context, series *types.InsightSeries, alert types.InsightSeriesAlert) error {
+	// 🚨 SECURITY: The rule is evaluated with the repository permissions of its creator, so that
+	// alerts never include data from repositories the creator cannot see.
+	ctx = actor.WithActor(ctx, actor.FromUser(alert.CreatedBy))
+
+	points, err := a.seriesStore.SeriesPoints(ctx, store.SeriesPointsOpts{SeriesID: &series.SeriesID})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	for _, trigger := range Evaluate(alert, points) {
+		fired, err := a.alertStore.HasAlertEventAfter(ctx, alert.ID, trigger.Capture, trigger.Since)
+		if err != nil {
+			return errors.Wrap(err, "HasAlertEventAfter")
+		}
+		if fired {
+			// The rule already fired for this crossing, for example on a snapshot.
+			continue
+		}
+
+		event, recorded, err := a.alertStore.RecordAlertEvent(ctx, types.InsightSeriesAlertEvent{
+			AlertID:        alert.ID,
+			SeriesID:       series.ID,
+			Capture:        trigger.Capture,
+			Time:           trigger.Time,
+			Value:          trigger.Value,
+			ReferenceValue: trigger.ReferenceValue,
+		})
+		if err != nil {
+			return errors.Wrap(err, "RecordAlertEvent")
+		}
+		if !recorded {
+			continue
+		}
+
+		deliveryErr := a.deliver(ctx, notification{
+			Alert:       alert,
+			Series:      series,
+			Trigger:     trigger,
+			InsightsURL: strings.TrimSuffix(a.externalURL(), "/") + "/insights",
+		})
+		if deliveryErr != nil {
+			a.logger.Warn("failed to deliver insight series alert",
+				log.Int("alertId", alert.ID),
+				log.Int("seriesId", series.ID),
+				log.Error(deliveryErr))
+		}
+		if err := a.alertStore.MarkAlertEventDelivered(ctx, event.ID, deliveryErr); err != nil {
+			return errors.Wrap(err, "MarkAlertEventDelivered")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package alerts
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+// Trigger describes a point of a series that fired an alert rule.
+type Trigger struct {
+	// Capture is the capture group value of the point, or empty for series not generated from
+	// capture groups.
+	Capture string
+	Time    time.Time
+	Value   float64
+	// ReferenceValue is the value at the start of the window of a change alert.
+	ReferenceValue *float64
+	// Since is the time of the previous point of the series. An alert is only delivered once
+	// per crossing, so a trigger is dropped if the rule already fired after this time.
+	Since time.Time
+}
+
+// Evaluate evaluates an alert rule against the points of a series and returns a trigger for
+// every capture whose latest point satisfies the rule while the previous point did not.
+func Evaluate(alert types.InsightSeriesAlert, points []store.SeriesPoint) []Trigger {
+	byCapture := make(map[string][]store.SeriesPoint)
+	for _, point := range points {
+		capture := ""
+		if point.Capture != nil {
+			capture = *point.Capture
+		}
+		byCapture[capture] = append(byCapture[capture], point)
+	}
+
+	captures := make([]string, 0, len(byCapture))
+	for capture := range byCapture {
+		captures = append(captures, capture)
+	}
+	sort.Strings(captures)
+
+	var triggers []Trigger
+	for _, capture := range captures {
+		series := byCapture[capture]
+		sort.SliceStable(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+
+		last := len(series) - 1
+		reference, ok := satisfied(alert, series, last)
+		if !ok {
+			continue
+		}
+		var since time.Time
+		if last > 0 {
+			if _, ok := satisfied(alert, series, last-1); ok {
+				// The rule was already satisfied by the previous point, so this is not a new crossing.
+				continue
+			}
+			since = series[last-1].Time
+		}
+
+		triggers = append(triggers, Trigger{
+			Capture:        capture,
+			Time:           series[last].Time,
+			Value:          series[last].Value,
+			ReferenceValue: reference,
+			Since:          since,
+		})
+	}
+	return triggers
+}
+
+// satisfied returns whether the point at index i of the time ordered series satisfies the rule.
+// For change alerts it also returns the value the change is measured from.
+func satisfied(alert types.InsightSeriesAlert, series []store.SeriesPoint, i int) (*float64, bool) {
+	value := series[i].Value
+	switch alert.Kind {
+	case types.AlertKindThreshold:
+		return nil, crosses(alert.Direction, value, alert.Threshold)
+
+	case types.AlertKindChange:
+		// The reference point is the latest point recorded at least one window before.
+		start := series[i].Time.Add(-alert.Window)
+		j := sort.Search(i, func(j int) bool { return series[j].Time.After(start) }) - 1
+		if j < 0 {
+			return nil, false
+		}
+		reference := series[j].Value
+		if reference == 0 {
+			// The percentage change from zero is undefined.
+			return nil, false
+		}
+		change := (value - reference) / reference * 100
+		threshold := alert.Threshold
+		if alert.Direction == types.AlertDirectionBelow {
+			threshold = -threshold
+		}
+		return &reference, crosses(alert.Direction, change, threshold)
+	}
+	return nil, false
+}
+
+func crosses(direction types.AlertDirection, value, threshold float64) bool {
+	if direction == types.AlertDirectionBelow {
+		return value < threshold
+	}
+	return value > threshold
+}
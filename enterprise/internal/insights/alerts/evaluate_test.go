@@ -0,0 +1,116 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	float := func(v float64) *float64 { return &v }
+
+	points := func(capture *string, values ...float64) []store.SeriesPoint {
+		var out []store.SeriesPoint
+		for i, v := range values {
+			out = append(out, store.SeriesPoint{SeriesID: "s", Time: start.Add(time.Duration(i) * day), Value: v, Capture: capture})
+		}
+		return out
+	}
+	threshold := func(direction types.AlertDirection, threshold float64) types.InsightSeriesAlert {
+		return types.InsightSeriesAlert{Kind: types.AlertKindThreshold, Direction: direction, Threshold: threshold}
+	}
+	change := func(direction types.AlertDirection, threshold float64, window time.Duration) types.InsightSeriesAlert {
+		return types.InsightSeriesAlert{Kind: types.AlertKindChange, Direction: direction, Threshold: threshold, Window: window}
+	}
+
+	capture := "capture"
+	testCases := []struct {
+		name   string
+		alert  types.InsightSeriesAlert
+		points []store.SeriesPoint
+		want   []Trigger
+	}{
+		{
+			name:   "no points",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: nil,
+		},
+		{
+			name:   "first point above threshold",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: points(nil, 11),
+			want:   []Trigger{{Time: start, Value: 11}},
+		},
+		{
+			name:   "crosses above threshold",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: points(nil, 5, 10, 11),
+			want:   []Trigger{{Time: start.Add(2 * day), Value: 11, Since: start.Add(day)}},
+		},
+		{
+			name:   "stays above threshold",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: points(nil, 5, 12, 13),
+		},
+		{
+			name:   "crosses below threshold",
+			alert:  threshold(types.AlertDirectionBelow, 10),
+			points: points(nil, 12, 9),
+			want:   []Trigger{{Time: start.Add(day), Value: 9, Since: start}},
+		},
+		{
+			name:   "evaluates captures separately",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: append(points(nil, 5, 11), points(&capture, 11, 12)...),
+			want:   []Trigger{{Time: start.Add(day), Value: 11, Since: start}},
+		},
+		{
+			name:   "fires for capture",
+			alert:  threshold(types.AlertDirectionAbove, 10),
+			points: points(&capture, 1, 20),
+			want:   []Trigger{{Capture: capture, Time: start.Add(day), Value: 20, Since: start}},
+		},
+		{
+			name:   "change above threshold",
+			alert:  change(types.AlertDirectionAbove, 50, 2*day),
+			points: points(nil, 10, 12, 16),
+			want:   []Trigger{{Time: start.Add(2 * day), Value: 16, ReferenceValue: float(10), Since: start.Add(day)}},
+		},
+		{
+			name:   "change below threshold",
+			alert:  change(types.AlertDirectionAbove, 50, 2*day),
+			points: points(nil, 10, 12, 14),
+		},
+		{
+			name:   "change over window longer than series",
+			alert:  change(types.AlertDirectionAbove, 50, 7*day),
+			points: points(nil, 10, 12, 30),
+		},
+		{
+			name:   "decrease below threshold",
+			alert:  change(types.AlertDirectionBelow, 25, day),
+			points: points(nil, 20, 20, 10),
+			want:   []Trigger{{Time: start.Add(2 * day), Value: 10, ReferenceValue: float(20), Since: start.Add(day)}},
+		},
+		{
+			name:   "change from zero",
+			alert:  change(types.AlertDirectionAbove, 25, day),
+			points: points(nil, 0, 10),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Evaluate(tc.alert, tc.points)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected triggers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// notification is the data delivered when an alert rule fires.
+type notification struct {
+	Alert       types.InsightSeriesAlert
+	Series      *types.InsightSeries
+	Trigger     Trigger
+	InsightsURL string
+}
+
+// Message returns a one line description of the alert.
+func (n notification) Message() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Code Insights series %q", n.Series.Query)
+	if n.Trigger.Capture != "" {
+		fmt.Fprintf(&b, " (capture %q)", n.Trigger.Capture)
+	}
+
+	verb := "rose above"
+	if n.Alert.Direction == types.AlertDirectionBelow {
+		verb = "fell below"
+	}
+	switch n.Alert.Kind {
+	case types.AlertKindChange:
+		reference := 0.0
+		if n.Trigger.ReferenceValue != nil {
+			reference = *n.Trigger.ReferenceValue
+		}
+		threshold := n.Alert.Threshold
+		if n.Alert.Direction == types.AlertDirectionBelow {
+			threshold = -threshold
+		}
+		fmt.Fprintf(&b, " changed by %+.1f%% over %s, from %s to %s, which %s the threshold of %+g%%.",
+			(n.Trigger.Value-reference)/reference*100,
+			n.Alert.Window,
+			formatValue(reference),
+			formatValue(n.Trigger.Value),
+			verb,
+			threshold,
+		)
+	default:
+		fmt.Fprintf(&b, " %s %s with a value of %s.", verb, formatValue(n.Alert.Threshold), formatValue(n.Trigger.Value))
+	}
+	return b.String()
+}
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+// webhookPayload is the JSON body posted to webhook channels.
+type webhookPayload struct {
+	SeriesID       string    `json:"seriesId"`
+	Query          string    `json:"query"`
+	Capture        string    `json:"capture,omitempty"`
+	Kind           string    `json:"kind"`
+	Direction      string    `json:"direction"`
+	Threshold      float64   `json:"threshold"`
+	WindowSeconds  int       `json:"windowSeconds,omitempty"`
+	Time           time.Time `json:"time"`
+	Value          float64   `json:"value"`
+	ReferenceValue *float64  `json:"referenceValue,omitempty"`
+	Message        string    `json:"message"`
+	InsightsURL    string    `json:"insightsURL"`
+}
+
+func newWebhookPayload(n notification) webhookPayload {
+	return webhookPayload{
+		SeriesID:       n.Series.SeriesID,
+		Query:          n.Series.Query,
+		Capture:        n.Trigger.Capture,
+		Kind:           string(n.Alert.Kind),
+		Direction:      string(n.Alert.Direction),
+		Threshold:      n.Alert.Threshold,
+		WindowSeconds:  int(n.Alert.Window / time.Second),
+		Time:           n.Trigger.Time,
+		Value:          n.Trigger.Value,
+		ReferenceValue: n.Trigger.ReferenceValue,
+		Message:        n.Message(),
+		InsightsURL:    n.InsightsURL,
+	}
+}
+
+func newSlackPayload(n notification) *slack.WebhookMessage {
+	text := fmt.Sprintf("%s <%s|View insights>", n.Message(), n.InsightsURL)
+	return &slack.WebhookMessage{
+		Text: n.Message(),
+		Blocks: &slack.Blocks{BlockSet: []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+		}},
+	}
+}
+
+var alertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Code Insights alert: {{.Query}}`,
+	Text: `{{.Message}}
+
+View insights: {{.InsightsURL}}
+`,
+	HTML: `<p>{{.Message}}</p>
+
+<p><a href="{{.InsightsURL}}">View insights</a></p>
+`,
+})
+
+func (a *Alerter) sendEmail(ctx context.Context, userID int32, n notification) error {
+	email, verified, err := a.userEmails.GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+		}
+		return errors.Wrapf(err, "getting primary email of user ID %d", userID)
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
+	}
+
+	return internalapi.Client.SendEmail(ctx, "insights-alert", txtypes.Message{
+		To:       []string{email},
+		Template: alertEmailTemplates,
+		Data: struct {
+			Query       string
+			Message     string
+			InsightsURL string
+		}{
+			Query:       n.Series.Query,
+			Message:     n.Message(),
+			InsightsURL: n.InsightsURL,
+		},
+	})
+}
+
+// deliver sends the notification through the channel of its alert rule.
+func (a *Alerter) deliver(ctx context.Context, n notification) error {
+	switch n.Alert.Channel {
+	case types.AlertChannelEmail:
+		if n.Alert.RecipientUserID == nil {
+			return errors.New("email alert has no recipient")
+		}
+		return a.sendEmail(ctx, *n.Alert.RecipientUserID, n)
+	case types.AlertChannelSlack:
+		return background.PostSlackWebhook(ctx, a.doer, *n.Alert.URL, newSlackPayload(n))
+	case types.AlertChannelWebhook:
+		raw, err := json.Marshal(newWebhookPayload(n))
+		if err != nil {
+			return errors.Wrap(err, "marshal failed")
+		}
+		return background.PostWebhookBody(ctx, a.doer, *n.Alert.URL, raw, nil, "")
+	}
+	return errors.Newf("unknown alert channel %q", n.Alert.Channel)
+}
@@ -0,0 +1,102 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestNotificationMessage(t *testing.T) {
+	series := &types.InsightSeries{SeriesID: "s", Query: "TODO"}
+	reference := 10.0
+
+	testCases := []struct {
+		name         string
+		notification notification
+		want         string
+	}{
+		{
+			name: "threshold",
+			notification: notification{
+				Alert:   types.InsightSeriesAlert{Kind: types.AlertKindThreshold, Direction: types.AlertDirectionAbove, Threshold: 100},
+				Series:  series,
+				Trigger: Trigger{Value: 120},
+			},
+			want: `Code Insights series "TODO" rose above 100 with a value of 120.`,
+		},
+		{
+			name: "threshold with capture",
+			notification: notification{
+				Alert:   types.InsightSeriesAlert{Kind: types.AlertKindThreshold, Direction: types.AlertDirectionBelow, Threshold: 5},
+				Series:  series,
+				Trigger: Trigger{Capture: "go", Value: 4},
+			},
+			want: `Code Insights series "TODO" (capture "go") fell below 5 with a value of 4.`,
+		},
+		{
+			name: "change",
+			notification: notification{
+				Alert:   types.InsightSeriesAlert{Kind: types.AlertKindChange, Direction: types.AlertDirectionBelow, Threshold: 20, Window: 24 * time.Hour},
+				Series:  series,
+				Trigger: Trigger{Value: 5, ReferenceValue: &reference},
+			},
+			want: `Code Insights series "TODO" changed by -50.0% over 24h0m0s, from 10 to 5, which fell below the threshold of -20%.`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.notification.Message(); got != tc.want {
+				t.Errorf("unexpected message: want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	var got webhookPayload
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	url := s.URL
+	a := &Alerter{doer: s.Client()}
+	n := notification{
+		Alert:       types.InsightSeriesAlert{Kind: types.AlertKindThreshold, Direction: types.AlertDirectionAbove, Threshold: 100, Channel: types.AlertChannelWebhook, URL: &url},
+		Series:      &types.InsightSeries{SeriesID: "s", Query: "TODO"},
+		Trigger:     Trigger{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Value: 120},
+		InsightsURL: "https://sourcegraph.example.com/insights",
+	}
+	if err := a.deliver(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	if got.SeriesID != "s" || got.Value != 120 || got.Kind != "THRESHOLD" || got.InsightsURL != n.InsightsURL {
+		t.Errorf("unexpected payload %+v", got)
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer s.Close()
+
+		url := s.URL
+		n.Alert.Channel = types.AlertChannelSlack
+		n.Alert.URL = &url
+		if err := a.deliver(context.Background(), n); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -30,6 +31,7 @@ type workHandler struct {
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
 	limiter         *ratelimit.InstrumentedLimiter
+	alerter         *alerts.Alerter
 	logger          log.Logger
 
 	mu          sync.RWMutex
@@ -145,6 +147,56 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
 }
 
+var _ workerutil.WithHooks[*Job] = &workHandler{}
+
+func (r *workHandler) PreHandle(ctx context.Context, logger log.Logger, record *Job) {}
+
+// PostHandle evaluates the alert rules of the series of the record once the record has been
+// marked as completed and no other job of the series is pending, so that alerts are never
+// evaluated against a partially recorded point. Alerts are best effort, so errors are only logged.
+func (r *workHandler) PostHandle(ctx context.Context, logger log.Logger, record *Job) {
+	if r.alerter == nil {
+		return
+	}
+
+	ctx = actor.WithInternalActor(ctx)
+	ss := basestore.NewWithHandle(r.baseWorkerStore.Handle())
+	complete, _, err := basestore.ScanFirstBool(ss.Query(ctx, sqlf.Sprintf(seriesRecordingCompleteQuery, record.RecordID(), record.SeriesID, record.SeriesID, record.RecordTime)))
+	if err != nil {
+		logger.Error("insights alert evaluation failed", log.String("seriesId", record.SeriesID), log.Error(err))
+		return
+	}
+	if !complete {
+		return
+	}
+
+	series, err := r.getSeries(ctx, record.SeriesID)
+	if err != nil {
+		logger.Error("insights alert evaluation failed", log.String("seriesId", record.SeriesID), log.Error(err))
+		return
+	}
+	if err := r.alerter.EvaluateSeries(ctx, series); err != nil {
+		logger.Error("insights alert evaluation failed", log.Int("seriesId", series.ID), log.Error(err))
+	}
+}
+
+// seriesRecordingCompleteQuery returns true if the given job has completed, no job of its
+// series is still pending, and no job recording the same point of the series has failed.
+// Concurrent jobs are marked as completed before their PostHandle runs, so the last of them
+// always sees the point as complete.
+const seriesRecordingCompleteQuery = `
+SELECT
+	EXISTS (
+		SELECT 1 FROM insights_query_runner_jobs WHERE id = %s AND state = 'completed'
+	) AND NOT EXISTS (
+		SELECT 1 FROM insights_query_runner_jobs
+		WHERE series_id = %s AND state IN ('queued', 'processing', 'errored')
+	) AND NOT EXISTS (
+		SELECT 1 FROM insights_query_runner_jobs
+		WHERE series_id = %s AND record_time IS NOT DISTINCT FROM %s AND state = 'failed'
+	)
+`
+
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
 	if errors.Is(err, SearchTimeoutError) {
 		return store.ReasonTimeout
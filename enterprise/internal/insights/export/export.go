@@ -0,0 +1,101 @@
+// Package export writes the points of Code Insights series in bulk, for loading them into
+// other systems.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is a file format points can be exported as.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+// ContentType returns the media type of files in the format.
+func (f Format) ContentType() string {
+	if f == FormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer writes exported points. Close must be called after the last point has been
+// written to complete the output.
+type Writer interface {
+	Write(point store.ExportedSeriesPoint) error
+	Close() error
+}
+
+// NewWriter returns a Writer writing points in the given format to w.
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatParquet:
+		return newParquetWriter(w), nil
+	}
+	return nil, errors.Newf("unsupported export format %q", format)
+}
+
+var csvHeader = []string{"series_id", "time", "value", "repo_id", "repo_name", "capture"}
+
+// csvWriter writes points as CSV, with a header row. Null values are written as empty
+// fields.
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Write(point store.ExportedSeriesPoint) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	var repoID, repoName, capture string
+	if point.RepoID != nil {
+		repoID = strconv.Itoa(int(*point.RepoID))
+	}
+	if point.RepoName != nil {
+		repoName = *point.RepoName
+	}
+	if point.Capture != nil {
+		capture = *point.Capture
+	}
+	return c.w.Write([]string{
+		point.SeriesID,
+		point.Time.UTC().Format(time.RFC3339),
+		strconv.FormatFloat(point.Value, 'f', -1, 64),
+		repoID,
+		repoName,
+		capture,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
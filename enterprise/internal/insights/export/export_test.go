@@ -0,0 +1,177 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func testPoints() []store.ExportedSeriesPoint {
+	repoID := api.RepoID(2)
+	repoName := "github.com/sourcegraph/sourcegraph"
+	capture := "go, 1.19"
+	t := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []store.ExportedSeriesPoint{
+		{SeriesID: "s1", Time: t, Value: 3, RepoID: &repoID, RepoName: &repoName},
+		{SeriesID: "s1", Time: t.Add(24 * time.Hour), Value: 1.5, RepoID: &repoID, RepoName: &repoName, Capture: &capture},
+		{SeriesID: "s2", Time: t, Value: 7},
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, point := range testPoints() {
+		if err := w.Write(point); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `series_id,time,value,repo_id,repo_name,capture
+s1,2023-01-01T00:00:00Z,3,2,github.com/sourcegraph/sourcegraph,
+s1,2023-01-02T00:00:00Z,1.5,2,github.com/sourcegraph/sourcegraph,"go, 1.19"
+s2,2023-01-01T00:00:00Z,7,,,
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected CSV (-want +got):\n%s", diff)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, _ := NewWriter(FormatCSV, &buf)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff("series_id,time,value,repo_id,repo_name,capture\n", buf.String()); diff != "" {
+			t.Errorf("unexpected CSV (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestParquetWriter(t *testing.T) {
+	for _, n := range []int{0, 3, parquetRowGroupSize + 1} {
+		var buf bytes.Buffer
+		w, err := NewWriter(FormatParquet, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points := testPoints()
+		for i := 0; i < n; i++ {
+			if err := w.Write(points[i%len(points)]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := buf.Bytes()
+		if !bytes.HasPrefix(file, parquetMagic) || !bytes.HasSuffix(file, parquetMagic) {
+			t.Fatalf("%d points: missing magic bytes", n)
+		}
+		metadataLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+		if metadataLen <= 0 || metadataLen > len(file)-12 {
+			t.Fatalf("%d points: invalid metadata length %d", n, metadataLen)
+		}
+		wantRowGroups := (n + parquetRowGroupSize - 1) / parquetRowGroupSize
+		if got := len(w.(*parquetWriter).rowGroups); got != wantRowGroups {
+			t.Errorf("%d points: expected %d row groups, got %d", n, wantRowGroups, got)
+		}
+		if got := w.(*parquetWriter).numRows; got != int64(n) {
+			t.Errorf("expected %d rows, got %d", n, got)
+		}
+	}
+}
+
+var update = flag.Bool("update", false, "update testdata")
+
+// TestParquetWriterGolden compares the output of the writer with files that have been
+// decoded by a Parquet reader (parquet-tools of github.com/xitongsys/parquet-go):
+//
+//	$ parquet-tools -cmd cat -file testdata/points.parquet
+//	[{"Series_id":"s1","Time":1672531200000,"Value":3,"Repo_id":2,"Repo_name":"github.com/sourcegraph/sourcegraph","Capture":null},
+//	 {"Series_id":"s1","Time":1672617600000,"Value":1.5,"Repo_id":2,"Repo_name":"github.com/sourcegraph/sourcegraph","Capture":"go, 1.19"},
+//	 {"Series_id":"s2","Time":1672531200000,"Value":7,"Repo_id":null,"Repo_name":null,"Capture":null}]
+//	$ parquet-tools -cmd rowcount -file testdata/empty.parquet
+//	0
+//
+// After running the test with -update, check the new files the same way before committing
+// them.
+func TestParquetWriterGolden(t *testing.T) {
+	for name, points := range map[string][]store.ExportedSeriesPoint{
+		"points": testPoints(),
+		"empty":  nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(FormatParquet, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, point := range points {
+				if err := w.Write(point); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", name+".parquet")
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(want, buf.Bytes()) {
+				t.Errorf("output differs from %s, run the test with -update and check the new file with parquet-tools", path)
+			}
+		})
+	}
+}
+
+func TestDefinitionLevels(t *testing.T) {
+	got := appendDefinitionLevels(nil, []bool{true, true, false, true})
+	// Length prefix, then runs of (count << 1) followed by the level.
+	want := []byte{6, 0, 0, 0, 4, 1, 2, 0, 2, 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected definition levels (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/.api/insights/export?format=parquet&seriesId=a&seriesId=b&from=2023-01-01T00:00:00Z", nil)
+	req, err := parseRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.format != FormatParquet || !cmp.Equal(req.seriesIDs, []string{"a", "b"}) || req.from == nil || req.to != nil {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	for _, query := range []string{"format=xlsx", "from=yesterday"} {
+		if _, err := parseRequest(httptest.NewRequest("GET", "/.api/insights/export?"+query, nil)); err == nil {
+			t.Errorf("expected error for %q", query)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Handler streams the points of the insight series visible to the current user as a file.
+//
+// The request accepts the following query parameters:
+//
+//   - format: the file format, "csv" (default) or "parquet".
+//   - seriesId: the series to export, may be repeated. All visible series are exported if
+//     omitted.
+//   - from, to: optional RFC 3339 timestamps limiting the exported points (inclusive).
+type Handler struct {
+	logger       log.Logger
+	db           database.DB
+	insightStore *store.InsightStore
+	seriesStore  *store.Store
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(db database.DB, insightsDB edb.InsightsDB) *Handler {
+	return &Handler{
+		logger:       log.Scoped("InsightsExportHandler", "Code Insights series export handler"),
+		db:           db,
+		insightStore: store.NewInsightStore(insightsDB),
+		seriesStore:  store.New(insightsDB, store.NewInsightPermissionStore(db)),
+	}
+}
+
+type exportRequest struct {
+	format    Format
+	seriesIDs []string
+	from, to  *time.Time
+}
+
+func parseRequest(r *http.Request) (*exportRequest, error) {
+	query := r.URL.Query()
+
+	req := &exportRequest{format: FormatCSV, seriesIDs: query["seriesId"]}
+	if format := query.Get("format"); format != "" {
+		req.format = Format(format)
+	}
+	if req.format != FormatCSV && req.format != FormatParquet {
+		return nil, errors.Newf("unsupported export format %q", req.format)
+	}
+
+	parseTime := func(name string) (*time.Time, error) {
+		v := query.Get(name)
+		if v == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", name)
+		}
+		t = t.UTC()
+		return &t, nil
+	}
+	var err error
+	if req.from, err = parseTime("from"); err != nil {
+		return nil, err
+	}
+	if req.to, err = parseTime("to"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seriesIDs, err := h.visibleSeriesIDs(ctx, req.seriesIDs)
+	if err != nil {
+		if errors.Is(err, errSeriesNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to load insight series", log.Error(err))
+		http.Error(w, "failed to load insight series", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", req.format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "insights-export."+string(req.format)))
+
+	// Once points have been written the status can no longer be changed, so errors are only
+	// logged and the client is left with a truncated file.
+	if err := h.export(ctx, w, req, seriesIDs); err != nil {
+		h.logger.Error("failed to export insight series", log.Error(err))
+	}
+}
+
+func (h *Handler) export(ctx context.Context, w http.ResponseWriter, req *exportRequest, seriesIDs []string) error {
+	writer, err := NewWriter(req.format, w)
+	if err != nil {
+		return err
+	}
+	for _, seriesID := range seriesIDs {
+		seriesID := seriesID
+		opts := store.SeriesPointsOpts{SeriesID: &seriesID, From: req.from, To: req.to}
+		if err := h.seriesStore.ExportSeriesPoints(ctx, opts, writer.Write); err != nil {
+			return errors.Wrapf(err, "exporting series %q", seriesID)
+		}
+	}
+	return writer.Close()
+}
+
+var errSeriesNotFound = errors.New("insight series not found")
+
+// visibleSeriesIDs returns the IDs of the requested series, or of all series if none are
+// requested, that belong to an insight view the current user has access to.
+func (h *Handler) visibleSeriesIDs(ctx context.Context, requested []string) ([]string, error) {
+	// 🚨 SECURITY: Only series of insights shared with the user or one of their organizations
+	// are visible.
+	userID := actor.FromContext(ctx).UID
+	orgs, err := h.db.Orgs().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	orgIDs := make([]int, 0, len(orgs))
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+
+	views, err := h.insightStore.GetAll(ctx, store.InsightQueryArgs{UserID: []int{int(userID)}, OrgID: orgIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(map[string]struct{}, len(views))
+	var all []string
+	for _, view := range views {
+		if _, ok := visible[view.SeriesID]; ok {
+			continue
+		}
+		visible[view.SeriesID] = struct{}{}
+		all = append(all, view.SeriesID)
+	}
+	if len(requested) == 0 {
+		return all, nil
+	}
+
+	seriesIDs := make([]string, 0, len(requested))
+	for _, seriesID := range requested {
+		if _, ok := visible[seriesID]; !ok {
+			return nil, errors.Wrapf(errSeriesNotFound, "%q", seriesID)
+		}
+		seriesIDs = append(seriesIDs, seriesID)
+	}
+	return seriesIDs, nil
+}
@@ -0,0 +1,393 @@
+package export
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+// parquetRowGroupSize is the number of rows buffered in memory before they are written
+// out as a row group.
+const parquetRowGroupSize = 50_000
+
+var parquetMagic = []byte("PAR1")
+
+// Constants of the Parquet format, see
+// https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift.
+const (
+	parquetTypeInt32     int32 = 1
+	parquetTypeInt64     int32 = 2
+	parquetTypeDouble    int32 = 5
+	parquetTypeByteArray int32 = 6
+
+	parquetRepetitionRequired int32 = 0
+	parquetRepetitionOptional int32 = 1
+
+	parquetConvertedTypeUTF8            int32 = 0
+	parquetConvertedTypeTimestampMillis int32 = 9
+
+	parquetEncodingPlain int32 = 0
+	parquetEncodingRLE   int32 = 3
+
+	parquetCodecUncompressed int32 = 0
+	parquetPageTypeData      int32 = 0
+)
+
+// parquetColumn describes a column of the exported Parquet files.
+type parquetColumn struct {
+	name          string
+	physicalType  int32
+	convertedType *int32
+	optional      bool
+	// appendValue appends the PLAIN encoded value of the column in point to buf. It
+	// returns false and buf unchanged if the value is null.
+	appendValue func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool)
+}
+
+func convertedType(t int32) *int32 { return &t }
+
+var parquetColumns = []parquetColumn{
+	{
+		name:          "series_id",
+		physicalType:  parquetTypeByteArray,
+		convertedType: convertedType(parquetConvertedTypeUTF8),
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			return appendByteArray(buf, point.SeriesID), true
+		},
+	},
+	{
+		name:          "time",
+		physicalType:  parquetTypeInt64,
+		convertedType: convertedType(parquetConvertedTypeTimestampMillis),
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			return binary.LittleEndian.AppendUint64(buf, uint64(point.Time.UnixMilli())), true
+		},
+	},
+	{
+		name:         "value",
+		physicalType: parquetTypeDouble,
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			return binary.LittleEndian.AppendUint64(buf, math.Float64bits(point.Value)), true
+		},
+	},
+	{
+		name:         "repo_id",
+		physicalType: parquetTypeInt32,
+		optional:     true,
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			if point.RepoID == nil {
+				return buf, false
+			}
+			return binary.LittleEndian.AppendUint32(buf, uint32(*point.RepoID)), true
+		},
+	},
+	{
+		name:          "repo_name",
+		physicalType:  parquetTypeByteArray,
+		convertedType: convertedType(parquetConvertedTypeUTF8),
+		optional:      true,
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			if point.RepoName == nil {
+				return buf, false
+			}
+			return appendByteArray(buf, *point.RepoName), true
+		},
+	},
+	{
+		name:          "capture",
+		physicalType:  parquetTypeByteArray,
+		convertedType: convertedType(parquetConvertedTypeUTF8),
+		optional:      true,
+		appendValue: func(buf []byte, point store.ExportedSeriesPoint) ([]byte, bool) {
+			if point.Capture == nil {
+				return buf, false
+			}
+			return appendByteArray(buf, *point.Capture), true
+		},
+	},
+}
+
+func appendByteArray(buf []byte, s string) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s)))
+	return append(buf, s...)
+}
+
+// parquetWriter writes points as an Apache Parquet file. Points are buffered into row
+// groups, and each column of a row group is written as a single uncompressed data page.
+// The file metadata is written by Close, the file is not readable before.
+type parquetWriter struct {
+	w         io.Writer
+	offset    int64
+	points    []store.ExportedSeriesPoint
+	rowGroups []parquetRowGroup
+	numRows   int64
+}
+
+type parquetRowGroup struct {
+	columns       []parquetColumnChunk
+	totalByteSize int64
+	numRows       int64
+}
+
+type parquetColumnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+func newParquetWriter(w io.Writer) *parquetWriter {
+	return &parquetWriter{w: w}
+}
+
+func (p *parquetWriter) write(b []byte) error {
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	return err
+}
+
+func (p *parquetWriter) Write(point store.ExportedSeriesPoint) error {
+	if p.offset == 0 {
+		if err := p.write(parquetMagic); err != nil {
+			return err
+		}
+	}
+	p.points = append(p.points, point)
+	if len(p.points) >= parquetRowGroupSize {
+		return p.flushRowGroup()
+	}
+	return nil
+}
+
+func (p *parquetWriter) flushRowGroup() error {
+	rowGroup := parquetRowGroup{numRows: int64(len(p.points))}
+	for _, column := range parquetColumns {
+		var values []byte
+		defined := make([]bool, 0, len(p.points))
+		for _, point := range p.points {
+			var ok bool
+			values, ok = column.appendValue(values, point)
+			defined = append(defined, ok)
+		}
+
+		var page []byte
+		if column.optional {
+			page = appendDefinitionLevels(page, defined)
+		}
+		page = append(page, values...)
+
+		var header compactEncoder
+		header.i32(1, parquetPageTypeData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.beginStruct(5)
+		header.i32(1, int32(len(p.points)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.end()
+		header.end()
+
+		chunk := parquetColumnChunk{
+			offset:    p.offset,
+			size:      int64(len(header.buf) + len(page)),
+			numValues: int64(len(p.points)),
+		}
+		if err := p.write(header.buf); err != nil {
+			return err
+		}
+		if err := p.write(page); err != nil {
+			return err
+		}
+		rowGroup.columns = append(rowGroup.columns, chunk)
+		rowGroup.totalByteSize += chunk.size
+	}
+
+	p.rowGroups = append(p.rowGroups, rowGroup)
+	p.numRows += rowGroup.numRows
+	p.points = p.points[:0]
+	return nil
+}
+
+// appendDefinitionLevels appends the definition levels of an optional column, encoded
+// with the RLE / bit-packing hybrid encoding using only RLE runs, and prefixed with
+// their length.
+func appendDefinitionLevels(buf []byte, defined []bool) []byte {
+	var levels []byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		levels = binary.AppendUvarint(levels, uint64(j-i)<<1)
+		if defined[i] {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		i = j
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(levels)))
+	return append(buf, levels...)
+}
+
+func (p *parquetWriter) Close() error {
+	if p.offset == 0 {
+		if err := p.write(parquetMagic); err != nil {
+			return err
+		}
+	}
+	if len(p.points) > 0 {
+		if err := p.flushRowGroup(); err != nil {
+			return err
+		}
+	}
+
+	metadata := p.fileMetadata()
+	if err := p.write(metadata); err != nil {
+		return err
+	}
+	if err := p.write(binary.LittleEndian.AppendUint32(nil, uint32(len(metadata)))); err != nil {
+		return err
+	}
+	return p.write(parquetMagic)
+}
+
+func (p *parquetWriter) fileMetadata() []byte {
+	var e compactEncoder
+	e.i32(1, 1)
+
+	e.list(2, compactTypeStruct, len(parquetColumns)+1)
+	e.beginElem()
+	e.binary(4, "schema")
+	e.i32(5, int32(len(parquetColumns)))
+	e.end()
+	for _, column := range parquetColumns {
+		e.beginElem()
+		e.i32(1, column.physicalType)
+		if column.optional {
+			e.i32(3, parquetRepetitionOptional)
+		} else {
+			e.i32(3, parquetRepetitionRequired)
+		}
+		e.binary(4, column.name)
+		if column.convertedType != nil {
+			e.i32(6, *column.convertedType)
+		}
+		e.end()
+	}
+
+	e.i64(3, p.numRows)
+
+	e.list(4, compactTypeStruct, len(p.rowGroups))
+	for _, rowGroup := range p.rowGroups {
+		e.beginElem()
+		e.list(1, compactTypeStruct, len(rowGroup.columns))
+		for i, chunk := range rowGroup.columns {
+			column := parquetColumns[i]
+			e.beginElem()
+			e.i64(2, chunk.offset)
+			e.beginStruct(3)
+			e.i32(1, column.physicalType)
+			e.list(2, compactTypeI32, 2)
+			e.i32Elem(parquetEncodingPlain)
+			e.i32Elem(parquetEncodingRLE)
+			e.list(3, compactTypeBinary, 1)
+			e.binaryElem(column.name)
+			e.i32(4, parquetCodecUncompressed)
+			e.i64(5, chunk.numValues)
+			e.i64(6, chunk.size)
+			e.i64(7, chunk.size)
+			e.i64(9, chunk.offset)
+			e.end()
+			e.end()
+		}
+		e.i64(2, rowGroup.totalByteSize)
+		e.i64(3, rowGroup.numRows)
+		e.end()
+	}
+
+	e.binary(6, "Sourcegraph")
+	e.end()
+	return e.buf
+}
+
+// Field types of the Thrift compact protocol.
+const (
+	compactTypeI32    byte = 5
+	compactTypeI64    byte = 6
+	compactTypeBinary byte = 8
+	compactTypeList   byte = 9
+	compactTypeStruct byte = 12
+)
+
+// compactEncoder encodes the Parquet metadata structs with the Thrift compact protocol.
+// Calls to end close the innermost struct, or the top-level one.
+type compactEncoder struct {
+	buf     []byte
+	lastID  int16
+	idStack []int16
+}
+
+func (e *compactEncoder) fieldHeader(id int16, typ byte) {
+	if delta := id - e.lastID; delta > 0 && delta <= 15 {
+		e.buf = append(e.buf, byte(delta)<<4|typ)
+	} else {
+		e.buf = append(e.buf, typ)
+		e.buf = binary.AppendVarint(e.buf, int64(id))
+	}
+	e.lastID = id
+}
+
+func (e *compactEncoder) i32(id int16, v int32) {
+	e.fieldHeader(id, compactTypeI32)
+	e.i32Elem(v)
+}
+
+func (e *compactEncoder) i64(id int16, v int64) {
+	e.fieldHeader(id, compactTypeI64)
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *compactEncoder) binary(id int16, s string) {
+	e.fieldHeader(id, compactTypeBinary)
+	e.binaryElem(s)
+}
+
+func (e *compactEncoder) list(id int16, elemType byte, size int) {
+	e.fieldHeader(id, compactTypeList)
+	if size < 15 {
+		e.buf = append(e.buf, byte(size)<<4|elemType)
+	} else {
+		e.buf = append(e.buf, 0xf0|elemType)
+		e.buf = binary.AppendUvarint(e.buf, uint64(size))
+	}
+}
+
+func (e *compactEncoder) i32Elem(v int32) {
+	e.buf = binary.AppendVarint(e.buf, int64(v))
+}
+
+func (e *compactEncoder) binaryElem(s string) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *compactEncoder) beginStruct(id int16) {
+	e.fieldHeader(id, compactTypeStruct)
+	e.beginElem()
+}
+
+func (e *compactEncoder) beginElem() {
+	e.idStack = append(e.idStack, e.lastID)
+	e.lastID = 0
+}
+
+func (e *compactEncoder) end() {
+	e.buf = append(e.buf, 0)
+	if n := len(e.idStack); n > 0 {
+		e.lastID = e.idStack[n-1]
+		e.idStack = e.idStack[:n-1]
+	}
+}
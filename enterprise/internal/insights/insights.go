@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/export"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -64,6 +65,7 @@ func Init(
 		return err
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(rawInsightsDB, db)
+	enterpriseServices.InsightsExportHandler = export.NewHandler(db, rawInsightsDB)
 
 	return nil
 }
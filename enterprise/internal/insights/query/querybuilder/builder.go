@@ -403,7 +403,28 @@ func buildFilterText(raw string) string {
 	return fmt.Sprintf("^%s$", quoted)
 }
 
+// AddDirectoryFilter restricts the query to files under the directory dir.
+func AddDirectoryFilter(query BasicQuery, dir string) (BasicQuery, error) {
+	dir = strings.TrimSuffix(dir, "/") + "/"
+	value := "^" + regexp.QuoteMeta(dir)
+	if strings.Contains(dir, " ") {
+		value = fmt.Sprintf("(%s)", value)
+	}
+	return addParameter(query, searchquery.FieldFile, value, false)
+}
+
+// AddOwnerFilter restricts the query to files owned by owner, which is either a handle
+// prefixed with `@` or an email.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	return addParameter(query, searchquery.FieldFile, fmt.Sprintf("has.owner(%s)", owner), false)
+}
+
 func AddFilter(query BasicQuery, field, value string, negated bool) (BasicQuery, error) {
+	return addParameter(query, field, buildFilterText(value), negated)
+}
+
+// addParameter appends a parameter with the given raw value to every step of the query.
+func addParameter(query BasicQuery, field, value string, negated bool) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
@@ -414,7 +435,7 @@ func AddFilter(query BasicQuery, field, value string, negated bool) (BasicQuery,
 		modified = append(modified, basic.Parameters...)
 		modified = append(modified, searchquery.Parameter{
 			Field:      field,
-			Value:      buildFilterText(value),
+			Value:      value,
 			Negated:    negated,
 			Annotation: searchquery.Annotation{},
 		})
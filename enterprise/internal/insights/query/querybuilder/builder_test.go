@@ -603,6 +603,64 @@ func Test_addFileFilter(t *testing.T) {
 	}
 }
 
+func Test_addDirectoryFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		dir   string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery",
+			dir:   "some/directory/",
+			want:  autogold.Want("no initial file filter", BasicQuery("file:^some/directory/ myquery")),
+		},
+		{
+			input: "myquery file:\\.go$",
+			dir:   "some.dir",
+			want:  autogold.Want("one initial file filter", BasicQuery("file:\\.go$ file:^some\\.dir/ myquery")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddDirectoryFilter(BasicQuery(test.input), test.dir)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addOwnerFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		owner string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery",
+			owner: "@sourcegraph/search",
+			want:  autogold.Want("handle", BasicQuery("file:has.owner(@sourcegraph/search) myquery")),
+		},
+		{
+			input: "(myquery repo:supergreat) or (big repo:asdf)",
+			owner: "alice@example.com",
+			want:  autogold.Want("compound query adding owner", BasicQuery("(repo:supergreat file:has.owner(alice@example.com) myquery OR repo:asdf file:has.owner(alice@example.com) big)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddOwnerFilter(BasicQuery(test.input), test.owner)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
 func TestRepositoryScopeQuery(t *testing.T) {
 	tests := []struct {
 		input string
@@ -128,6 +128,50 @@ func Test_canAggregateByPath(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByOwnerAndDirectory(t *testing.T) {
+	testCases := []struct {
+		mode             types.SearchAggregationMode
+		canAggregateFunc canAggregateBy
+		unsupportedFmt   string
+	}{
+		{types.OWNER_AGGREGATION_MODE, canAggregateByOwner, ownerUnsupportedFieldValueFmt},
+		{types.DIRECTORY_AGGREGATION_MODE, canAggregateByDirectory, directoryUnsupportedFieldValueFmt},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			suite := canAggregateBySuite{
+				canAggregateByFunc: tc.canAggregateFunc,
+				testCases: []canAggregateTestCase{
+					{
+						name:         "can aggregate for query without parameters",
+						query:        "func(t *testing.T)",
+						canAggregate: true,
+					},
+					{
+						name:         "can aggregate for symbol search",
+						query:        "type:symbol insights",
+						canAggregate: true,
+					},
+					{
+						name:         "cannot aggregate for query with select:repo parameter",
+						query:        "repo:contains.path(README) select:repo",
+						reason:       fmt.Sprintf(tc.unsupportedFmt, "select", "repo"),
+						canAggregate: false,
+					},
+					{
+						name:         "cannot aggregate for query with type:diff parameter",
+						query:        "insights type:diff",
+						reason:       fmt.Sprintf(tc.unsupportedFmt, "type", "diff"),
+						canAggregate: false,
+					},
+				},
+				t: t,
+			}
+			suite.Test_canAggregateBy()
+		})
+	}
+}
+
 func Test_canAggregateByAuthor(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -456,6 +500,34 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.PATH_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("owner_handle", "file:has.owner(@sourcegraph/search) findme"),
+			query:       "findme",
+			drilldown:   "@sourcegraph/search",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("owner_email", "file:has.owner(alice@example.com) findme"),
+			query:       "findme",
+			drilldown:   "alice@example.com",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("directory_no_whitespace", "file:^cmd/frontend/ findme"),
+			query:       "findme",
+			drilldown:   "cmd/frontend/",
+			patternType: "standard",
+			mode:        types.DIRECTORY_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("directory_with_whitespace", "file:(^Drill down/) findme"),
+			query:       "findme",
+			drilldown:   "Drill down/",
+			patternType: "standard",
+			mode:        types.DIRECTORY_AGGREGATION_MODE,
+		},
 		{
 			want:        autogold.Want("capturegroup_with_whitespace", "case:yes /fin(?:d m)e/"),
 			query:       "/fin(.*)e/",
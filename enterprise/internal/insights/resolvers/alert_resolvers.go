@@ -0,0 +1,296 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+var _ graphqlbackend.InsightSeriesAlertEventResolver = &insightSeriesAlertEventResolver{}
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+func marshalInsightSeriesAlertID(id int) graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, id)
+}
+
+func unmarshalInsightSeriesAlertID(id graphql.ID) (alertID int, err error) {
+	if kind := relay.UnmarshalKind(id); kind != insightSeriesAlertKind {
+		return 0, errors.Newf("expected graphql ID to have kind %q; got %q", insightSeriesAlertKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &alertID)
+	return alertID, err
+}
+
+// seriesOfView returns the series with the given series ID of the insight view, after checking that the current
+// user can access the view.
+func (r *Resolver) seriesOfView(ctx context.Context, insightViewID graphql.ID, seriesID string) (types.InsightViewSeries, error) {
+	var viewID string
+	if err := relay.UnmarshalSpec(insightViewID, &viewID); err != nil {
+		return types.InsightViewSeries{}, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+		return types.InsightViewSeries{}, err
+	}
+
+	viewSeries, err := r.insightStore.Get(ctx, store.InsightQueryArgs{UniqueID: viewID, WithoutAuthorization: true})
+	if err != nil {
+		return types.InsightViewSeries{}, errors.Wrap(err, "Get")
+	}
+	for _, series := range viewSeries {
+		if series.SeriesID == seriesID {
+			return series, nil
+		}
+	}
+	return types.InsightViewSeries{}, errors.New("series not found")
+}
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	series, err := r.seriesOfView(ctx, args.InsightViewId, args.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	alertStore := store.NewAlertStore(r.insightsDB)
+	alerts, err := alertStore.GetAlerts(ctx, store.AlertQueryArgs{SeriesID: &series.InsightSeriesID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID, alertStore: alertStore, db: r.postgresDB})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	// 🚨 SECURITY: alert rules deliver data of the series, so they can only be created by a signed in user that can
+	// access the insight view.
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("must be signed in to create an alert")
+	}
+	series, err := r.seriesOfView(ctx, args.Input.InsightViewId, args.Input.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := types.InsightSeriesAlert{
+		SeriesID:  series.InsightSeriesID,
+		Kind:      types.AlertKind(args.Input.Kind),
+		Direction: types.AlertDirection(args.Input.Direction),
+		Threshold: args.Input.Threshold,
+		Channel:   types.AlertChannel(args.Input.Channel),
+		Enabled:   true,
+		CreatedBy: uid,
+	}
+	if args.Input.WindowSeconds != nil {
+		alert.Window = time.Duration(*args.Input.WindowSeconds) * time.Second
+	}
+	if args.Input.Enabled != nil {
+		alert.Enabled = *args.Input.Enabled
+	}
+	switch alert.Channel {
+	case types.AlertChannelEmail:
+		// 🚨 SECURITY: users can only subscribe themselves to email alerts.
+		alert.RecipientUserID = &uid
+	case types.AlertChannelSlack, types.AlertChannelWebhook:
+		alert.URL = args.Input.URL
+	}
+
+	alertStore := store.NewAlertStore(r.insightsDB)
+	created, err := alertStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesAlertResolver{alert: created, seriesID: series.SeriesID, alertStore: alertStore, db: r.postgresDB}, nil
+}
+
+// editableAlert returns the alert rule with the given ID, after checking that the current user created it or is a
+// site admin.
+func (r *Resolver) editableAlert(ctx context.Context, alertStore *store.AlertStore, id graphql.ID) (types.InsightSeriesAlert, error) {
+	alertID, err := unmarshalInsightSeriesAlertID(id)
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	alerts, err := alertStore.GetAlerts(ctx, store.AlertQueryArgs{ID: &alertID})
+	if err != nil {
+		return types.InsightSeriesAlert{}, errors.Wrap(err, "GetAlerts")
+	}
+	if len(alerts) == 0 {
+		return types.InsightSeriesAlert{}, errors.New("alert not found")
+	}
+	// 🚨 SECURITY: only the creator of an alert rule and site admins can change it.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.postgresDB, alerts[0].CreatedBy); err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	return alerts[0], nil
+}
+
+func (r *Resolver) UpdateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	alertStore := store.NewAlertStore(r.insightsDB)
+	alert, err := r.editableAlert(ctx, alertStore, args.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := alertStore.SetAlertEnabled(ctx, alert.ID, args.Enabled); err != nil {
+		return nil, errors.Wrap(err, "SetAlertEnabled")
+	}
+	alert.Enabled = args.Enabled
+
+	series, err := r.insightStore.GetDataSeriesByID(ctx, alert.SeriesID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDataSeriesByID")
+	}
+	return &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID, alertStore: alertStore, db: r.postgresDB}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	alertStore := store.NewAlertStore(r.insightsDB)
+	alert, err := r.editableAlert(ctx, alertStore, args.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := alertStore.DeleteAlert(ctx, alert.ID); err != nil {
+		return nil, errors.Wrap(err, "DeleteAlert")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert      types.InsightSeriesAlert
+	seriesID   string
+	alertStore *store.AlertStore
+	db         database.DB
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return marshalInsightSeriesAlertID(r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string {
+	return r.seriesID
+}
+
+func (r *insightSeriesAlertResolver) Kind() string {
+	return string(r.alert.Kind)
+}
+
+func (r *insightSeriesAlertResolver) Direction() string {
+	return string(r.alert.Direction)
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64 {
+	return r.alert.Threshold
+}
+
+func (r *insightSeriesAlertResolver) WindowSeconds() *int32 {
+	if r.alert.Window == 0 {
+		return nil
+	}
+	seconds := int32(r.alert.Window / time.Second)
+	return &seconds
+}
+
+func (r *insightSeriesAlertResolver) Channel() string {
+	return string(r.alert.Channel)
+}
+
+func (r *insightSeriesAlertResolver) URL(ctx context.Context) *string {
+	// 🚨 SECURITY: Slack and webhook URLs are credentials, so only the creator of the alert rule can see them.
+	if actor.FromContext(ctx).UID != r.alert.CreatedBy {
+		return nil
+	}
+	return r.alert.URL
+}
+
+func (r *insightSeriesAlertResolver) Recipient(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.alert.RecipientUserID == nil {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, *r.alert.RecipientUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *insightSeriesAlertResolver) Enabled() bool {
+	return r.alert.Enabled
+}
+
+func (r *insightSeriesAlertResolver) CreatedBy(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.alert.CreatedBy)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.alert.CreatedAt}
+}
+
+func (r *insightSeriesAlertResolver) Events(ctx context.Context, args *graphqlbackend.InsightSeriesAlertEventsArgs) ([]graphqlbackend.InsightSeriesAlertEventResolver, error) {
+	limit := 50
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	events, err := r.alertStore.GetAlertEvents(ctx, store.AlertEventQueryArgs{AlertID: &r.alert.ID, Limit: limit})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlertEvents")
+	}
+
+	resolvers := make([]graphqlbackend.InsightSeriesAlertEventResolver, 0, len(events))
+	for _, event := range events {
+		resolvers = append(resolvers, &insightSeriesAlertEventResolver{event: event})
+	}
+	return resolvers, nil
+}
+
+type insightSeriesAlertEventResolver struct {
+	event types.InsightSeriesAlertEvent
+}
+
+func (r *insightSeriesAlertEventResolver) Capture() *string {
+	if r.event.Capture == "" {
+		return nil
+	}
+	return &r.event.Capture
+}
+
+func (r *insightSeriesAlertEventResolver) Time() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.Time}
+}
+
+func (r *insightSeriesAlertEventResolver) Value() float64 {
+	return r.event.Value
+}
+
+func (r *insightSeriesAlertEventResolver) ReferenceValue() *float64 {
+	return r.event.ReferenceValue
+}
+
+func (r *insightSeriesAlertEventResolver) DeliveredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.event.DeliveredAt)
+}
+
+func (r *insightSeriesAlertEventResolver) Error() *string {
+	return r.event.Error
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/scheduler/iterator"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -64,6 +65,7 @@ func makeInProgressWorker(ctx context.Context, config JobMonitorConfig) (*worker
 		insightsStore:      config.InsightStore,
 		backfillRunner:     config.BackfillRunner,
 		repoStore:          config.RepoStore,
+		alerter:            config.Alerter,
 		clock:              glock.NewRealClock(),
 		config:             handlerConfig,
 	}
@@ -103,6 +105,7 @@ type inProgressHandler struct {
 	repoStore          database.RepoStore
 	insightsStore      store.Interface
 	backfillRunner     pipeline.Backfiller
+	alerter            *alerts.Alerter
 	config             handlerConfig
 
 	clock glock.Clock
@@ -234,7 +237,11 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 	}
 
 	if !execution.itr.HasMore() && !execution.itr.HasErrors() {
-		return false, h.finish(ctx, execution)
+		if err := h.finish(ctx, execution); err != nil {
+			return false, err
+		}
+		h.evaluateAlerts(ctx, execution)
+		return false, nil
 	} else {
 		// in this state we have some errors that will need reprocessing, we will place this job back in queue
 		return true, nil
@@ -265,6 +272,17 @@ func (h *inProgressHandler) finish(ctx context.Context, ex *backfillExecution) (
 	return nil
 }
 
+// evaluateAlerts evaluates the alert rules of a series once its backfill is complete. Alerts are
+// best effort, so errors are only logged.
+func (h *inProgressHandler) evaluateAlerts(ctx context.Context, ex *backfillExecution) {
+	if h.alerter == nil {
+		return
+	}
+	if err := h.alerter.EvaluateSeries(ctx, ex.series); err != nil {
+		ex.logger.Error("insights alert evaluation failed", ex.logFields(log.Error(err))...)
+	}
+}
+
 func (h *inProgressHandler) disableBackfill(ctx context.Context, ex *backfillExecution) (err error) {
 	tx, err := h.backfillStore.Transact(ctx)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/pipeline"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/priority"
@@ -114,6 +115,8 @@ type JobMonitorConfig struct {
 	AllRepoIterator   *discovery.AllReposIterator
 	CostAnalyzer      *priority.QueryAnalyzer
 	RepoQueryExecutor query.RepoQueryExecutor
+	// Alerter, if not nil, evaluates the alert rules of a series once its backfill completes.
+	Alerter *alerts.Alerter
 }
 
 func NewBackgroundJobMonitor(ctx context.Context, config JobMonitorConfig) *BackgroundJobMonitor {
@@ -0,0 +1,286 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore stores the alert rules of insight series and their history.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+// ValidateAlert returns an error if the alert rule is not well formed.
+func ValidateAlert(alert types.InsightSeriesAlert) error {
+	switch alert.Kind {
+	case types.AlertKindThreshold:
+	case types.AlertKindChange:
+		if alert.Window <= 0 {
+			return errors.New("change alerts require a positive window")
+		}
+		if alert.Threshold < 0 {
+			return errors.New("change alerts require a non-negative threshold")
+		}
+	default:
+		return errors.Newf("invalid alert kind %q", alert.Kind)
+	}
+
+	switch alert.Direction {
+	case types.AlertDirectionAbove, types.AlertDirectionBelow:
+	default:
+		return errors.Newf("invalid alert direction %q", alert.Direction)
+	}
+
+	switch alert.Channel {
+	case types.AlertChannelEmail:
+		if alert.RecipientUserID == nil {
+			return errors.New("email alerts require a recipient")
+		}
+	case types.AlertChannelSlack, types.AlertChannelWebhook:
+		if alert.URL == nil || *alert.URL == "" {
+			return errors.Newf("%s alerts require a URL", alert.Channel)
+		}
+	default:
+		return errors.Newf("invalid alert channel %q", alert.Channel)
+	}
+	return nil
+}
+
+// CreateAlert creates an alert rule for an insight series.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	if err := ValidateAlert(alert); err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	q := sqlf.Sprintf(
+		createAlertSql,
+		alert.SeriesID,
+		alert.Kind,
+		alert.Direction,
+		alert.Threshold,
+		int(alert.Window/time.Second),
+		alert.Channel,
+		alert.URL,
+		alert.RecipientUserID,
+		alert.Enabled,
+		alert.CreatedBy,
+		s.Now(),
+	)
+	alerts, err := scanAlerts(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	if len(alerts) == 0 {
+		return types.InsightSeriesAlert{}, errors.New("failed to create alert")
+	}
+	return alerts[0], nil
+}
+
+const createAlertSql = `
+INSERT INTO insight_series_alerts (series_id, kind, direction, threshold, window_seconds, channel, url, recipient_user_id, enabled, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING ` + alertColumns
+
+type AlertQueryArgs struct {
+	ID       *int
+	SeriesID *int
+	// EnabledOnly restricts the results to the alerts that are evaluated.
+	EnabledOnly bool
+}
+
+// GetAlerts returns the alert rules matching args.
+func (s *AlertStore) GetAlerts(ctx context.Context, args AlertQueryArgs) ([]types.InsightSeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID != nil {
+		preds = append(preds, sqlf.Sprintf("id = %s", *args.ID))
+	}
+	if args.SeriesID != nil {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", *args.SeriesID))
+	}
+	if args.EnabledOnly {
+		preds = append(preds, sqlf.Sprintf("enabled"))
+	}
+	q := sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "\n AND "))
+	return scanAlerts(s.Query(ctx, q))
+}
+
+var getAlertsSql = `
+SELECT ` + alertColumns + `
+FROM insight_series_alerts
+WHERE %s
+ORDER BY id
+`
+
+// SetAlertEnabled enables or disables an alert rule.
+func (s *AlertStore) SetAlertEnabled(ctx context.Context, id int, enabled bool) error {
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE insight_series_alerts SET enabled = %s WHERE id = %s", enabled, id))
+}
+
+// DeleteAlert deletes an alert rule and its history.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf("DELETE FROM insight_series_alerts WHERE id = %s", id))
+}
+
+const alertColumns = `id, series_id, kind, direction, threshold, window_seconds, channel, url, recipient_user_id, enabled, created_by, created_at`
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	var results []types.InsightSeriesAlert
+	err = scanAll(rows, func(sc scanner) error {
+		var alert types.InsightSeriesAlert
+		var windowSeconds int
+		if err := sc.Scan(
+			&alert.ID,
+			&alert.SeriesID,
+			&alert.Kind,
+			&alert.Direction,
+			&alert.Threshold,
+			&windowSeconds,
+			&alert.Channel,
+			&alert.URL,
+			&alert.RecipientUserID,
+			&alert.Enabled,
+			&alert.CreatedBy,
+			&alert.CreatedAt,
+		); err != nil {
+			return err
+		}
+		alert.Window = time.Duration(windowSeconds) * time.Second
+		results = append(results, alert)
+		return nil
+	})
+	return results, err
+}
+
+// HasAlertEventAfter returns whether the alert already fired for the capture on a point
+// recorded after the given time.
+func (s *AlertStore) HasAlertEventAfter(ctx context.Context, alertID int, capture string, after time.Time) (bool, error) {
+	q := sqlf.Sprintf(
+		"SELECT EXISTS (SELECT 1 FROM insight_series_alert_events WHERE alert_id = %s AND capture = %s AND time > %s)",
+		alertID, capture, after,
+	)
+	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
+	return exists, err
+}
+
+// RecordAlertEvent stores an alert in the history of its series. It returns false if the alert
+// was already recorded for the same point, in which case it must not be delivered again.
+func (s *AlertStore) RecordAlertEvent(ctx context.Context, event types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, bool, error) {
+	q := sqlf.Sprintf(
+		recordAlertEventSql,
+		event.AlertID,
+		event.SeriesID,
+		event.Capture,
+		event.Time,
+		event.Value,
+		event.ReferenceValue,
+		s.Now(),
+	)
+	events, err := scanAlertEvents(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightSeriesAlertEvent{}, false, err
+	}
+	if len(events) == 0 {
+		return types.InsightSeriesAlertEvent{}, false, nil
+	}
+	return events[0], true, nil
+}
+
+const recordAlertEventSql = `
+INSERT INTO insight_series_alert_events (alert_id, series_id, capture, time, value, reference_value, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (alert_id, capture, time) DO NOTHING
+RETURNING ` + alertEventColumns
+
+// MarkAlertEventDelivered records the outcome of the delivery of an alert.
+func (s *AlertStore) MarkAlertEventDelivered(ctx context.Context, id int, deliveryErr error) error {
+	if deliveryErr != nil {
+		return s.Exec(ctx, sqlf.Sprintf("UPDATE insight_series_alert_events SET error = %s WHERE id = %s", deliveryErr.Error(), id))
+	}
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE insight_series_alert_events SET delivered_at = %s, error = NULL WHERE id = %s", s.Now(), id))
+}
+
+type AlertEventQueryArgs struct {
+	AlertID  *int
+	SeriesID *int
+	Limit    int
+}
+
+// GetAlertEvents returns the alert history matching args, most recent first.
+func (s *AlertStore) GetAlertEvents(ctx context.Context, args AlertEventQueryArgs) ([]types.InsightSeriesAlertEvent, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.AlertID != nil {
+		preds = append(preds, sqlf.Sprintf("alert_id = %s", *args.AlertID))
+	}
+	if args.SeriesID != nil {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", *args.SeriesID))
+	}
+	limit := sqlf.Sprintf("")
+	if args.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %s", args.Limit)
+	}
+	q := sqlf.Sprintf(getAlertEventsSql, sqlf.Join(preds, "\n AND "), limit)
+	return scanAlertEvents(s.Query(ctx, q))
+}
+
+var getAlertEventsSql = `
+SELECT ` + alertEventColumns + `
+FROM insight_series_alert_events
+WHERE %s
+ORDER BY time DESC, id DESC
+%s
+`
+
+const alertEventColumns = `id, alert_id, series_id, capture, time, value, reference_value, delivered_at, error, created_at`
+
+func scanAlertEvents(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlertEvent, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	var results []types.InsightSeriesAlertEvent
+	err = scanAll(rows, func(sc scanner) error {
+		var event types.InsightSeriesAlertEvent
+		if err := sc.Scan(
+			&event.ID,
+			&event.AlertID,
+			&event.SeriesID,
+			&event.Capture,
+			&event.Time,
+			&event.Value,
+			&event.ReferenceValue,
+			&event.DeliveredAt,
+			&event.Error,
+			&event.CreatedAt,
+		); err != nil {
+			return err
+		}
+		results = append(results, event)
+		return nil
+	})
+	return results, err
+}
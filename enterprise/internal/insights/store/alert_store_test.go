@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAlertStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := insightsDB.ExecContext(ctx, `INSERT INTO insight_series (id, series_id, query, generation_method)
+									VALUES (1, 'series-id-1', 'query-1', 'search')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewAlertStore(insightsDB)
+	store.Now = func() time.Time { return now }
+
+	url := "https://example.com/webhook"
+	alert, err := store.CreateAlert(ctx, types.InsightSeriesAlert{
+		SeriesID:  1,
+		Kind:      types.AlertKindChange,
+		Direction: types.AlertDirectionAbove,
+		Threshold: 50,
+		Window:    24 * time.Hour,
+		Channel:   types.AlertChannelWebhook,
+		URL:       &url,
+		Enabled:   true,
+		CreatedBy: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("invalid alert", func(t *testing.T) {
+		_, err := store.CreateAlert(ctx, types.InsightSeriesAlert{
+			SeriesID:  1,
+			Kind:      types.AlertKindChange,
+			Direction: types.AlertDirectionAbove,
+			Channel:   types.AlertChannelSlack,
+			URL:       &url,
+		})
+		if err == nil {
+			t.Fatal("expected error for change alert without window")
+		}
+	})
+
+	t.Run("get alerts", func(t *testing.T) {
+		seriesID := 1
+		alerts, err := store.GetAlerts(ctx, AlertQueryArgs{SeriesID: &seriesID, EnabledOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(alerts) != 1 || alerts[0].ID != alert.ID || alerts[0].Window != 24*time.Hour || *alerts[0].URL != url {
+			t.Fatalf("unexpected alerts %+v", alerts)
+		}
+
+		if err := store.SetAlertEnabled(ctx, alert.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		alerts, err = store.GetAlerts(ctx, AlertQueryArgs{SeriesID: &seriesID, EnabledOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(alerts) != 0 {
+			t.Fatalf("expected no enabled alerts, got %+v", alerts)
+		}
+	})
+
+	t.Run("alert events", func(t *testing.T) {
+		reference := 10.0
+		event := types.InsightSeriesAlertEvent{
+			AlertID:        alert.ID,
+			SeriesID:       1,
+			Time:           now.Add(-time.Hour),
+			Value:          20,
+			ReferenceValue: &reference,
+		}
+		recorded, ok, err := store.RecordAlertEvent(ctx, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("expected event to be recorded")
+		}
+
+		// Recording the same point again is a noop.
+		if _, ok, err := store.RecordAlertEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			t.Fatal("expected duplicate event to be ignored")
+		}
+
+		fired, err := store.HasAlertEventAfter(ctx, alert.ID, "", now.Add(-2*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fired {
+			t.Error("expected event after time")
+		}
+		fired, err = store.HasAlertEventAfter(ctx, alert.ID, "", now.Add(-time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fired {
+			t.Error("expected no event after time")
+		}
+
+		if err := store.MarkAlertEventDelivered(ctx, recorded.ID, errors.New("boom")); err != nil {
+			t.Fatal(err)
+		}
+		events, err := store.GetAlertEvents(ctx, AlertEventQueryArgs{AlertID: &alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].DeliveredAt != nil || events[0].Error == nil || *events[0].Error != "boom" {
+			t.Fatalf("unexpected events %+v", events)
+		}
+
+		if err := store.MarkAlertEventDelivered(ctx, recorded.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+		events, err = store.GetAlertEvents(ctx, AlertEventQueryArgs{AlertID: &alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].DeliveredAt == nil || events[0].Error != nil || *events[0].ReferenceValue != reference {
+			t.Fatalf("unexpected events %+v", events)
+		}
+	})
+
+	t.Run("delete alert", func(t *testing.T) {
+		if err := store.DeleteAlert(ctx, alert.ID); err != nil {
+			t.Fatal(err)
+		}
+		events, err := store.GetAlertEvents(ctx, AlertEventQueryArgs{AlertID: &alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 0 {
+			t.Fatalf("expected history to be deleted, got %+v", events)
+		}
+	})
+}
@@ -299,6 +299,61 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 	return points, err
 }
 
+// ExportedSeriesPoint is a point of an insight series in a single repository, as exported
+// in bulk. Points recorded without a repository have a nil RepoID and RepoName.
+type ExportedSeriesPoint struct {
+	SeriesID string
+	Time     time.Time
+	Value    float64
+	RepoID   *api.RepoID
+	RepoName *string
+	Capture  *string
+}
+
+// ExportSeriesPoints calls fn with every point of the series matching opts broken down by
+// repository, ordered by series and time. Rows are streamed from the database, so fn should not
+// block for long. Iteration stops at the first error returned by fn.
+func (s *Store) ExportSeriesPoints(ctx context.Context, opts SeriesPointsOpts, fn func(ExportedSeriesPoint) error) error {
+	// 🚨 SECURITY: Points in repositories the current user cannot see are excluded, see
+	// SeriesPoints.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	q := seriesPointsQuery(exportSeriesPointsSql, opts)
+	return s.query(ctx, q, func(sc scanner) error {
+		var point ExportedSeriesPoint
+		if err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.Value,
+			&point.RepoID,
+			&point.RepoName,
+			&point.Capture,
+		); err != nil {
+			return err
+		}
+		return fn(point)
+	})
+}
+
+// Like fullVectorSeriesAggregation, duplicate points recorded for a repository in an interval
+// are collapsed to their maximum, but points are not summed across repositories.
+const exportSeriesPointsSql = `
+SELECT sp.series_id, date_trunc('seconds', sp.time) AS interval_time, MAX(sp.value), sp.repo_id, export_rn.name, sp.capture
+FROM (  select * from series_points
+		union all
+		select * from series_points_snapshots
+) AS sp
+LEFT JOIN repo_names export_rn ON sp.repo_name_id = export_rn.id
+%s
+WHERE %s
+GROUP BY sp.series_id, interval_time, sp.repo_id, export_rn.name, sp.capture
+ORDER BY sp.series_id, interval_time, export_rn.name, sp.capture
+`
+
 // Delete will delete the time series data for a particular series_id. This will hard (permanently) delete the data.
 func (s *Store) Delete(ctx context.Context, seriesId string) (err error) {
 	tx, err := s.Transact(ctx)
@@ -125,6 +125,39 @@ SELECT time,
 			t.Errorf("unexpected results from include list: %v", diff)
 		}
 	})
+	t.Run("export", func(t *testing.T) {
+		var exported []ExportedSeriesPoint
+		err := store.ExportSeriesPoints(ctx, SeriesPointsOpts{}, func(point ExportedSeriesPoint) error {
+			exported = append(exported, point)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(16, len(exported)); diff != "" {
+			t.Fatalf("unexpected number of exported points: %v", diff)
+		}
+		for _, point := range exported {
+			if point.SeriesID != "somehash" || point.RepoID == nil || *point.RepoID != 2 || point.RepoName == nil || *point.RepoName != "github.com/gorilla/mux-renamed" {
+				t.Fatalf("unexpected exported point %+v", point)
+			}
+		}
+		for i := 1; i < len(exported); i++ {
+			if !exported[i-1].Time.Before(exported[i].Time) {
+				t.Fatalf("exported points are not ordered by time: %v, %v", exported[i-1].Time, exported[i].Time)
+			}
+		}
+
+		stopErr := errors.New("stop")
+		var calls int
+		err = store.ExportSeriesPoints(ctx, SeriesPointsOpts{}, func(ExportedSeriesPoint) error {
+			calls++
+			return stopErr
+		})
+		if !errors.Is(err, stopErr) || calls != 1 {
+			t.Errorf("expected export to stop at first error, got %v after %d calls", err, calls)
+		}
+	})
 }
 
 func TestCountData(t *testing.T) {
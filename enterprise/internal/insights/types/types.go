@@ -204,6 +204,61 @@ type RecordingTime struct {
 	Snapshot  bool
 }
 
+// AlertKind is the condition an insight series alert rule checks.
+type AlertKind string
+
+const (
+	// AlertKindThreshold compares the value of the latest point with the threshold.
+	AlertKindThreshold AlertKind = "THRESHOLD"
+	// AlertKindChange compares the percentage change of the value over the window with the threshold.
+	AlertKindChange AlertKind = "CHANGE"
+)
+
+type AlertDirection string
+
+const (
+	AlertDirectionAbove AlertDirection = "ABOVE"
+	AlertDirectionBelow AlertDirection = "BELOW"
+)
+
+type AlertChannel string
+
+const (
+	AlertChannelEmail   AlertChannel = "EMAIL"
+	AlertChannelSlack   AlertChannel = "SLACK"
+	AlertChannelWebhook AlertChannel = "WEBHOOK"
+)
+
+// InsightSeriesAlert is an alert rule on an insight series, evaluated whenever new points are recorded.
+type InsightSeriesAlert struct {
+	ID              int
+	SeriesID        int // references insight_series(id)
+	Kind            AlertKind
+	Direction       AlertDirection
+	Threshold       float64
+	Window          time.Duration
+	Channel         AlertChannel
+	URL             *string
+	RecipientUserID *int32
+	Enabled         bool
+	CreatedBy       int32
+	CreatedAt       time.Time
+}
+
+// InsightSeriesAlertEvent is an entry of the alert history of an insight series.
+type InsightSeriesAlertEvent struct {
+	ID             int
+	AlertID        int
+	SeriesID       int
+	Capture        string
+	Time           time.Time
+	Value          float64
+	ReferenceValue *float64
+	DeliveredAt    *time.Time
+	Error          *string
+	CreatedAt      time.Time
+}
+
 type SearchAggregationMode string
 
 const (
@@ -211,9 +266,11 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
+	DIRECTORY_AGGREGATION_MODE     SearchAggregationMode = "DIRECTORY"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, OWNER_AGGREGATION_MODE, DIRECTORY_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
@@ -0,0 +1,111 @@
+package actor
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor is a grpc.UnaryClientInterceptor that sets the actor within the
+// request context as metadata on outgoing requests, like HTTPTransport does for HTTP
+// requests. The attached metadata can be picked up and attached to incoming request
+// contexts with UnaryServerInterceptor and StreamServerInterceptor.
+//
+// 🚨 SECURITY: Wherever possible, prefer to act in the context of a specific user rather
+// than as an internal actor, which can grant a lot of access in some cases.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withOutgoingActorMetadata(ctx, method), method, req, reply, cc, opts...)
+}
+
+// StreamClientInterceptor is the grpc.StreamClientInterceptor counterpart of
+// UnaryClientInterceptor.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withOutgoingActorMetadata(ctx, method), desc, cc, method, opts...)
+}
+
+func withOutgoingActorMetadata(ctx context.Context, method string) context.Context {
+	actor := FromContext(ctx)
+	switch {
+	// Indicate this is an internal user
+	case actor.IsInternal():
+		metricOutgoingActors.WithLabelValues(metricActorTypeInternal, method).Inc()
+		return metadata.AppendToOutgoingContext(ctx, headerKeyActorUID, headerValueInternalActor)
+
+	// Indicate this is an authenticated user
+	case actor.IsAuthenticated():
+		metricOutgoingActors.WithLabelValues(metricActorTypeUser, method).Inc()
+		return metadata.AppendToOutgoingContext(ctx, headerKeyActorUID, actor.UIDString())
+
+	// Indicate no authenticated actor is associated with request
+	default:
+		metricOutgoingActors.WithLabelValues(metricActorTypeNone, method).Inc()
+		if actor.AnonymousUID != "" {
+			return metadata.AppendToOutgoingContext(ctx,
+				headerKeyActorUID, headerValueNoActor,
+				headerKeyActorAnonymousUID, actor.AnonymousUID,
+			)
+		}
+		return metadata.AppendToOutgoingContext(ctx, headerKeyActorUID, headerValueNoActor)
+	}
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that attaches the actor
+// indicated in the metadata of incoming requests to the request context, like
+// HTTPMiddleware does for HTTP requests.
+//
+// 🚨 SECURITY: This should *never* be used by externally accessible servers, because
+// internal requests can bypass repository permissions checks.
+func UnaryServerInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := withIncomingActorMetadata(ctx, logger, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the grpc.StreamServerInterceptor counterpart of
+// UnaryServerInterceptor.
+//
+// 🚨 SECURITY: This should *never* be used by externally accessible servers, because
+// internal requests can bypass repository permissions checks.
+func StreamServerInterceptor(logger log.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withIncomingActorMetadata(ss.Context(), logger, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withIncomingActorMetadata(ctx context.Context, logger log.Logger, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx, err := withIncomingActor(ctx, logger, firstMetadataValue(md, headerKeyActorUID), firstMetadataValue(md, headerKeyActorAnonymousUID), method)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return ctx, nil
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// serverStreamWithContext overrides the context of a grpc.ServerStream.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,149 @@
+package actor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name         string
+		actor        *Actor
+		wantMetadata map[string]string
+	}{{
+		name:  "unauthenticated",
+		actor: nil,
+		wantMetadata: map[string]string{
+			headerKeyActorUID: headerValueNoActor,
+		},
+	}, {
+		name:  "anonymous actor",
+		actor: FromAnonymousUser("foobar"),
+		wantMetadata: map[string]string{
+			headerKeyActorUID:          headerValueNoActor,
+			headerKeyActorAnonymousUID: "foobar",
+		},
+	}, {
+		name:  "internal actor",
+		actor: &Actor{Internal: true},
+		wantMetadata: map[string]string{
+			headerKeyActorUID: headerValueInternalActor,
+		},
+	}, {
+		name:  "user actor",
+		actor: &Actor{UID: 1234},
+		wantMetadata: map[string]string{
+			headerKeyActorUID: "1234",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got := map[string]string{}
+				for k, values := range md {
+					got[k] = strings.Join(values, ",")
+				}
+				want := map[string]string{}
+				for k, v := range tt.wantMetadata {
+					want[strings.ToLower(k)] = v
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("metadata mismatch (-want +got):\n%s", diff)
+				}
+				return nil
+			}
+
+			ctx := WithActor(context.Background(), tt.actor)
+			if err := UnaryClientInterceptor(ctx, "/test.Service/Method", nil, nil, nil, invoker); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestServerInterceptors(t *testing.T) {
+	tests := []struct {
+		name      string
+		metadata  map[string]string
+		wantActor *Actor
+		wantCode  codes.Code
+	}{{
+		name:      "no metadata",
+		metadata:  nil,
+		wantActor: &Actor{},
+	}, {
+		name: "anonymous actor",
+		metadata: map[string]string{
+			headerKeyActorUID:          headerValueNoActor,
+			headerKeyActorAnonymousUID: "foobar",
+		},
+		wantActor: &Actor{AnonymousUID: "foobar"},
+	}, {
+		name: "internal actor",
+		metadata: map[string]string{
+			headerKeyActorUID: headerValueInternalActor,
+		},
+		wantActor: &Actor{Internal: true},
+	}, {
+		name: "user actor",
+		metadata: map[string]string{
+			headerKeyActorUID: "1234",
+		},
+		wantActor: &Actor{UID: 1234},
+	}, {
+		name: "invalid user",
+		metadata: map[string]string{
+			headerKeyActorUID: "not-a-uid",
+		},
+		wantCode: codes.PermissionDenied,
+	}}
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(tt.metadata))
+
+		checkActor := func(t *testing.T, ctx context.Context) {
+			t.Helper()
+			if diff := cmp.Diff(tt.wantActor.String(), FromContext(ctx).String()); diff != "" {
+				t.Errorf("actor mismatch (-want +got):\n%s", diff)
+			}
+		}
+
+		t.Run(tt.name+"/unary", func(t *testing.T) {
+			var handled bool
+			_, err := UnaryServerInterceptor(logtest.Scoped(t))(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, func(ctx context.Context, req any) (any, error) {
+				handled = true
+				checkActor(t, ctx)
+				return nil, nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("unexpected code %s, want %s", got, tt.wantCode)
+			}
+			if handled != (tt.wantCode == codes.OK) {
+				t.Fatalf("unexpected handled %v", handled)
+			}
+		})
+
+		t.Run(tt.name+"/stream", func(t *testing.T) {
+			var handled bool
+			err := StreamServerInterceptor(logtest.Scoped(t))(nil, &serverStreamWithContext{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Method"}, func(srv any, ss grpc.ServerStream) error {
+				handled = true
+				checkActor(t, ss.Context())
+				return nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("unexpected code %s, want %s", got, tt.wantCode)
+			}
+			if handled != (tt.wantCode == codes.OK) {
+				t.Fatalf("unexpected handled %v", handled)
+			}
+		})
+	}
+}
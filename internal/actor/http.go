@@ -1,7 +1,7 @@
 package actor
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const (
@@ -105,54 +106,62 @@ func (t *HTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 // permissions checks.
 func HTTPMiddleware(logger log.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		ctx := req.Context()
-		uidStr := req.Header.Get(headerKeyActorUID)
 		path := getCondensedURLPath(req.URL.Path)
-		switch uidStr {
-		// Request associated with internal actor - add internal actor to context
-		//
-		// 🚨 SECURITY: Wherever possible, prefer to set the actor ID explicitly through
-		// actor.HTTPTransport or similar, since assuming internal actor grants a lot of
-		// access in some cases.
-		case headerValueInternalActor:
-			ctx = WithInternalActor(ctx)
-			metricIncomingActors.WithLabelValues(metricActorTypeInternal, path).Inc()
-
-		// Request not associated with an authenticated user
-		case "", headerValueNoActor:
-			// Even though the current user is not authenticated, we may still have an
-			// anonymous UID to propagate.
-			if anonymousUID := req.Header.Get(headerKeyActorAnonymousUID); anonymousUID != "" {
-				ctx = WithActor(ctx, FromAnonymousUser(anonymousUID))
-			}
-			metricIncomingActors.WithLabelValues(metricActorTypeNone, path).Inc()
-
-		// Request associated with authenticated user - add user actor to context
-		default:
-			uid, err := strconv.Atoi(uidStr)
-			if err != nil {
-				trace.Logger(ctx, logger).
-					Warn("invalid user ID in request",
-						log.Error(err),
-						log.String("uid", uidStr))
-				metricIncomingActors.WithLabelValues(metricActorTypeInvalid, path).Inc()
-
-				// Do not proceed with request
-				rw.WriteHeader(http.StatusForbidden)
-				_, _ = rw.Write([]byte(fmt.Sprintf("%s was provided, but the value was invalid", headerKeyActorUID)))
-				return
-			}
-
-			// Valid user, add to context
-			actor := FromUser(int32(uid))
-			ctx = WithActor(ctx, actor)
-			metricIncomingActors.WithLabelValues(metricActorTypeUser, path).Inc()
+		ctx, err := withIncomingActor(req.Context(), logger, req.Header.Get(headerKeyActorUID), req.Header.Get(headerKeyActorAnonymousUID), path)
+		if err != nil {
+			// Do not proceed with request
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(err.Error()))
+			return
 		}
 
 		next.ServeHTTP(rw, req.WithContext(ctx))
 	})
 }
 
+// withIncomingActor attaches the actor indicated by the given values of the actor headers
+// of an incoming request to ctx. It returns an error if uidStr is not a valid user ID, in
+// which case the request must not proceed.
+func withIncomingActor(ctx context.Context, logger log.Logger, uidStr, anonymousUID, path string) (context.Context, error) {
+	switch uidStr {
+	// Request associated with internal actor - add internal actor to context
+	//
+	// 🚨 SECURITY: Wherever possible, prefer to set the actor ID explicitly through
+	// actor.HTTPTransport or similar, since assuming internal actor grants a lot of
+	// access in some cases.
+	case headerValueInternalActor:
+		ctx = WithInternalActor(ctx)
+		metricIncomingActors.WithLabelValues(metricActorTypeInternal, path).Inc()
+
+	// Request not associated with an authenticated user
+	case "", headerValueNoActor:
+		// Even though the current user is not authenticated, we may still have an
+		// anonymous UID to propagate.
+		if anonymousUID != "" {
+			ctx = WithActor(ctx, FromAnonymousUser(anonymousUID))
+		}
+		metricIncomingActors.WithLabelValues(metricActorTypeNone, path).Inc()
+
+	// Request associated with authenticated user - add user actor to context
+	default:
+		uid, err := strconv.Atoi(uidStr)
+		if err != nil {
+			trace.Logger(ctx, logger).
+				Warn("invalid user ID in request",
+					log.Error(err),
+					log.String("uid", uidStr))
+			metricIncomingActors.WithLabelValues(metricActorTypeInvalid, path).Inc()
+			return nil, errors.Newf("%s was provided, but the value was invalid", headerKeyActorUID)
+		}
+
+		// Valid user, add to context
+		actor := FromUser(int32(uid))
+		ctx = WithActor(ctx, actor)
+		metricIncomingActors.WithLabelValues(metricActorTypeUser, path).Inc()
+	}
+	return ctx, nil
+}
+
 // getCondensedURLPath truncates known high-cardinality paths to be used as metric labels in order to reduce the
 // label cardinality. This can and should be expanded to include other paths as necessary.
 func getCondensedURLPath(urlPath string) string {
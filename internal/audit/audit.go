@@ -41,6 +41,18 @@ func Log(ctx context.Context, logger log.Logger, record Record) {
 	loggerFunc := getLoggerFuncWithSeverity(logger, siteConfig)
 	// message string looks like: #{record.Action} (sampling immunity token: #{auditId})
 	loggerFunc(fmt.Sprintf("%s (sampling immunity token: %s)", record.Action, auditId), fields...)
+
+	if IsEnabled(siteConfig, Persist) {
+		persist(logger, &Entry{
+			AuditID:      auditId,
+			ActorUID:     actorId(act),
+			ActorIP:      ip(client),
+			ForwardedFor: forwardedFor(client),
+			Entity:       record.Entity,
+			Action:       record.Action,
+			Fields:       marshalFields(record.Fields),
+		})
+	}
 }
 
 func actorId(act *actor.Actor) string {
@@ -82,6 +94,7 @@ const (
 	GitserverAccess = iota
 	InternalTraffic
 	GraphQL
+	Persist
 )
 
 // IsEnabled returns the value of the respective setting from the site config (if set).
@@ -95,12 +108,23 @@ func IsEnabled(cfg schema.SiteConfiguration, setting AuditLogSetting) bool {
 			return auditCfg.InternalTraffic
 		case GraphQL:
 			return auditCfg.GraphQL
+		case Persist:
+			return auditCfg.Persist
 		}
 	}
 	// all settings now currently default to 'false', but that's a coincidence, not intention
 	return false
 }
 
+// RetentionDays returns the number of days persisted records are retained for, or 0 if
+// they are retained indefinitely.
+func RetentionDays(cfg schema.SiteConfiguration) int {
+	if auditCfg := getAuditCfg(cfg); auditCfg != nil && auditCfg.RetentionDays > 0 {
+		return auditCfg.RetentionDays
+	}
+	return 0
+}
+
 // getLoggerFuncWithSeverity returns a specific logger function (logger.Info, logger.Warn, etc.), a the severity is configurable.
 func getLoggerFuncWithSeverity(logger log.Logger, cfg schema.SiteConfiguration) func(string, ...log.Field) {
 	if auditCfg := getAuditCfg(cfg); auditCfg != nil {
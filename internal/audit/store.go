@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+// Entry is an audit log record as persisted to a Store.
+//
+// Entries form a hash chain: the hash of each entry covers its contents and the hash of
+// the entry appended before it, so that altering, inserting or removing entries can be
+// detected with a ChainVerifier.
+type Entry struct {
+	ID           int64
+	AuditID      string
+	CreatedAt    time.Time
+	ActorUID     string
+	ActorIP      string
+	ForwardedFor string
+	Entity       string
+	Action       string
+	// Fields is a JSON object holding the additional context of the record.
+	Fields       json.RawMessage
+	PreviousHash string
+	Hash         string
+}
+
+// hashKey is the secret key of the HMAC chaining persisted entries. As it is not stored in
+// the database, the chain can't be rewritten with database access alone.
+var hashKey = env.Get("AUDIT_LOG_HASH_KEY", "", "Secret key used to chain persisted audit log records. Must be the same for all services.")
+
+// HashKey returns the secret key entries are chained with, or nil if none is configured.
+func HashKey() []byte {
+	if hashKey == "" {
+		return nil
+	}
+	return []byte(hashKey)
+}
+
+// ComputeHash returns the hex encoded HMAC-SHA256 of the entry chained to e.PreviousHash,
+// keyed with the given key. The ID and Hash of the entry are not part of the hash.
+func (e *Entry) ComputeHash(key []byte) string {
+	h := hmac.New(sha256.New, key)
+	for _, v := range []string{
+		e.PreviousHash,
+		e.AuditID,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.ActorUID,
+		e.ActorIP,
+		e.ForwardedFor,
+		e.Entity,
+		e.Action,
+		string(e.Fields),
+	} {
+		// Prefix every value with its length, so that the boundaries between values
+		// can't be moved without changing the hash.
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], uint64(len(v)))
+		h.Write(n[:])
+		h.Write([]byte(v))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChainError is returned by ChainVerifier when an entry breaks the hash chain.
+type ChainError struct {
+	EntryID int64
+	Reason  string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit log entry %d: %s", e.EntryID, e.Reason)
+}
+
+// ChainVerifier checks that entries, passed in the order they were appended, form an
+// intact hash chain. The previous hash of the first entry is trusted, as older entries
+// may have been removed by the retention policy.
+//
+// Removing entries from the end of the chain can't be detected from the chain itself; the
+// checkpoints logged by LogCheckpoint serve as external anchors for that.
+type ChainVerifier struct {
+	// Key is the secret key the entries were chained with.
+	Key []byte
+
+	previous *Entry
+	// Checked is the number of entries verified so far.
+	Checked int
+}
+
+// Verify checks the next entry of the chain, returning a *ChainError if it has been
+// tampered with.
+func (v *ChainVerifier) Verify(e *Entry) error {
+	if !hmac.Equal([]byte(e.ComputeHash(v.Key)), []byte(e.Hash)) {
+		return &ChainError{EntryID: e.ID, Reason: "hash does not match contents"}
+	}
+	if v.previous != nil && e.PreviousHash != v.previous.Hash {
+		return &ChainError{EntryID: e.ID, Reason: fmt.Sprintf("previous hash does not match entry %d", v.previous.ID)}
+	}
+	v.previous = e
+	v.Checked++
+	return nil
+}
+
+// LogCheckpoint writes the ID and hash of the most recently appended entry to the log.
+// Shipped to a log store outside of the database, these checkpoints anchor the chain:
+// an entry whose hash differs from a checkpoint, or a checkpointed entry that no longer
+// exists before the retention period ends, indicates that the chain has been rewritten
+// or truncated.
+func LogCheckpoint(logger log.Logger, head *Entry) {
+	logger.Info("audit log checkpoint",
+		log.Int64("entryId", head.ID),
+		log.String("hash", head.Hash),
+		log.Time("createdAt", head.CreatedAt))
+}
+
+// Store persists audit log entries.
+type Store interface {
+	// Append sets the CreatedAt, PreviousHash and Hash of the given entries, chaining them
+	// with the given key, and persists them in order at the end of the hash chain.
+	Append(ctx context.Context, key []byte, entries []*Entry) error
+}
+
+const (
+	// persistBufferSize is the number of records that can wait to be persisted before new
+	// records are dropped (they are still written to the log).
+	persistBufferSize = 10000
+	// persistBatchSize is the maximum number of records appended to the store at once.
+	persistBatchSize = 500
+	// persistInterval is how long records may wait before they are appended to the store.
+	persistInterval = time.Second
+	// persistTimeout bounds the duration of a single append.
+	persistTimeout = 30 * time.Second
+)
+
+// persister appends records to a Store in batches from a background goroutine, so that
+// logging a record does not wait for the store.
+type persister struct {
+	store   Store
+	logger  log.Logger
+	entries chan *Entry
+	done    chan struct{}
+}
+
+func newPersister(s Store) *persister {
+	p := &persister{
+		store:   s,
+		logger:  log.Scoped("audit", "persisted audit log"),
+		entries: make(chan *Entry, persistBufferSize),
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// enqueue schedules the entry to be persisted without blocking.
+func (p *persister) enqueue(logger log.Logger, entry *Entry) {
+	select {
+	case p.entries <- entry:
+	default:
+		logger.Error("audit log persistence buffer is full, dropping record", log.String("auditId", entry.AuditID))
+	}
+}
+
+// stop persists the buffered entries and stops the background goroutine.
+func (p *persister) stop() {
+	close(p.entries)
+	<-p.done
+}
+
+func (p *persister) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Entry, 0, persistBatchSize)
+	for {
+		select {
+		case entry, ok := <-p.entries:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			if batch = append(batch, entry); len(batch) < persistBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		p.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+func (p *persister) flush(batch []*Entry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	key := HashKey()
+	if key == nil {
+		p.logger.Warn("audit log persistence is enabled, but AUDIT_LOG_HASH_KEY is not set; dropping records", log.Int("count", len(batch)))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+	defer cancel()
+
+	if err := p.store.Append(ctx, key, batch); err != nil {
+		auditIDs := make([]string, 0, len(batch))
+		for _, entry := range batch {
+			auditIDs = append(auditIDs, entry.AuditID)
+		}
+		p.logger.Error("failed to persist audit log records", log.Strings("auditIds", auditIDs), log.Error(err))
+	}
+}
+
+var (
+	persisterMu sync.RWMutex
+	current     *persister
+)
+
+// RegisterStore sets the store records are persisted to when persistence is enabled in
+// the site configuration, replacing (and flushing) the previously registered store.
+// Records are appended to the store asynchronously and in batches.
+func RegisterStore(s Store) {
+	persisterMu.Lock()
+	defer persisterMu.Unlock()
+
+	if current != nil {
+		current.stop()
+		current = nil
+	}
+	if s != nil {
+		current = newPersister(s)
+	}
+}
+
+// warnNoStore ensures that a service without a registered store warns about dropped
+// records only once.
+var warnNoStore sync.Once
+
+func persist(logger log.Logger, entry *Entry) {
+	persisterMu.RLock()
+	defer persisterMu.RUnlock()
+
+	if current == nil {
+		warnNoStore.Do(func() {
+			logger.Warn("audit log persistence is enabled, but this service has no audit log store; records are only written to the log")
+		})
+		return
+	}
+	current.enqueue(logger, entry)
+}
+
+// marshalFields encodes the fields of a record as a JSON object, as they would appear
+// in a structured log entry.
+func marshalFields(fields []log.Field) json.RawMessage {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	// Map keys are sorted by json.Marshal, so the encoding is stable.
+	b, err := json.Marshal(enc.Fields)
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"fieldsError": err.Error()})
+	}
+	return b
+}
@@ -0,0 +1,187 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var testKey = []byte("test key")
+
+// memoryStore chains entries in memory, like the database store does.
+type memoryStore struct {
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+func (s *memoryStore) Append(_ context.Context, key []byte, entries []*Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range entries {
+		entry.ID = int64(len(s.entries) + 1)
+		entry.CreatedAt = time.Date(2023, 1, 1, 0, 0, len(s.entries), 0, time.UTC)
+		if len(s.entries) > 0 {
+			entry.PreviousHash = s.entries[len(s.entries)-1].Hash
+		}
+		entry.Hash = entry.ComputeHash(key)
+		s.entries = append(s.entries, entry)
+	}
+	return nil
+}
+
+func (s *memoryStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+func TestLogPersist(t *testing.T) {
+	hashKey = string(testKey)
+	defer func() { hashKey = "" }()
+
+	store := &memoryStore{}
+	RegisterStore(store)
+	defer RegisterStore(nil)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.168.1.1"})
+	record := Record{
+		Entity: "test entity",
+		Action: "test audit action",
+		Fields: []log.Field{log.Object("request", log.String("name", "foo"), log.Int("count", 2))},
+	}
+
+	logger, exportLogs := logtest.Captured(t)
+
+	// Records are not persisted by default.
+	Log(ctx, logger, record)
+	require.Equal(t, 0, store.len())
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Log: &schema.Log{AuditLog: &schema.AuditLog{Persist: true}},
+	}})
+	defer conf.Mock(nil)
+
+	Log(ctx, logger, record)
+	Log(ctx, logger, record)
+	require.Len(t, exportLogs(), 3)
+
+	// Registering another store flushes the records buffered for the previous one.
+	RegisterStore(nil)
+	require.Len(t, store.entries, 2)
+
+	entry := store.entries[0]
+	assert.Equal(t, "1", entry.ActorUID)
+	assert.Equal(t, "192.168.1.1", entry.ActorIP)
+	assert.Equal(t, "test entity", entry.Entity)
+	assert.Equal(t, "test audit action", entry.Action)
+	assert.NotEmpty(t, entry.AuditID)
+	assert.JSONEq(t, `{"request":{"name":"foo","count":2}}`, string(entry.Fields))
+	assert.Equal(t, entry.Hash, store.entries[1].PreviousHash)
+}
+
+func TestLogPersistWithoutKey(t *testing.T) {
+	store := &memoryStore{}
+	RegisterStore(store)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Log: &schema.Log{AuditLog: &schema.AuditLog{Persist: true}},
+	}})
+	defer conf.Mock(nil)
+
+	// Records can't be chained without a key, so they are not persisted.
+	Log(context.Background(), logtest.Scoped(t), Record{Entity: "test entity", Action: "test audit action"})
+	RegisterStore(nil)
+	assert.Equal(t, 0, store.len())
+}
+
+func TestChainVerifier(t *testing.T) {
+	newChain := func() []*Entry {
+		store := &memoryStore{}
+		for _, action := range []string{"create", "update", "delete"} {
+			_ = store.Append(context.Background(), testKey, []*Entry{{
+				AuditID:  action,
+				ActorUID: "1",
+				Entity:   "test entity",
+				Action:   action,
+				Fields:   json.RawMessage(`{}`),
+			}})
+		}
+		return store.entries
+	}
+
+	verify := func(entries []*Entry) (int, error) {
+		v := ChainVerifier{Key: testKey}
+		for _, e := range entries {
+			if err := v.Verify(e); err != nil {
+				return v.Checked, err
+			}
+		}
+		return v.Checked, nil
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		checked, err := verify(newChain())
+		require.NoError(t, err)
+		assert.Equal(t, 3, checked)
+	})
+
+	t.Run("chain starting after removed entries", func(t *testing.T) {
+		checked, err := verify(newChain()[1:])
+		require.NoError(t, err)
+		assert.Equal(t, 2, checked)
+	})
+
+	t.Run("altered entry", func(t *testing.T) {
+		entries := newChain()
+		entries[1].ActorUID = "2"
+		checked, err := verify(entries)
+		var chainErr *ChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, int64(2), chainErr.EntryID)
+		assert.Equal(t, 1, checked)
+	})
+
+	t.Run("rehashed without the key", func(t *testing.T) {
+		entries := newChain()
+		entries[1].Fields = json.RawMessage(`{"hidden":true}`)
+		entries[1].Hash = entries[1].ComputeHash(nil)
+		entries[2].PreviousHash = entries[1].Hash
+		entries[2].Hash = entries[2].ComputeHash(nil)
+		_, err := verify(entries)
+		var chainErr *ChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, int64(2), chainErr.EntryID)
+	})
+
+	t.Run("altered and rehashed entry", func(t *testing.T) {
+		entries := newChain()
+		entries[1].Fields = json.RawMessage(`{"hidden":true}`)
+		entries[1].Hash = entries[1].ComputeHash(testKey)
+		_, err := verify(entries)
+		var chainErr *ChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, int64(3), chainErr.EntryID)
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		entries := newChain()
+		_, err := verify([]*Entry{entries[0], entries[2]})
+		var chainErr *ChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, int64(3), chainErr.EntryID)
+	})
+}
@@ -46,6 +46,7 @@ type AutoindexingServiceResolver interface {
 	RepositorySummary(ctx context.Context, id graphql.ID) (CodeIntelRepositorySummaryResolver, error)
 	CodeIntelligenceInferenceScript(ctx context.Context) (string, error)
 	UpdateCodeIntelligenceInferenceScript(ctx context.Context, args *UpdateCodeIntelligenceInferenceScriptArgs) (*EmptyResponse, error)
+	ExplainAutoIndexingInference(ctx context.Context, args *ExplainAutoIndexingInferenceArgs) (AutoIndexingInferenceExplanationResolver, error)
 }
 
 type UploadsServiceResolver interface {
@@ -188,6 +189,34 @@ type IndexConfigurationResolver interface {
 	InferredConfiguration(ctx context.Context) (*string, error)
 }
 
+type AutoIndexingInferenceExplanationResolver interface {
+	Commit() string
+	IndexJobs() (string, error)
+	Hints() []AutoIndexingInferenceHintResolver
+	RecognizerInvocations() []AutoIndexingRecognizerInvocationResolver
+	DisabledRecognizers() []string
+	Error() *string
+}
+
+type AutoIndexingInferenceHintResolver interface {
+	Root() string
+	Indexer() string
+	Confidence() string
+}
+
+type AutoIndexingRecognizerInvocationResolver interface {
+	Recognizer() string
+	Callback() string
+	Overridden() bool
+	Paths() []string
+	PathCount() int32
+	PathsWithContent() []string
+	SkipReason() *string
+	IndexJobs() (string, error)
+	Hints() []AutoIndexingInferenceHintResolver
+	Error() *string
+}
+
 type GitTreeCodeIntelSupportResolver interface {
 	SearchBasedSupport(context.Context) (*[]GitTreeSearchBasedCoverage, error)
 	PreciseSupport(context.Context) (*[]GitTreePreciseCoverage, error)
@@ -213,6 +242,8 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Prototypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 }
 
@@ -603,6 +634,12 @@ type UpdateCodeIntelligenceInferenceScriptArgs struct {
 	Script string
 }
 
+type ExplainAutoIndexingInferenceArgs struct {
+	Repository graphql.ID
+	Rev        *string
+	Script     *string
+}
+
 type LSIFUploadsWithRepositoryNamespaceResolver interface {
 	Root() string
 	Indexer() CodeIntelIndexerResolver
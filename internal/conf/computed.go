@@ -317,6 +317,18 @@ func StructuralSearchEnabled() bool {
 	return val == "enabled"
 }
 
+// GRPCEnabled returns true if clients of gitserver should use its gRPC API
+// instead of its HTTP API.
+func GRPCEnabled() bool {
+	return ExperimentalFeatures().EnableGRPC
+}
+
+// DistributedRateLimitsEnabled returns true if the rate limits of code host connections
+// should be shared between processes through Redis.
+func DistributedRateLimitsEnabled() bool {
+	return ExperimentalFeatures().DistributedRateLimits
+}
+
 func ExperimentalFeatures() schema.ExperimentalFeatures {
 	val := Get().ExperimentalFeatures
 	if val == nil {
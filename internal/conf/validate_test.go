@@ -18,6 +18,7 @@ const (
 	authOpenIDClientSecret                      = "authOpenIDClientSecret"
 	authGitHubClientSecret                      = "authGitHubClientSecret"
 	authGitLabClientSecret                      = "authGitLabClientSecret"
+	authBitbucketCloudClientSecret              = "authBitbucketCloudClientSecret"
 	emailSMTPPassword                           = "emailSMTPPassword"
 	organizationInvitationsSigningKey           = "organizationInvitationsSigningKey"
 	githubClientSecret                          = "githubClientSecret"
@@ -217,7 +218,7 @@ func TestRedactSecrets(t *testing.T) {
 		conftypes.RawUnified{
 			Site: getTestSiteWithSecrets(
 				executorsAccessToken,
-				authOpenIDClientSecret, authGitLabClientSecret, authGitHubClientSecret,
+				authOpenIDClientSecret, authGitLabClientSecret, authGitHubClientSecret, authBitbucketCloudClientSecret,
 				emailSMTPPassword,
 				organizationInvitationsSigningKey,
 				githubClientSecret,
@@ -253,7 +254,7 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
-		authOpenIDClientSecret, authGitLabClientSecret, authGitHubClientSecret,
+		authOpenIDClientSecret, authGitLabClientSecret, authGitHubClientSecret, authBitbucketCloudClientSecret,
 		emailSMTPPassword,
 		organizationInvitationsSigningKey,
 		githubClientSecret,
@@ -275,7 +276,7 @@ func TestUnredactSecrets(t *testing.T) {
 	t.Run("unredacts secrets AND respects specified edits to secret", func(t *testing.T) {
 		input := getTestSiteWithSecrets(
 			"new"+executorsAccessToken,
-			redactedSecret, "new"+authGitLabClientSecret, redactedSecret,
+			redactedSecret, "new"+authGitLabClientSecret, redactedSecret, redactedSecret,
 			redactedSecret,
 			redactedSecret,
 			redactedSecret,
@@ -291,7 +292,7 @@ func TestUnredactSecrets(t *testing.T) {
 		// Expect to have newly-specified secrets and to fill in "REDACTED" secrets with secrets from previous site
 		want := getTestSiteWithSecrets(
 			"new"+executorsAccessToken,
-			authOpenIDClientSecret, "new"+authGitLabClientSecret, authGitHubClientSecret,
+			authOpenIDClientSecret, "new"+authGitLabClientSecret, authGitHubClientSecret, authBitbucketCloudClientSecret,
 			emailSMTPPassword,
 			organizationInvitationsSigningKey,
 			githubClientSecret,
@@ -308,7 +309,7 @@ func TestUnredactSecrets(t *testing.T) {
 		const newEmail = "new_email@example.com"
 		input := getTestSiteWithSecrets(
 			"new"+executorsAccessToken,
-			redactedSecret, "new"+authGitLabClientSecret, redactedSecret,
+			redactedSecret, "new"+authGitLabClientSecret, redactedSecret, redactedSecret,
 			redactedSecret,
 			redactedSecret,
 			redactedSecret,
@@ -325,7 +326,7 @@ func TestUnredactSecrets(t *testing.T) {
 		// Expect new secrets and new email to show up in the unredacted version
 		want := getTestSiteWithSecrets(
 			"new"+executorsAccessToken,
-			authOpenIDClientSecret, "new"+authGitLabClientSecret, authGitHubClientSecret,
+			authOpenIDClientSecret, "new"+authGitLabClientSecret, authGitHubClientSecret, authBitbucketCloudClientSecret,
 			emailSMTPPassword,
 			organizationInvitationsSigningKey,
 			githubClientSecret,
@@ -341,12 +342,12 @@ func TestUnredactSecrets(t *testing.T) {
 }
 
 func getTestSiteWithRedactedSecrets() string {
-	return getTestSiteWithSecrets(redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret)
+	return getTestSiteWithSecrets(redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret, redactedSecret)
 }
 
 func getTestSiteWithSecrets(
 	executorsAccessToken,
-	authOpenIDClientSecret, authGitHubClientSecret, authGitLabClientSecret,
+	authOpenIDClientSecret, authGitHubClientSecret, authGitLabClientSecret, authBitbucketCloudClientSecret,
 	emailSMTPPassword,
 	organizationInvitationsSigningKey,
 	githubClientSecret,
@@ -390,6 +391,13 @@ func getTestSiteWithSecrets(
       "displayName": "GitLab.com",
       "type": "gitlab",
       "url": "https://gitlab.com"
+    },
+    {
+      "clientKey": "sourcegraph-client-bitbucketcloud",
+      "clientSecret": "%s",
+      "displayName": "Bitbucket Cloud",
+      "type": "bitbucketcloud",
+      "url": "https://bitbucket.org"
     }
   ],
   "observability.tracing": {
@@ -420,7 +428,7 @@ func getTestSiteWithSecrets(
 }`,
 		email,
 		executorsAccessToken,
-		authOpenIDClientSecret, authGitHubClientSecret, authGitLabClientSecret,
+		authOpenIDClientSecret, authGitHubClientSecret, authGitLabClientSecret, authBitbucketCloudClientSecret,
 		emailSMTPPassword, // used again as username
 		emailSMTPPassword,
 		organizationInvitationsSigningKey,
@@ -0,0 +1,321 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AuditLogStore persists audit log records, see audit.RegisterStore.
+type AuditLogStore interface {
+	basestore.ShareableStore
+	audit.Store
+
+	Count(context.Context, AuditLogListOpts) (int64, error)
+	// List returns the entries matching opts, newest first, and the cursor of the next
+	// page if there is one.
+	List(context.Context, AuditLogListOpts) ([]*audit.Entry, int64, error)
+	// Iterate calls fn with every entry matching opts, oldest first, without loading them
+	// all into memory. Limit and Cursor are ignored.
+	Iterate(context.Context, AuditLogListOpts, func(*audit.Entry) error) error
+	// Head returns the most recently appended entry, or nil if there is none.
+	Head(context.Context) (*audit.Entry, error)
+	// Verify checks the hash chain of all entries against the given key, returning the
+	// number of entries checked. An *audit.ChainError is returned if an entry has been
+	// tampered with.
+	Verify(context.Context, []byte) (int, error)
+	DeleteStale(context.Context, time.Duration) error
+}
+
+type auditLogStore struct {
+	*basestore.Store
+}
+
+var _ AuditLogStore = &auditLogStore{}
+
+// AuditLogsWith instantiates and returns a new AuditLogStore using the other store handle.
+func AuditLogsWith(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+// auditLogLockNamespace is the advisory lock namespace serializing appends, so that every
+// entry is chained to the entry appended before it.
+const auditLogLockNamespace = "audit_logs"
+
+func (s *auditLogStore) Append(ctx context.Context, key []byte, entries []*audit.Entry) (err error) {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if _, err := locker.NewWith(tx, auditLogLockNamespace).LockInTransaction(ctx, 0, true); err != nil {
+		return errors.Wrap(err, "locking audit log")
+	}
+
+	previousHash, _, err := basestore.ScanFirstString(tx.Query(ctx, sqlf.Sprintf(auditLogLastHashQueryFmtstr)))
+	if err != nil {
+		return errors.Wrap(err, "getting previous hash")
+	}
+
+	now := timeutil.Now()
+	for _, entry := range entries {
+		entry.CreatedAt = now
+		entry.PreviousHash = previousHash
+		if len(entry.Fields) == 0 {
+			entry.Fields = json.RawMessage("{}")
+		}
+		entry.Hash = entry.ComputeHash(key)
+
+		q := sqlf.Sprintf(
+			auditLogCreateQueryFmtstr,
+			entry.AuditID,
+			entry.CreatedAt,
+			entry.ActorUID,
+			entry.ActorIP,
+			entry.ForwardedFor,
+			entry.Entity,
+			entry.Action,
+			string(entry.Fields),
+			entry.PreviousHash,
+			entry.Hash,
+		)
+		if err := tx.QueryRow(ctx, q).Scan(&entry.ID); err != nil {
+			return err
+		}
+		previousHash = entry.Hash
+	}
+
+	return nil
+}
+
+type AuditLogListOpts struct {
+	// The maximum number of entries to return, and the cursor, if any. As with webhook
+	// logs, the cursor is the ID of the first entry of the next page.
+	Limit  int
+	Cursor int64
+
+	ActorUID *string
+	Entity   *string
+	Action   *string
+	Since    *time.Time
+	Until    *time.Time
+}
+
+func (opts *AuditLogListOpts) predicates() []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.ActorUID != nil {
+		preds = append(preds, sqlf.Sprintf("actor_uid = %s", *opts.ActorUID))
+	}
+	if opts.Entity != nil {
+		preds = append(preds, sqlf.Sprintf("entity = %s", *opts.Entity))
+	}
+	if opts.Action != nil {
+		preds = append(preds, sqlf.Sprintf("action = %s", *opts.Action))
+	}
+	if since := opts.Since; since != nil {
+		preds = append(preds, sqlf.Sprintf("created_at >= %s", *since))
+	}
+	if until := opts.Until; until != nil {
+		preds = append(preds, sqlf.Sprintf("created_at <= %s", *until))
+	}
+	return preds
+}
+
+func (s *auditLogStore) Count(ctx context.Context, opts AuditLogListOpts) (int64, error) {
+	q := sqlf.Sprintf(
+		auditLogCountQueryFmtstr,
+		sqlf.Join(opts.predicates(), " AND "),
+	)
+
+	var count int64
+	if err := s.QueryRow(ctx, q).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *auditLogStore) List(ctx context.Context, opts AuditLogListOpts) ([]*audit.Entry, int64, error) {
+	preds := opts.predicates()
+	if cursor := opts.Cursor; cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id <= %s", cursor))
+	}
+
+	limit := sqlf.Sprintf("")
+	if opts.Limit != 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit+1)
+	}
+
+	q := sqlf.Sprintf(
+		auditLogListQueryFmtstr,
+		sqlf.Join(auditLogColumns, ", "),
+		sqlf.Join(preds, " AND "),
+		sqlf.Sprintf("DESC"),
+		limit,
+	)
+
+	entries := []*audit.Entry{}
+	err := basestore.NewCallbackScanner(func(sc dbutil.Scanner) (bool, error) {
+		entry, err := scanAuditLogEntry(sc)
+		if err != nil {
+			return false, err
+		}
+		entries = append(entries, entry)
+		return true, nil
+	})(s.Query(ctx, q))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var next int64 = 0
+	if opts.Limit != 0 && len(entries) == opts.Limit+1 {
+		next = entries[len(entries)-1].ID
+		entries = entries[:len(entries)-1]
+	}
+
+	return entries, next, nil
+}
+
+func (s *auditLogStore) Iterate(ctx context.Context, opts AuditLogListOpts, fn func(*audit.Entry) error) error {
+	q := sqlf.Sprintf(
+		auditLogListQueryFmtstr,
+		sqlf.Join(auditLogColumns, ", "),
+		sqlf.Join(opts.predicates(), " AND "),
+		sqlf.Sprintf("ASC"),
+		sqlf.Sprintf(""),
+	)
+
+	return basestore.NewCallbackScanner(func(sc dbutil.Scanner) (bool, error) {
+		entry, err := scanAuditLogEntry(sc)
+		if err != nil {
+			return false, err
+		}
+		return true, fn(entry)
+	})(s.Query(ctx, q))
+}
+
+func (s *auditLogStore) Head(ctx context.Context) (*audit.Entry, error) {
+	entries, _, err := s.List(ctx, AuditLogListOpts{Limit: 1})
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+func (s *auditLogStore) Verify(ctx context.Context, key []byte) (int, error) {
+	verifier := audit.ChainVerifier{Key: key}
+	err := s.Iterate(ctx, AuditLogListOpts{}, verifier.Verify)
+	return verifier.Checked, err
+}
+
+func (s *auditLogStore) DeleteStale(ctx context.Context, retention time.Duration) error {
+	before := timeutil.Now().Add(-retention)
+	return s.Exec(ctx, sqlf.Sprintf(auditLogDeleteStaleQueryFmtstr, before))
+}
+
+var auditLogColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("audit_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("actor_uid"),
+	sqlf.Sprintf("actor_ip"),
+	sqlf.Sprintf("actor_forwarded_for"),
+	sqlf.Sprintf("entity"),
+	sqlf.Sprintf("action"),
+	sqlf.Sprintf("fields"),
+	sqlf.Sprintf("previous_hash"),
+	sqlf.Sprintf("hash"),
+}
+
+func scanAuditLogEntry(sc dbutil.Scanner) (*audit.Entry, error) {
+	var (
+		entry  audit.Entry
+		fields []byte
+	)
+	if err := sc.Scan(
+		&entry.ID,
+		&entry.AuditID,
+		&entry.CreatedAt,
+		&entry.ActorUID,
+		&entry.ActorIP,
+		&entry.ForwardedFor,
+		&entry.Entity,
+		&entry.Action,
+		&fields,
+		&entry.PreviousHash,
+		&entry.Hash,
+	); err != nil {
+		return nil, err
+	}
+	entry.Fields = fields
+	return &entry, nil
+}
+
+const auditLogLastHashQueryFmtstr = `
+SELECT
+	hash
+FROM
+	audit_logs
+ORDER BY
+	id DESC
+LIMIT 1
+`
+
+const auditLogCreateQueryFmtstr = `
+INSERT INTO
+	audit_logs (
+		audit_id,
+		created_at,
+		actor_uid,
+		actor_ip,
+		actor_forwarded_for,
+		entity,
+		action,
+		fields,
+		previous_hash,
+		hash
+	)
+	VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	RETURNING id
+`
+
+const auditLogCountQueryFmtstr = `
+SELECT
+	COUNT(id)
+FROM
+	audit_logs
+WHERE
+	%s
+`
+
+const auditLogListQueryFmtstr = `
+SELECT
+	%s
+FROM
+	audit_logs
+WHERE
+	%s
+ORDER BY
+	id %s
+%s -- LIMIT
+`
+
+const auditLogDeleteStaleQueryFmtstr = `
+DELETE FROM
+	audit_logs
+WHERE
+	created_at <= %s
+`
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAuditLogStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := db.AuditLogs()
+
+	key := []byte("test key")
+	entries := []*audit.Entry{
+		{AuditID: "a", ActorUID: "1", ActorIP: "127.0.0.1", Entity: "GraphQL", Action: "request", Fields: json.RawMessage(`{"b": 1, "a": [true]}`)},
+		{AuditID: "b", ActorUID: "2", ActorIP: "127.0.0.1", Entity: "GraphQL", Action: "request"},
+		{AuditID: "c", ActorUID: "1", ActorIP: "127.0.0.1", Entity: "security events", Action: "SignInSucceeded"},
+	}
+	// Entries are chained within and across batches.
+	require.NoError(t, store.Append(ctx, key, entries[:2]))
+	require.NoError(t, store.Append(ctx, key, entries[2:]))
+	for _, e := range entries {
+		assert.NotZero(t, e.ID)
+		assert.NotEmpty(t, e.Hash)
+	}
+
+	t.Run("Head", func(t *testing.T) {
+		head, err := store.Head(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, head)
+		assert.Equal(t, entries[2].ID, head.ID)
+		assert.Equal(t, entries[2].Hash, head.Hash)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		entries, next, err := store.List(ctx, AuditLogListOpts{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, []string{"c", "b"}, []string{entries[0].AuditID, entries[1].AuditID})
+		assert.Equal(t, entries[0].PreviousHash, entries[1].Hash)
+
+		entries, next, err = store.List(ctx, AuditLogListOpts{Limit: 2, Cursor: next})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Zero(t, next)
+		// The exact text of the fields is preserved.
+		assert.Equal(t, `{"b": 1, "a": [true]}`, string(entries[0].Fields))
+		assert.Empty(t, entries[0].PreviousHash)
+
+		actor, entity := "1", "GraphQL"
+		count, err := store.Count(ctx, AuditLogListOpts{ActorUID: &actor, Entity: &entity})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		future := time.Now().Add(time.Hour)
+		count, err = store.Count(ctx, AuditLogListOpts{Since: &future})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Iterate", func(t *testing.T) {
+		var ids []string
+		err := store.Iterate(ctx, AuditLogListOpts{}, func(e *audit.Entry) error {
+			ids = append(ids, e.AuditID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, ids)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		checked, err := store.Verify(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, 3, checked)
+
+		// Entries can't be verified (or rehashed) without the key.
+		_, err = store.Verify(ctx, []byte("other key"))
+		var chainErr *audit.ChainError
+		require.ErrorAs(t, err, &chainErr)
+
+		tx, err := db.Transact(ctx)
+		require.NoError(t, err)
+		defer func() { _ = tx.Done(errors.New("rollback")) }()
+
+		_, err = tx.ExecContext(ctx, `UPDATE audit_logs SET actor_uid = '3' WHERE audit_id = 'b'`)
+		require.NoError(t, err)
+
+		checked, err = tx.AuditLogs().Verify(ctx, key)
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, 1, checked)
+	})
+
+	t.Run("DeleteStale", func(t *testing.T) {
+		require.NoError(t, store.DeleteStale(ctx, time.Hour))
+		count, err := store.Count(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		require.NoError(t, store.DeleteStale(ctx, -time.Hour))
+		count, err = store.Count(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
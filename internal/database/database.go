@@ -21,6 +21,7 @@ type DB interface {
 	basestore.ShareableStore
 
 	AccessTokens() AccessTokenStore
+	AuditLogs() AuditLogStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
 	Conf() ConfStore
@@ -108,6 +109,10 @@ func (d *db) AccessTokens() AccessTokenStore {
 	return AccessTokensWith(d.Store, d.logger.Scoped("AccessTokenStore", ""))
 }
 
+func (d *db) AuditLogs() AuditLogStore {
+	return AuditLogsWith(d.Store)
+}
+
 func (d *db) BitbucketProjectPermissions() BitbucketProjectPermissionsStore {
 	return BitbucketProjectPermissionsStoreWith(d.Store)
 }
@@ -3870,6 +3870,9 @@ type MockDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *DBAccessTokensFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *DBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *DBAuthzFunc
@@ -4011,6 +4014,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() (r0 AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() (r0 AuthzStore) {
 				return
@@ -4238,6 +4246,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.AccessTokens")
 			},
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: func() AuditLogStore {
+				panic("unexpected invocation of MockDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: func() AuthzStore {
 				panic("unexpected invocation of MockDB.Authz")
@@ -4463,6 +4476,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		AccessTokensFunc: &DBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AuditLogsFunc: &DBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &DBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -4693,6 +4709,104 @@ func (c DBAccessTokensFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBAuditLogsFunc describes the behavior when the AuditLogs method of the
+// parent MockDB instance is invoked.
+type DBAuditLogsFunc struct {
+	defaultHook func() AuditLogStore
+	hooks       []func() AuditLogStore
+	history     []DBAuditLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// AuditLogs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDB) AuditLogs() AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(DBAuditLogsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBAuditLogsFunc) SetDefaultHook(hook func() AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AuditLogs method of the parent MockDB instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *DBAuditLogsFunc) PushHook(hook func() AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBAuditLogsFunc) SetDefaultReturn(r0 AuditLogStore) {
+	f.SetDefaultHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBAuditLogsFunc) PushReturn(r0 AuditLogStore) {
+	f.PushHook(func() AuditLogStore {
+		return r0
+	})
+}
+
+func (f *DBAuditLogsFunc) nextHook() func() AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBAuditLogsFunc) appendCall(r0 DBAuditLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBAuditLogsFuncCall objects describing the
+// invocations of this function.
+func (f *DBAuditLogsFunc) History() []DBAuditLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBAuditLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBAuditLogsFuncCall is an object that describes an invocation of method
+// AuditLogs on an instance of MockDB.
+type DBAuditLogsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBAuditLogsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBAuditLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBAuthzFunc describes the behavior when the Authz method of the parent
 // MockDB instance is invoked.
 type DBAuthzFunc struct {
@@ -11,12 +11,17 @@ import (
 	"strings"
 
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/oauth2"
 
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/oauthutil"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -45,6 +50,10 @@ type Client interface {
 	ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error)
 
 	CurrentUser(ctx context.Context) (*User, error)
+	CurrentUserEmails(ctx context.Context, pageToken *PageToken) ([]*UserEmail, *PageToken, error)
+
+	CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error)
+	RepoPermissions(ctx context.Context, pageToken *PageToken, namespace, slug string) ([]*RepoPermission, *PageToken, error)
 }
 
 // client access a Bitbucket Cloud via the REST API 2.0.
@@ -112,8 +121,8 @@ func (c *client) Authenticator() auth.Authenticator {
 // the given authenticator instance.
 //
 // Note that using an unsupported Authenticator implementation may result in
-// unexpected behaviour, or (more likely) errors. At present, only BasicAuth is
-// supported.
+// unexpected behaviour, or (more likely) errors. At present, only BasicAuth and
+// OAuthBearerToken are supported.
 func (c *client) WithAuthenticator(a auth.Authenticator) Client {
 	return &client{
 		httpClient: c.httpClient,
@@ -200,15 +209,12 @@ func (c *client) do(ctx context.Context, req *http.Request, result any) error {
 		nethttp.ClientTrace(false))
 	defer ht.Finish()
 
-	if err := c.Auth.Authenticate(req); err != nil {
-		return err
-	}
-
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	// OAuth tokens of user accounts are refreshed once they expire.
+	resp, err := oauthutil.DoRequest(ctx, log.Scoped("bitbucketcloud client", "do request"), c.httpClient, req, c.Auth)
 	if err != nil {
 		return err
 	}
@@ -284,3 +290,37 @@ func UrlFromConfig(config *schema.BitbucketCloudConnection) (*url.URL, error) {
 	}
 	return url.Parse(config.ApiURL)
 }
+
+var MockGetOAuthContext func() *oauthutil.OAuthContext
+
+// GetOAuthContext returns the OAuth context of the Bitbucket Cloud auth
+// provider with the given base URL, which is used to refresh the OAuth tokens
+// of its user accounts. It returns nil if there is no such auth provider.
+func GetOAuthContext(baseURL string) *oauthutil.OAuthContext {
+	if MockGetOAuthContext != nil {
+		return MockGetOAuthContext()
+	}
+
+	for _, authProvider := range conf.SiteConfig().AuthProviders {
+		if authProvider.Bitbucketcloud != nil {
+			p := authProvider.Bitbucketcloud
+			bbURL := strings.TrimSuffix(p.Url, "/")
+			if bbURL == "" {
+				bbURL = "https://bitbucket.org"
+			}
+			if !strings.HasPrefix(baseURL, bbURL) {
+				continue
+			}
+
+			return &oauthutil.OAuthContext{
+				ClientID:     p.ClientKey,
+				ClientSecret: p.ClientSecret,
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  bbURL + "/site/oauth2/authorize",
+					TokenURL: bbURL + "/site/oauth2/access_token",
+				},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+)
+
+// CurrentUserRepoPermissions returns the effective permissions of the user
+// associated with the authenticator in use on each repository they can access,
+// based on the given pagination criteria.
+//
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-permissions-repositories-get
+func (c *client) CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, "/2.0/user/permissions/repositories", nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
+// RepoPermissions returns the effective permissions of each user that can
+// access the repository with the given namespace and slug, including those
+// granted through group membership, based on the given pagination criteria.
+// The authenticator in use must belong to an administrator of the workspace.
+//
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (c *client) RepoPermissions(ctx context.Context, pageToken *PageToken, namespace, slug string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", namespace, slug), nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
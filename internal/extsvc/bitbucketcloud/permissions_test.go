@@ -0,0 +1,108 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_CurrentUserRepoPermissions(t *testing.T) {
+	var paths []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Query().Get("page") == "" {
+			_, _ = io.WriteString(w, `{
+  "pagelen": 1,
+  "next": "`+srv.URL+`/2.0/user/permissions/repositories?page=2",
+  "values": [{"permission": "admin", "repository": {"full_name": "ws/a", "uuid": "{a}"}, "user": {"uuid": "{u}"}}]
+}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{
+  "pagelen": 1,
+  "values": [{"permission": "read", "repository": {"full_name": "ws/b", "uuid": "{b}"}, "user": {"uuid": "{u}"}}]
+}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+	ctx := context.Background()
+
+	var got []*RepoPermission
+	var next *PageToken
+	for {
+		perms, nextPage, err := cli.CurrentUserRepoPermissions(ctx, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, perms...)
+		if !nextPage.HasMore() {
+			break
+		}
+		next = nextPage
+	}
+
+	want := []*RepoPermission{
+		{Permission: RepoPermissionLevelAdmin, Repo: &Repo{FullName: "ws/a", UUID: "{a}"}, User: &Account{UUID: "{u}"}},
+		{Permission: RepoPermissionLevelRead, Repo: &Repo{FullName: "ws/b", UUID: "{b}"}, User: &Account{UUID: "{u}"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected permissions (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/2.0/user/permissions/repositories", "/2.0/user/permissions/repositories?page=2"}, paths); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_RepoPermissions(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = io.WriteString(w, `{
+  "pagelen": 10,
+  "values": [
+    {"permission": "write", "repository": {"full_name": "ws/a"}, "user": {"uuid": "{u1}"}},
+    {"permission": "read", "repository": {"full_name": "ws/a"}, "user": {"uuid": "{u2}"}}
+  ]
+}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+
+	perms, next, err := cli.RepoPermissions(context.Background(), nil, "ws", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.HasMore() {
+		t.Errorf("unexpected next page: %+v", next)
+	}
+	if want := "/2.0/workspaces/ws/permissions/repositories/a"; path != want {
+		t.Errorf("unexpected path: want %q, have %q", want, path)
+	}
+
+	var users []string
+	for _, p := range perms {
+		users = append(users, p.User.UUID)
+	}
+	if diff := cmp.Diff([]string{"{u1}", "{u2}"}, users); diff != "" {
+		t.Errorf("unexpected users (-want +got):\n%s", diff)
+	}
+}
+
+func newTestServerClient(t *testing.T, srv *httptest.Server) *client {
+	t.Helper()
+
+	cli, err := newClient("urn", &schema.BitbucketCloudConnection{ApiURL: srv.URL}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
@@ -179,6 +179,22 @@ const (
 	ParticipantStateNull             ParticipantState = "null"
 )
 
+// RepoPermission is the effective permission of a single user on a
+// repository, as returned by the repository permissions endpoints.
+type RepoPermission struct {
+	Permission RepoPermissionLevel `json:"permission"`
+	User       *Account            `json:"user"`
+	Repo       *Repo               `json:"repository"`
+}
+
+type RepoPermissionLevel string
+
+const (
+	RepoPermissionLevelRead  RepoPermissionLevel = "read"
+	RepoPermissionLevelWrite RepoPermissionLevel = "write"
+	RepoPermissionLevelAdmin RepoPermissionLevel = "admin"
+)
+
 // Repo represents the Repository type returned by Bitbucket Cloud.
 //
 // When used as an input into functions, only the FullName field is actually
@@ -22,8 +22,33 @@ func (c *client) CurrentUser(ctx context.Context) (*User, error) {
 	return &user, nil
 }
 
+// CurrentUserEmails returns the email addresses of the user associated with the
+// authenticator in use, based on the given pagination criteria.
+//
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-emails-get
+func (c *client) CurrentUserEmails(ctx context.Context, pageToken *PageToken) ([]*UserEmail, *PageToken, error) {
+	var emails []*UserEmail
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &emails)
+	} else {
+		next, err = c.page(ctx, "/2.0/user/emails", nil, pageToken, &emails)
+	}
+	return emails, next, err
+}
+
 type User struct {
 	Account
 	IsStaff   bool   `json:"is_staff"`
 	AccountID string `json:"account_id"`
 }
+
+type UserEmail struct {
+	Email       string `json:"email"`
+	IsConfirmed bool   `json:"is_confirmed"`
+	IsPrimary   bool   `json:"is_primary"`
+}
@@ -2,8 +2,12 @@ package bitbucketcloud
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -34,3 +38,48 @@ func TestClient_CurrentUser(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestClient_CurrentUserEmails(t *testing.T) {
+	var paths []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Query().Get("page") == "" {
+			_, _ = io.WriteString(w, `{
+  "next": "`+srv.URL+`/2.0/user/emails?page=2",
+  "values": [{"email": "alice@example.com", "is_primary": true, "is_confirmed": true}]
+}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"values": [{"email": "alice@example.org", "is_primary": false, "is_confirmed": false}]}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+	ctx := context.Background()
+
+	var got []*UserEmail
+	var next *PageToken
+	for {
+		emails, nextPage, err := cli.CurrentUserEmails(ctx, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, emails...)
+		if !nextPage.HasMore() {
+			break
+		}
+		next = nextPage
+	}
+
+	want := []*UserEmail{
+		{Email: "alice@example.com", IsConfirmed: true, IsPrimary: true},
+		{Email: "alice@example.org"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected emails (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/2.0/user/emails", "/2.0/user/emails?page=2"}, paths); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
@@ -477,6 +477,16 @@ type UpdatePullRequestInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ToRef       Ref    `json:"toRef"`
+
+	// Reviewers replaces the current reviewers of the pull request if set.
+	// Setting it to an empty slice removes all reviewers.
+	Reviewers *[]ReviewerInput `json:"reviewers,omitempty"`
+}
+
+// ReviewerInput identifies a user to request a review from when updating a
+// pull request.
+type ReviewerInput struct {
+	User User `json:"user"`
 }
 
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
@@ -1197,8 +1207,9 @@ type RefRepository struct {
 }
 
 type Ref struct {
-	ID         string        `json:"id"`
-	Repository RefRepository `json:"repository"`
+	ID           string        `json:"id"`
+	LatestCommit string        `json:"latestCommit,omitempty"`
+	Repository   RefRepository `json:"repository"`
 }
 
 type PullRequest struct {
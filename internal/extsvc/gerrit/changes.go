@@ -0,0 +1,254 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change, as returned by the Gerrit API when requesting
+// detailed labels and accounts as well as the current revision and commit.
+type Change struct {
+	ID              string                 `json:"id"`
+	Project         string                 `json:"project"`
+	Branch          string                 `json:"branch"`
+	Topic           string                 `json:"topic,omitempty"`
+	ChangeID        string                 `json:"change_id"`
+	Subject         string                 `json:"subject"`
+	Status          ChangeStatus           `json:"status"`
+	Created         Timestamp              `json:"created"`
+	Updated         Timestamp              `json:"updated"`
+	WorkInProgress  bool                   `json:"work_in_progress,omitempty"`
+	Number          int                    `json:"_number"`
+	Owner           Account                `json:"owner"`
+	Labels          map[string]ChangeLabel `json:"labels,omitempty"`
+	CurrentRevision string                 `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision    `json:"revisions,omitempty"`
+}
+
+// Current returns the current revision of the change, if it was requested.
+func (c *Change) Current() (Revision, bool) {
+	r, ok := c.Revisions[c.CurrentRevision]
+	return r, ok
+}
+
+// ChangeLabel is the state of a single review label, such as Code-Review or
+// Verified, on a change.
+type ChangeLabel struct {
+	Approved    *Account   `json:"approved,omitempty"`
+	Rejected    *Account   `json:"rejected,omitempty"`
+	Recommended *Account   `json:"recommended,omitempty"`
+	Disliked    *Account   `json:"disliked,omitempty"`
+	All         []Approval `json:"all,omitempty"`
+}
+
+// Approval is a vote of a single reviewer on a label.
+type Approval struct {
+	Account
+	Value int `json:"value"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int     `json:"_number"`
+	Ref    string  `json:"ref"`
+	Commit *Commit `json:"commit,omitempty"`
+}
+
+// Commit is the commit of a revision.
+type Commit struct {
+	Parents []CommitParent `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// CommitParent is a parent of a commit.
+type CommitParent struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// timestampLayout is the layout of timestamps in the Gerrit API, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp wraps time.Time to (un)marshal the timestamp format used by the
+// Gerrit API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// ChangeTriplet returns the identifier of a change made up of the project,
+// destination branch and Change-Id trailer, which is unique even before the
+// change number is known.
+func ChangeTriplet(project, branch, changeID string) string {
+	return project + "~" + strings.TrimPrefix(branch, "refs/heads/") + "~" + changeID
+}
+
+// GetChange returns the change with the given identifier, which can either be
+// the change number or a ChangeTriplet.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := make(url.Values)
+	for _, o := range []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"} {
+		qs.Add("o", o)
+	}
+
+	req, err := http.NewRequest("GET", changePath(changeID, "")+"?"+qs.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "abandon", nil)
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "restore", nil)
+}
+
+// SubmitChange submits, i.e. merges, the given change.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "submit", nil)
+}
+
+// SetWorkInProgress marks the given change as work in progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "wip", nil)
+}
+
+// SetReadyForReview marks the given work in progress change as ready for
+// review.
+func (c *Client) SetReadyForReview(ctx context.Context, changeID string) error {
+	return c.postChangeAction(ctx, changeID, "ready", nil)
+}
+
+// MoveChange moves the given change to another destination branch.
+func (c *Client) MoveChange(ctx context.Context, changeID, branch string) error {
+	return c.postChangeAction(ctx, changeID, "move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{strings.TrimPrefix(branch, "refs/heads/")})
+}
+
+// SetCommitMessage creates a new patch set of the given change with an
+// updated commit message.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("PUT", changePath(changeID, "message"), struct {
+		Message string `json:"message"`
+	}{message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// WriteReviewComment posts a review message on the current revision of the
+// given change, without voting on any label.
+func (c *Client) WriteReviewComment(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("POST", changePath(changeID, "revisions/current/review"), struct {
+		Message string `json:"message"`
+	}{message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err = c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) postChangeAction(ctx context.Context, changeID, action string, body any) error {
+	req, err := newJSONRequest("POST", changePath(changeID, action), body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// changePath returns the path of the given change endpoint. The change ID is
+// escaped, since project names and triplets may contain slashes.
+func changePath(changeID, endpoint string) string {
+	p := "a/changes/" + url.PathEscape(changeID)
+	if endpoint != "" {
+		p += "/" + endpoint
+	}
+	return p
+}
+
+func newJSONRequest(method, urlStr string, body any) (*http.Request, error) {
+	if body == nil {
+		return http.NewRequest(method, urlStr, nil)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, urlStr, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
+}
@@ -0,0 +1,194 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_GetChange(t *testing.T) {
+	var gotPath string
+	var gotQuery []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotQuery = r.URL.Query()["o"]
+		_, _ = io.WriteString(w, `)]}'
+{
+  "id": "foo%2Fbar~main~I0123456789abcdef0123456789abcdef01234567",
+  "project": "foo/bar",
+  "branch": "main",
+  "topic": "my-topic",
+  "change_id": "I0123456789abcdef0123456789abcdef01234567",
+  "subject": "Fix all the things",
+  "status": "NEW",
+  "created": "2022-10-11 12:13:14.000000000",
+  "updated": "2022-10-12 12:13:14.500000000",
+  "_number": 42,
+  "owner": {"_account_id": 1000, "name": "Jane", "username": "jane"},
+  "labels": {"Code-Review": {"approved": {"_account_id": 1001}, "all": [{"_account_id": 1001, "value": 2}]}},
+  "current_revision": "deadbeef",
+  "revisions": {"deadbeef": {"_number": 2, "ref": "refs/changes/42/42/2", "commit": {"parents": [{"commit": "cafebabe"}], "subject": "Fix all the things", "message": "Fix all the things\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n"}}}
+}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+
+	change, err := cli.GetChange(context.Background(), ChangeTriplet("foo/bar", "refs/heads/main", "I0123456789abcdef0123456789abcdef01234567"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/a/changes/foo%2Fbar~main~I0123456789abcdef0123456789abcdef01234567"; gotPath != want {
+		t.Errorf("unexpected path: want %q, have %q", want, gotPath)
+	}
+	if diff := cmp.Diff([]string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}, gotQuery); diff != "" {
+		t.Errorf("unexpected options (-want +got):\n%s", diff)
+	}
+
+	if change.Number != 42 || change.Status != ChangeStatusNew || change.Owner.Username != "jane" {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if want := time.Date(2022, 10, 12, 12, 13, 14, 500000000, time.UTC); !change.Updated.Equal(want) {
+		t.Errorf("unexpected updated timestamp: want %s, have %s", want, change.Updated)
+	}
+	if change.Labels["Code-Review"].Approved == nil {
+		t.Errorf("expected Code-Review label to be approved")
+	}
+	rev, ok := change.Current()
+	if !ok {
+		t.Fatal("expected current revision")
+	}
+	if rev.Commit.Parents[0].Commit != "cafebabe" {
+		t.Errorf("unexpected parent commit: %q", rev.Commit.Parents[0].Commit)
+	}
+
+	// Timestamps must round trip, since changes are stored as changeset
+	// metadata.
+	data, err := json.Marshal(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTripped Change
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(change, &roundTripped); diff != "" {
+		t.Errorf("change did not round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   string
+	}
+
+	var got []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, request{Method: r.Method, Path: r.URL.EscapedPath(), Body: string(body)})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+	ctx := context.Background()
+
+	for _, action := range []func() error{
+		func() error { return cli.AbandonChange(ctx, "42") },
+		func() error { return cli.RestoreChange(ctx, "42") },
+		func() error { return cli.SubmitChange(ctx, "42") },
+		func() error { return cli.SetWorkInProgress(ctx, "42") },
+		func() error { return cli.SetReadyForReview(ctx, "42") },
+		func() error { return cli.MoveChange(ctx, "42", "refs/heads/release") },
+		func() error { return cli.SetCommitMessage(ctx, "42", "New subject") },
+		func() error { return cli.WriteReviewComment(ctx, "42", "LGTM") },
+	} {
+		if err := action(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []request{
+		{Method: "POST", Path: "/a/changes/42/abandon"},
+		{Method: "POST", Path: "/a/changes/42/restore"},
+		{Method: "POST", Path: "/a/changes/42/submit"},
+		{Method: "POST", Path: "/a/changes/42/wip"},
+		{Method: "POST", Path: "/a/changes/42/ready"},
+		{Method: "POST", Path: "/a/changes/42/move", Body: `{"destination_branch":"release"}`},
+		{Method: "PUT", Path: "/a/changes/42/message", Body: `{"message":"New subject"}`},
+		{Method: "POST", Path: "/a/changes/42/revisions/current/review", Body: `{"message":"LGTM"}`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ChangeNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not found: 42", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+
+	_, err := cli.GetChange(context.Background(), "42")
+	if e, ok := err.(*httpError); !ok || !e.NotFound() {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	var gotUser, gotPass string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, _ = r.BasicAuth()
+		_, _ = io.WriteString(w, ")]}'\n"+`{"_account_id": 1000, "username": "jane"}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestServerClient(t, srv)
+
+	if _, err := cli.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"}); err == nil {
+		t.Fatal("expected error for unsupported authenticator")
+	}
+
+	authed, err := cli.WithAuthenticator(&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "jane", Password: "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := authed.GetAuthenticatedAccount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Username != "jane" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+	if gotUser != "jane" || gotPass != "secret" {
+		t.Errorf("unexpected credentials: %q:%q", gotUser, gotPass)
+	}
+	if cli.Config.Username != "" {
+		t.Errorf("original client credentials were modified")
+	}
+}
+
+func newTestServerClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+
+	cli, err := NewClient("urn", &schema.GerritConnection{Url: srv.URL + "/"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
@@ -10,8 +10,10 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -52,6 +54,29 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 	}, nil
 }
 
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client and rate limiter as the current Client, except authenticated
+// with the given authenticator. Gerrit only supports HTTP basic auth, so any
+// other authenticator type results in an error.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	config := *c.Config
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		config.Username, config.Password = a.Username, a.Password
+	case *auth.BasicAuthWithSSH:
+		config.Username, config.Password = a.Username, a.Password
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gerrit clients: %T", a)
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     &config,
+		URL:        c.URL,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -199,6 +224,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints, such as the ones toggling the work in progress state of a
+	// change, respond with 204 No Content, so there is nothing to unmarshal.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -174,6 +174,22 @@ func (c *V3Client) delete(ctx context.Context, requestURI string) (*httpResponse
 	return c.request(ctx, req, struct{}{})
 }
 
+func (c *V3Client) deleteWithPayload(ctx context.Context, requestURI string, payload, result any) (*httpResponseState, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling payload")
+	}
+
+	req, err := http.NewRequest("DELETE", requestURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	return c.request(ctx, req, result)
+}
+
 func (c *V3Client) request(ctx context.Context, req *http.Request, result any) (*httpResponseState, error) {
 	// Include node_id (GraphQL ID) in response. See
 	// https://developer.github.com/changes/2017-12-19-graphql-node-id/.
@@ -728,6 +744,89 @@ func (c *V3Client) Fork(ctx context.Context, owner, repo string, org *string, fo
 	return convertRestRepo(restRepo), nil
 }
 
+// RequestReviewers requests a review of the given pull request from the given
+// users and teams. Teams are identified by their slug.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, users, teams []string) error {
+	payload := reviewRequestsPayload{Reviewers: users, TeamReviewers: teams}
+
+	var result json.RawMessage
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &result)
+	return err
+}
+
+// RemoveRequestedReviewers removes the review requests of the given users and
+// teams from the given pull request. Teams are identified by their slug.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#remove-requested-reviewers-from-a-pull-request
+func (c *V3Client) RemoveRequestedReviewers(ctx context.Context, owner, repo string, number int64, users, teams []string) error {
+	payload := reviewRequestsPayload{Reviewers: users, TeamReviewers: teams}
+
+	var result json.RawMessage
+	_, err := c.deleteWithPayload(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &result)
+	return err
+}
+
+type reviewRequestsPayload struct {
+	Reviewers     []string `json:"reviewers"`
+	TeamReviewers []string `json:"team_reviewers"`
+}
+
+// AddLabels adds the given labels to the given issue or pull request. Labels
+// that don't exist in the repository yet are created.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#add-labels-to-an-issue
+func (c *V3Client) AddLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+
+	var result json.RawMessage
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number), payload, &result)
+	return err
+}
+
+// RemoveLabel removes the given label from the given issue or pull request.
+// Removing a label that isn't applied is not an error.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#remove-a-label-from-an-issue
+func (c *V3Client) RemoveLabel(ctx context.Context, owner, repo string, number int64, label string) error {
+	var result json.RawMessage
+	_, err := c.deleteWithPayload(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label)), struct{}{}, &result)
+	if err != nil && HTTPErrorCode(err) != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// AddAssignees assigns the given users to the given issue or pull request.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#add-assignees-to-an-issue
+func (c *V3Client) AddAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := assigneesPayload{Assignees: assignees}
+
+	var result json.RawMessage
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &result)
+	return err
+}
+
+// RemoveAssignees unassigns the given users from the given issue or pull
+// request.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#remove-assignees-from-an-issue
+func (c *V3Client) RemoveAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := assigneesPayload{Assignees: assignees}
+
+	var result json.RawMessage
+	_, err := c.deleteWithPayload(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &result)
+	return err
+}
+
+type assigneesPayload struct {
+	Assignees []string `json:"assignees"`
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
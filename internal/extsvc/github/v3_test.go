@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -1020,3 +1021,85 @@ func TestListPublicRepositories(t *testing.T) {
 		assert.Equal(t, "2", repositories[1].ID)
 	})
 }
+
+func TestV3Client_PullRequestMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	type request struct {
+		Method string
+		Path   string
+		Body   string
+	}
+
+	for name, tc := range map[string]struct {
+		call func(c *V3Client) error
+		want request
+	}{
+		"RequestReviewers": {
+			call: func(c *V3Client) error {
+				return c.RequestReviewers(ctx, "sourcegraph", "sourcegraph", 42, []string{"alice"}, []string{"batchers"})
+			},
+			want: request{"POST", "/repos/sourcegraph/sourcegraph/pulls/42/requested_reviewers", `{"reviewers":["alice"],"team_reviewers":["batchers"]}`},
+		},
+		"RemoveRequestedReviewers": {
+			call: func(c *V3Client) error {
+				return c.RemoveRequestedReviewers(ctx, "sourcegraph", "sourcegraph", 42, []string{"alice"}, nil)
+			},
+			want: request{"DELETE", "/repos/sourcegraph/sourcegraph/pulls/42/requested_reviewers", `{"reviewers":["alice"],"team_reviewers":null}`},
+		},
+		"AddLabels": {
+			call: func(c *V3Client) error {
+				return c.AddLabels(ctx, "sourcegraph", "sourcegraph", 42, []string{"automated"})
+			},
+			want: request{"POST", "/repos/sourcegraph/sourcegraph/issues/42/labels", `{"labels":["automated"]}`},
+		},
+		"RemoveLabel": {
+			call: func(c *V3Client) error {
+				return c.RemoveLabel(ctx, "sourcegraph", "sourcegraph", 42, "batch changes")
+			},
+			want: request{"DELETE", "/repos/sourcegraph/sourcegraph/issues/42/labels/batch changes", `{}`},
+		},
+		"AddAssignees": {
+			call: func(c *V3Client) error {
+				return c.AddAssignees(ctx, "sourcegraph", "sourcegraph", 42, []string{"bob"})
+			},
+			want: request{"POST", "/repos/sourcegraph/sourcegraph/issues/42/assignees", `{"assignees":["bob"]}`},
+		},
+		"RemoveAssignees": {
+			call: func(c *V3Client) error {
+				return c.RemoveAssignees(ctx, "sourcegraph", "sourcegraph", 42, []string{"bob"})
+			},
+			want: request{"DELETE", "/repos/sourcegraph/sourcegraph/issues/42/assignees", `{"assignees":["bob"]}`},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var have request
+			doer := httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				have = request{Method: req.Method, Path: req.URL.Path, Body: string(body)}
+				return &http.Response{
+					Request:    req,
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}, nil
+			})
+
+			if err := tc.call(newTestClient(t, doer)); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected request (-want +have):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("RemoveLabel not applied", func(t *testing.T) {
+		doer := &mockHTTPResponseBody{status: http.StatusNotFound, responseBody: `{"message": "Label does not exist"}`}
+		if err := newTestClient(t, doer).RemoveLabel(ctx, "sourcegraph", "sourcegraph", 42, "automated"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
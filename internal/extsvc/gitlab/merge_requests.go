@@ -47,6 +47,8 @@ type MergeRequest struct {
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees,omitempty"`
+	Reviewers              []User            `json:"reviewers,omitempty"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +223,15 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+
+	// AddLabels and RemoveLabels are comma-separated lists of labels.
+	AddLabels    string `json:"add_labels,omitempty"`
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the current assignees and reviewers
+	// of the merge request if set. Setting them to an empty slice removes all
+	// assignees or reviewers.
+	AssigneeIDs *[]int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs *[]int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
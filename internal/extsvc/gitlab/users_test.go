@@ -0,0 +1,54 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestGetUserByUsername(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		user, err := client.GetUserByUsername(ctx, "alice")
+		if user != nil {
+			t.Errorf("unexpected non-nil user: %+v", user)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{responseBody: `[]`}
+
+		user, err := client.GetUserByUsername(ctx, "alice")
+		if user != nil {
+			t.Errorf("unexpected non-nil user: %+v", user)
+		}
+		if !errcode.IsNotFound(err) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{responseBody: `[{"id":42,"username":"alice"}]`}
+
+		user, err := client.GetUserByUsername(ctx, "alice")
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(user, &User{ID: 42, Username: "alice"}); diff != "" {
+			t.Errorf("unexpected user: %s", diff)
+		}
+	})
+}
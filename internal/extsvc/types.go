@@ -672,10 +672,11 @@ func (e ErrRateLimitUnsupported) Error() string {
 }
 
 const (
-	URNGitHubApp   = "GitHubApp"
-	URNGitHubOAuth = "GitHubOAuth"
-	URNGitLabOAuth = "GitLabOAuth"
-	URNCodeIntel   = "CodeIntel"
+	URNGitHubApp           = "GitHubApp"
+	URNGitHubOAuth         = "GitHubOAuth"
+	URNGitLabOAuth         = "GitLabOAuth"
+	URNBitbucketCloudOAuth = "BitbucketCloudOAuth"
+	URNCodeIntel           = "CodeIntel"
 )
 
 // URN returns a unique resource identifier of an external service by given kind and ID.
@@ -630,6 +630,10 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, _ htt
 		Stdin:          c.stdin,
 		NoTimeout:      c.noTimeout,
 	}
+	if c.execGRPCFn != nil {
+		return c.execGRPCFn(ctx, req)
+	}
+
 	resp, err := c.execFn(ctx, repoName, "exec", req)
 	if err != nil {
 		return nil, nil, err
@@ -670,6 +674,10 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 
 	repoName := protocol.NormalizeRepo(args.Repo)
 
+	if c.grpcEnabled() {
+		return c.grpcSearch(ctx, repoName, args, onMatches)
+	}
+
 	protocol.RegisterGob()
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -788,14 +796,63 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 			})
 		}()
 
-		uri := "http://" + addr + "/batch-log"
-		repoName := api.RepoName(strings.Join(repoNames, ",")) // only used to label spans
-
 		request := protocol.BatchLogRequest{
 			RepoCommits: repoCommits,
 			Format:      opts.Format,
 		}
 
+		handleResults := func(results []protocol.BatchLogResult) error {
+			for _, result := range results {
+				var err error
+				if result.CommandError != "" {
+					err = errors.New(result.CommandError)
+				}
+
+				rawResult := RawBatchLogResult{
+					Stdout: result.CommandOutput,
+					Error:  err,
+				}
+				if err := callback(result.RepoCommit, rawResult); err != nil {
+					return errors.Wrap(err, "commitLogCallback")
+				}
+
+				numProcessed++
+			}
+			return nil
+		}
+
+		if c.grpcEnabled() {
+			client, err := c.grpcClientForAddr(addr)
+			if err != nil {
+				return err
+			}
+			stream, err := client.BatchLog(ctx, request.ToProto())
+			if err != nil {
+				return convertGRPCErr(ctx, "", err)
+			}
+			// The results are streamed in batches, which are handled as they
+			// arrive.
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return convertGRPCErr(ctx, "", err)
+				}
+				var response protocol.BatchLogResponse
+				response.FromProto(resp)
+				logger.AddEvent("read response", attribute.Int("numResults", len(response.Results)))
+				if err := handleResults(response.Results); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		uri := "http://" + addr + "/batch-log"
+		repoName := api.RepoName(strings.Join(repoNames, ",")) // only used to label spans
+
 		var buf bytes.Buffer
 		if err := json.NewEncoder(&buf).Encode(request); err != nil {
 			return err
@@ -818,24 +875,7 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 		}
 		logger.AddEvent("read response", attribute.Int("numResults", len(response.Results)))
 
-		for _, result := range response.Results {
-			var err error
-			if result.CommandError != "" {
-				err = errors.New(result.CommandError)
-			}
-
-			rawResult := RawBatchLogResult{
-				Stdout: result.CommandOutput,
-				Error:  err,
-			}
-			if err := callback(result.RepoCommit, rawResult); err != nil {
-				return errors.Wrap(err, "commitLogCallback")
-			}
-
-			numProcessed++
-		}
-
-		return nil
+		return handleResults(response.Results)
 	}
 
 	// Construct batches of requests keyed by the address of the server that will receive the batch.
@@ -921,11 +961,15 @@ func (c *clientImplementor) gitCommand(repo api.RepoName, arg ...string) GitComm
 		}
 		return cmd
 	}
-	return &RemoteGitCommand{
+	cmd := &RemoteGitCommand{
 		repo:   repo,
 		execFn: c.httpPost,
 		args:   append([]string{git}, arg...),
 	}
+	if c.grpcEnabled() {
+		cmd.execGRPCFn = c.grpcExec
+	}
+	return cmd
 }
 
 func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since time.Duration) (*protocol.RepoUpdateResponse, error) {
@@ -933,6 +977,15 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		Repo:  repo,
 		Since: since,
 	}
+
+	if c.grpcEnabled() {
+		client, err := c.grpcClientForRepo(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		return c.grpcRepoUpdate(ctx, client, req)
+	}
+
 	resp, err := c.httpPost(ctx, repo, "repo-update", req)
 	if err != nil {
 		return nil, err
@@ -965,6 +1018,14 @@ func (c *clientImplementor) RequestRepoMigrate(ctx context.Context, repo api.Rep
 	// the request at /repo-update, it will treat it as a new clone operation and attempt to clone
 	// the repo from the URL set in CloneFromShard - the gitserver instance that owns this repo based
 	// on the existing hashing scheme.
+	if c.grpcEnabled() {
+		client, err := c.grpcClientForAddr(to)
+		if err != nil {
+			return nil, err
+		}
+		return c.grpcRepoUpdate(ctx, client, req)
+	}
+
 	uri := "http://" + to + "/repo-update"
 	resp, err := c.httpPostWithURI(ctx, repo, uri, req)
 	if err != nil {
@@ -2444,6 +2444,26 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
+	if c.grpcEnabled() {
+		rc, trailer, err := c.grpcArchive(ctx, repo, options)
+		if err != nil {
+			var notExistErr *gitdomain.RepoNotExistError
+			if errors.As(err, &notExistErr) {
+				return nil, &badRequestError{error: err}
+			}
+			return nil, err
+		}
+
+		return &archiveReader{
+			base: &cmdReader{
+				rc:      rc,
+				trailer: trailer,
+			},
+			repo: repo,
+			spec: options.Treeish,
+		}, nil
+	}
+
 	u, err := c.archiveURL(ctx, repo, options)
 	if err != nil {
 		return nil, err
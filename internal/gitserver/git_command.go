@@ -169,6 +169,9 @@ type RemoteGitCommand struct {
 	noTimeout      bool
 	exitStatus     int
 	execFn         func(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error)
+	// execGRPCFn, if set, is used instead of execFn to run the command through
+	// the gRPC API of gitserver.
+	execGRPCFn func(ctx context.Context, req *protocol.ExecRequest) (io.ReadCloser, http.Header, error)
 }
 
 // DividedOutput runs the command and returns its standard output and standard error.
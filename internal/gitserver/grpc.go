@@ -0,0 +1,316 @@
+package gitserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// grpcConns holds the gRPC connections to gitserver instances, keyed by address.
+// Connections are shared by all clients, as each of them multiplexes any number
+// of concurrent requests.
+var grpcConns = struct {
+	sync.Mutex
+	// addrs is the list of gitserver addresses that m was last pruned against.
+	addrs []string
+	m     map[string]*grpc.ClientConn
+}{m: map[string]*grpc.ClientConn{}}
+
+// grpcEnabled returns true if requests should be sent to the gRPC API of
+// gitserver rather than to its HTTP API. The HTTP API remains available as a
+// fallback while the gRPC API is being rolled out.
+func (c *clientImplementor) grpcEnabled() bool {
+	return conf.GRPCEnabled()
+}
+
+// grpcClientForAddr returns a client of the gRPC API of the gitserver instance
+// at the given address.
+func (c *clientImplementor) grpcClientForAddr(addr string) (proto.GitserverServiceClient, error) {
+	grpcConns.Lock()
+	defer grpcConns.Unlock()
+
+	closeStaleGRPCConnsLocked(c.Addrs())
+
+	conn, ok := grpcConns.m[addr]
+	if !ok {
+		var err error
+		// Dialing does not block, connections are established lazily.
+		conn, err = grpc.Dial(addr,
+			// gitserver serves gRPC over cleartext HTTP/2, just as it serves
+			// its HTTP API over cleartext HTTP/1.
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUserAgent(c.userAgent),
+			grpc.WithChainUnaryInterceptor(actor.UnaryClientInterceptor),
+			grpc.WithChainStreamInterceptor(actor.StreamClientInterceptor),
+			grpc.WithDefaultCallOptions(
+				grpc.MaxCallRecvMsgSize(protocol.GRPCMaxMessageSize),
+				grpc.MaxCallSendMsgSize(protocol.GRPCMaxMessageSize),
+			),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dialing gitserver %s", addr)
+		}
+		grpcConns.m[addr] = conn
+	}
+
+	return proto.NewGitserverServiceClient(conn), nil
+}
+
+// closeStaleGRPCConnsLocked closes the connections to gitserver instances that
+// are no longer part of addrs, such as instances that were scaled down, so that
+// they do not leak. It does nothing unless addrs changed since the last call.
+// The caller must hold the lock of grpcConns.
+func closeStaleGRPCConnsLocked(addrs []string) {
+	if slices.Equal(addrs, grpcConns.addrs) {
+		return
+	}
+	grpcConns.addrs = slices.Clone(addrs)
+
+	for addr, conn := range grpcConns.m {
+		if slices.Contains(addrs, addr) {
+			continue
+		}
+		// Requests still in flight on the connection fail, just as they would
+		// once the instance is gone.
+		_ = conn.Close()
+		delete(grpcConns.m, addr)
+	}
+}
+
+// grpcClientForRepo returns a client of the gRPC API of the gitserver instance
+// that hosts the given repository.
+func (c *clientImplementor) grpcClientForRepo(ctx context.Context, repo api.RepoName) (proto.GitserverServiceClient, error) {
+	addr, err := c.AddrForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.grpcClientForAddr(addr)
+}
+
+// grpcExec is the gRPC counterpart of RemoteGitCommand.execFn. The returned
+// trailer is populated with the exit status of the command in the same way as
+// the trailer of the HTTP API, once the returned reader is drained.
+func (c *clientImplementor) grpcExec(ctx context.Context, req *protocol.ExecRequest) (io.ReadCloser, http.Header, error) {
+	client, err := c.grpcClientForRepo(ctx, req.Repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The stream is canceled once the reader is closed, which terminates the
+	// command on gitserver if it is still running.
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := client.Exec(ctx, req.ToProto())
+	if err != nil {
+		cancel()
+		return nil, nil, convertGRPCErr(ctx, req.Repo, err)
+	}
+
+	return newGRPCExecReader(ctx, req.Repo, stream, cancel)
+}
+
+// grpcArchive is the gRPC counterpart of the HTTP request in ArchiveReader.
+func (c *clientImplementor) grpcArchive(ctx context.Context, repo api.RepoName, opt ArchiveOptions) (io.ReadCloser, http.Header, error) {
+	client, err := c.grpcClientForRepo(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pathspecs := make([]string, 0, len(opt.Pathspecs))
+	for _, pathspec := range opt.Pathspecs {
+		pathspecs = append(pathspecs, string(pathspec))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := client.Archive(ctx, &proto.ArchiveRequest{
+		Repo:      string(repo),
+		Treeish:   opt.Treeish,
+		Format:    string(opt.Format),
+		Pathspecs: pathspecs,
+	})
+	if err != nil {
+		cancel()
+		return nil, nil, convertGRPCErr(ctx, repo, err)
+	}
+
+	return newGRPCExecReader(ctx, repo, stream, cancel)
+}
+
+// grpcSearch is the gRPC counterpart of the HTTP request in Search.
+func (c *clientImplementor) grpcSearch(ctx context.Context, repo api.RepoName, args *protocol.SearchRequest, onMatches func([]protocol.CommitMatch)) (bool, error) {
+	client, err := c.grpcClientForRepo(ctx, repo)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req := args.ToProto()
+	req.Repo = string(repo)
+	stream, err := client.Search(ctx, req)
+	if err != nil {
+		return false, convertGRPCErr(ctx, repo, err)
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return false, errors.New("search stream ended without done event")
+			}
+			return false, convertGRPCErr(ctx, repo, err)
+		}
+
+		switch event := msg.GetEvent().(type) {
+		case *proto.SearchResponse_Matches:
+			matches := make([]protocol.CommitMatch, len(event.Matches.GetMatches()))
+			for i, match := range event.Matches.GetMatches() {
+				matches[i].FromProto(match)
+			}
+			onMatches(matches)
+		case *proto.SearchResponse_Done:
+			var done protocol.SearchEventDone
+			done.FromProto(event.Done)
+			return done.LimitHit, done.Err()
+		default:
+			return false, errors.Errorf("unknown event %T", event)
+		}
+	}
+}
+
+// grpcRepoUpdate is the gRPC counterpart of the HTTP requests in
+// RequestRepoUpdate and RequestRepoMigrate.
+func (c *clientImplementor) grpcRepoUpdate(ctx context.Context, client proto.GitserverServiceClient, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := client.RepoUpdate(ctx, req.ToProto())
+	if err != nil {
+		return nil, convertGRPCErr(ctx, req.Repo, err)
+	}
+
+	var info protocol.RepoUpdateResponse
+	info.FromProto(resp)
+	return &info, nil
+}
+
+// grpcExecStream is implemented by the client streams of both Exec and Archive.
+type grpcExecStream interface {
+	Recv() (*proto.ExecResponse, error)
+}
+
+// grpcExecReader reads the output of a command from a stream of ExecResponse
+// messages.
+type grpcExecReader struct {
+	ctx     context.Context
+	repo    api.RepoName
+	stream  grpcExecStream
+	cancel  context.CancelFunc
+	trailer http.Header
+	buf     []byte
+	err     error
+}
+
+// newGRPCExecReader receives the first message of the given stream before
+// returning, so that errors such as a missing repository are reported by the
+// call that started the command rather than by the first read.
+func newGRPCExecReader(ctx context.Context, repo api.RepoName, stream grpcExecStream, cancel context.CancelFunc) (io.ReadCloser, http.Header, error) {
+	r := &grpcExecReader{
+		ctx:     ctx,
+		repo:    repo,
+		stream:  stream,
+		cancel:  cancel,
+		trailer: http.Header{},
+	}
+	if err := r.recv(); err != nil && err != io.EOF {
+		cancel()
+		return nil, nil, err
+	}
+	return r, r.trailer, nil
+}
+
+func (r *grpcExecReader) recv() error {
+	msg, err := r.stream.Recv()
+	if err != nil {
+		if err != io.EOF {
+			err = convertGRPCErr(r.ctx, r.repo, err)
+		}
+		r.err = err
+		return err
+	}
+
+	switch payload := msg.GetPayload().(type) {
+	case *proto.ExecResponse_Data:
+		r.buf = payload.Data
+	case *proto.ExecResponse_Status:
+		r.trailer.Set("X-Exec-Error", payload.Status.GetError())
+		r.trailer.Set("X-Exec-Exit-Status", strconv.Itoa(int(payload.Status.GetExitStatus())))
+		r.trailer.Set("X-Exec-Stderr", payload.Status.GetStderr())
+	}
+	return nil
+}
+
+func (r *grpcExecReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		_ = r.recv()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *grpcExecReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// convertGRPCErr converts an error returned by the gRPC API into the error the
+// HTTP API would have produced in the same situation.
+func convertGRPCErr(ctx context.Context, repo api.RepoName, err error) error {
+	// Prefer the error of the context, as callers check for context errors.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		for _, detail := range st.Details() {
+			if payload, ok := detail.(*proto.NotFoundPayload); ok {
+				return &gitdomain.RepoNotExistError{
+					Repo:            repo,
+					CloneInProgress: payload.GetCloneInProgress(),
+					CloneProgress:   payload.GetCloneProgress(),
+				}
+			}
+		}
+	case codes.InvalidArgument:
+		return badRequestError{errors.New(st.Message())}
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	case codes.Canceled:
+		return context.Canceled
+	}
+	return errors.Newf("gitserver: %s", st.Message())
+}
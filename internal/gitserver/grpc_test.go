@@ -0,0 +1,135 @@
+package gitserver
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeExecStream struct {
+	responses []*proto.ExecResponse
+	err       error
+}
+
+func (s *fakeExecStream) Recv() (*proto.ExecResponse, error) {
+	if len(s.responses) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func execData(data string) *proto.ExecResponse {
+	return &proto.ExecResponse{Payload: &proto.ExecResponse_Data{Data: []byte(data)}}
+}
+
+func execStatus(exitStatus int32, stderr string) *proto.ExecResponse {
+	return &proto.ExecResponse{Payload: &proto.ExecResponse_Status{Status: &proto.ExecStatus{ExitStatus: exitStatus, Stderr: stderr}}}
+}
+
+func TestGRPCExecReader(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		stream := &fakeExecStream{responses: []*proto.ExecResponse{
+			execData("hello "),
+			execData("world"),
+			execStatus(0, ""),
+		}}
+		rc, trailer, err := newGRPCExecReader(context.Background(), "repo", stream, func() {})
+		require.NoError(t, err)
+
+		output, err := io.ReadAll(&cmdReader{rc: rc, trailer: trailer})
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(output))
+		require.Equal(t, "0", trailer.Get("X-Exec-Exit-Status"))
+	})
+
+	t.Run("non-zero exit status", func(t *testing.T) {
+		stream := &fakeExecStream{responses: []*proto.ExecResponse{
+			execStatus(128, "fatal: bad revision"),
+		}}
+		rc, trailer, err := newGRPCExecReader(context.Background(), "repo", stream, func() {})
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(&cmdReader{rc: rc, trailer: trailer})
+		require.ErrorContains(t, err, "non-zero exit status: 128")
+		require.ErrorContains(t, err, "fatal: bad revision")
+	})
+
+	t.Run("repo not found", func(t *testing.T) {
+		st, err := status.New(codes.NotFound, "repository clone in progress").WithDetails(&proto.NotFoundPayload{
+			Repo:            "repo",
+			CloneInProgress: true,
+			CloneProgress:   "cloning",
+		})
+		require.NoError(t, err)
+
+		canceled := false
+		stream := &fakeExecStream{err: st.Err()}
+		_, _, err = newGRPCExecReader(context.Background(), "repo", stream, func() { canceled = true })
+
+		var notExistErr *gitdomain.RepoNotExistError
+		require.True(t, errors.As(err, &notExistErr), "unexpected error %v", err)
+		require.True(t, notExistErr.CloneInProgress)
+		require.Equal(t, "cloning", notExistErr.CloneProgress)
+		require.True(t, canceled)
+	})
+}
+
+func TestConvertGRPCErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, context.Canceled, convertGRPCErr(ctx, "repo", status.Error(codes.Unavailable, "connection closing")))
+
+	err := convertGRPCErr(context.Background(), "repo", status.Error(codes.InvalidArgument, "invalid command"))
+	var badRequest interface{ BadRequest() bool }
+	require.True(t, errors.As(err, &badRequest))
+
+	require.Equal(t, context.DeadlineExceeded, convertGRPCErr(context.Background(), "repo", status.Error(codes.DeadlineExceeded, "deadline exceeded")))
+}
+
+func TestCloseStaleGRPCConnsLocked(t *testing.T) {
+	grpcConns.Lock()
+	defer grpcConns.Unlock()
+
+	dial := func(addr string) *grpc.ClientConn {
+		// Dialing does not block, so no server is needed.
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		return conn
+	}
+	kept, removed := dial("gitserver-0:3178"), dial("gitserver-1:3178")
+	grpcConns.addrs = []string{"gitserver-0:3178", "gitserver-1:3178"}
+	grpcConns.m = map[string]*grpc.ClientConn{
+		"gitserver-0:3178": kept,
+		"gitserver-1:3178": removed,
+	}
+	t.Cleanup(func() {
+		_ = kept.Close()
+		grpcConns.addrs = nil
+		grpcConns.m = map[string]*grpc.ClientConn{}
+	})
+
+	// Unchanged addresses keep all connections.
+	closeStaleGRPCConnsLocked([]string{"gitserver-0:3178", "gitserver-1:3178"})
+	require.Len(t, grpcConns.m, 2)
+
+	closeStaleGRPCConnsLocked([]string{"gitserver-0:3178", "gitserver-2:3178"})
+	require.Equal(t, map[string]*grpc.ClientConn{"gitserver-0:3178": kept}, grpcConns.m)
+	require.Equal(t, connectivity.Shutdown, removed.GetState())
+	require.NotEqual(t, connectivity.Shutdown, kept.GetState())
+}
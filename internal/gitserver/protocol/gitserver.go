@@ -326,6 +326,12 @@ type PushConfig struct {
 	// Passphrase is the passphrase to decrypt the private key. It is required
 	// when passing PrivateKey.
 	Passphrase string
+
+	// RemoteRef is the ref on the remote to push the commit to. If empty, the
+	// commit is pushed to the same ref it is created at on gitserver. This is
+	// used by code hosts such as Gerrit, which expect pushes to a magic ref
+	// like `refs/for/main` instead of the branch itself.
+	RemoteRef string `json:",omitempty"`
 }
 
 // CreateCommitFromPatchResponse is the response type returned after creating
@@ -0,0 +1,422 @@
+package protocol
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// This file converts the types of this package to and from their counterparts
+// in the gRPC API of gitserver.
+
+const (
+	// GRPCMaxMessageSize is the maximum size of the messages exchanged through
+	// the gRPC API of gitserver, in bytes.
+	GRPCMaxMessageSize = 16 << 20
+
+	// GRPCChunkSize is the size in bytes that messages carrying payloads that
+	// are not bounded in size, such as the output of commands, search matches
+	// and the results of BatchLog, are split at. It is far below
+	// GRPCMaxMessageSize so that a single oversized item still fits into a
+	// message of its own.
+	GRPCChunkSize = 1 << 20
+)
+
+func (r *ExecRequest) ToProto() *proto.ExecRequest {
+	return &proto.ExecRequest{
+		Repo:           string(r.Repo),
+		EnsureRevision: r.EnsureRevision,
+		Args:           r.Args,
+		Stdin:          r.Stdin,
+		NoTimeout:      r.NoTimeout,
+	}
+}
+
+func (r *ExecRequest) FromProto(p *proto.ExecRequest) {
+	*r = ExecRequest{
+		Repo:           api.RepoName(p.GetRepo()),
+		EnsureRevision: p.GetEnsureRevision(),
+		Args:           p.GetArgs(),
+		Stdin:          p.GetStdin(),
+		NoTimeout:      p.GetNoTimeout(),
+	}
+}
+
+func (p *NotFoundPayload) ToProto(repo api.RepoName) *proto.NotFoundPayload {
+	return &proto.NotFoundPayload{
+		Repo:            string(repo),
+		CloneInProgress: p.CloneInProgress,
+		CloneProgress:   p.CloneProgress,
+	}
+}
+
+func (p *NotFoundPayload) FromProto(pp *proto.NotFoundPayload) {
+	*p = NotFoundPayload{
+		CloneInProgress: pp.GetCloneInProgress(),
+		CloneProgress:   pp.GetCloneProgress(),
+	}
+}
+
+func (r *SearchRequest) ToProto() *proto.SearchRequest {
+	revisions := make([]*proto.RevisionSpecifier, 0, len(r.Revisions))
+	for _, rev := range r.Revisions {
+		revisions = append(revisions, &proto.RevisionSpecifier{
+			RevSpec:        rev.RevSpec,
+			RefGlob:        rev.RefGlob,
+			ExcludeRefGlob: rev.ExcludeRefGlob,
+		})
+	}
+
+	return &proto.SearchRequest{
+		Repo:                 string(r.Repo),
+		Revisions:            revisions,
+		Query:                NodeToProto(r.Query),
+		IncludeDiff:          r.IncludeDiff,
+		Limit:                int64(r.Limit),
+		IncludeModifiedFiles: r.IncludeModifiedFiles,
+	}
+}
+
+func (r *SearchRequest) FromProto(p *proto.SearchRequest) error {
+	query, err := NodeFromProto(p.GetQuery())
+	if err != nil {
+		return err
+	}
+
+	var revisions []RevisionSpecifier
+	for _, rev := range p.GetRevisions() {
+		revisions = append(revisions, RevisionSpecifier{
+			RevSpec:        rev.GetRevSpec(),
+			RefGlob:        rev.GetRefGlob(),
+			ExcludeRefGlob: rev.GetExcludeRefGlob(),
+		})
+	}
+
+	*r = SearchRequest{
+		Repo:                 api.RepoName(p.GetRepo()),
+		Revisions:            revisions,
+		Query:                query,
+		IncludeDiff:          p.GetIncludeDiff(),
+		Limit:                int(p.GetLimit()),
+		IncludeModifiedFiles: p.GetIncludeModifiedFiles(),
+	}
+	return nil
+}
+
+// NodeToProto converts a query to its gRPC representation. A nil query is
+// converted to a nil node.
+func NodeToProto(n Node) *proto.QueryNode {
+	switch v := n.(type) {
+	case *AuthorMatches:
+		return &proto.QueryNode{Value: &proto.QueryNode_AuthorMatches{AuthorMatches: &proto.AuthorMatchesNode{Expr: v.Expr, IgnoreCase: v.IgnoreCase}}}
+	case *CommitterMatches:
+		return &proto.QueryNode{Value: &proto.QueryNode_CommitterMatches{CommitterMatches: &proto.CommitterMatchesNode{Expr: v.Expr, IgnoreCase: v.IgnoreCase}}}
+	case *CommitBefore:
+		return &proto.QueryNode{Value: &proto.QueryNode_CommitBefore{CommitBefore: &proto.CommitBeforeNode{Timestamp: timestamppb.New(v.Time)}}}
+	case *CommitAfter:
+		return &proto.QueryNode{Value: &proto.QueryNode_CommitAfter{CommitAfter: &proto.CommitAfterNode{Timestamp: timestamppb.New(v.Time)}}}
+	case *MessageMatches:
+		return &proto.QueryNode{Value: &proto.QueryNode_MessageMatches{MessageMatches: &proto.MessageMatchesNode{Expr: v.Expr, IgnoreCase: v.IgnoreCase}}}
+	case *DiffMatches:
+		return &proto.QueryNode{Value: &proto.QueryNode_DiffMatches{DiffMatches: &proto.DiffMatchesNode{Expr: v.Expr, IgnoreCase: v.IgnoreCase}}}
+	case *DiffModifiesFile:
+		return &proto.QueryNode{Value: &proto.QueryNode_DiffModifiesFile{DiffModifiesFile: &proto.DiffModifiesFileNode{Expr: v.Expr, IgnoreCase: v.IgnoreCase}}}
+	case *Boolean:
+		return &proto.QueryNode{Value: &proto.QueryNode_Boolean{Boolean: &proto.BooleanNode{Value: v.Value}}}
+	case Boolean:
+		return &proto.QueryNode{Value: &proto.QueryNode_Boolean{Boolean: &proto.BooleanNode{Value: v.Value}}}
+	case *Operator:
+		operands := make([]*proto.QueryNode, 0, len(v.Operands))
+		for _, operand := range v.Operands {
+			operands = append(operands, NodeToProto(operand))
+		}
+		return &proto.QueryNode{Value: &proto.QueryNode_Operator{Operator: &proto.OperatorNode{
+			Kind:     proto.OperatorNode_Kind(v.Kind),
+			Operands: operands,
+		}}}
+	default:
+		return nil
+	}
+}
+
+// NodeFromProto converts a query from its gRPC representation. A nil node is
+// converted to a nil query.
+func NodeFromProto(p *proto.QueryNode) (Node, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	switch v := p.GetValue().(type) {
+	case *proto.QueryNode_AuthorMatches:
+		return &AuthorMatches{Expr: v.AuthorMatches.GetExpr(), IgnoreCase: v.AuthorMatches.GetIgnoreCase()}, nil
+	case *proto.QueryNode_CommitterMatches:
+		return &CommitterMatches{Expr: v.CommitterMatches.GetExpr(), IgnoreCase: v.CommitterMatches.GetIgnoreCase()}, nil
+	case *proto.QueryNode_CommitBefore:
+		return &CommitBefore{Time: v.CommitBefore.GetTimestamp().AsTime()}, nil
+	case *proto.QueryNode_CommitAfter:
+		return &CommitAfter{Time: v.CommitAfter.GetTimestamp().AsTime()}, nil
+	case *proto.QueryNode_MessageMatches:
+		return &MessageMatches{Expr: v.MessageMatches.GetExpr(), IgnoreCase: v.MessageMatches.GetIgnoreCase()}, nil
+	case *proto.QueryNode_DiffMatches:
+		return &DiffMatches{Expr: v.DiffMatches.GetExpr(), IgnoreCase: v.DiffMatches.GetIgnoreCase()}, nil
+	case *proto.QueryNode_DiffModifiesFile:
+		return &DiffModifiesFile{Expr: v.DiffModifiesFile.GetExpr(), IgnoreCase: v.DiffModifiesFile.GetIgnoreCase()}, nil
+	case *proto.QueryNode_Boolean:
+		return &Boolean{Value: v.Boolean.GetValue()}, nil
+	case *proto.QueryNode_Operator:
+		operands := make([]Node, 0, len(v.Operator.GetOperands()))
+		for _, operand := range v.Operator.GetOperands() {
+			node, err := NodeFromProto(operand)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, node)
+		}
+		return &Operator{Kind: OperatorKind(v.Operator.GetKind()), Operands: operands}, nil
+	default:
+		return nil, errors.Newf("unknown query node type %T", v)
+	}
+}
+
+func (m *CommitMatch) ToProto() *proto.CommitMatch {
+	return &proto.CommitMatch{
+		Oid:           string(m.Oid),
+		Author:        m.Author.ToProto(),
+		Committer:     m.Committer.ToProto(),
+		Parents:       commitIDsToStrings(m.Parents),
+		Refs:          m.Refs,
+		SourceRefs:    m.SourceRefs,
+		Message:       matchedStringToProto(m.Message),
+		Diff:          matchedStringToProto(m.Diff),
+		ModifiedFiles: m.ModifiedFiles,
+	}
+}
+
+func (m *CommitMatch) FromProto(p *proto.CommitMatch) {
+	*m = CommitMatch{
+		Oid:           api.CommitID(p.GetOid()),
+		Parents:       stringsToCommitIDs(p.GetParents()),
+		Refs:          p.GetRefs(),
+		SourceRefs:    p.GetSourceRefs(),
+		Message:       matchedStringFromProto(p.GetMessage()),
+		Diff:          matchedStringFromProto(p.GetDiff()),
+		ModifiedFiles: p.GetModifiedFiles(),
+	}
+	m.Author.FromProto(p.GetAuthor())
+	m.Committer.FromProto(p.GetCommitter())
+}
+
+func (s Signature) ToProto() *proto.Signature {
+	return &proto.Signature{
+		Name:  s.Name,
+		Email: s.Email,
+		Date:  timestamppb.New(s.Date),
+	}
+}
+
+func (s *Signature) FromProto(p *proto.Signature) {
+	*s = Signature{
+		Name:  p.GetName(),
+		Email: p.GetEmail(),
+		Date:  timeFromProto(p.GetDate()),
+	}
+}
+
+func (s SearchEventDone) ToProto() *proto.SearchDone {
+	return &proto.SearchDone{
+		LimitHit: s.LimitHit,
+		Error:    s.Error,
+	}
+}
+
+func (s *SearchEventDone) FromProto(p *proto.SearchDone) {
+	*s = SearchEventDone{
+		LimitHit: p.GetLimitHit(),
+		Error:    p.GetError(),
+	}
+}
+
+func (r *BatchLogRequest) ToProto() *proto.BatchLogRequest {
+	repoCommits := make([]*proto.RepoCommit, 0, len(r.RepoCommits))
+	for _, repoCommit := range r.RepoCommits {
+		repoCommits = append(repoCommits, repoCommitToProto(repoCommit))
+	}
+
+	return &proto.BatchLogRequest{
+		RepoCommits: repoCommits,
+		Format:      r.Format,
+	}
+}
+
+func (r *BatchLogRequest) FromProto(p *proto.BatchLogRequest) {
+	repoCommits := make([]api.RepoCommit, 0, len(p.GetRepoCommits()))
+	for _, repoCommit := range p.GetRepoCommits() {
+		repoCommits = append(repoCommits, repoCommitFromProto(repoCommit))
+	}
+
+	*r = BatchLogRequest{
+		RepoCommits: repoCommits,
+		Format:      p.GetFormat(),
+	}
+}
+
+func (r *BatchLogResponse) ToProto() *proto.BatchLogResponse {
+	results := make([]*proto.BatchLogResult, 0, len(r.Results))
+	for _, result := range r.Results {
+		results = append(results, &proto.BatchLogResult{
+			RepoCommit:    repoCommitToProto(result.RepoCommit),
+			CommandOutput: result.CommandOutput,
+			CommandError:  result.CommandError,
+		})
+	}
+
+	return &proto.BatchLogResponse{Results: results}
+}
+
+func (r *BatchLogResponse) FromProto(p *proto.BatchLogResponse) {
+	results := make([]BatchLogResult, 0, len(p.GetResults()))
+	for _, result := range p.GetResults() {
+		results = append(results, BatchLogResult{
+			RepoCommit:    repoCommitFromProto(result.GetRepoCommit()),
+			CommandOutput: result.GetCommandOutput(),
+			CommandError:  result.GetCommandError(),
+		})
+	}
+
+	*r = BatchLogResponse{Results: results}
+}
+
+func (r *RepoUpdateRequest) ToProto() *proto.RepoUpdateRequest {
+	return &proto.RepoUpdateRequest{
+		Repo:           string(r.Repo),
+		Since:          int64(r.Since),
+		CloneFromShard: r.CloneFromShard,
+	}
+}
+
+func (r *RepoUpdateRequest) FromProto(p *proto.RepoUpdateRequest) {
+	*r = RepoUpdateRequest{
+		Repo:           api.RepoName(p.GetRepo()),
+		Since:          time.Duration(p.GetSince()),
+		CloneFromShard: p.GetCloneFromShard(),
+	}
+}
+
+func (r *RepoUpdateResponse) ToProto() *proto.RepoUpdateResponse {
+	p := &proto.RepoUpdateResponse{Error: r.Error}
+	if r.LastFetched != nil {
+		p.LastFetched = timestamppb.New(*r.LastFetched)
+	}
+	if r.LastChanged != nil {
+		p.LastChanged = timestamppb.New(*r.LastChanged)
+	}
+	return p
+}
+
+func (r *RepoUpdateResponse) FromProto(p *proto.RepoUpdateResponse) {
+	*r = RepoUpdateResponse{Error: p.GetError()}
+	if p.GetLastFetched() != nil {
+		lastFetched := p.GetLastFetched().AsTime()
+		r.LastFetched = &lastFetched
+	}
+	if p.GetLastChanged() != nil {
+		lastChanged := p.GetLastChanged().AsTime()
+		r.LastChanged = &lastChanged
+	}
+}
+
+func repoCommitToProto(rc api.RepoCommit) *proto.RepoCommit {
+	return &proto.RepoCommit{
+		Repo:   string(rc.Repo),
+		Commit: string(rc.CommitID),
+	}
+}
+
+func repoCommitFromProto(p *proto.RepoCommit) api.RepoCommit {
+	return api.RepoCommit{
+		Repo:     api.RepoName(p.GetRepo()),
+		CommitID: api.CommitID(p.GetCommit()),
+	}
+}
+
+func matchedStringToProto(m result.MatchedString) *proto.MatchedString {
+	ranges := make([]*proto.Range, 0, len(m.MatchedRanges))
+	for _, r := range m.MatchedRanges {
+		ranges = append(ranges, &proto.Range{
+			Start: locationToProto(r.Start),
+			End:   locationToProto(r.End),
+		})
+	}
+
+	return &proto.MatchedString{
+		Content:       m.Content,
+		MatchedRanges: ranges,
+	}
+}
+
+func matchedStringFromProto(p *proto.MatchedString) result.MatchedString {
+	var ranges result.Ranges
+	for _, r := range p.GetMatchedRanges() {
+		ranges = append(ranges, result.Range{
+			Start: locationFromProto(r.GetStart()),
+			End:   locationFromProto(r.GetEnd()),
+		})
+	}
+
+	return result.MatchedString{
+		Content:       p.GetContent(),
+		MatchedRanges: ranges,
+	}
+}
+
+func locationToProto(l result.Location) *proto.Location {
+	return &proto.Location{
+		Offset: int64(l.Offset),
+		Line:   int64(l.Line),
+		Column: int64(l.Column),
+	}
+}
+
+func locationFromProto(p *proto.Location) result.Location {
+	return result.Location{
+		Offset: int(p.GetOffset()),
+		Line:   int(p.GetLine()),
+		Column: int(p.GetColumn()),
+	}
+}
+
+// timeFromProto converts a timestamp, mapping an unset timestamp to the zero
+// time rather than to the Unix epoch.
+func timeFromProto(p *timestamppb.Timestamp) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return p.AsTime()
+}
+
+func commitIDsToStrings(ids []api.CommitID) []string {
+	if ids == nil {
+		return nil
+	}
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, string(id))
+	}
+	return s
+}
+
+func stringsToCommitIDs(s []string) []api.CommitID {
+	if s == nil {
+		return nil
+	}
+	ids := make([]api.CommitID, 0, len(s))
+	for _, id := range s {
+		ids = append(ids, api.CommitID(id))
+	}
+	return ids
+}
@@ -0,0 +1,105 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestSearchRequestProtoRoundTrip(t *testing.T) {
+	date := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	req := &SearchRequest{
+		Repo: "github.com/sourcegraph/sourcegraph",
+		Revisions: []RevisionSpecifier{
+			{RevSpec: "main"},
+			{RefGlob: "refs/heads/*", ExcludeRefGlob: "refs/heads/wip/*"},
+		},
+		Query: NewAnd(
+			&AuthorMatches{Expr: "camden", IgnoreCase: true},
+			&CommitterMatches{Expr: "ci"},
+			&CommitBefore{Time: date},
+			&CommitAfter{Time: date.Add(-time.Hour)},
+			NewOr(
+				&MessageMatches{Expr: "fix"},
+				&DiffMatches{Expr: "TODO", IgnoreCase: true},
+			),
+			NewNot(&DiffModifiesFile{Expr: `\.md$`}),
+			&Boolean{Value: true},
+		),
+		IncludeDiff:          true,
+		Limit:                100,
+		IncludeModifiedFiles: true,
+	}
+
+	var got SearchRequest
+	require.NoError(t, got.FromProto(req.ToProto()))
+	if diff := cmp.Diff(req, &got); diff != "" {
+		t.Errorf("unexpected search request (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitMatchProtoRoundTrip(t *testing.T) {
+	match := &CommitMatch{
+		Oid: "deadbeef",
+		Author: Signature{
+			Name:  "Alice",
+			Email: "alice@example.com",
+			Date:  time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+		},
+		Committer: Signature{Name: "Bob"},
+		Parents:   []api.CommitID{"cafebabe"},
+		Refs:      []string{"refs/heads/main"},
+		Message: result.MatchedString{
+			Content: "fix things",
+			MatchedRanges: result.Ranges{{
+				Start: result.Location{Offset: 0, Line: 0, Column: 0},
+				End:   result.Location{Offset: 3, Line: 0, Column: 3},
+			}},
+		},
+		Diff:          result.MatchedString{Content: "diff"},
+		ModifiedFiles: []string{"README.md"},
+	}
+
+	var got CommitMatch
+	got.FromProto(match.ToProto())
+	if diff := cmp.Diff(match, &got); diff != "" {
+		t.Errorf("unexpected commit match (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepoUpdateProtoRoundTrip(t *testing.T) {
+	req := &RepoUpdateRequest{Repo: "github.com/foo/bar", Since: time.Minute, CloneFromShard: "http://gitserver-0"}
+	var gotReq RepoUpdateRequest
+	gotReq.FromProto(req.ToProto())
+	require.Equal(t, req, &gotReq)
+
+	lastFetched := time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	resp := &RepoUpdateResponse{LastFetched: &lastFetched, Error: "oops"}
+	var gotResp RepoUpdateResponse
+	gotResp.FromProto(resp.ToProto())
+	require.Equal(t, resp, &gotResp)
+}
+
+func TestBatchLogProtoRoundTrip(t *testing.T) {
+	req := &BatchLogRequest{
+		RepoCommits: []api.RepoCommit{{Repo: "github.com/foo/bar", CommitID: "deadbeef"}},
+		Format:      "--format=%H",
+	}
+	var gotReq BatchLogRequest
+	gotReq.FromProto(req.ToProto())
+	require.Equal(t, req, &gotReq)
+
+	resp := &BatchLogResponse{Results: []BatchLogResult{{
+		RepoCommit:    api.RepoCommit{Repo: "github.com/foo/bar", CommitID: "deadbeef"},
+		CommandOutput: "deadbeef\n",
+		CommandError:  "",
+	}}}
+	var gotResp BatchLogResponse
+	gotResp.FromProto(resp.ToProto())
+	require.Equal(t, resp, &gotResp)
+}
@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitserver.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GitserverServiceClient is the client API for GitserverService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GitserverServiceClient interface {
+	// Exec runs a git command in a repository and streams its standard output.
+	// The final message of the stream carries the exit status of the command.
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (GitserverService_ExecClient, error)
+	// Archive streams an archive of a tree of a repository, as produced by
+	// `git archive`. It shares the response format of Exec.
+	Archive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (GitserverService_ArchiveClient, error)
+	// Search streams the commits of a repository matching a query.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (GitserverService_SearchClient, error)
+	// BatchLog runs `git log` for a set of repository and commit pairs. The
+	// results are streamed in batches, as the output of `git log` is not
+	// bounded in size.
+	BatchLog(ctx context.Context, in *BatchLogRequest, opts ...grpc.CallOption) (GitserverService_BatchLogClient, error)
+	// RepoUpdate synchronously updates a repository, or clones it if it does
+	// not exist yet.
+	RepoUpdate(ctx context.Context, in *RepoUpdateRequest, opts ...grpc.CallOption) (*RepoUpdateResponse, error)
+}
+
+type gitserverServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitserverServiceClient(cc grpc.ClientConnInterface) GitserverServiceClient {
+	return &gitserverServiceClient{cc}
+}
+
+func (c *gitserverServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (GitserverService_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[0], "/gitserver.v1.GitserverService/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_ExecClient interface {
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) Archive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (GitserverService_ArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[1], "/gitserver.v1.GitserverService/Archive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_ArchiveClient interface {
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceArchiveClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (GitserverService_SearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[2], "/gitserver.v1.GitserverService/Search", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_SearchClient interface {
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceSearchClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) BatchLog(ctx context.Context, in *BatchLogRequest, opts ...grpc.CallOption) (GitserverService_BatchLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[3], "/gitserver.v1.GitserverService/BatchLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceBatchLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_BatchLogClient interface {
+	Recv() (*BatchLogResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceBatchLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceBatchLogClient) Recv() (*BatchLogResponse, error) {
+	m := new(BatchLogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) RepoUpdate(ctx context.Context, in *RepoUpdateRequest, opts ...grpc.CallOption) (*RepoUpdateResponse, error) {
+	out := new(RepoUpdateResponse)
+	err := c.cc.Invoke(ctx, "/gitserver.v1.GitserverService/RepoUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitserverServiceServer is the server API for GitserverService service.
+// All implementations must embed UnimplementedGitserverServiceServer
+// for forward compatibility
+type GitserverServiceServer interface {
+	// Exec runs a git command in a repository and streams its standard output.
+	// The final message of the stream carries the exit status of the command.
+	Exec(*ExecRequest, GitserverService_ExecServer) error
+	// Archive streams an archive of a tree of a repository, as produced by
+	// `git archive`. It shares the response format of Exec.
+	Archive(*ArchiveRequest, GitserverService_ArchiveServer) error
+	// Search streams the commits of a repository matching a query.
+	Search(*SearchRequest, GitserverService_SearchServer) error
+	// BatchLog runs `git log` for a set of repository and commit pairs. The
+	// results are streamed in batches, as the output of `git log` is not
+	// bounded in size.
+	BatchLog(*BatchLogRequest, GitserverService_BatchLogServer) error
+	// RepoUpdate synchronously updates a repository, or clones it if it does
+	// not exist yet.
+	RepoUpdate(context.Context, *RepoUpdateRequest) (*RepoUpdateResponse, error)
+	mustEmbedUnimplementedGitserverServiceServer()
+}
+
+// UnimplementedGitserverServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGitserverServiceServer struct {
+}
+
+func (UnimplementedGitserverServiceServer) Exec(*ExecRequest, GitserverService_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedGitserverServiceServer) Archive(*ArchiveRequest, GitserverService_ArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedGitserverServiceServer) Search(*SearchRequest, GitserverService_SearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedGitserverServiceServer) BatchLog(*BatchLogRequest, GitserverService_BatchLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchLog not implemented")
+}
+func (UnimplementedGitserverServiceServer) RepoUpdate(context.Context, *RepoUpdateRequest) (*RepoUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepoUpdate not implemented")
+}
+func (UnimplementedGitserverServiceServer) mustEmbedUnimplementedGitserverServiceServer() {}
+
+// UnsafeGitserverServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitserverServiceServer will
+// result in compilation errors.
+type UnsafeGitserverServiceServer interface {
+	mustEmbedUnimplementedGitserverServiceServer()
+}
+
+func RegisterGitserverServiceServer(s grpc.ServiceRegistrar, srv GitserverServiceServer) {
+	s.RegisterService(&GitserverService_ServiceDesc, srv)
+}
+
+func _GitserverService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).Exec(m, &gitserverServiceExecServer{stream})
+}
+
+type GitserverService_ExecServer interface {
+	Send(*ExecResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_Archive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).Archive(m, &gitserverServiceArchiveServer{stream})
+}
+
+type GitserverService_ArchiveServer interface {
+	Send(*ExecResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceArchiveServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).Search(m, &gitserverServiceSearchServer{stream})
+}
+
+type GitserverService_SearchServer interface {
+	Send(*SearchResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceSearchServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_BatchLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).BatchLog(m, &gitserverServiceBatchLogServer{stream})
+}
+
+type GitserverService_BatchLogServer interface {
+	Send(*BatchLogResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceBatchLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceBatchLogServer) Send(m *BatchLogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_RepoUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitserverServiceServer).RepoUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitserver.v1.GitserverService/RepoUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitserverServiceServer).RepoUpdate(ctx, req.(*RepoUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GitserverService_ServiceDesc is the grpc.ServiceDesc for GitserverService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitserverService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitserver.v1.GitserverService",
+	HandlerType: (*GitserverServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RepoUpdate",
+			Handler:    _GitserverService_RepoUpdate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Exec",
+			Handler:       _GitserverService_Exec_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Archive",
+			Handler:       _GitserverService_Archive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Search",
+			Handler:       _GitserverService_Search_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BatchLog",
+			Handler:       _GitserverService_BatchLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gitserver.proto",
+}
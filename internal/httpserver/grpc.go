@@ -0,0 +1,143 @@
+package httpserver
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// sniffTimeout is how long a connection may take to send enough bytes to tell
+// whether it is a gRPC connection.
+const sniffTimeout = 10 * time.Second
+
+// GRPCListenerMux splits the connections accepted by a listener between a gRPC
+// server and an HTTP server, so that both can be served natively on the same
+// port. Connections that start with the HTTP/2 client preface, which is how
+// gRPC clients connect over cleartext, are routed to GRPCListener. All other
+// connections are routed to HTTPListener.
+type GRPCListenerMux struct {
+	root net.Listener
+	grpc *muxListener
+	http *muxListener
+}
+
+// NewGRPCListenerMux returns a GRPCListenerMux for the connections accepted by
+// l. Connections are only accepted once Serve is called.
+func NewGRPCListenerMux(l net.Listener) *GRPCListenerMux {
+	return &GRPCListenerMux{
+		root: l,
+		grpc: newMuxListener(l.Addr()),
+		http: newMuxListener(l.Addr()),
+	}
+}
+
+// GRPCListener returns the listener to pass to grpc.Server.Serve.
+func (m *GRPCListenerMux) GRPCListener() net.Listener { return m.grpc }
+
+// HTTPListener returns the listener to pass to http.Server.Serve.
+func (m *GRPCListenerMux) HTTPListener() net.Listener { return m.http }
+
+// Serve accepts connections until the underlying listener is closed. Once it
+// returns, both GRPCListener and HTTPListener are closed as well.
+func (m *GRPCListenerMux) Serve() error {
+	defer m.grpc.Close()
+	defer m.http.Close()
+
+	for {
+		conn, err := m.root.Accept()
+		if err != nil {
+			return err
+		}
+		go m.route(conn)
+	}
+}
+
+// Close closes the underlying listener, and thereby stops Serve.
+func (m *GRPCListenerMux) Close() error {
+	return m.root.Close()
+}
+
+func (m *GRPCListenerMux) route(conn net.Conn) {
+	// Peeking blocks until the client sends something, which it should do as
+	// soon as it is connected. Connections that time out are routed to the HTTP
+	// listener, whose server closes them on the first read.
+	_ = conn.SetReadDeadline(time.Now().Add(sniffTimeout))
+	r := bufio.NewReaderSize(conn, len(http2.ClientPreface))
+	isGRPC := hasPrefix(r, http2.ClientPreface)
+	_ = conn.SetReadDeadline(time.Time{})
+
+	conn = &bufferedConn{Conn: conn, r: r}
+	if isGRPC {
+		m.grpc.deliver(conn)
+	} else {
+		m.http.deliver(conn)
+	}
+}
+
+// hasPrefix reports whether the bytes buffered in r start with prefix. It peeks
+// one byte at a time, so that it does not wait for more bytes than needed to
+// rule out requests that are shorter than prefix.
+func hasPrefix(r *bufio.Reader, prefix string) bool {
+	for n := 1; n <= len(prefix); n++ {
+		b, err := r.Peek(n)
+		if err != nil || b[n-1] != prefix[n-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// bufferedConn is a net.Conn whose reads start with the bytes buffered while
+// sniffing it.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// muxListener is a net.Listener accepting the connections routed to it by a
+// GRPCListenerMux.
+type muxListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newMuxListener(addr net.Addr) *muxListener {
+	return &muxListener{
+		addr:  addr,
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+func (l *muxListener) deliver(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		_ = conn.Close()
+	}
+}
+
+func (l *muxListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *muxListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *muxListener) Addr() net.Addr { return l.addr }
@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGRPCListenerMux(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := NewGRPCListenerMux(l)
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() { _ = grpcServer.Serve(mux.GRPCListener()) }()
+	t.Cleanup(grpcServer.Stop)
+
+	httpServer := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("http"))
+	})}
+	go func() { _ = httpServer.Serve(mux.HTTPListener()) }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- mux.Serve() }()
+
+	addr := l.Addr().String()
+
+	t.Run("http", func(t *testing.T) {
+		resp, err := http.Get("http://" + addr + "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != "http" {
+			t.Fatalf("unexpected body %q", body)
+		}
+	})
+
+	t.Run("grpc", func(t *testing.T) {
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("unexpected status %s", resp.GetStatus())
+		}
+	})
+
+	if err := mux.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-serveErr; err == nil {
+		t.Fatal("expected Serve to return an error once closed")
+	}
+	if _, err := mux.GRPCListener().Accept(); err != net.ErrClosed {
+		t.Fatalf("unexpected error %v, want %v", err, net.ErrClosed)
+	}
+}
@@ -2,11 +2,12 @@ package codeowners
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
-	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
 )
@@ -14,45 +15,52 @@ import (
 // Parse parses CODEOWNERS file given as a Reader and returns the proto
 // representation of all rules within. The rules are in the same order
 // as in the file, since this matters for evaluation.
+//
+// Parsing is tolerant: Lines that cannot be understood are recorded
+// as errors within the returned proto instead of failing the whole file,
+// so that ownership can still be determined from the remaining rules.
+// An error is only returned if reading the file fails.
+//
+// Both GitHub and GitLab flavors are supported. This includes GitLab sections,
+// which can be optional (`^[Section]`), require a number of approvals
+// (`[Section][2]`) and list default owners (`[Section] @owner`) that apply
+// to rules within the section which do not list owners themselves.
 func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 	scanner := bufio.NewScanner(codeownersFile)
-	var rs []*codeownerspb.Rule
+	f := new(codeownerspb.File)
 	p := new(parsing)
 	for scanner.Scan() {
 		p.nextLine(scanner.Text())
 		if p.isBlank() {
 			continue
 		}
-		if p.matchSection() {
+		if p.matchSection(f) {
 			continue
 		}
-		pattern, owners, ok := p.matchRule()
+		pattern, ownersText, ok := p.matchRule()
 		if !ok {
-			return nil, errors.Errorf("failed to match rule: %s", p.line)
+			p.addError(f, "failed to match rule")
+			continue
 		}
-		// Need to handle this error once, codeownerspb.File supports
-		// error metadata.
-		r := codeownerspb.Rule{
-			Pattern:     unescape(pattern),
-			SectionName: strings.TrimSpace(strings.ToLower(p.section)),
+		owners := p.parseOwners(f, ownersText)
+		if len(ownersText) > 0 && len(owners) == 0 {
+			p.addError(f, "rule has no valid owners")
+			continue
 		}
-		for _, ownerText := range owners {
-			var o codeownerspb.Owner
-			if strings.HasPrefix(ownerText, "@") {
-				o.Handle = strings.TrimPrefix(ownerText, "@")
-			} else {
-				// Note: we assume owner text is an email if it does not
-				// start with an `@` which would make it a handle.
-				o.Email = ownerText
-			}
-			r.Owner = append(r.Owner, &o)
+		if len(owners) == 0 && p.section != nil {
+			owners = p.section.GetDefaultOwner()
 		}
-		rs = append(rs, &r)
+		f.Rule = append(f.Rule, &codeownerspb.Rule{
+			Pattern:     unescape(pattern),
+			Owner:       owners,
+			SectionName: p.section.GetName(),
+			LineNumber:  p.lineNumber,
+		})
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
-	return &codeownerspb.File{Rule: rs}, nil
+	return f, nil
 }
 
 // parsing implements matching and parsing primitives for CODEOWNERS files
@@ -62,13 +70,52 @@ type parsing struct {
 	// in such a way that for syntactic purposes, every line can be considered
 	// in isolation.
 	line string
-	// The most recently defined section, or "" if none.
-	section string
+	// lineNumber is the 1-based number of the current line.
+	lineNumber int32
+	// The most recently defined section, or nil if none.
+	section *codeownerspb.Section
 }
 
 // nextLine advances parsing to focus on the next line.
 func (p *parsing) nextLine(line string) {
 	p.line = line
+	p.lineNumber++
+}
+
+// addError records a parse error for the current line.
+func (p *parsing) addError(f *codeownerspb.File, message string) {
+	f.Error = append(f.Error, &codeownerspb.ParseError{
+		LineNumber: p.lineNumber,
+		Line:       p.line,
+		Message:    message,
+	})
+}
+
+// parseOwners translates owners text into owners. Invalid owners are
+// reported as errors and skipped.
+func (p *parsing) parseOwners(f *codeownerspb.File, ownersText []string) []*codeownerspb.Owner {
+	var owners []*codeownerspb.Owner
+	for _, ownerText := range ownersText {
+		o, ok := parseOwner(ownerText)
+		if !ok {
+			p.addError(f, fmt.Sprintf("invalid owner %q", ownerText))
+			continue
+		}
+		owners = append(owners, o)
+	}
+	return owners
+}
+
+// parseOwner interprets owner text starting with an `@` as a handle,
+// and otherwise as an email. A lone `@` is not a valid owner, which is
+// indicated by the second return value.
+func parseOwner(ownerText string) (*codeownerspb.Owner, bool) {
+	if handle := strings.TrimPrefix(ownerText, "@"); handle != ownerText {
+		return &codeownerspb.Owner{Handle: handle}, handle != ""
+	}
+	// Note: we assume owner text is an email if it does not
+	// start with an `@` which would make it a handle.
+	return &codeownerspb.Owner{Email: ownerText}, true
 }
 
 // rulePattern is expected to match a rule line like:
@@ -104,15 +151,46 @@ func (p *parsing) matchRule() (string, []string, bool) {
 	return filePattern, owners, true
 }
 
-var sectionPattern = lazyregexp.New(`^\s*\[([^\]]+)\]\s*$`)
+// sectionPattern is expected to match a GitLab section header like:
+// `^[Section name][2] @default-owner owner@example.com`.
+//
+// The capturing groups extract, in order: the `^` marking an optional section,
+// the section name, the number of approvals required, and the default owners.
+//
+// Only lines following this grammar in full are sections. Others, like
+// `[Dd]ocs/ @docs`, are rules with a pattern that starts with a bracket.
+var sectionPattern = lazyregexp.New(`^\s*(\^)?\[([^\]]+)\](?:\[(\d+)\])?((?:\s+\S+)*)\s*$`)
 
 // matchSection tries to extract a section which looks like `[section name]`.
-func (p *parsing) matchSection() bool {
+// Sections declared more than once are combined into one, and the metadata
+// (optionality, approvals and default owners) of the first declaration applies.
+func (p *parsing) matchSection(f *codeownerspb.File) bool {
 	match := sectionPattern.FindStringSubmatch(p.lineWithoutComments())
-	if len(match) != 2 {
+	if len(match) != 5 {
 		return false
 	}
-	p.section = match[1]
+	name := strings.TrimSpace(strings.ToLower(match[2]))
+	for _, s := range f.Section {
+		if s.Name == name {
+			p.section = s
+			return true
+		}
+	}
+	section := &codeownerspb.Section{
+		Name:         name,
+		Optional:     match[1] != "",
+		DefaultOwner: p.parseOwners(f, strings.Fields(match[4])),
+	}
+	if approvals := match[3]; approvals != "" {
+		n, err := strconv.ParseInt(approvals, 10, 32)
+		if err != nil || n < 1 {
+			p.addError(f, fmt.Sprintf("invalid number of approvals %q", approvals))
+		} else {
+			section.ApprovalsRequired = int32(n)
+		}
+	}
+	f.Section = append(f.Section, section)
+	p.section = section
 	return true
 }
 
@@ -71,70 +71,81 @@ apps/ @octocat
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{
 		{
-			Pattern: "*",
+			Pattern:    "*",
+			LineNumber: 8,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "global-owner1"},
 				{Handle: "global-owner2"},
 			},
 		},
 		{
-			Pattern: "*.js",
+			Pattern:    "*.js",
+			LineNumber: 14,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "js-owner"},
 			},
 		},
 		{
-			Pattern: "*.go",
+			Pattern:    "*.go",
+			LineNumber: 19,
 			Owner: []*codeownerspb.Owner{
 				{Email: "docs@example.com"},
 			},
 		},
 		{
-			Pattern: "*.txt",
+			Pattern:    "*.txt",
+			LineNumber: 25,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "octo-org/octocats"},
 			},
 		},
 		{
-			Pattern: "/build/logs/",
+			Pattern:    "/build/logs/",
+			LineNumber: 30,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "doctocat"},
 			},
 		},
 		{
-			Pattern: "docs/*",
+			Pattern:    "docs/*",
+			LineNumber: 35,
 			Owner: []*codeownerspb.Owner{
 				{Email: "docs@example.com"},
 			},
 		},
 		{
-			Pattern: "apps/",
+			Pattern:    "apps/",
+			LineNumber: 39,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "octocat"},
 			},
 		},
 		{
-			Pattern: "/docs/",
+			Pattern:    "/docs/",
+			LineNumber: 44,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "doctocat"},
 			},
 		},
 		{
-			Pattern: "/scripts/",
+			Pattern:    "/scripts/",
+			LineNumber: 48,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "doctocat"},
 				{Handle: "octocat"},
 			},
 		},
 		{
-			Pattern: "/apps/",
+			Pattern:    "/apps/",
+			LineNumber: 53,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "octocat"},
 			},
 		},
 		{
-			Pattern: "/apps/github",
-			Owner:   nil,
+			Pattern:    "/apps/github",
+			LineNumber: 54,
+			Owner:      nil,
 		},
 	}
 	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
@@ -215,13 +226,15 @@ README.md  @docs
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{
 		{
-			Pattern: "*",
+			Pattern:    "*",
+			LineNumber: 7,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "default-codeowner"},
 			},
 		},
 		{
-			Pattern: "*",
+			Pattern:    "*",
+			LineNumber: 10,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "multiple"},
 				{Handle: "code"},
@@ -229,19 +242,22 @@ README.md  @docs
 			},
 		},
 		{
-			Pattern: "*.rb",
+			Pattern:    "*.rb",
+			LineNumber: 15,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "ruby-owner"},
 			},
 		},
 		{
-			Pattern: "#file_with_pound.rb",
+			Pattern:    "#file_with_pound.rb",
+			LineNumber: 18,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "owner-file-with-pound"},
 			},
 		},
 		{
-			Pattern: "CODEOWNERS",
+			Pattern:    "CODEOWNERS",
+			LineNumber: 23,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "multiple"},
 				{Handle: "code"},
@@ -249,7 +265,8 @@ README.md  @docs
 			},
 		},
 		{
-			Pattern: "LICENSE",
+			Pattern:    "LICENSE",
+			LineNumber: 29,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "legal"},
 				// Note: To match GitLab parsing, we should not consider this as email.
@@ -258,92 +275,109 @@ README.md  @docs
 			},
 		},
 		{
-			Pattern: "README",
+			Pattern:    "README",
+			LineNumber: 33,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "group"},
 				{Handle: "group/with-nested/subgroup"},
 			},
 		},
 		{
-			Pattern: "/docs/",
+			Pattern:    "/docs/",
+			LineNumber: 37,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "all-docs"},
 			},
 		},
 		{
-			Pattern: "/docs/*",
+			Pattern:    "/docs/*",
+			LineNumber: 42,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "root-docs"},
 			},
 		},
 		{
-			Pattern: "/docs/**/*.md",
+			Pattern:    "/docs/**/*.md",
+			LineNumber: 47,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "root-docs"},
 			},
 		},
 		{
-			Pattern: "lib/",
+			Pattern:    "lib/",
+			LineNumber: 50,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "lib-owner"},
 			},
 		},
 		{
-			Pattern: "/config/",
+			Pattern:    "/config/",
+			LineNumber: 53,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "config-owner"},
 			},
 		},
 		{
-			Pattern: "path with spaces/",
+			Pattern:    "path with spaces/",
+			LineNumber: 56,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "space-owner"},
 			},
 		},
 		{
-			Pattern: "ee/docs",
+			Pattern:    "ee/docs",
+			LineNumber: 60,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "docs"},
 			},
 			SectionName: "documentation",
 		},
 		{
-			Pattern: "docs",
+			Pattern:    "docs",
+			LineNumber: 61,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "docs"},
 			},
 			SectionName: "documentation",
 		},
 		{
-			Pattern: "README.md",
+			Pattern:    "README.md",
+			LineNumber: 64,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "database"},
 			},
 			SectionName: "database",
 		},
 		{
-			Pattern: "model/db",
+			Pattern:    "model/db",
+			LineNumber: 65,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "database"},
 			},
 			SectionName: "database",
 		},
 		{
-			Pattern: "README.md",
+			Pattern:    "README.md",
+			LineNumber: 69,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "docs"},
 			},
 			SectionName: "documentation",
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{
+		{Name: "documentation"},
+		{Name: "database"},
+	}
+	assert.Equal(t, &codeownerspb.File{Rule: want, Section: wantSections}, got)
 }
 
 func TestParseAtHandle(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader("README.md @readme-team"))
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
-		Pattern: "README.md",
+		Pattern:    "README.md",
+		LineNumber: 1,
 		Owner: []*codeownerspb.Owner{
 			{Handle: "readme-team"},
 		},
@@ -355,7 +389,8 @@ func TestParseAtHandleSupportsNesting(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader("README.md @readme-team/readme-subteam"))
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
-		Pattern: "README.md",
+		Pattern:    "README.md",
+		LineNumber: 1,
 		Owner: []*codeownerspb.Owner{
 			{Handle: "readme-team/readme-subteam"},
 		},
@@ -367,7 +402,8 @@ func TestParseEmailHandle(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader("README.md me@example.com"))
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
-		Pattern: "README.md",
+		Pattern:    "README.md",
+		LineNumber: 1,
 		Owner: []*codeownerspb.Owner{
 			{Email: "me@example.com"},
 		},
@@ -379,7 +415,8 @@ func TestParseTwoHandles(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader("README.md @readme-team me@example.com"))
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
-		Pattern: "README.md",
+		Pattern:    "README.md",
+		LineNumber: 1,
 		Owner: []*codeownerspb.Owner{
 			{Handle: "readme-team"},
 			{Email: "me@example.com"},
@@ -392,7 +429,8 @@ func TestParsePathWithSpaces(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader(`path\ with\ spaces/* @space-owner`))
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
-		Pattern: "path with spaces/*",
+		Pattern:    "path with spaces/*",
+		LineNumber: 1,
 		Owner: []*codeownerspb.Owner{
 			{Handle: "space-owner"},
 		},
@@ -407,12 +445,14 @@ func TestParseSection(t *testing.T) {
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{{
 		Pattern:     "own/codeowners/*",
+		LineNumber:  2,
 		SectionName: "pm",
 		Owner: []*codeownerspb.Owner{
 			{Handle: "own-pms"},
 		},
 	}}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{{Name: "pm"}}
+	assert.Equal(t, &codeownerspb.File{Rule: want, Section: wantSections}, got)
 }
 
 func TestParseManySections(t *testing.T) {
@@ -425,13 +465,15 @@ func TestParseManySections(t *testing.T) {
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{
 		{
-			Pattern: "own/codeowners/*",
+			Pattern:    "own/codeowners/*",
+			LineNumber: 1,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "own-eng"},
 			},
 		},
 		{
 			Pattern:     "own/codeowners/*",
+			LineNumber:  3,
 			SectionName: "pm",
 			Owner: []*codeownerspb.Owner{
 				{Handle: "own-pms"},
@@ -439,13 +481,15 @@ func TestParseManySections(t *testing.T) {
 		},
 		{
 			Pattern:     "own/**/*.md",
+			LineNumber:  5,
 			SectionName: "docs",
 			Owner: []*codeownerspb.Owner{
 				{Handle: "own-docs"},
 			},
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{{Name: "pm"}, {Name: "docs"}}
+	assert.Equal(t, &codeownerspb.File{Rule: want, Section: wantSections}, got)
 }
 
 func TestParseEmptyString(t *testing.T) {
@@ -471,7 +515,8 @@ func TestParseRuleWithComment(t *testing.T) {
 	require.NoError(t, err)
 	want := []*codeownerspb.Rule{
 		{
-			Pattern: "/escaped#/is/pattern",
+			Pattern:    "/escaped#/is/pattern",
+			LineNumber: 1,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "and-then"},
 			},
@@ -490,11 +535,173 @@ func TestParseSectionWithComment(t *testing.T) {
 	want := []*codeownerspb.Rule{
 		{
 			Pattern:     "/pattern",
+			LineNumber:  2,
 			SectionName: "section",
 			Owner: []*codeownerspb.Owner{
 				{Handle: "owner"},
 			},
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{{Name: "section"}}
+	assert.Equal(t, &codeownerspb.File{Rule: want, Section: wantSections}, got)
+}
+
+func TestParseGitlabSectionMetadata(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`^[Optional Docs] @docs-team
+		docs/
+		/docs/internal/ @internal-docs
+		[Database][2] @db-owner db@example.com
+		model/db
+		^[Optional Docs]
+		README.md`))
+	require.NoError(t, err)
+	want := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern:     "docs/",
+				SectionName: "optional docs",
+				LineNumber:  2,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "docs-team"},
+				},
+			},
+			{
+				Pattern:     "/docs/internal/",
+				SectionName: "optional docs",
+				LineNumber:  3,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "internal-docs"},
+				},
+			},
+			{
+				Pattern:     "model/db",
+				SectionName: "database",
+				LineNumber:  5,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "db-owner"},
+					{Email: "db@example.com"},
+				},
+			},
+			{
+				Pattern:     "README.md",
+				SectionName: "optional docs",
+				LineNumber:  7,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "docs-team"},
+				},
+			},
+		},
+		Section: []*codeownerspb.Section{
+			{
+				Name:     "optional docs",
+				Optional: true,
+				DefaultOwner: []*codeownerspb.Owner{
+					{Handle: "docs-team"},
+				},
+			},
+			{
+				Name:              "database",
+				ApprovalsRequired: 2,
+				DefaultOwner: []*codeownerspb.Owner{
+					{Handle: "db-owner"},
+					{Email: "db@example.com"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestParseBracketPatterns(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`[Dd]ocs/ @docs
+		[Section
+		[Section][two] @section-owner`))
+	require.NoError(t, err)
+	want := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern:    "[Dd]ocs/",
+				LineNumber: 1,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "docs"},
+				},
+			},
+			{
+				Pattern:    "[Section",
+				LineNumber: 2,
+			},
+			{
+				Pattern:    "[Section][two]",
+				LineNumber: 3,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "section-owner"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestParseErrors(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`[Section][0] @ @section-owner
+		/invalid/owners @
+		/valid @owner`))
+	require.NoError(t, err)
+	want := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern:     "/valid",
+				SectionName: "section",
+				LineNumber:  3,
+				Owner: []*codeownerspb.Owner{
+					{Handle: "owner"},
+				},
+			},
+		},
+		Section: []*codeownerspb.Section{
+			{
+				Name: "section",
+				DefaultOwner: []*codeownerspb.Owner{
+					{Handle: "section-owner"},
+				},
+			},
+		},
+		Error: []*codeownerspb.ParseError{
+			{
+				LineNumber: 1,
+				Line:       "[Section][0] @ @section-owner",
+				Message:    `invalid owner "@"`,
+			},
+			{
+				LineNumber: 1,
+				Line:       "[Section][0] @ @section-owner",
+				Message:    `invalid number of approvals "0"`,
+			},
+			{
+				LineNumber: 2,
+				Line:       "\t\t/invalid/owners @",
+				Message:    `invalid owner "@"`,
+			},
+			{
+				LineNumber: 2,
+				Line:       "\t\t/invalid/owners @",
+				Message:    "rule has no valid owners",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestParseReprRoundTrip(t *testing.T) {
+	text := `/root @root-owner
+^[docs][2] @docs-owner
+docs/ @docs-owner
+README.md readme@example.com
+`
+	got, err := codeowners.Parse(strings.NewReader(text))
+	require.NoError(t, err)
+	assert.Equal(t, text, got.Repr())
 }
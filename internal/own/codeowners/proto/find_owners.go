@@ -8,21 +8,104 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
+// Ruleset is a CODEOWNERS file prepared for evaluation. The glob patterns
+// of all rules are compiled once, so a Ruleset should be used when
+// looking up owners of many paths against the same file.
+type Ruleset struct {
+	file *File
+	// globs holds the compiled pattern of every rule of file, at the same
+	// index. Rules whose pattern does not compile have a nil glob,
+	// and never match.
+	globs []globPattern
+}
+
+// NewRuleset compiles the rules of given CODEOWNERS file. The file may be nil,
+// in which case no path has owners.
+func NewRuleset(file *File) *Ruleset {
+	globs := make([]globPattern, len(file.GetRule()))
+	for i, rule := range file.GetRule() {
+		if glob, err := compile(rule.GetPattern()); err == nil {
+			globs[i] = glob
+		}
+	}
+	return &Ruleset{file: file, globs: globs}
+}
+
+// GetFile returns the CODEOWNERS file this ruleset was compiled from.
+func (r *Ruleset) GetFile() *File {
+	if r == nil {
+		return nil
+	}
+	return r.file
+}
+
 // FindOwners returns the Owners associated with given path as per this CODEOWNERS file.
 // Rules are evaluated in order: Returned owners come from the rule which pattern matches
-// given path, that is the furthest down the file.
-func (x *File) FindOwners(path string) []*Owner {
+// given path, that is the furthest down the file. If the file uses sections, every
+// section is evaluated separately, and owners from all sections are returned
+// in the order in which sections first appear, without duplicates.
+func (r *Ruleset) FindOwners(path string) []*Owner {
 	var owners []*Owner
-	for _, rule := range x.GetRule() {
-		glob, err := compile(rule.GetPattern())
-		if err != nil {
+	seen := map[ownerKey]struct{}{}
+	for _, rule := range r.FindRules(path) {
+		for _, owner := range rule.GetOwner() {
+			k := ownerKey{handle: owner.GetHandle(), email: owner.GetEmail()}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// FindRules returns the rules that determine ownership of given path. That is
+// for every section, the rule which pattern matches given path and is the furthest
+// down the file. There is at most one rule per section, and rules are ordered by
+// the first matching rule within each section. A returned rule
+// may have no owners, which denotes that the path is explicitly not owned
+// within its section.
+func (r *Ruleset) FindRules(path string) []*Rule {
+	if r == nil {
+		return nil
+	}
+	var sections []string
+	matches := map[string]*Rule{}
+	for i, rule := range r.file.GetRule() {
+		if glob := r.globs[i]; glob == nil || !glob.match(path) {
 			continue
 		}
-		if glob.match(path) {
-			owners = rule.GetOwner()
+		section := rule.GetSectionName()
+		if _, ok := matches[section]; !ok {
+			sections = append(sections, section)
 		}
+		matches[section] = rule
 	}
-	return owners
+	rules := make([]*Rule, 0, len(sections))
+	for _, section := range sections {
+		rules = append(rules, matches[section])
+	}
+	return rules
+}
+
+// FindOwners returns the Owners associated with given path, see Ruleset.FindOwners.
+// It compiles the rules of this file on every call, so use NewRuleset
+// to look up owners of many paths.
+func (x *File) FindOwners(path string) []*Owner {
+	return NewRuleset(x).FindOwners(path)
+}
+
+// FindRules returns the rules that determine ownership of given path, see
+// Ruleset.FindRules. It compiles the rules of this file on every call,
+// so use NewRuleset to look up rules for many paths.
+func (x *File) FindRules(path string) []*Rule {
+	return NewRuleset(x).FindRules(path)
+}
+
+type ownerKey struct {
+	handle string
+	email  string
 }
 
 const separator = "/"
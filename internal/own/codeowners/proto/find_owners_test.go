@@ -160,3 +160,76 @@ func TestFileOwnersOrder(t *testing.T) {
 	got := file.FindOwners("/top-level-directory/some/path/main.go")
 	assert.Equal(t, wantOwner, got)
 }
+
+func TestFileOwnersSections(t *testing.T) {
+	file := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern: "/src/",
+				Owner:   []*codeownerspb.Owner{{Handle: "src-owner"}},
+			},
+			{
+				Pattern:     "src/",
+				SectionName: "docs",
+				Owner:       []*codeownerspb.Owner{{Handle: "docs-owner"}},
+			},
+			{
+				Pattern:     "/src/",
+				SectionName: "pm",
+				Owner:       []*codeownerspb.Owner{{Handle: "pm"}, {Handle: "src-owner"}},
+			},
+			{
+				Pattern:     "README.md",
+				SectionName: "docs",
+				Owner:       []*codeownerspb.Owner{{Handle: "readme-owner"}},
+			},
+		},
+	}
+	// Within a section the last matching rule applies, every section is
+	// evaluated separately.
+	gotRules := file.FindRules("/src/README.md")
+	assert.Equal(t, []*codeownerspb.Rule{file.Rule[0], file.Rule[3], file.Rule[2]}, gotRules)
+	// Owners from all sections are returned without duplicates.
+	gotOwners := file.FindOwners("/src/README.md")
+	want := []*codeownerspb.Owner{{Handle: "src-owner"}, {Handle: "readme-owner"}, {Handle: "pm"}}
+	assert.Equal(t, want, gotOwners)
+}
+
+func TestFileRulesNoMatch(t *testing.T) {
+	file := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern: "/src/",
+				Owner:   []*codeownerspb.Owner{{Handle: "src-owner"}},
+			},
+		},
+	}
+	assert.Empty(t, file.FindRules("/docs/README.md"))
+}
+
+func TestRulesetFindOwners(t *testing.T) {
+	file := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern: "/src/",
+				Owner:   []*codeownerspb.Owner{{Handle: "src-owner"}},
+			},
+			{
+				Pattern: "src//main.go",
+				Owner:   []*codeownerspb.Owner{{Handle: "invalid-owner"}},
+			},
+			{
+				Pattern: "*/README.md",
+				Owner:   []*codeownerspb.Owner{{Handle: "readme-owner"}},
+			},
+		},
+	}
+	ruleset := codeownerspb.NewRuleset(file)
+	assert.Equal(t, file, ruleset.GetFile())
+	// The same ruleset evaluates any number of paths. Rules with an invalid
+	// pattern never match.
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "src-owner"}}, ruleset.FindOwners("/src/main.go"))
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "readme-owner"}}, ruleset.FindOwners("/src/README.md"))
+	// A ruleset of a missing file has no owners.
+	assert.Empty(t, codeownerspb.NewRuleset(nil).FindOwners("/src/main.go"))
+}
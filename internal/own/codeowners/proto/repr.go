@@ -11,23 +11,45 @@ import (
 // representation is deterministic. This is useful in tests,
 // where deep comparison may not work due to protobuf metadata.
 func (f *File) Repr() string {
+	sections := map[string]*Section{}
+	for _, s := range f.GetSection() {
+		sections[s.GetName()] = s
+	}
 	w := new(strings.Builder)
 	var lastSeenSection string
 	for _, r := range f.GetRule() {
 		if s := r.SectionName; s != lastSeenSection {
-			fmt.Fprintf(w, "[%s]\n", s)
+			writeSectionHeader(w, s, sections[s])
 			lastSeenSection = s
 		}
 		fmt.Fprint(w, r.Pattern)
-		for _, o := range r.GetOwner() {
-			if h := o.GetHandle(); h != "" {
-				fmt.Fprintf(w, " @%s", h)
-			}
-			if e := o.GetEmail(); e != "" {
-				fmt.Fprintf(w, " %s", e)
-			}
-		}
+		writeOwners(w, r.GetOwner())
 		fmt.Fprintln(w)
 	}
 	return w.String()
 }
+
+// writeSectionHeader writes a section header line like `^[name][2] @owner`.
+// The given section metadata may be nil.
+func writeSectionHeader(w *strings.Builder, name string, s *Section) {
+	if s.GetOptional() {
+		fmt.Fprint(w, "^")
+	}
+	fmt.Fprintf(w, "[%s]", name)
+	if n := s.GetApprovalsRequired(); n > 0 {
+		fmt.Fprintf(w, "[%d]", n)
+	}
+	writeOwners(w, s.GetDefaultOwner())
+	fmt.Fprintln(w)
+}
+
+func writeOwners(w *strings.Builder, owners []*Owner) {
+	for _, o := range owners {
+		if h := o.GetHandle(); h != "" {
+			fmt.Fprintf(w, " @%s", h)
+		}
+		if e := o.GetEmail(); e != "" {
+			fmt.Fprintf(w, " %s", e)
+		}
+	}
+}
@@ -6,17 +6,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // DefaultRegistry is the default global rate limit registry, which holds rate
-// limit mappings for each instance of our services.
-var DefaultRegistry = NewRegistry()
+// limit mappings for each instance of our services. Its rate limiters are shared by
+// all replicas through the Redis cache while the experimentalFeatures.distributedRateLimits
+// site setting is enabled.
+var DefaultRegistry = newDistributedRegistry(redispool.Cache, conf.DistributedRateLimitsEnabled)
 
 const defaultBurst = 10
 
@@ -28,8 +32,28 @@ func NewRegistry() *Registry {
 	}
 }
 
+// NewDistributedRegistry creates and returns an empty rate limit registry whose rate
+// limiters keep their token buckets in the given Redis, so that every process using the
+// same URN shares a single limit. When Redis is unavailable, the rate limiters fall back
+// to enforcing their limit within the process.
+func NewDistributedRegistry(pool *redis.Pool) *Registry {
+	return newDistributedRegistry(pool, nil)
+}
+
+// newDistributedRegistry creates a distributed registry that only uses Redis while
+// enabled, if supplied, returns true.
+func newDistributedRegistry(pool *redis.Pool, enabled func() bool) *Registry {
+	r := NewRegistry()
+	r.backend = newRedisBackend(pool, enabled)
+	return r
+}
+
 // Registry manages rate limiters for external services.
 type Registry struct {
+	// backend shares the rate limiters of the registry between processes. It is nil for
+	// process-local registries.
+	backend *redisBackend
+
 	mu sync.Mutex
 	// rateLimiters contains mappings of external service to its *rate.Limiter. The
 	// key should be the URN of the external service.
@@ -68,6 +92,9 @@ func (r *Registry) getOrSet(urn string, fallback *InstrumentedLimiter) *Instrume
 		}
 		fallback = NewInstrumentedLimiter(urn, rate.NewLimiter(fallbackRateLimit, defaultBurst))
 	}
+	if r.backend != nil {
+		fallback.backend = r.backend
+	}
 	r.rateLimiters[urn] = fallback
 	return fallback
 }
@@ -88,16 +115,29 @@ type LimitInfo struct {
 	// Infinite is true if Limit is infinite. This is required since infinity cannot
 	// be marshalled in JSON.
 	Infinite bool
+	// Distributed is true if the rate limiter is currently shared with other processes
+	// through Redis.
+	Distributed bool
+	// Available is the number of requests that can currently be made without waiting,
+	// according to the shared state in Redis. It is negative while callers are waiting
+	// for tokens, and only set if Distributed is true.
+	Available float64
 }
 
 // LimitInfo reports how all the existing rate limiters are configured, keyed by
-// URN.
+// URN. The tokens available to rate limiters shared through Redis are read live.
 func (r *Registry) LimitInfo() map[string]LimitInfo {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	m := make(map[string]LimitInfo, len(r.rateLimiters))
+	limiters := make(map[string]*InstrumentedLimiter, len(r.rateLimiters))
 	for urn, rl := range r.rateLimiters {
+		limiters[urn] = rl
+	}
+	r.mu.Unlock()
+
+	// Redis is queried without holding the lock, so that Get isn't blocked meanwhile.
+	ctx := context.Background()
+	m := make(map[string]LimitInfo, len(limiters))
+	for urn, rl := range limiters {
 		limit := rl.Limit()
 		info := LimitInfo{
 			Burst: rl.Burst(),
@@ -107,6 +147,9 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 			info.Limit = 0
 			info.Infinite = true
 		}
+		if rl.backend != nil {
+			info.Available, info.Distributed = rl.backend.tokens(ctx, urn, limit, info.Burst)
+		}
 		m[urn] = info
 	}
 	return m
@@ -116,6 +159,10 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 type InstrumentedLimiter struct {
 	urn string
 	*rate.Limiter
+
+	// backend, if set, enforces the limit and burst of the wrapped *rate.Limiter across
+	// all processes. The wrapped *rate.Limiter is only used while Redis is unavailable.
+	backend *redisBackend
 }
 
 // NewInstrumentedLimiter creates new InstrumentedLimiter with given URN and rate.Limiter
@@ -143,7 +190,7 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	}
 
 	start := time.Now()
-	err := i.Limiter.WaitN(ctx, n)
+	err := i.waitN(ctx, n)
 	d := time.Since(start)
 	failedLabel := "false"
 	if err != nil {
@@ -154,6 +201,15 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	return err
 }
 
+func (i *InstrumentedLimiter) waitN(ctx context.Context, n int) error {
+	if i.backend != nil {
+		if handled, err := i.backend.waitN(ctx, i.urn, i.Limit(), i.Burst(), n); handled {
+			return err
+		}
+	}
+	return i.Limiter.WaitN(ctx, n)
+}
+
 // SetBurst is calling SetBurstAt(time.Now(), newBurst) method of the wrapped *rate.Limiter.
 func (i *InstrumentedLimiter) SetBurst(newBurst int) {
 	i.Limiter.SetBurstAt(time.Now(), newBurst)
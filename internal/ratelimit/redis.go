@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// redisKeyPrefix is prepended to the URN of a rate limiter to form the key of its
+// token bucket in Redis.
+const redisKeyPrefix = "ratelimit:"
+
+// redisRetryInterval is how long limiters fall back to their process-local state after
+// Redis failed to respond, before trying Redis again.
+const redisRetryInterval = 30 * time.Second
+
+// waitScript takes n tokens from the bucket stored at KEYS[1], which refills at ARGV[1]
+// tokens per second up to ARGV[2] tokens. Like rate.Limiter, the bucket may go into debt,
+// and the script returns the number of milliseconds the caller has to wait before acting.
+// If that exceeds ARGV[4] milliseconds (unless negative), no tokens are taken and -1 is
+// returned.
+//
+// The clock of the Redis server is used, so that the clocks of the callers don't need to
+// agree.
+var waitScript = redis.NewScript(1, `
+redis.replicate_commands()
+
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local max_wait = tonumber(ARGV[4])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', key, 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - updated) * limit / 1000) - n
+
+local wait = 0
+if tokens < 0 then
+	wait = math.ceil(-tokens * 1000 / limit)
+end
+if max_wait >= 0 and wait > max_wait then
+	return -1
+end
+
+redis.call('HMSET', key, 'tokens', tostring(tokens), 'updated', now)
+-- Once the bucket has refilled it is equivalent to a missing one.
+redis.call('PEXPIRE', key, math.ceil(burst * 1000 / limit) + 1000)
+return wait
+`)
+
+// tokensScript returns the number of tokens currently available in the bucket stored at
+// KEYS[1], as a string, without modifying it.
+var tokensScript = redis.NewScript(1, `
+local limit = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	return tostring(burst)
+end
+return tostring(math.min(burst, tokens + math.max(0, now - updated) * limit / 1000))
+`)
+
+// redisBackend shares the token buckets of rate limiters between all processes
+// connected to the same Redis, so that replicas of a service don't each consume the full
+// rate limit of a code host.
+type redisBackend struct {
+	pool *redis.Pool
+	// enabled, if set, reports whether Redis should currently be used at all.
+	enabled func() bool
+
+	mu               sync.Mutex
+	unavailableUntil time.Time
+}
+
+func newRedisBackend(pool *redis.Pool, enabled func() bool) *redisBackend {
+	return &redisBackend{pool: pool, enabled: enabled}
+}
+
+// available reports whether Redis should be used, that is when it is enabled and hasn't
+// failed to respond within the last redisRetryInterval.
+func (b *redisBackend) available() bool {
+	if b.enabled != nil && !b.enabled() {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.unavailableUntil)
+}
+
+func (b *redisBackend) markUnavailable() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unavailableUntil = time.Now().Add(redisRetryInterval)
+}
+
+// shared reports whether the limit and burst can be enforced through Redis. Infinite and
+// blocking limiters don't need any shared state, and invalid requests are left to
+// rate.Limiter so that they fail the same way.
+func shared(limit rate.Limit, burst, n int) bool {
+	return limit > 0 && limit != rate.Inf && !math.IsInf(float64(limit), 0) && n <= burst
+}
+
+// waitN blocks until the shared bucket of urn permits n events to happen. handled is
+// false if Redis could not be used, in which case the caller should fall back to its
+// process-local limiter.
+//
+// Unlike rate.Limiter, tokens taken from the bucket are not returned if ctx is canceled
+// while waiting.
+func (b *redisBackend) waitN(ctx context.Context, urn string, limit rate.Limit, burst, n int) (handled bool, err error) {
+	if !shared(limit, burst, n) || !b.available() {
+		return false, nil
+	}
+
+	maxWait := int64(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline).Milliseconds()
+		if maxWait < 0 {
+			maxWait = 0
+		}
+	}
+
+	wait, err := do(ctx, b, func(c redis.Conn) (int64, error) {
+		return redis.Int64(waitScript.Do(c, redisKeyPrefix+urn, float64(limit), burst, n, maxWait))
+	})
+	if err != nil {
+		if !isContextError(ctx, err) {
+			metricRedisFallback.WithLabelValues(urn).Inc()
+		}
+		return false, nil
+	}
+	if wait < 0 {
+		return true, errors.Newf("rate: Wait(n=%d) would exceed context deadline", n)
+	}
+	if wait == 0 {
+		return true, nil
+	}
+
+	t := time.NewTimer(time.Duration(wait) * time.Millisecond)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// tokens returns the number of tokens currently available in the shared bucket of urn.
+// ok is false if the bucket isn't shared or Redis could not be used.
+func (b *redisBackend) tokens(ctx context.Context, urn string, limit rate.Limit, burst int) (tokens float64, ok bool) {
+	if !shared(limit, burst, 0) || !b.available() {
+		return 0, false
+	}
+
+	tokens, err := do(ctx, b, func(c redis.Conn) (float64, error) {
+		s, err := redis.String(tokensScript.Do(c, redisKeyPrefix+urn, float64(limit), burst))
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(s, 64)
+	})
+	return tokens, err == nil
+}
+
+// do runs fn with a connection from the pool, marking Redis as unavailable if it fails.
+// Errors caused by the caller's context are returned without affecting other callers.
+func do[T any](ctx context.Context, b *redisBackend, fn func(redis.Conn) (T, error)) (T, error) {
+	var zero T
+	c, err := b.pool.GetContext(ctx)
+	if err != nil {
+		if !isContextError(ctx, err) {
+			b.markUnavailable()
+		}
+		return zero, err
+	}
+	defer c.Close()
+
+	v, err := fn(c)
+	if err != nil {
+		if !isContextError(ctx, err) {
+			b.markUnavailable()
+		}
+		return zero, err
+	}
+	return v, nil
+}
+
+// isContextError reports whether err was caused by ctx being canceled or reaching its
+// deadline, rather than by Redis.
+func isContextError(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.IsContextError(err)
+}
+
+var metricRedisFallback = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_internal_rate_limit_redis_fallback_total",
+	Help: "Number of times a rate limiter fell back to its process-local state because Redis was unavailable",
+}, []string{"urn"})
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestDistributedRegistry(t *testing.T) {
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	c := pool.Get()
+	defer c.Close()
+
+	// If we are not on CI, skip the test if our redis connection fails.
+	if os.Getenv("CI") == "" {
+		if _, err := c.Do("PING"); err != nil {
+			t.Skip("could not connect to redis", err)
+		}
+	}
+
+	urn := "extsvc:github:" + t.Name()
+	_, err := c.Do("DEL", redisKeyPrefix+urn)
+	require.NoError(t, err)
+
+	// Two registries sharing the same Redis behave like two replicas of a service.
+	replicas := []*Registry{NewDistributedRegistry(pool), NewDistributedRegistry(pool)}
+	for _, r := range replicas {
+		rl := r.Get(urn)
+		rl.SetLimit(rate.Limit(0.1))
+		rl.SetBurst(2)
+	}
+
+	ctx := context.Background()
+	require.NoError(t, replicas[0].Get(urn).Wait(ctx))
+	require.NoError(t, replicas[1].Get(urn).Wait(ctx))
+
+	// The burst has been used up by both replicas together, so the next token is only
+	// available in 10 seconds.
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.Error(t, replicas[0].Get(urn).Wait(ctx))
+
+	info := replicas[1].LimitInfo()[urn]
+	assert.True(t, info.Distributed)
+	assert.Equal(t, 0.1, info.Limit)
+	assert.Equal(t, 2, info.Burst)
+	assert.Less(t, info.Available, 1.0)
+}
+
+func TestDistributedRegistryFallback(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, redis.ErrPoolExhausted
+		},
+	}
+
+	r := NewDistributedRegistry(pool)
+	rl := r.Get("extsvc:github:1")
+	rl.SetLimit(rate.Limit(10))
+	rl.SetBurst(1)
+
+	// The process-local limiter is used when Redis is unavailable.
+	require.NoError(t, rl.Wait(context.Background()))
+	assert.False(t, r.backend.available())
+
+	info := r.LimitInfo()["extsvc:github:1"]
+	assert.False(t, info.Distributed)
+	assert.Zero(t, info.Available)
+}
+
+func TestDistributedRegistryContextError(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, context.Canceled
+		},
+	}
+
+	r := NewDistributedRegistry(pool)
+	rl := r.Get("extsvc:github:1")
+	rl.SetLimit(rate.Limit(10))
+	rl.SetBurst(1)
+
+	// A canceled caller must not switch other callers to the process-local limiter.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, rl.Wait(ctx))
+	assert.True(t, r.backend.available())
+}
+
+func TestDistributedRegistryDisabled(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			t.Fatal("unexpected connection to redis")
+			return nil, nil
+		},
+	}
+
+	r := newDistributedRegistry(pool, func() bool { return false })
+	rl := r.Get("extsvc:github:1")
+	rl.SetLimit(rate.Limit(10))
+	rl.SetBurst(1)
+
+	require.NoError(t, rl.Wait(context.Background()))
+	assert.False(t, r.LimitInfo()["extsvc:github:1"].Distributed)
+}
@@ -0,0 +1,128 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// New creates a filter job for the file:has.owner() predicate. Only file matches
+// owned by every included owner and by none of the excluded owners are passed
+// through, as per the CODEOWNERS file at the revision of each match.
+func New(child job.Job, includeOwners, excludeOwners []string) job.Job {
+	return &codeownershipJob{
+		child:         child,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+	}
+}
+
+type codeownershipJob struct {
+	child job.Job
+
+	includeOwners []string
+	excludeOwners []string
+}
+
+func (s *codeownershipJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := NewRulesCache(backend.NewOwnService(clients.Gitserver))
+	includes := parseOwnerReferences(s.includeOwners)
+	excludes := parseOwnerReferences(s.excludeOwners)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = applyCodeOwnershipFiltering(ctx, rules, includes, excludes, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *codeownershipJob) Name() string {
+	return "CodeOwnershipFilterJob"
+}
+
+func (s *codeownershipJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", s.includeOwners),
+			trace.Strings("excludeOwners", s.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (s *codeownershipJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *codeownershipJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func applyCodeOwnershipFiltering(ctx context.Context, rules *RulesCache, includes, excludes []ownerReference, matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	filtered := matches[:0]
+matchesLoop:
+	for _, m := range matches {
+		// Ownership is resolved for files only.
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		owners := ruleset.FindOwners(fm.File.Path)
+
+		for _, include := range includes {
+			if !containsOwner(owners, include) {
+				continue matchesLoop
+			}
+		}
+		for _, exclude := range excludes {
+			if containsOwner(owners, exclude) {
+				continue matchesLoop
+			}
+		}
+
+		filtered = append(filtered, m)
+	}
+
+	return filtered, errs
+}
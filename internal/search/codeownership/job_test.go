@@ -0,0 +1,111 @@
+package codeownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// fakeOwnService returns the same CODEOWNERS file for every repository and commit.
+type fakeOwnService struct {
+	file  *codeownerspb.File
+	calls int
+}
+
+func (s *fakeOwnService) OwnersFile(context.Context, api.RepoName, api.CommitID) (*codeownerspb.File, error) {
+	s.calls++
+	return s.file, nil
+}
+
+var testCodeowners = &codeownerspb.File{
+	Rule: []*codeownerspb.Rule{
+		{Pattern: "cmd/", Owner: []*codeownerspb.Owner{{Handle: "go-team"}}},
+		{Pattern: "/payments/", Owner: []*codeownerspb.Owner{{Handle: "payments-team"}, {Email: "billing@example.com"}}},
+	},
+}
+
+func fileMatch(path string) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{
+			Path:     path,
+			Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"},
+			CommitID: "deadbeef",
+		},
+	}
+}
+
+func TestApplyCodeOwnershipFiltering(t *testing.T) {
+	for name, tc := range map[string]struct {
+		include []string
+		exclude []string
+		want    []string
+	}{
+		"include handle": {
+			include: []string{"@payments-team"},
+			want:    []string{"payments/api.ts", "payments/charge.go"},
+		},
+		"include handle without at sign is case insensitive": {
+			include: []string{"Go-Team"},
+			want:    []string{"cmd/main.go"},
+		},
+		"include email": {
+			include: []string{"billing@example.com"},
+			want:    []string{"payments/api.ts", "payments/charge.go"},
+		},
+		"exclude": {
+			exclude: []string{"@payments-team"},
+			want:    []string{"cmd/main.go", "README.md"},
+		},
+		"include and exclude": {
+			include: []string{"@go-team"},
+			exclude: []string{"@payments-team"},
+			want:    []string{"cmd/main.go"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ownService := &fakeOwnService{file: testCodeowners}
+			matches := []result.Match{
+				fileMatch("cmd/main.go"),
+				fileMatch("payments/api.ts"),
+				fileMatch("payments/charge.go"),
+				fileMatch("README.md"),
+				&result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"},
+			}
+
+			filtered, err := applyCodeOwnershipFiltering(
+				context.Background(),
+				NewRulesCache(ownService),
+				parseOwnerReferences(tc.include),
+				parseOwnerReferences(tc.exclude),
+				matches,
+			)
+			require.NoError(t, err)
+
+			var paths []string
+			for _, m := range filtered {
+				paths = append(paths, m.(*result.FileMatch).Path)
+			}
+			require.Equal(t, tc.want, paths)
+			require.Equal(t, 1, ownService.calls)
+		})
+	}
+}
+
+func TestApplyCodeOwnershipFilteringWithoutCodeowners(t *testing.T) {
+	filtered, err := applyCodeOwnershipFiltering(
+		context.Background(),
+		NewRulesCache(&fakeOwnService{}),
+		parseOwnerReferences([]string{"@go-team"}),
+		nil,
+		[]result.Match{fileMatch("cmd/main.go")},
+	)
+	require.NoError(t, err)
+	require.Empty(t, filtered)
+}
@@ -0,0 +1,51 @@
+package codeownership
+
+import (
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// ownerReference is an owner given as a search parameter, which
+// is either a handle (optionally prefixed with `@`) or an email.
+type ownerReference struct {
+	handle string
+	email  string
+}
+
+func parseOwnerReference(s string) ownerReference {
+	if strings.HasPrefix(s, "@") {
+		return ownerReference{handle: strings.TrimPrefix(s, "@")}
+	}
+	if strings.Contains(s, "@") {
+		return ownerReference{email: s}
+	}
+	return ownerReference{handle: s}
+}
+
+func parseOwnerReferences(ss []string) []ownerReference {
+	refs := make([]ownerReference, 0, len(ss))
+	for _, s := range ss {
+		refs = append(refs, parseOwnerReference(s))
+	}
+	return refs
+}
+
+// matches returns true if the given CODEOWNERS owner is the owner referenced.
+// Handles and emails are compared case-insensitively.
+func (r ownerReference) matches(owner *codeownerspb.Owner) bool {
+	if r.handle != "" {
+		return strings.EqualFold(r.handle, owner.GetHandle())
+	}
+	return strings.EqualFold(r.email, owner.GetEmail())
+}
+
+// containsOwner returns true if any of the given owners is referenced.
+func containsOwner(owners []*codeownerspb.Owner, ref ownerReference) bool {
+	for _, owner := range owners {
+		if ref.matches(owner) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+type rulesKey struct {
+	repoName api.RepoName
+	commitID api.CommitID
+}
+
+// RulesCache memoizes the CODEOWNERS file of each repository and commit seen
+// in a single search, so that every file match of a result set resolves owners
+// against the file at the searched revision without refetching it.
+type RulesCache struct {
+	ownService backend.OwnService
+
+	mu    sync.Mutex
+	rules map[rulesKey]*rulesEntry
+}
+
+// rulesEntry holds the ruleset of a single repository and commit. Its mutex
+// is held while fetching, so that concurrent lookups of the same key wait for
+// a single fetch, while lookups of other keys are not blocked.
+type rulesEntry struct {
+	mu sync.Mutex
+	// ruleset is nil until it was fetched successfully.
+	ruleset *codeownerspb.Ruleset
+}
+
+func NewRulesCache(ownService backend.OwnService) *RulesCache {
+	return &RulesCache{
+		ownService: ownService,
+		rules:      make(map[rulesKey]*rulesEntry),
+	}
+}
+
+// GetFromCacheOrFetch returns the ruleset of the CODEOWNERS file for the given
+// repository at the given commit. If the repository has no CODEOWNERS file at
+// that commit, the returned ruleset has no owners for any path. Failed fetches
+// are not cached, so the next lookup of the same key tries again.
+func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoName, commitID api.CommitID) (*codeownerspb.Ruleset, error) {
+	key := rulesKey{repoName: repoName, commitID: commitID}
+	c.mu.Lock()
+	entry, ok := c.rules[key]
+	if !ok {
+		entry = &rulesEntry{}
+		c.rules[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.ruleset != nil {
+		return entry.ruleset, nil
+	}
+
+	file, err := c.ownService.OwnersFile(ctx, repoName, commitID)
+	if err != nil {
+		return nil, err
+	}
+	entry.ruleset = codeownerspb.NewRuleset(file)
+	return entry.ruleset, nil
+}
@@ -0,0 +1,60 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// blockingOwnService blocks fetching the CODEOWNERS file of blockedRepo
+// until unblock is closed.
+type blockingOwnService struct {
+	blockedRepo api.RepoName
+	unblock     chan struct{}
+	calls       atomic.Int32
+}
+
+func (s *blockingOwnService) OwnersFile(_ context.Context, repoName api.RepoName, _ api.CommitID) (*codeownerspb.File, error) {
+	s.calls.Add(1)
+	if repoName == s.blockedRepo {
+		<-s.unblock
+	}
+	return testCodeowners, nil
+}
+
+func TestRulesCacheConcurrentFetches(t *testing.T) {
+	ctx := context.Background()
+	ownService := &blockingOwnService{
+		blockedRepo: "github.com/sourcegraph/slow",
+		unblock:     make(chan struct{}),
+	}
+	rules := NewRulesCache(ownService)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ruleset, err := rules.GetFromCacheOrFetch(ctx, "github.com/sourcegraph/slow", "deadbeef")
+			require.NoError(t, err)
+			require.Equal(t, testCodeowners, ruleset.GetFile())
+		}()
+	}
+
+	// A slow fetch does not block lookups of other repositories.
+	ruleset, err := rules.GetFromCacheOrFetch(ctx, "github.com/sourcegraph/fast", "deadbeef")
+	require.NoError(t, err)
+	require.Equal(t, testCodeowners, ruleset.GetFile())
+
+	close(ownService.unblock)
+	wg.Wait()
+	// Concurrent lookups of the same repository and commit share one fetch.
+	require.Equal(t, int32(2), ownService.calls.Load())
+}
@@ -0,0 +1,118 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwners creates a job for `select:file.owners`. Each file match streamed
+// by the child is replaced by the owners of that file, as per the CODEOWNERS file
+// at the revision of the match. Every owner is sent once per search.
+func NewSelectOwners(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (s *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := NewRulesCache(backend.NewOwnService(clients.Gitserver))
+	dedup := result.NewDeduper()
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var err error
+		event.Results, err = getCodeOwnersFromMatches(ctx, rules, event.Results)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+
+		deduped := event.Results[:0]
+		for _, m := range event.Results {
+			if !dedup.Seen(m) {
+				dedup.Add(m)
+				deduped = append(deduped, m)
+			}
+		}
+		event.Results = deduped
+
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (s *selectOwnersJob) Fields(_ job.Verbosity) []otlog.Field {
+	return nil
+}
+
+func (s *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func getCodeOwnersFromMatches(ctx context.Context, rules *RulesCache, matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	var ownerMatches []result.Match
+	for _, m := range matches {
+		// Ownership is resolved for files only.
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		for _, owner := range ruleset.FindOwners(fm.File.Path) {
+			ownerMatches = append(ownerMatches, &result.OwnerMatch{
+				Handle:   owner.GetHandle(),
+				Email:    owner.GetEmail(),
+				Repo:     fm.Repo,
+				CommitID: fm.CommitID,
+				InputRev: fm.InputRev,
+				Path:     fm.Path,
+				LimitHit: fm.LimitHit,
+			})
+		}
+	}
+
+	return ownerMatches, errs
+}
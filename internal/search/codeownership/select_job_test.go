@@ -0,0 +1,28 @@
+package codeownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestGetCodeOwnersFromMatches(t *testing.T) {
+	matches := []result.Match{
+		fileMatch("cmd/main.go"),
+		fileMatch("payments/api.ts"),
+		fileMatch("README.md"),
+		&result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"},
+	}
+
+	owners, err := getCodeOwnersFromMatches(context.Background(), NewRulesCache(&fakeOwnService{file: testCodeowners}), matches)
+	require.NoError(t, err)
+
+	var identifiers []string
+	for _, m := range owners {
+		identifiers = append(identifiers, m.(*result.OwnerMatch).Identifier())
+	}
+	require.Equal(t, []string{"@go-team", "@payments-team", "billing@example.com"}, identifiers)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -184,10 +185,20 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		if includeOwners, excludeOwners := b.FileHasOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = codeownership.New(basicJob, includeOwners, excludeOwners)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if isSelectOwners(sp) {
+				basicJob = codeownership.NewSelectOwners(basicJob)
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -1008,3 +1019,10 @@ func isGlobal(op search.RepoOptions) bool {
 	// - OnlyArchived
 	return true
 }
+
+// isSelectOwners returns true if the given select path is `select:file.owners`,
+// which needs to resolve CODEOWNERS files and so cannot be applied by a plain
+// select job.
+func isSelectOwners(sp filter.SelectPath) bool {
+	return sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners"
+}
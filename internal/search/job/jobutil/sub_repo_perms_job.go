@@ -117,6 +117,22 @@ func applySubRepoFiltering(ctx context.Context, checker authz.SubRepoPermissionC
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// The owner is only revealed if the file it was resolved for
+			// can be read.
+			content := authz.RepoContent{
+				Repo: mm.Repo.Name,
+				Path: mm.Path,
+			}
+			perms, err := authz.ActorPermissions(ctx, checker, a, content)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
+			if perms.Include(authz.Read) {
+				filtered = append(filtered, m)
+			}
 		}
 
 	}
@@ -181,6 +181,28 @@ func TestApplySubRepoFiltering(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should filter owner matches of files the user doesn't have access to",
+			args: args{
+				ctxActor: actor.FromUser(userWithSubRepoPerms),
+				matches: []result.Match{
+					&result.OwnerMatch{
+						Handle: "readme-owner",
+						Path:   unauthorizedFileName,
+					},
+					&result.OwnerMatch{
+						Handle: "random-owner",
+						Path:   "random-name.md",
+					},
+				},
+			},
+			wantMatches: []result.Match{
+				&result.OwnerMatch{
+					Handle: "random-owner",
+					Path:   "random-name.md",
+				},
+			},
+		},
 		{
 			name: "should filter commit matches where the diff is empty",
 			args: args{
@@ -56,3 +56,23 @@ func TestRepoHasKVPs(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasKVPs())
 }
+
+func TestFileHasOwner(t *testing.T) {
+	ps := Parameters{
+		Parameter{
+			Field:      FieldFile,
+			Value:      "has.owner(@payments-team)",
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+		Parameter{
+			Field:      FieldFile,
+			Value:      "has.owner(owner@example.com)",
+			Negated:    true,
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+	}
+
+	include, exclude := ps.FileHasOwner()
+	require.Equal(t, []string{"@payments-team"}, include)
+	require.Equal(t, []string{"owner@example.com"}, exclude)
+}
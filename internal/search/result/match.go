@@ -8,7 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Match is *FileMatch | *RepoMatch | *CommitMatch. We have a private method
+// Match is *FileMatch | *RepoMatch | *CommitMatch | *OwnerMatch. We have a private method
 // to ensure only those types implement Match.
 type Match interface {
 	ResultCount() int
@@ -34,6 +34,7 @@ var (
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
+	_ Match = (*OwnerMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
@@ -44,6 +45,7 @@ const (
 	rankCommitMatch = 1
 	rankDiffMatch   = 2
 	rankRepoMatch   = 3
+	rankOwnerMatch  = 4
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -71,7 +73,8 @@ type Key struct {
 	Commit api.CommitID
 
 	// Path is the path of the file the match belongs to.
-	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch)
+	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch).
+	// For an OwnerMatch, this is the identifier of the owner.
 	Path string
 
 	// TypeRank is the sorting rank of the type this key belongs to.
@@ -0,0 +1,66 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of one or more files in a result set, as resolved
+// from the CODEOWNERS file at the searched revision. It is produced by
+// `select:file.owners`. Owner matches are keyed by the owner only, so the same
+// owner found across several files or repositories is a single result.
+type OwnerMatch struct {
+	// Handle is the owner handle without the leading `@`, if the owner is
+	// identified by a handle.
+	Handle string
+	// Email is the owner email, if the owner is identified by an email.
+	Email string
+
+	// Repo, CommitID, InputRev and Path identify the first file match for which
+	// this owner was resolved.
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+	InputRev *string
+	Path     string
+
+	LimitHit bool
+}
+
+// Identifier returns the handle of the owner prefixed with `@`, or
+// the email of the owner if there is no handle.
+func (o *OwnerMatch) Identifier() string {
+	if o.Handle != "" {
+		return "@" + o.Handle
+	}
+	return o.Email
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Path:     o.Identifier(),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
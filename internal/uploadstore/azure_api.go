@@ -0,0 +1,330 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureAPI interface {
+	CreateContainer(ctx context.Context, container string) error
+	GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error)
+	PutBlock(ctx context.Context, container, name, blockID string, data []byte) error
+	PutBlockList(ctx context.Context, container, name string, blockIDs []string) error
+	DeleteBlob(ctx context.Context, container, name string) error
+	ListBlobs(ctx context.Context, container, prefix, marker string) (blobs []azureBlob, nextMarker string, err error)
+}
+
+type azureBlob struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// azureError is the error returned for unsuccessful Blob service responses.
+type azureError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *azureError) Error() string {
+	return fmt.Sprintf("azure blob storage: unexpected status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// isAzureBlobNotFound returns true if the given error indicates that the target blob does not exist.
+func isAzureBlobNotFound(err error) bool {
+	var e *azureError
+	return errors.As(err, &e) && e.Code == "BlobNotFound"
+}
+
+// azureAPIVersion is the version of the Blob service REST API sent with each request.
+const azureAPIVersion = "2021-08-06"
+
+// azureAPIShim implements azureAPI over the Blob service REST API, authenticating
+// requests with a storage account shared key.
+type azureAPIShim struct {
+	endpoint    *url.URL
+	accountName string
+	accountKey  []byte
+	doer        httpcli.Doer
+}
+
+var _ azureAPI = &azureAPIShim{}
+
+func newAzureAPIShim(config AzureConfig, doer httpcli.Doer) (*azureAPIShim, error) {
+	accountKey, err := base64.StdEncoding.DecodeString(config.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid account key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid endpoint")
+	}
+
+	return &azureAPIShim{
+		endpoint:    u,
+		accountName: config.AccountName,
+		accountKey:  accountKey,
+		doer:        doer,
+	}, nil
+}
+
+func (s *azureAPIShim) CreateContainer(ctx context.Context, container string) error {
+	resp, err := s.do(ctx, http.MethodPut, container, "", url.Values{"restype": {"container"}}, nil, http.StatusCreated)
+	if err != nil {
+		var e *azureError
+		if errors.As(err, &e) && e.Code == "ContainerAlreadyExists" {
+			return nil
+		}
+
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *azureAPIShim) GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, container, name, nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *azureAPIShim) PutBlock(ctx context.Context, container, name, blockID string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, container, name, url.Values{"comp": {"block"}, "blockid": {blockID}}, data, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+func (s *azureAPIShim) PutBlockList(ctx context.Context, container, name string, blockIDs []string) error {
+	body, err := xml.Marshal(azureBlockList{Latest: blockIDs})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, container, name, url.Values{"comp": {"blocklist"}}, append([]byte(xml.Header), body...), http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *azureAPIShim) DeleteBlob(ctx context.Context, container, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, container, name, nil, nil, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+type azureEnumerationResults struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			CreationTime string `xml:"Creation-Time"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (s *azureAPIShim) ListBlobs(ctx context.Context, container, prefix, marker string) (_ []azureBlob, _ string, err error) {
+	query := url.Values{"restype": {"container"}, "comp": {"list"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, container, "", query, nil, http.StatusOK)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = errors.Append(err, closeErr)
+		}
+	}()
+
+	var results azureEnumerationResults
+	if err := xml.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, "", errors.Wrap(err, "failed to decode blob list")
+	}
+
+	blobs := make([]azureBlob, 0, len(results.Blobs))
+	for _, blob := range results.Blobs {
+		createdAt, err := time.Parse(http.TimeFormat, blob.Properties.CreationTime)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "invalid creation time for blob %q", blob.Name)
+		}
+
+		blobs = append(blobs, azureBlob{Name: blob.Name, CreatedAt: createdAt})
+	}
+
+	return blobs, results.NextMarker, nil
+}
+
+// do performs a signed request against the given container or blob and returns the response if
+// it has the expected status code. The caller is responsible for closing the response body.
+func (s *azureAPIShim) do(ctx context.Context, method, container, name string, query url.Values, body []byte, expectedStatus int) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = u.Path + "/" + container
+	if name != "" {
+		u.Path = u.Path + "/" + name
+	}
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", s.accountName, s.sign(req)))
+
+	resp, err := s.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != expectedStatus {
+		defer resp.Body.Close()
+		return nil, readAzureError(resp)
+	}
+
+	return resp, nil
+}
+
+// sign computes the shared key signature of the given request.
+func (s *azureAPIShim) sign(req *http.Request) string {
+	mac := hmac.New(sha256.New, s.accountKey)
+	mac.Write([]byte(azureStringToSign(s.accountName, req)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// azureStringToSign constructs the string signed with the account key for the given request.
+//
+// See https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func azureStringToSign(accountName string, req *http.Request) string {
+	// Since version 2015-02-21, a zero content length is signed as an empty string.
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		req.Header.Get("Date"),
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}, "\n") + "\n" + azureCanonicalizedHeaders(req.Header) + azureCanonicalizedResource(accountName, req.URL)
+}
+
+// azureCanonicalizedHeaders returns the x-ms- headers of a request, lowercased and sorted by
+// name, each followed by a newline.
+func azureCanonicalizedHeaders(header http.Header) string {
+	var names []string
+	for name := range header {
+		if strings.HasPrefix(strings.ToLower(name), "x-ms-") {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+
+	var b strings.Builder
+	for _, name := range names {
+		values := make([]string, 0, len(header[name]))
+		for _, value := range header[name] {
+			values = append(values, strings.TrimSpace(value))
+		}
+		b.WriteString(strings.ToLower(name) + ":" + strings.Join(values, ",") + "\n")
+	}
+
+	return b.String()
+}
+
+// azureCanonicalizedResource returns the account name followed by the encoded URI path and
+// the lowercased, sorted query parameters of a request URL. Values of repeated parameters are
+// sorted and joined by commas.
+func azureCanonicalizedResource(accountName string, u *url.URL) string {
+	query := map[string][]string{}
+	for name, values := range u.Query() {
+		name = strings.ToLower(name)
+		query[name] = append(query[name], values...)
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("/" + accountName + u.EscapedPath())
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		b.WriteString("\n" + name + ":" + strings.Join(values, ","))
+	}
+
+	return b.String()
+}
+
+func readAzureError(resp *http.Response) error {
+	e := &azureError{
+		StatusCode: resp.StatusCode,
+		Code:       resp.Header.Get("x-ms-error-code"),
+	}
+
+	var payload struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if content, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil && xml.Unmarshal(content, &payload) == nil {
+		if e.Code == "" {
+			e.Code = payload.Code
+		}
+		e.Message = payload.Message
+	}
+
+	return e
+}
@@ -0,0 +1,94 @@
+package uploadstore
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// The expectations below are the examples given in
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+
+func TestAzureCanonicalizedResource(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "http://myaccount.blob.core.windows.net/mycontainer?restype=container&comp=metadata",
+			expected: "/myaccount/mycontainer\ncomp:metadata\nrestype:container",
+		},
+		{
+			url:      "http://myaccount.blob.core.windows.net/mycontainer?restype=container&comp=list&include=snapshots&include=metadata&include=uncommittedblobs",
+			expected: "/myaccount/mycontainer\ncomp:list\ninclude:metadata,snapshots,uncommittedblobs\nrestype:container",
+		},
+		{
+			url:      "https://myaccount-secondary.blob.core.windows.net/mycontainer/myblob",
+			expected: "/myaccount/mycontainer/myblob",
+		},
+		{
+			// The emulator (Azurite) addresses the account in the path
+			url:      "http://127.0.0.1:10000/myaccount/mycontainer/myblob",
+			expected: "/myaccount/myaccount/mycontainer/myblob",
+		},
+		{
+			// Parameter names are lowercased and merged
+			url:      "http://myaccount.blob.core.windows.net/mycontainer/my%20blob?COMP=block&blockid=MDA%3D",
+			expected: "/myaccount/mycontainer/my%20blob\nblockid:MDA=\ncomp:block",
+		},
+	}
+
+	for _, testCase := range testCases {
+		u, err := url.Parse(testCase.url)
+		if err != nil {
+			t.Fatalf("unexpected error parsing url: %s", err)
+		}
+
+		if resource := azureCanonicalizedResource("myaccount", u); resource != testCase.expected {
+			t.Errorf("unexpected canonicalized resource for %s. want=%q have=%q", testCase.url, testCase.expected, resource)
+		}
+	}
+}
+
+func TestAzureStringToSign(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://myaccount.blob.core.windows.net/myaccount/mycontainer?restype=container&comp=metadata&timeout=20", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %s", err)
+	}
+	req.Header.Set("x-ms-version", "2009-09-19")
+	req.Header.Set("x-ms-date", "Sun, 11 Oct 2009 21:49:13 GMT")
+
+	expected := "GET\n\n\n\n\n\n\n\n\n\n\n\n" +
+		"x-ms-date:Sun, 11 Oct 2009 21:49:13 GMT\nx-ms-version:2009-09-19\n" +
+		"/myaccount/myaccount/mycontainer\ncomp:metadata\nrestype:container\ntimeout:20"
+	if stringToSign := azureStringToSign("myaccount", req); stringToSign != expected {
+		t.Errorf("unexpected string to sign. want=%q have=%q", expected, stringToSign)
+	}
+}
+
+func TestAzureStringToSignContentLength(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "http://myaccount.blob.core.windows.net/mycontainer/myblob?comp=block&blockid=MDA%3D", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %s", err)
+	}
+	req.ContentLength = 11
+	req.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+	req.Header.Set("x-ms-date", "Fri, 26 Jun 2015 23:39:12 GMT")
+	req.Header.Set("X-Ms-Version", "2015-02-21")
+
+	expected := "PUT\n\n\n11\n\ntext/plain; charset=UTF-8\n\n\n\n\n\n\n" +
+		"x-ms-date:Fri, 26 Jun 2015 23:39:12 GMT\nx-ms-version:2015-02-21\n" +
+		"/myaccount/mycontainer/myblob\nblockid:MDA=\ncomp:block"
+	if stringToSign := azureStringToSign("myaccount", req); stringToSign != expected {
+		t.Errorf("unexpected string to sign. want=%q have=%q", expected, stringToSign)
+	}
+
+	// A zero content length is signed as an empty string
+	req.ContentLength = 0
+	expected = "PUT\n\n\n\n\ntext/plain; charset=UTF-8\n\n\n\n\n\n\n" +
+		"x-ms-date:Fri, 26 Jun 2015 23:39:12 GMT\nx-ms-version:2015-02-21\n" +
+		"/myaccount/mycontainer/myblob\nblockid:MDA=\ncomp:block"
+	if stringToSign := azureStringToSign("myaccount", req); stringToSign != expected {
+		t.Errorf("unexpected string to sign. want=%q have=%q", expected, stringToSign)
+	}
+}
@@ -0,0 +1,282 @@
+package uploadstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureStore struct {
+	container    string
+	manageBucket bool
+	client       azureAPI
+	operations   *Operations
+}
+
+var _ Store = &azureStore{}
+
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string
+	// Endpoint overrides the default https://<account>.blob.core.windows.net
+	// endpoint, e.g. http://azurite:10000/devstoreaccount1 to target Azurite.
+	Endpoint string
+}
+
+// azureBlockSize is the size of the blocks in which objects are staged before they
+// are committed. A block blob consists of at most 50,000 blocks.
+const azureBlockSize = 8 * 1024 * 1024
+
+// newAzureFromConfig creates a new store backed by Azure Blob Storage.
+func newAzureFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	api, err := newAzureAPIShim(config.Azure, httpcli.ExternalDoer)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAzureWithClient(api, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newAzureWithClient(client azureAPI, container string, manageBucket bool, operations *Operations) *azureStore {
+	return &azureStore{
+		container:    container,
+		manageBucket: manageBucket,
+		client:       client,
+		operations:   operations,
+	}
+}
+
+func (s *azureStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	if err := s.client.CreateContainer(ctx, s.container); err != nil {
+		return errors.Wrap(err, "failed to create container")
+	}
+
+	return nil
+}
+
+func (s *azureStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rc, err := s.client.GetBlob(ctx, s.container, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return rc, nil
+}
+
+func (s *azureStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	w := s.newBlockWriter(ctx, key)
+	n, err := w.readFrom(r)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+	if err := w.commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *azureStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(ctx, sources); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	w := s.newBlockWriter(ctx, destination)
+
+	var total int64
+	for _, source := range sources {
+		n, err := s.copyFrom(ctx, w, source)
+		total += n
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to compose objects")
+		}
+	}
+	if err := w.commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return total, nil
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := s.client.DeleteBlob(ctx, s.container, key); err != nil && !isAzureBlobNotFound(err) {
+		return errors.Wrap(err, "failed to delete object")
+	}
+
+	return nil
+}
+
+func (s *azureStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marker := ""
+	for {
+		blobs, nextMarker, err := s.client.ListBlobs(ctx, s.container, prefix, marker)
+		if err != nil {
+			s.operations.ExpireObjects.Logger.Error("Failed to list Azure container", sglog.Error(err))
+			break // we'll try again later
+		}
+
+		for _, blob := range blobs {
+			if time.Since(blob.CreatedAt) >= maxAge {
+				if err := s.client.DeleteBlob(ctx, s.container, blob.Name); err != nil {
+					s.operations.ExpireObjects.Logger.Error("Failed to delete expired Azure blob",
+						sglog.Error(err),
+						sglog.String("container", s.container),
+						sglog.String("object", blob.Name))
+					continue
+				}
+			}
+		}
+
+		if nextMarker == "" {
+			break
+		}
+		marker = nextMarker
+	}
+
+	return nil
+}
+
+func (s *azureStore) copyFrom(ctx context.Context, w *azureBlockWriter, key string) (_ int64, err error) {
+	rc, err := s.client.GetBlob(ctx, s.container, key)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := rc.Close(); closeErr != nil {
+			err = errors.Append(err, closeErr)
+		}
+	}()
+
+	return w.readFrom(rc)
+}
+
+func (s *azureStore) deleteSources(ctx context.Context, sources []string) error {
+	return goroutine.RunWorkersOverStrings(sources, func(index int, source string) error {
+		if err := s.client.DeleteBlob(ctx, s.container, source); err != nil {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
+
+// azureBlockWriter stages the content of a block blob in uncommitted blocks. The blob
+// becomes visible (replacing any previous content) only once the block list is committed.
+type azureBlockWriter struct {
+	ctx       context.Context
+	client    azureAPI
+	container string
+	key       string
+	blockIDs  []string
+	buf       []byte
+}
+
+func (s *azureStore) newBlockWriter(ctx context.Context, key string) *azureBlockWriter {
+	return &azureBlockWriter{
+		ctx:       ctx,
+		client:    s.client,
+		container: s.container,
+		key:       key,
+	}
+}
+
+// readFrom stages the content of the given reader and returns the number of bytes read.
+// Blocks are filled across calls so that composed objects do not consist of many small
+// blocks.
+func (w *azureBlockWriter) readFrom(r io.Reader) (int64, error) {
+	if w.buf == nil {
+		w.buf = make([]byte, 0, azureBlockSize)
+	}
+
+	var total int64
+	for {
+		n, err := io.ReadFull(r, w.buf[len(w.buf):cap(w.buf)])
+		w.buf = w.buf[:len(w.buf)+n]
+		total += int64(n)
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.flush(); err != nil {
+				return total, err
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+func (w *azureBlockWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	// Block identifiers must be base64-encoded and of equal length within a blob.
+	blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(w.blockIDs))))
+	if err := w.client.PutBlock(w.ctx, w.container, w.key, blockID, w.buf); err != nil {
+		return err
+	}
+
+	w.blockIDs = append(w.blockIDs, blockID)
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func (w *azureBlockWriter) commit() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	return w.client.PutBlockList(w.ctx, w.container, w.key, w.blockIDs)
+}
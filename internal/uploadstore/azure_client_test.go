@@ -0,0 +1,355 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestAzureInit(t *testing.T) {
+	server := newFakeAzureServer(t)
+
+	if err := testAzureClient(t, server.URL, false).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if server.hasContainer("test-container") {
+		t.Fatalf("expected unmanaged container not to be created")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := testAzureClient(t, server.URL, true).Init(context.Background()); err != nil {
+			t.Fatalf("unexpected error initializing client: %s", err)
+		}
+	}
+	if !server.hasContainer("test-container") {
+		t.Fatalf("expected container to be created")
+	}
+}
+
+func TestAzureUploadGet(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	size, err := client.Upload(context.Background(), "uploads/test key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	assertAzureObject(t, client, "uploads/test key", "TEST PAYLOAD")
+
+	if _, err := client.Get(context.Background(), "uploads/missing-key"); err == nil {
+		t.Fatalf("expected an error getting a missing object")
+	}
+}
+
+func TestAzureCompose(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, content := range map[string]string{"test-src1": "A", "test-src2": "BC", "test-src3": "DEF"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(content))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 6 {
+		t.Errorf("unexpected size. want=%d have=%d", 6, size)
+	}
+
+	assertAzureObject(t, client, "test-key", "ABCDEF")
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := client.Get(context.Background(), key); err == nil {
+			t.Errorf("expected source object %q to be deleted", key)
+		}
+	}
+}
+
+func TestAzureExpireObjects(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for _, key := range []string{"uploads/old1", "uploads/old2", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	for _, key := range []string{"uploads/old1", "uploads/old2", "other/old"} {
+		server.setCreatedAt("test-container", key, time.Now().Add(-2*time.Hour))
+	}
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	for _, key := range []string{"uploads/old1", "uploads/old2"} {
+		if _, err := client.Get(context.Background(), key); err == nil {
+			t.Errorf("expected expired object %q to be deleted", key)
+		}
+	}
+	assertAzureObject(t, client, "uploads/new", "TEST PAYLOAD")
+	assertAzureObject(t, client, "other/old", "TEST PAYLOAD")
+}
+
+func TestAzureDelete(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "test-key"); err == nil {
+		t.Fatalf("expected object to be deleted")
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+}
+
+// TestAzurite runs against an Azurite instance when AZURITE_BLOB_ENDPOINT is set, e.g. to
+// http://127.0.0.1:10000/devstoreaccount1.
+func TestAzurite(t *testing.T) {
+	endpoint := os.Getenv("AZURITE_BLOB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("AZURITE_BLOB_ENDPOINT is not set")
+	}
+
+	client := testAzureClient(t, endpoint, true)
+	client.container = fmt.Sprintf("test-%d", time.Now().UnixNano())
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, content := range map[string]string{"uploads/src1": "TEST ", "uploads/src2": "PAYLOAD"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(content))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	if _, err := client.Compose(context.Background(), "uploads/test key", "uploads/src1", "uploads/src2"); err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	assertAzureObject(t, client, "uploads/test key", "TEST PAYLOAD")
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", 0); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "uploads/test key"); err == nil {
+		t.Fatalf("expected expired object to be deleted")
+	}
+}
+
+// azuriteAccountKey is the well-known shared key of the Azurite development account.
+const azuriteAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+func testAzureClient(t *testing.T, endpoint string, manageBucket bool) *azureStore {
+	api, err := newAzureAPIShim(AzureConfig{
+		AccountName: "devstoreaccount1",
+		AccountKey:  azuriteAccountKey,
+		Endpoint:    endpoint,
+	}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return newAzureWithClient(api, "test-container", manageBucket, NewOperations(&observation.TestContext, "test", "brittleStore"))
+}
+
+func assertAzureObject(t *testing.T, client Store, key, expected string) {
+	t.Helper()
+
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting object %q: %s", key, err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object %q: %s", key, err)
+	}
+	if string(contents) != expected {
+		t.Errorf("unexpected contents for object %q. want=%q have=%q", key, expected, contents)
+	}
+}
+
+type fakeAzureBlob struct {
+	content   []byte
+	createdAt time.Time
+}
+
+// fakeAzureServer is an in-memory implementation of the subset of the Blob service
+// REST API used by azureAPIShim.
+type fakeAzureServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	containers map[string]map[string]fakeAzureBlob
+	blocks     map[string][]byte
+}
+
+func newFakeAzureServer(t *testing.T) *fakeAzureServer {
+	s := &fakeAzureServer{
+		containers: map[string]map[string]fakeAzureBlob{},
+		blocks:     map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAzureServer) hasContainer(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.containers[name]
+	return ok
+}
+
+func (s *fakeAzureServer) setCreatedAt(container, name string, createdAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob := s.containers[container][name]
+	blob.createdAt = createdAt
+	s.containers[container][name] = blob
+}
+
+func (s *fakeAzureServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:") || r.Header.Get("x-ms-date") == "" {
+		writeFakeAzureError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+
+	container, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	blobs, containerExists := s.containers[container]
+
+	switch {
+	case name == "" && r.Method == http.MethodPut && query.Get("restype") == "container":
+		if containerExists {
+			writeFakeAzureError(w, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+		s.containers[container] = map[string]fakeAzureBlob{}
+		w.WriteHeader(http.StatusCreated)
+		return
+
+	case !containerExists:
+		writeFakeAzureError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+
+	case name == "" && r.Method == http.MethodGet && query.Get("comp") == "list":
+		s.listBlobs(w, blobs, query.Get("prefix"), query.Get("marker"))
+		return
+
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		content, _ := io.ReadAll(r.Body)
+		s.blocks[container+"/"+name+"/"+query.Get("blockid")] = content
+		w.WriteHeader(http.StatusCreated)
+		return
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList azureBlockList
+		if err := xml.NewDecoder(r.Body).Decode(&blockList); err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+		var content []byte
+		for _, blockID := range blockList.Latest {
+			block, ok := s.blocks[container+"/"+name+"/"+blockID]
+			if !ok {
+				writeFakeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			content = append(content, block...)
+		}
+		blobs[name] = fakeAzureBlob{content: content, createdAt: time.Now()}
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	blob, ok := blobs[name]
+	if !ok {
+		writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		_, _ = w.Write(blob.content)
+	case http.MethodDelete:
+		delete(blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeFakeAzureError(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+// listBlobs writes a page of at most two blobs so that pagination is exercised.
+func (s *fakeAzureServer) listBlobs(w http.ResponseWriter, blobs map[string]fakeAzureBlob, prefix, marker string) {
+	var names []string
+	for name := range blobs {
+		if strings.HasPrefix(name, prefix) && name >= marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	nextMarker := ""
+	if len(names) > 2 {
+		nextMarker = names[2]
+		names = names[:2]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+	for _, name := range names {
+		buf.WriteString("<Blob><Name>")
+		_ = xml.EscapeText(&buf, []byte(name))
+		fmt.Fprintf(&buf, "</Name><Properties><Creation-Time>%s</Creation-Time></Properties></Blob>", blobs[name].createdAt.UTC().Format(http.TimeFormat))
+	}
+	fmt.Fprintf(&buf, "</Blobs><NextMarker>%s</NextMarker></EnumerationResults>", nextMarker)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeFakeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>%s</Code><Message>fake error</Message></Error>`, code)
+}
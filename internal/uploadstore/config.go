@@ -14,6 +14,8 @@ type Config struct {
 	TTL          time.Duration
 	S3           S3Config
 	GCS          GCSConfig
+	Azure        AzureConfig
+	Filesystem   FilesystemConfig
 }
 
 func normalizeConfig(t Config) Config {
@@ -0,0 +1,285 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type filesystemStore struct {
+	root         string
+	manageBucket bool
+	operations   *Operations
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Root is the directory (usually on a shared volume) under which a directory
+	// named after the bucket holds the stored objects.
+	Root string
+}
+
+// filesystemTempPrefix is the name prefix of the temporary files written by Upload
+// and Compose before they are atomically moved into place.
+const filesystemTempPrefix = ".uploadstore-"
+
+// newFilesystemFromConfig creates a new store backed by a local filesystem.
+func newFilesystemFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Root == "" {
+		return nil, errors.New("no filesystem root supplied")
+	}
+
+	return newFilesystemWithRoot(filepath.Join(config.Filesystem.Root, config.Bucket), config.ManageBucket, operations), nil
+}
+
+func newFilesystemWithRoot(root string, manageBucket bool, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		root:         filepath.Clean(root),
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.root, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create bucket")
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	_, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	_, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.write(key, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	_, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sources); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	n, err := s.write(destination, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, source := range sources {
+			n, err := s.copyFrom(w, source)
+			total += n
+			if err != nil {
+				return total, err
+			}
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	_, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete object")
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	_, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	walkErr := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), filesystemTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if time.Since(info.ModTime()) >= maxAge {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				s.operations.ExpireObjects.Logger.Error("Failed to delete expired filesystem object",
+					sglog.Error(err),
+					sglog.String("root", s.root),
+					sglog.String("object", key))
+			}
+		}
+
+		return nil
+	})
+	if walkErr != nil && !os.IsNotExist(walkErr) {
+		s.operations.ExpireObjects.Logger.Error("Failed to walk filesystem bucket", sglog.Error(walkErr))
+		// we'll try again later
+	}
+
+	return nil
+}
+
+// path returns the path of the file holding the object with the given key. Keys that
+// would resolve to a path outside of the bucket directory are rejected.
+func (s *filesystemStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", errors.Newf("invalid object key %q", key)
+	}
+
+	return path, nil
+}
+
+// write creates the object with the given key from the content written by the given
+// function. The content is written to a temporary file first so that readers never
+// observe a partially written object.
+func (s *filesystemStore) write(key string, f func(w io.Writer) (int64, error)) (_ int64, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(dir, filesystemTempPrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	n, err := f(tmp)
+	if err != nil {
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) copyFrom(w io.Writer, key string) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
+
+func (s *filesystemStore) deleteSources(sources []string) (err error) {
+	for _, source := range sources {
+		path, pathErr := s.path(source)
+		if pathErr != nil {
+			err = errors.Append(err, pathErr)
+			continue
+		}
+
+		if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
+			err = errors.Append(err, errors.Wrap(removeErr, "failed to delete source object"))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,151 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+
+	if err := testFilesystemClient(root, false).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Fatalf("expected unmanaged bucket directory not to be created")
+	}
+
+	if err := testFilesystemClient(root, true).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		t.Fatalf("expected bucket directory to be created")
+	}
+}
+
+func TestFilesystemUploadGet(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	size, err := client.Upload(context.Background(), "uploads/test-key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	assertFilesystemObject(t, client, "uploads/test-key", "TEST PAYLOAD")
+
+	if _, err := client.Get(context.Background(), "uploads/missing-key"); err == nil {
+		t.Fatalf("expected an error getting a missing object")
+	}
+}
+
+func TestFilesystemInvalidKey(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	for _, key := range []string{"../test-key", "uploads/../../test-key", ""} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader(nil)); err == nil {
+			t.Errorf("expected an error uploading object with key %q", key)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	for key, content := range map[string]string{"test-src1": "A", "test-src2": "BC", "test-src3": "DEF"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(content))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 6 {
+		t.Errorf("unexpected size. want=%d have=%d", 6, size)
+	}
+
+	assertFilesystemObject(t, client, "test-key", "ABCDEF")
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := client.Get(context.Background(), key); err == nil {
+			t.Errorf("expected source object %q to be deleted", key)
+		}
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "test-key"); err == nil {
+		t.Fatalf("expected object to be deleted")
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+
+	for _, key := range []string{"uploads/old", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"uploads/old", "other/old"} {
+		path, _ := client.path(key)
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("unexpected error changing modification time: %s", err)
+		}
+	}
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if _, err := client.Get(context.Background(), "uploads/old"); err == nil {
+		t.Errorf("expected expired object to be deleted")
+	}
+	assertFilesystemObject(t, client, "uploads/new", "TEST PAYLOAD")
+	assertFilesystemObject(t, client, "other/old", "TEST PAYLOAD")
+}
+
+func testFilesystemClient(root string, manageBucket bool) *filesystemStore {
+	return newFilesystemWithRoot(root, manageBucket, NewOperations(&observation.TestContext, "test", "brittleStore"))
+}
+
+func assertFilesystemObject(t *testing.T, client Store, key, expected string) {
+	t.Helper()
+
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting object %q: %s", key, err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object %q: %s", key, err)
+	}
+	if string(contents) != expected {
+		t.Errorf("unexpected contents for object %q. want=%q have=%q", key, expected, contents)
+	}
+}
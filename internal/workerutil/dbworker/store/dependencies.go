@@ -0,0 +1,323 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrDependencyCycle is returned by AddDependencies when adding the given edges would make a
+// record (transitively) depend on itself.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// DependencyStore is the persistence layer for edges between work records of a Store configured
+// with a `DependencyTableName`. It is used by the code that enqueues records, usually within the
+// same transaction that inserts them.
+type DependencyStore interface {
+	basestore.ShareableStore
+
+	// With creates a new instance of DependencyStore using the underlying database
+	// handle of the other ShareableStore.
+	With(other basestore.ShareableStore) DependencyStore
+
+	// AddDependencies records that the record with the given identifier must not be dequeued
+	// before each of the given parent records has completed. If one of the parents has already
+	// failed or been canceled, the record (and its own dependents) are failed or canceled as
+	// well. ErrDependencyCycle is returned if a parent already depends on the record.
+	AddDependencies(ctx context.Context, recordID int, parentIDs []int) error
+
+	// FailDependents moves the queued and errored (transitive) dependents of the given records
+	// into the failed or canceled state of that record. The store does this itself for records
+	// it marks as failed; this method must be called for records moved into a terminal state
+	// by other means, such as a cancellation by the user.
+	FailDependents(ctx context.Context, ids []int) error
+}
+
+type dependencyStore[T workerutil.Record] struct {
+	*store[T]
+}
+
+var _ DependencyStore = &dependencyStore[workerutil.Record]{}
+
+// NewDependencyStore creates a DependencyStore over the tables described by the given options.
+// The store constructor will fail if no `DependencyTableName` is supplied.
+func NewDependencyStore[T workerutil.Record](observationCtx *observation.Context, handle basestore.TransactableHandle, options Options[T]) DependencyStore {
+	if options.DependencyTableName == "" {
+		panic("no dependency table name supplied to github.com/sourcegraph/sourcegraph/internal/dbworker/store:NewDependencyStore")
+	}
+
+	return &dependencyStore[T]{store: newStore(observationCtx, handle, options)}
+}
+
+// With creates a new DependencyStore with the given basestore.Shareable store as the
+// underlying basestore.Store.
+func (s *dependencyStore[T]) With(other basestore.ShareableStore) DependencyStore {
+	return &dependencyStore[T]{store: s.store.With(other).(*store[T])}
+}
+
+// AddDependencies records that the record with the given identifier must not be dequeued before
+// each of the given parent records has completed.
+func (s *dependencyStore[T]) AddDependencies(ctx context.Context, recordID int, parentIDs []int) (err error) {
+	ctx, _, endObservation := s.operations.addDependencies.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("recordID", recordID),
+		otlog.Int("numParentIDs", len(parentIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(parentIDs) == 0 {
+		return nil
+	}
+	for _, parentID := range parentIDs {
+		if parentID == recordID {
+			return ErrDependencyCycle
+		}
+	}
+
+	txBase, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = txBase.Done(err) }()
+	tx := s.store.With(txBase).(*store[T])
+
+	// Serialize edge insertions into the same dependency table so that two concurrent
+	// calls cannot each pass the cycle check below and together introduce a cycle.
+	if _, err := locker.NewWith(tx, "workerutil_dependencies").LockInTransaction(ctx, locker.StringKey(s.options.DependencyTableName), true); err != nil {
+		return err
+	}
+
+	cycle, _, err := basestore.ScanFirstBool(tx.Query(ctx, tx.formatQuery(
+		dependencyCycleQuery,
+		quote(s.options.DependencyTableName),
+		recordID,
+		quote(s.options.DependencyTableName),
+		pq.Array(parentIDs),
+	)))
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrDependencyCycle
+	}
+
+	if err := tx.Exec(ctx, tx.formatQuery(
+		addDependenciesQuery,
+		quote(s.options.DependencyTableName),
+		recordID,
+		pq.Array(parentIDs),
+	)); err != nil {
+		return err
+	}
+
+	_, err = tx.failDependents(ctx, parentIDs)
+	return err
+}
+
+// FailDependents moves the queued and errored (transitive) dependents of the given records into
+// the failed or canceled state of that record.
+func (s *dependencyStore[T]) FailDependents(ctx context.Context, ids []int) (err error) {
+	ctx, _, endObservation := s.operations.failDependents.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numIDs", len(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, err = s.failDependents(ctx, ids)
+	return err
+}
+
+const dependencyCycleQuery = `
+WITH RECURSIVE descendants(record_id) AS (
+	SELECT d.record_id FROM %s d WHERE d.parent_id = %s
+	UNION
+	SELECT d.record_id FROM %s d JOIN descendants ON descendants.record_id = d.parent_id
+)
+SELECT EXISTS (SELECT 1 FROM descendants WHERE record_id = ANY(%s))
+`
+
+const addDependenciesQuery = `
+INSERT INTO %s (record_id, parent_id)
+SELECT %s, parent_id FROM unnest(%s::integer[]) AS parent_id
+ON CONFLICT DO NOTHING
+`
+
+// makeDependencyCondition returns a dequeue condition that excludes records with a parent record
+// that has not yet completed. This method returns nil if no dependency table has been configured.
+func (s *store[T]) makeDependencyCondition() *sqlf.Query {
+	if s.options.DependencyTableName == "" {
+		return nil
+	}
+
+	return s.formatQuery(
+		dependencyConditionQuery,
+		quote(s.options.DependencyTableName),
+		quote(s.options.TableName),
+		quote(extractTableName(s.options.ViewName)),
+	)
+}
+
+const dependencyConditionQuery = `
+NOT EXISTS (
+	SELECT 1
+	FROM %s dependency
+	JOIN %s parent ON parent.{id} = dependency.parent_id
+	WHERE
+		dependency.record_id = %s.{id} AND
+		parent.{state} != 'completed'
+)
+`
+
+// failDependents moves the queued and errored (transitive) dependents of the given records into
+// the state of that record, if it is failed or canceled. This method returns the number of records
+// that were updated.
+func (s *store[T]) failDependents(ctx context.Context, parentIDs []int) (int, error) {
+	if s.options.DependencyTableName == "" || len(parentIDs) == 0 {
+		return 0, nil
+	}
+
+	ids, err := basestore.ScanInts(s.Query(ctx, s.formatQuery(
+		failDependentsQuery,
+		quote(s.options.DependencyTableName),
+		quote(s.options.TableName),
+		pq.Array(parentIDs),
+		quote(s.options.DependencyTableName),
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+const failDependentsQuery = `
+WITH RECURSIVE dependents(record_id, parent_id, root_state) AS (
+	SELECT d.record_id, d.parent_id, parent.{state}
+	FROM %s d
+	JOIN %s parent ON parent.{id} = d.parent_id
+	WHERE
+		parent.{state} IN ('failed', 'canceled') AND
+		d.parent_id = ANY(%s)
+	UNION
+	SELECT d.record_id, d.parent_id, dependents.root_state
+	FROM %s d
+	JOIN dependents ON dependents.record_id = d.parent_id
+),
+candidates AS (
+	SELECT DISTINCT ON (dependents.record_id)
+		dependents.record_id,
+		dependents.root_state,
+		'parent record ' || dependents.parent_id || ' is ' || dependents.root_state AS message
+	FROM dependents
+	JOIN %s r ON r.{id} = dependents.record_id
+	WHERE r.{state} IN ('queued', 'errored')
+	ORDER BY dependents.record_id, dependents.parent_id
+)
+UPDATE %s
+SET
+	{state} = candidates.root_state,
+	{finished_at} = clock_timestamp(),
+	{failure_message} = candidates.message,
+	{execution_logs} = {execution_logs} || json_build_object(
+		'key', 'dependencies',
+		'command', '[]'::json,
+		'startTime', clock_timestamp(),
+		'exitCode', 1,
+		'out', candidates.message,
+		'durationMs', 0
+	)
+FROM candidates
+WHERE
+	{id} = candidates.record_id AND
+	{state} IN ('queued', 'errored')
+RETURNING {id}
+`
+
+// addDependencyLogEntry adds an execution log entry listing the parents and children of the
+// given (just dequeued) record, if it has any, so that the dependency graph is visible next to
+// the output of the handler.
+func (s *store[T]) addDependencyLogEntry(ctx context.Context, id int, workerHostname string) error {
+	if s.options.DependencyTableName == "" {
+		return nil
+	}
+
+	start := s.now()
+
+	type relative struct {
+		relation string
+		id       int
+		state    string
+	}
+	relatives, err := basestore.NewSliceScanner(func(scanner dbutil.Scanner) (r relative, err error) {
+		err = scanner.Scan(&r.relation, &r.id, &r.state)
+		return r, err
+	})(s.Query(ctx, s.formatQuery(
+		dependencyRelativesQuery,
+		quote(s.options.DependencyTableName),
+		quote(s.options.TableName),
+		id,
+		quote(s.options.DependencyTableName),
+		quote(s.options.TableName),
+		id,
+	)))
+	if err != nil {
+		return err
+	}
+	if len(relatives) == 0 {
+		return nil
+	}
+
+	var out strings.Builder
+	for _, r := range relatives {
+		fmt.Fprintf(&out, "%s record %d: %s\n", r.relation, r.id, r.state)
+	}
+
+	exitCode := 0
+	durationMs := int(s.now().Sub(start).Milliseconds())
+	_, err = s.AddExecutionLogEntry(ctx, id, workerutil.ExecutionLogEntry{
+		Key:        "dependencies",
+		Command:    []string{},
+		StartTime:  start,
+		ExitCode:   &exitCode,
+		Out:        out.String(),
+		DurationMs: &durationMs,
+	}, ExecutionLogEntryOptions{
+		WorkerHostname: workerHostname,
+		State:          "processing",
+	})
+	return err
+}
+
+const dependencyRelativesQuery = `
+(
+	SELECT 'parent' AS relation, d.parent_id AS id, r.{state} AS state
+	FROM %s d
+	JOIN %s r ON r.{id} = d.parent_id
+	WHERE d.record_id = %s
+	UNION ALL
+	SELECT 'child' AS relation, d.record_id AS id, r.{state} AS state
+	FROM %s d
+	JOIN %s r ON r.{id} = d.record_id
+	WHERE d.parent_id = %s
+)
+ORDER BY relation DESC, id
+`
+
+// logDependencyError logs a failure to maintain the dependency graph of the given record. Such
+// failures do not fail the enclosing operation, as the record's state has already been updated.
+func (s *store[T]) logDependencyError(message string, id int, err error) {
+	s.logger.Error(message, log.Int("recordID", id), log.Error(err))
+}
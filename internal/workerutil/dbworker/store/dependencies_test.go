@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestStoreDequeueDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(1, 'queued', NOW() - '1 minute'::interval),
+			(2, 'queued', NOW() - '2 minute'::interval),
+			(3, 'queued', NOW() - '3 minute'::interval),
+			(4, 'completed', NOW() - '4 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.DependencyTableName = "workerutil_test_dependencies"
+	store := testStore(db, options)
+	dependencyStore := NewDependencyStore(&observation.TestContext, basestore.NewHandleWithDB(log.NoOp(), db, sql.TxOptions{}), options)
+
+	// 3 depends on 2, which depends on 1 and the already completed 4
+	if err := dependencyStore.AddDependencies(context.Background(), 2, []int{1, 4}); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+	if err := dependencyStore.AddDependencies(context.Background(), 3, []int{2}); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+
+	for _, expectedID := range []int{1, 2, 3} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+
+		if _, ok, err := store.Dequeue(context.Background(), "test", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		} else if ok {
+			t.Fatalf("did not expect a dequeueable record while record %d is processing", expectedID)
+		}
+
+		if _, err := store.MarkComplete(context.Background(), expectedID, MarkFinalOptions{}); err != nil {
+			t.Fatalf("unexpected error marking record as complete: %s", err)
+		}
+	}
+
+	var out string
+	if err := db.QueryRowContext(context.Background(), `SELECT execution_logs[1]->>'out' FROM workerutil_test WHERE id = 2`).Scan(&out); err != nil {
+		t.Fatalf("unexpected error querying execution logs: %s", err)
+	}
+	if expected := "parent record 1: completed\nparent record 4: completed\nchild record 3: queued\n"; out != expected {
+		t.Errorf("unexpected dependency log output. want=%q have=%q", expected, out)
+	}
+}
+
+func TestStoreMarkFailedDependents(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'processing'),
+			(2, 'queued'),
+			(3, 'errored'),
+			(4, 'queued');
+
+		INSERT INTO workerutil_test_dependencies (record_id, parent_id)
+		VALUES
+			(2, 1),
+			(3, 2)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.DependencyTableName = "workerutil_test_dependencies"
+
+	marked, err := testStore(db, options).MarkFailed(context.Background(), 1, "new message", MarkFinalOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error marking record as failed: %s", err)
+	}
+	if !marked {
+		t.Fatalf("expected record to be marked")
+	}
+
+	expected := map[int]struct {
+		state          string
+		failureMessage string
+	}{
+		1: {"failed", "new message"},
+		2: {"failed", "parent record 1 is failed"},
+		3: {"failed", "parent record 2 is failed"},
+		4: {"queued", ""},
+	}
+	for id, e := range expected {
+		var state string
+		var failureMessage sql.NullString
+		if err := db.QueryRowContext(context.Background(), `SELECT state, failure_message FROM workerutil_test WHERE id = $1`, id).Scan(&state, &failureMessage); err != nil {
+			t.Fatalf("unexpected error querying record: %s", err)
+		}
+		if state != e.state {
+			t.Errorf("unexpected state for record %d. want=%q have=%q", id, e.state, state)
+		}
+		if failureMessage.String != e.failureMessage {
+			t.Errorf("unexpected failure message for record %d. want=%q have=%q", id, e.failureMessage, failureMessage.String)
+		}
+	}
+}
+
+func TestStoreResetStalledFailedDependents(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, last_heartbeat_at, num_resets)
+		VALUES
+			(1, 'processing', NOW() - '1 hour'::interval, 5),
+			(2, 'queued', NULL, 0),
+			(3, 'failed', NULL, 0),
+			(4, 'queued', NULL, 0);
+
+		INSERT INTO workerutil_test_dependencies (record_id, parent_id)
+		VALUES
+			(2, 1),
+			(4, 3)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.DependencyTableName = "workerutil_test_dependencies"
+	options.MaxNumResets = 5
+
+	if _, _, err := testStore(db, options).ResetStalled(context.Background()); err != nil {
+		t.Fatalf("unexpected error resetting stalled records: %s", err)
+	}
+
+	// Only the dependents of the record failed by this reset are updated
+	for id, expectedState := range map[int]string{2: "failed", 4: "queued"} {
+		var state string
+		if err := db.QueryRowContext(context.Background(), `SELECT state FROM workerutil_test WHERE id = $1`, id).Scan(&state); err != nil {
+			t.Fatalf("unexpected error querying record: %s", err)
+		}
+		if state != expectedState {
+			t.Errorf("unexpected state for record %d. want=%q have=%q", id, expectedState, state)
+		}
+	}
+}
+
+func TestDependencyStoreFailDependents(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'canceled'),
+			(2, 'queued'),
+			(3, 'errored');
+
+		INSERT INTO workerutil_test_dependencies (record_id, parent_id)
+		VALUES
+			(2, 1),
+			(3, 2)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.DependencyTableName = "workerutil_test_dependencies"
+	store := NewDependencyStore(&observation.TestContext, basestore.NewHandleWithDB(log.NoOp(), db, sql.TxOptions{}), options)
+
+	if err := store.FailDependents(context.Background(), []int{1}); err != nil {
+		t.Fatalf("unexpected error failing dependents: %s", err)
+	}
+
+	for _, id := range []int{2, 3} {
+		var state string
+		if err := db.QueryRowContext(context.Background(), `SELECT state FROM workerutil_test WHERE id = $1`, id).Scan(&state); err != nil {
+			t.Fatalf("unexpected error querying record: %s", err)
+		}
+		if state != "canceled" {
+			t.Errorf("unexpected state for record %d. want=%q have=%q", id, "canceled", state)
+		}
+	}
+}
+
+func TestDependencyStoreAddDependenciesCycle(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'queued'),
+			(2, 'queued'),
+			(3, 'queued')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.DependencyTableName = "workerutil_test_dependencies"
+	store := NewDependencyStore(&observation.TestContext, basestore.NewHandleWithDB(log.NoOp(), db, sql.TxOptions{}), options)
+
+	if err := store.AddDependencies(context.Background(), 2, []int{1}); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+	if err := store.AddDependencies(context.Background(), 3, []int{2}); err != nil {
+		t.Fatalf("unexpected error adding dependencies: %s", err)
+	}
+
+	for _, parentIDs := range [][]int{{1}, {3}, {2, 3}} {
+		if err := store.AddDependencies(context.Background(), 1, parentIDs); err != ErrDependencyCycle {
+			t.Errorf("unexpected error for parents %v. want=%q have=%v", parentIDs, ErrDependencyCycle, err)
+		}
+	}
+
+	var numEdges int
+	if err := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM workerutil_test_dependencies`).Scan(&numEdges); err != nil {
+		t.Fatalf("unexpected error counting edges: %s", err)
+	}
+	if numEdges != 2 {
+		t.Errorf("unexpected number of edges. want=%d have=%d", 2, numEdges)
+	}
+}
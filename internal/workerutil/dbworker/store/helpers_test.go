@@ -86,12 +86,24 @@ func setupStoreTest(t *testing.T) *sql.DB {
 			created_at        timestamp with time zone NOT NULL default NOW(),
 			execution_logs    json[],
 			worker_hostname   text NOT NULL default '',
-			cancel            boolean NOT NULL default false
+			cancel            boolean NOT NULL default false,
+			partition_key     text,
+			priority          integer NOT NULL default 0
 		)
 	`); err != nil {
 		t.Fatalf("unexpected error creating test table: %s", err)
 	}
 
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS workerutil_test_dependencies (
+			record_id integer NOT NULL,
+			parent_id integer NOT NULL,
+			PRIMARY KEY (record_id, parent_id)
+		)
+	`); err != nil {
+		t.Fatalf("unexpected error creating test dependency table: %s", err)
+	}
+
 	if _, err := db.Exec(`
 		CREATE OR REPLACE VIEW workerutil_test_view AS (
 			SELECT w.*, (w.id * 7) as new_field FROM workerutil_test w
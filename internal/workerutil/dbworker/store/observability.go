@@ -9,8 +9,10 @@ import (
 )
 
 type operations struct {
+	addDependencies         *observation.Operation
 	addExecutionLogEntry    *observation.Operation
 	dequeue                 *observation.Operation
+	failDependents          *observation.Operation
 	heartbeat               *observation.Operation
 	markComplete            *observation.Operation
 	markErrored             *observation.Operation
@@ -58,8 +60,10 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 	}
 
 	return &operations{
+		addDependencies:         op("AddDependencies"),
 		addExecutionLogEntry:    op("AddExecutionLogEntry"),
 		dequeue:                 op("Dequeue"),
+		failDependents:          op("FailDependents"),
 		heartbeat:               op("Heartbeat"),
 		markComplete:            op("MarkComplete"),
 		markErrored:             op("MarkErrored"),
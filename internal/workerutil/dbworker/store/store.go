@@ -132,7 +132,7 @@ type Store[T workerutil.Record] interface {
 	// queued state. In order to prevent input that continually crashes worker instances, records that have been reset
 	// more than `MaxNumResets` times will be marked as failed. This method returns a pair of maps from record
 	// identifiers the age of the record's last heartbeat timestamp for each record reset to queued and failed states,
-	// respectively.
+	// respectively. If a dependency table is configured, the dependents of records marked as failed are failed as well.
 	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
 }
 
@@ -221,6 +221,38 @@ type Options[T workerutil.Record] struct {
 	// supplied.
 	OrderByExpression *sqlf.Query
 
+	// PartitionExpression is an optional SQL expression (such as a repository, user, or namespace
+	// identifier) by which candidate records are partitioned when selecting the next batch of work to
+	// perform. If supplied, records are dequeued round-robin across partitions so that a partition with
+	// a large number of queued records does not starve the others: a record is not selected while
+	// another partition has fewer records in the processing state, counting the record itself. Within a
+	// partition, records are selected by `OrderByExpression`. This expression may use the alias provided
+	// in `ViewName`, if one was supplied.
+	//
+	// Fair-share dequeueing ranks every queued record on each dequeue, so the state column should be
+	// indexed when this option is used on large tables.
+	PartitionExpression *sqlf.Query
+
+	// PriorityExpression is an optional SQL expression evaluating to an integer priority tier. If
+	// supplied, records of a higher tier are always selected before records of a lower tier. Within a
+	// tier, records are selected by `PartitionExpression` and `OrderByExpression`. This expression may
+	// use the alias provided in `ViewName`, if one was supplied.
+	PriorityExpression *sqlf.Query
+
+	// DependencyTableName is an optional name of a table containing edges between work records in
+	// `TableName`. If supplied, a record is not dequeued until all of its parent records are completed,
+	// and a record is moved into the failed (or canceled) state when one of its parents becomes failed
+	// (or canceled). Parents and children are recorded in the execution logs of a dequeued record.
+	//
+	// The dependency table must have the following columns and types:
+	//
+	//   - record_id: integer not null, referencing the dependent (child) record
+	//   - parent_id: integer not null, referencing the record that must complete first
+	//
+	// with a primary key on (record_id, parent_id) and an index on parent_id. Edges are inserted with
+	// the AddDependencies method of the store returned by NewDependencyStore.
+	DependencyTableName string
+
 	// ColumnExpressions are the target columns provided to the query when selecting a job record. These
 	// expressions may use the alias provided in `ViewName`, if one was supplied.
 	ColumnExpressions []*sqlf.Query
@@ -489,6 +521,10 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 	now := s.now()
 	retryAfter := int(s.options.RetryAfter / time.Second)
 
+	if dependencyCondition := s.makeDependencyCondition(); dependencyCondition != nil {
+		conditions = append(conditions[:len(conditions):len(conditions)], dependencyCondition)
+	}
+
 	var (
 		processingExpr     = sqlf.Sprintf("%s", "processing")
 		nowTimestampExpr   = sqlf.Sprintf("%s::timestamp", now)
@@ -510,14 +546,7 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 
 	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
-		quote(s.options.ViewName),
-		now,
-		retryAfter,
-		now,
-		retryAfter,
-		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		s.makeDequeueCandidatesQuery(now, retryAfter, conditions),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
@@ -536,30 +565,15 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("recordID", records[0].RecordID()))
 
+	if err := s.addDependencyLogEntry(ctx, records[0].RecordID(), workerHostname); err != nil {
+		s.logDependencyError("failed to add dependency execution log entry", records[0].RecordID(), err)
+	}
+
 	return records[0], true, nil
 }
 
 const dequeueQuery = `
-WITH potential_candidates AS (
-	SELECT
-		{id} AS candidate_id,
-		ROW_NUMBER() OVER (ORDER BY %s) AS order
-	FROM %s
-	WHERE
-		(
-			(
-				{state} = 'queued' AND
-				({process_after} IS NULL OR {process_after} <= %s)
-			) OR (
-				%s > 0 AND
-				{state} = 'errored' AND
-				%s - {finished_at} > (%s * '1 second'::interval)
-			)
-		)
-		%s
-	ORDER BY %s
-	LIMIT 50
-),
+WITH %s,
 candidate AS (
 	SELECT
 		{id} FROM %s
@@ -587,6 +601,119 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// makeDequeueCandidatesQuery constructs the common table expression(s) defining potential_candidates,
+// the set of records the dequeue query attempts to lock, in order of preference. Fair-share ordering is
+// used only when a partition or priority expression has been configured.
+func (s *store[T]) makeDequeueCandidatesQuery(now time.Time, retryAfter int, conditions []*sqlf.Query) *sqlf.Query {
+	if s.options.PartitionExpression == nil && s.options.PriorityExpression == nil {
+		return s.formatQuery(
+			dequeueCandidatesQuery,
+			s.options.OrderByExpression,
+			quote(s.options.ViewName),
+			now,
+			retryAfter,
+			now,
+			retryAfter,
+			makeConditionSuffix(conditions),
+			s.options.OrderByExpression,
+		)
+	}
+
+	partitionExpression := s.options.PartitionExpression
+	if partitionExpression == nil {
+		partitionExpression = sqlf.Sprintf("NULL")
+	}
+	priorityExpression := s.options.PriorityExpression
+	if priorityExpression == nil {
+		priorityExpression = sqlf.Sprintf("0")
+	}
+
+	return s.formatQuery(
+		fairDequeueCandidatesQuery,
+		// processing_partitions
+		partitionExpression,
+		quote(s.options.ViewName),
+		// potential_candidates
+		priorityExpression,
+		priorityExpression,
+		partitionExpression,
+		s.options.OrderByExpression,
+		s.options.OrderByExpression,
+		quote(s.options.ViewName),
+		partitionExpression,
+		now,
+		retryAfter,
+		now,
+		retryAfter,
+		makeConditionSuffix(conditions),
+	)
+}
+
+const dequeueCandidatesQuery = `
+potential_candidates AS (
+	SELECT
+		{id} AS candidate_id,
+		ROW_NUMBER() OVER (ORDER BY %s) AS order
+	FROM %s
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval)
+			)
+		)
+		%s
+	ORDER BY %s
+	LIMIT 50
+)
+`
+
+const fairDequeueCandidatesQuery = `
+processing_partitions AS (
+	SELECT
+		%s AS dequeue_partition_key,
+		COUNT(*) AS dequeue_num_processing
+	FROM %s
+	WHERE {state} = 'processing'
+	GROUP BY 1
+),
+ranked_candidates AS (
+	SELECT
+		{id} AS candidate_id,
+		%s AS priority,
+		-- The nth queued record of a partition with m processing records is dequeued in
+		-- the (n+m)th round of the round-robin across partitions.
+		COALESCE(pp.dequeue_num_processing, 0) + ROW_NUMBER() OVER (PARTITION BY %s, %s ORDER BY %s) AS partition_rank,
+		ROW_NUMBER() OVER (ORDER BY %s) AS global_rank
+	FROM %s
+	LEFT JOIN processing_partitions pp ON pp.dequeue_partition_key IS NOT DISTINCT FROM %s
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval)
+			)
+		)
+		%s
+),
+potential_candidates AS (
+	SELECT
+		candidate_id,
+		ROW_NUMBER() OVER (ORDER BY priority DESC, partition_rank, global_rank) AS order
+	FROM ranked_candidates
+	ORDER BY priority DESC, partition_rank, global_rank
+	LIMIT 50
+)
+`
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -880,7 +1007,15 @@ func (s *store[T]) MarkErrored(ctx context.Context, id int, failureMessage strin
 
 	q := s.formatQuery(markErroredQuery, quote(s.options.TableName), s.options.MaxNumRetries, failureMessage, sqlf.Join(conds, "AND"))
 	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
-	return ok, err
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	if _, err := s.failDependents(ctx, []int{id}); err != nil {
+		s.logDependencyError("failed to fail dependents of errored record", id, err)
+	}
+
+	return true, nil
 }
 
 const markErroredQuery = `
@@ -910,7 +1045,15 @@ func (s *store[T]) MarkFailed(ctx context.Context, id int, failureMessage string
 
 	q := s.formatQuery(markFailedQuery, quote(s.options.TableName), failureMessage, sqlf.Join(conds, "AND"))
 	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
-	return ok, err
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	if _, err := s.failDependents(ctx, []int{id}); err != nil {
+		s.logDependencyError("failed to fail dependents of failed record", id, err)
+	}
+
+	return true, nil
 }
 
 const markFailedQuery = `
@@ -929,7 +1072,7 @@ const defaultResetFailureMessage = "job processor died while handling this messa
 // queued state. In order to prevent input that continually crashes worker instances, records that have been reset
 // more than `MaxNumResets` times will be marked as failed. This method returns a pair of maps from record
 // identifiers the age of the record's last heartbeat timestamp for each record reset to queued and failed states,
-// respectively.
+// respectively. If a dependency table is configured, the dependents of records marked as failed are failed as well.
 func (s *store[T]) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error) {
 	ctx, trace, endObservation := s.operations.resetStalled.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -975,6 +1118,19 @@ func (s *store[T]) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs,
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numErroredIDs", len(failedLastHeartbeatsByIDs)))
 
+	if s.options.DependencyTableName != "" && len(failedLastHeartbeatsByIDs) > 0 {
+		failedIDs := make([]int, 0, len(failedLastHeartbeatsByIDs))
+		for id := range failedLastHeartbeatsByIDs {
+			failedIDs = append(failedIDs, id)
+		}
+
+		numFailedDependents, err := s.failDependents(ctx, failedIDs)
+		if err != nil {
+			return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
+		}
+		trace.AddEvent("TODO Domain Owner", attribute.Int("numFailedDependents", numFailedDependents))
+	}
+
 	return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, nil
 }
 
@@ -349,6 +349,100 @@ func TestStoreDequeueConcurrent(t *testing.T) {
 	}
 }
 
+func TestStoreDequeueFairShare(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, partition_key)
+		VALUES
+			(1, 'queued', NOW() - '6 minute'::interval, 'a'),
+			(2, 'queued', NOW() - '5 minute'::interval, 'a'),
+			(3, 'queued', NOW() - '4 minute'::interval, 'a'),
+			(4, 'queued', NOW() - '3 minute'::interval, 'b'),
+			(5, 'queued', NOW() - '2 minute'::interval, 'c'),
+			(6, 'processing', NOW() - '7 minute'::interval, 'c')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.PartitionExpression = sqlf.Sprintf("workerutil_test.partition_key")
+	store := testStore(db, options)
+
+	// Partition c already has a record being processed, so its queued record
+	// is dequeued after the first record of every other partition.
+	for _, expectedID := range []int{1, 4, 2, 5, 3} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+
+	_, ok, err := store.Dequeue(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ok {
+		t.Fatalf("did not expect a dequeueable record")
+	}
+}
+
+func TestStoreDequeuePriority(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, partition_key, priority)
+		VALUES
+			(1, 'queued', NOW() - '5 minute'::interval, 'a', 0),
+			(2, 'queued', NOW() - '4 minute'::interval, 'a', 1),
+			(3, 'queued', NOW() - '3 minute'::interval, 'a', 1),
+			(4, 'queued', NOW() - '2 minute'::interval, 'b', 1),
+			(5, 'queued', NOW() - '1 minute'::interval, 'b', 2)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.PartitionExpression = sqlf.Sprintf("workerutil_test.partition_key")
+	options.PriorityExpression = sqlf.Sprintf("workerutil_test.priority")
+	store := testStore(db, options)
+
+	// Higher tiers are dequeued first. Once record 5 is processing, partition b has
+	// a record in flight, so record 4 ranks level with the second record of partition
+	// a and the older record 3 is selected first.
+	for _, expectedID := range []int{5, 2, 3, 4, 1} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
+func TestStoreDequeuePriorityFairShare(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, partition_key, priority)
+		VALUES
+			(1, 'queued', NOW() - '7 minute'::interval, 'c', 0),
+			(2, 'queued', NOW() - '6 minute'::interval, 'a', 1),
+			(3, 'queued', NOW() - '5 minute'::interval, 'a', 1),
+			(4, 'queued', NOW() - '4 minute'::interval, 'a', 1),
+			(5, 'queued', NOW() - '3 minute'::interval, 'b', 1),
+			(6, 'queued', NOW() - '2 minute'::interval, 'b', 1)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.PartitionExpression = sqlf.Sprintf("workerutil_test.partition_key")
+	options.PriorityExpression = sqlf.Sprintf("workerutil_test.priority")
+	store := testStore(db, options)
+
+	// Records of tier 1 alternate between partitions a and b even though partition
+	// a holds the oldest records. The lower tier is only dequeued once tier 1 is empty.
+	for _, expectedID := range []int{2, 5, 3, 6, 4, 1} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
 func TestStoreDequeueRetryAfter(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -3,6 +3,7 @@ package batches
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
 	"github.com/sourcegraph/sourcegraph/lib/batches/overridable"
@@ -45,6 +46,43 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	AutoMerge *AutoMerge                   `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+
+	Reviewers               []string `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
+	ReviewersFromCodeOwners bool     `json:"reviewersFromCodeOwners,omitempty" yaml:"reviewersFromCodeOwners,omitempty"`
+	Labels                  []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Assignees               []string `json:"assignees,omitempty" yaml:"assignees,omitempty"`
+}
+
+type AutoMerge struct {
+	RequiredApprovals *int              `json:"requiredApprovals,omitempty" yaml:"requiredApprovals,omitempty"`
+	ChecksPassed      *bool             `json:"checksPassed,omitempty" yaml:"checksPassed,omitempty"`
+	Squash            bool              `json:"squash,omitempty" yaml:"squash,omitempty"`
+	Windows           []AutoMergeWindow `json:"windows,omitempty" yaml:"windows,omitempty"`
+}
+
+// GetRequiredApprovals returns the number of approvals required before a
+// changeset is merged, which defaults to 1.
+func (am *AutoMerge) GetRequiredApprovals() int {
+	if am.RequiredApprovals == nil {
+		return 1
+	}
+	return *am.RequiredApprovals
+}
+
+// GetChecksPassed returns whether checks need to pass before a changeset is
+// merged, which defaults to true.
+func (am *AutoMerge) GetChecksPassed() bool {
+	if am.ChecksPassed == nil {
+		return true
+	}
+	return *am.ChecksPassed
+}
+
+type AutoMergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days,omitempty"`
+	Start string   `json:"start,omitempty" yaml:"start,omitempty"`
+	End   string   `json:"end,omitempty" yaml:"end,omitempty"`
 }
 
 type GitCommitAuthor struct {
@@ -95,6 +133,10 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+
+	Timeout         string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries         int    `json:"retries,omitempty" yaml:"retries,omitempty"`
+	ContinueOnError bool   `json:"continueOnError,omitempty" yaml:"continueOnError,omitempty"`
 }
 
 func (s *Step) IfCondition() string {
@@ -111,6 +153,21 @@ func (s *Step) IfCondition() string {
 	}
 }
 
+// TimeoutDuration returns the parsed timeout of a single attempt to run the
+// step, or 0 if the step has no timeout.
+func (s *Step) TimeoutDuration() (time.Duration, error) {
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s.Timeout)
+}
+
+// HasFailurePolicy returns whether the step configures a timeout, retries or
+// continues on error, in which case it needs to be wrapped when executed.
+func (s *Step) HasFailurePolicy() bool {
+	return s.Timeout != "" || s.Retries > 0 || s.ContinueOnError
+}
+
 type Outputs map[string]Output
 
 type Output struct {
@@ -174,6 +231,14 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount mountpoint contains invalid characters", i+1)))
 			}
 		}
+		if timeout, err := step.TimeoutDuration(); err != nil {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("step %d timeout is not a valid duration: %s", i+1, err)))
+		} else if timeout < 0 {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("step %d timeout must not be negative", i+1)))
+		}
+		if step.Retries < 0 || step.Retries > maxStepRetries {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("step %d retries must be between 0 and %d", i+1, maxStepRetries)))
+		}
 	}
 
 	return &spec, errs
@@ -181,6 +246,9 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 
 const invalidMountCharacters = ","
 
+// maxStepRetries is the maximum number of times a step can be retried.
+const maxStepRetries = 10
+
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -210,6 +211,145 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("parsing step failure policy", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: npm install
+    container: node:18
+    timeout: 10m
+    retries: 2
+    continueOnError: true
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		step := batchSpec.Steps[0]
+		timeout, err := step.TimeoutDuration()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 10*time.Minute, timeout)
+		assert.Equal(t, 2, step.Retries)
+		assert.True(t, step.ContinueOnError)
+		assert.True(t, step.HasFailurePolicy())
+	})
+
+	t.Run("invalid step timeout", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: npm install
+    container: node:18
+    timeout: forever
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `step 1 timeout is not a valid duration: time: invalid duration "forever"`, err.Error())
+	})
+
+	t.Run("invalid step retries", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: npm install
+    container: node:18
+    retries: 11
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		// The schema already rejects the value, so validate the spec without it
+		// to make sure that it is also rejected when parsing.
+		_, err := parseBatchSpec(`{}`, []byte(spec))
+		assert.Equal(t, "step 1 retries must be between 0 and 10", err.Error())
+	})
+
+	t.Run("parsing autoMerge", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  autoMerge:
+    requiredApprovals: 2
+    squash: true
+    windows:
+      - days: [saturday, sunday]
+        start: 10:00
+        end: 15:00
+`
+
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		am := batchSpec.ChangesetTemplate.AutoMerge
+		if am == nil {
+			t.Fatal("autoMerge not parsed")
+		}
+		assert.Equal(t, 2, am.GetRequiredApprovals())
+		assert.True(t, am.GetChecksPassed())
+		assert.True(t, am.Squash)
+		assert.Equal(t, []AutoMergeWindow{{Days: []string{"saturday", "sunday"}, Start: "10:00", End: "15:00"}}, am.Windows)
+	})
+
+	t.Run("autoMerge window without end", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  autoMerge:
+    windows:
+      - start: 10:00
+`
+
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
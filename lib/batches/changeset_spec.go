@@ -80,6 +80,13 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, Labels and Assignees are applied to the changeset on the
+	// code host once it is published.
+	Reviewers               []string `json:"reviewers,omitempty"`
+	ReviewersFromCodeOwners bool     `json:"reviewersFromCodeOwners,omitempty"`
+	Labels                  []string `json:"labels,omitempty"`
+	Assignees               []string `json:"assignees,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +109,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+
+		Reviewers               []string `json:"reviewers,omitempty"`
+		ReviewersFromCodeOwners bool     `json:"reviewersFromCodeOwners,omitempty"`
+		Labels                  []string `json:"labels,omitempty"`
+		Assignees               []string `json:"assignees,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +124,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+
+		Reviewers:               c.Reviewers,
+		ReviewersFromCodeOwners: c.ReviewersFromCodeOwners,
+		Labels:                  c.Labels,
+		Assignees:               c.Assignees,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
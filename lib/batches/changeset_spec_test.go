@@ -39,6 +39,29 @@ func TestParseChangesetSpec(t *testing.T) {
 				}]
 			}`,
 		},
+		{
+			name: "valid GitBranchChangesetDescription with reviewers, labels and assignees",
+			rawSpec: `{
+				"baseRepository": "graphql-id",
+				"baseRef": "refs/heads/master",
+				"baseRev": "d34db33f",
+				"headRef": "refs/heads/my-branch",
+				"headRepository": "graphql-id",
+				"title": "my title",
+				"body": "my body",
+				"published": true,
+				"reviewers": ["alice", "sourcegraph/batchers"],
+				"reviewersFromCodeOwners": true,
+				"labels": ["automated"],
+				"assignees": ["bob"],
+				"commits": [{
+				  "message": "commit message",
+				  "diff": "the diff",
+				  "authorName": "Mary McButtons",
+				  "authorEmail": "mary@example.com"
+				}]
+			}`,
+		},
 		{
 			name: "missing fields in GitBranchChangesetDescription",
 			rawSpec: `{
@@ -124,6 +124,11 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 				},
 			},
 			Published: PublishedValue{Val: published},
+
+			Reviewers:               input.Template.Reviewers,
+			ReviewersFromCodeOwners: input.Template.ReviewersFromCodeOwners,
+			Labels:                  input.Template.Labels,
+			Assignees:               input.Template.Assignees,
 		}
 	}
 
@@ -133,6 +133,26 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "reviewers, labels and assignees",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "true")
+				input.Template.Reviewers = []string{"alice", "sourcegraph/batchers"}
+				input.Template.ReviewersFromCodeOwners = true
+				input.Template.Labels = []string{"automated"}
+				input.Template.Assignees = []string{"bob"}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Published = PublishedValue{Val: true}
+					s.Reviewers = []string{"alice", "sourcegraph/batchers"}
+					s.ReviewersFromCodeOwners = true
+					s.Labels = []string{"automated"}
+					s.Assignees = []string{"bob"}
+				}),
+			},
+			wantErr: "",
+		},
 		{
 			name: "publish in UI",
 			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
@@ -22,6 +22,14 @@ type AfterStepResult struct {
 	Diff []byte `json:"diff"`
 	// Outputs is a copy of the Outputs after executing the Step.
 	Outputs map[string]any `json:"outputs"`
+	// ExitCode is the exit code of the last attempt to run the Step. It can
+	// only be non-zero if the Step is configured to continue on error.
+	ExitCode int `json:"exitCode,omitempty"`
+	// Attempts is the number of times the Step was run, including retries.
+	Attempts int `json:"attempts,omitempty"`
+	// TimedOut is true if the last attempt to run the Step exceeded its
+	// timeout.
+	TimedOut bool `json:"timedOut,omitempty"`
 }
 
 func (a AfterStepResult) MarshalJSON() ([]byte, error) {
@@ -55,6 +63,9 @@ func (a *AfterStepResult) UnmarshalJSON(data []byte) error {
 		a.StepIndex = v2.StepIndex
 		a.Diff = v2.Diff
 		a.Outputs = v2.Outputs
+		a.ExitCode = v2.ExitCode
+		a.Attempts = v2.Attempts
+		a.TimedOut = v2.TimedOut
 		return nil
 	}
 	var v1 v1AfterStepResult
@@ -82,6 +93,9 @@ type v2AfterStepResult struct {
 	StepIndex    int            `json:"stepIndex"`
 	Diff         []byte         `json:"diff"`
 	Outputs      map[string]any `json:"outputs"`
+	ExitCode     int            `json:"exitCode,omitempty"`
+	Attempts     int            `json:"attempts,omitempty"`
+	TimedOut     bool           `json:"timedOut,omitempty"`
 }
 
 type v1AfterStepResult struct {
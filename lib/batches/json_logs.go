@@ -227,6 +227,12 @@ type TaskStepMetadata struct {
 
 	ExitCode int
 	Error    string
+
+	// Attempts is the number of times the step was run, including retries.
+	Attempts int
+	// TimedOut is true if the last attempt to run the step exceeded its
+	// timeout.
+	TimedOut bool
 }
 
 func (m TaskStepMetadata) MarshalJSON() ([]byte, error) {
@@ -242,6 +248,8 @@ func (m TaskStepMetadata) MarshalJSON() ([]byte, error) {
 			Outputs:   m.Outputs,
 			ExitCode:  m.ExitCode,
 			Error:     m.Error,
+			Attempts:  m.Attempts,
+			TimedOut:  m.TimedOut,
 		})
 	}
 	return json.Marshal(v1TaskStepMetadata{
@@ -254,6 +262,8 @@ func (m TaskStepMetadata) MarshalJSON() ([]byte, error) {
 		Outputs:   m.Outputs,
 		ExitCode:  m.ExitCode,
 		Error:     m.Error,
+		Attempts:  m.Attempts,
+		TimedOut:  m.TimedOut,
 	})
 }
 
@@ -277,6 +287,8 @@ func (m *TaskStepMetadata) UnmarshalJSON(data []byte) error {
 		m.Outputs = v2.Outputs
 		m.ExitCode = v2.ExitCode
 		m.Error = v2.Error
+		m.Attempts = v2.Attempts
+		m.TimedOut = v2.TimedOut
 		return nil
 	}
 	var v1 v1TaskStepMetadata
@@ -292,6 +304,8 @@ func (m *TaskStepMetadata) UnmarshalJSON(data []byte) error {
 	m.Outputs = v1.Outputs
 	m.ExitCode = v1.ExitCode
 	m.Error = v1.Error
+	m.Attempts = v1.Attempts
+	m.TimedOut = v1.TimedOut
 	return nil
 }
 
@@ -310,6 +324,8 @@ type v2TaskStepMetadata struct {
 	Outputs   map[string]any    `json:"outputs,omitempty"`
 	ExitCode  int               `json:"exitCode,omitempty"`
 	Error     string            `json:"error,omitempty"`
+	Attempts  int               `json:"attempts,omitempty"`
+	TimedOut  bool              `json:"timedOut,omitempty"`
 }
 
 type v1TaskStepMetadata struct {
@@ -322,6 +338,8 @@ type v1TaskStepMetadata struct {
 	Outputs   map[string]any    `json:"outputs,omitempty"`
 	ExitCode  int               `json:"exitCode,omitempty"`
 	Error     string            `json:"error,omitempty"`
+	Attempts  int               `json:"attempts,omitempty"`
+	TimedOut  bool              `json:"timedOut,omitempty"`
 }
 
 type CacheAfterStepResultMetadata struct {
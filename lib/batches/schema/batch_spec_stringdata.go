@@ -237,6 +237,21 @@ const BatchSpecJSON = `{
                 }
               }
             }
+          },
+          "timeout": {
+            "type": "string",
+            "description": "The maximum duration of a single attempt to run the step, as a Go duration string. The step is terminated and counts as failed when it exceeds the timeout. If omitted, the step can run indefinitely.",
+            "examples": ["10m", "1h30m"]
+          },
+          "retries": {
+            "type": "integer",
+            "description": "The number of times to retry the step if it fails or times out. The repository is reset to its state before the first attempt before every retry. Defaults to 0.",
+            "minimum": 0,
+            "maximum": 10
+          },
+          "continueOnError": {
+            "type": "boolean",
+            "description": "Whether to continue with the next step if this step fails after all retries. The changes made by the failed step are kept. Defaults to false."
           }
         }
       }
@@ -395,6 +410,88 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review of the changeset from. On GitHub, teams can be requested as ` + "`" + `organization/team` + "`" + `. Reviewers are not supported on Bitbucket Cloud and Gerrit.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "reviewersFromCodeOwners": {
+          "type": "boolean",
+          "description": "Whether to also request a review from the owners of the changed files, as defined in the CODEOWNERS file of the repository. Only owners given by username are requested.",
+          "default": false
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to apply to the changeset. Labels are only supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changeset to. Assignees are only supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "autoMerge": {
+          "type": "object",
+          "description": "A policy to automatically merge published changesets once they pass review and checks. If omitted, changesets are never merged automatically.",
+          "additionalProperties": false,
+          "properties": {
+            "requiredApprovals": {
+              "type": "integer",
+              "description": "The number of approving reviews a changeset needs before it is merged. Defaults to 1.",
+              "minimum": 0,
+              "default": 1
+            },
+            "checksPassed": {
+              "type": "boolean",
+              "description": "Whether all checks on the changeset need to pass before it is merged. Changesets without any checks are considered to pass. Defaults to true.",
+              "default": true
+            },
+            "squash": {
+              "type": "boolean",
+              "description": "Whether to squash the commits of the changeset when merging it, if the code host supports it.",
+              "default": false
+            },
+            "windows": {
+              "type": "array",
+              "description": "Windows in which changesets may be merged. All days and times are handled in UTC. If omitted, changesets are merged as soon as the policy is satisfied.",
+              "items": {
+                "title": "AutoMergeWindow",
+                "type": "object",
+                "additionalProperties": false,
+                "properties": {
+                  "days": {
+                    "type": "array",
+                    "description": "Day(s) the window applies to. If omitted, the window applies to all days of the week.",
+                    "items": {
+                      "type": "string",
+                      "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+                    }
+                  },
+                  "start": {
+                    "type": "string",
+                    "description": "Window start time. If omitted, no time window is applied to the day(s) that match this window.",
+                    "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                  },
+                  "end": {
+                    "type": "string",
+                    "description": "Window end time. If omitted, no time window is applied to the day(s) that match this window.",
+                    "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+                  }
+                },
+                "dependencies": {
+                  "start": ["end"],
+                  "end": ["start"]
+                }
+              }
+            }
+          }
         }
       }
     }
@@ -107,6 +107,25 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review of the changeset from.",
+          "items": { "type": "string" }
+        },
+        "reviewersFromCodeOwners": {
+          "type": "boolean",
+          "description": "Whether to also request a review from the owners of the changed files, as defined in the CODEOWNERS file of the repository."
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to apply to the changeset.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changeset to.",
+          "items": { "type": "string" }
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
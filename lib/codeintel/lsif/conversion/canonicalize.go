@@ -48,6 +48,7 @@ func canonicalizeDocuments(state *State) {
 	canonicalizeDocumentsInDefinitionReferences(state.DefinitionData, canonicalIDs)
 	canonicalizeDocumentsInDefinitionReferences(state.ReferenceData, canonicalIDs)
 	canonicalizeDocumentsInDefinitionReferences(state.ImplementationData, canonicalIDs)
+	canonicalizeDocumentsInDefinitionReferences(state.TypeDefinitionData, canonicalIDs)
 
 	for documentID, canonicalID := range canonicalIDs {
 		// Move ranges and diagnostics into the canonical document
@@ -182,6 +183,9 @@ func mergeNextResultSetData(state *State, itemID int, item ResultSet, nextID int
 	if item.ImplementationResultID == 0 {
 		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
@@ -203,6 +207,9 @@ func mergeNextRangeData(state *State, itemID int, item Range, nextID int, nextIt
 	if item.ImplementationResultID == 0 {
 		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
@@ -194,9 +194,10 @@ func TestCanonicalizeResultSets(t *testing.T) {
 				ImplementationResultID: 2010,
 			},
 			5005: {
-				DefinitionResultID: 0,
-				ReferenceResultID:  2008,
-				HoverResultID:      2008,
+				DefinitionResultID:     0,
+				ReferenceResultID:      2008,
+				HoverResultID:          2008,
+				TypeDefinitionResultID: 2011,
 			},
 		},
 		NextData: map[int]int{
@@ -222,6 +223,7 @@ func TestCanonicalizeResultSets(t *testing.T) {
 				ReferenceResultID:      2007,
 				HoverResultID:          2008,
 				ImplementationResultID: 2010,
+				TypeDefinitionResultID: 2011,
 			},
 			5002: {
 				DefinitionResultID: 2001,
@@ -229,20 +231,23 @@ func TestCanonicalizeResultSets(t *testing.T) {
 				HoverResultID:      2003,
 			},
 			5003: {
-				DefinitionResultID: 2004,
-				ReferenceResultID:  2005,
-				HoverResultID:      2008,
+				DefinitionResultID:     2004,
+				ReferenceResultID:      2005,
+				HoverResultID:          2008,
+				TypeDefinitionResultID: 2011,
 			},
 			5004: {
 				DefinitionResultID:     2006,
 				ReferenceResultID:      2007,
 				HoverResultID:          2008,
 				ImplementationResultID: 2010,
+				TypeDefinitionResultID: 2011,
 			},
 			5005: {
-				DefinitionResultID: 0,
-				ReferenceResultID:  2008,
-				HoverResultID:      2008,
+				DefinitionResultID:     0,
+				ReferenceResultID:      2008,
+				HoverResultID:          2008,
+				TypeDefinitionResultID: 2011,
 			},
 		},
 		NextData:       map[int]int{},
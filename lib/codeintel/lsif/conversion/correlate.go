@@ -182,6 +182,7 @@ var vertexHandlers = map[string]vertexHandler{
 	"definitionResult":     correlateDefinitionResult,
 	"referenceResult":      correlateReferenceResult,
 	"implementationResult": correlateImplementationResult,
+	"typeDefinitionResult": correlateTypeDefinitionResult,
 	"hoverResult":          correlateHoverResult,
 	"moniker":              correlateMoniker,
 	"packageInformation":   correlatePackageInformation,
@@ -211,6 +212,7 @@ var edgeHandlers = map[string]func(state *wrappedState, id int, edge Edge) error
 	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
 	"textDocument/references":     correlateTextDocumentReferencesEdge,
 	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/typeDefinition": correlateTextDocumentTypeDefinitionEdge,
 	"textDocument/hover":          correlateTextDocumentHoverEdge,
 	"moniker":                     correlateMonikerEdge,
 	"nextMoniker":                 correlateNextMonikerEdge,
@@ -310,6 +312,11 @@ func correlateImplementationResult(state *wrappedState, element Element) error {
 	return nil
 }
 
+func correlateTypeDefinitionResult(state *wrappedState, element Element) error {
+	state.TypeDefinitionData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
 func correlateHoverResult(state *wrappedState, element Element) error {
 	payload, ok := element.Payload.(string)
 	if !ok {
@@ -440,6 +447,19 @@ func correlateItemEdge(state *wrappedState, id int, edge Edge) error {
 		return nil
 	}
 
+	if documentMap, ok := state.TypeDefinitionData[edge.OutV]; ok {
+		for _, inV := range edge.InVs {
+			if _, ok := state.RangeData[inV]; !ok {
+				return malformedDump(id, inV, "range")
+			}
+
+			// Link type definition data to defining range
+			documentMap.AddID(edge.Document, inV)
+		}
+
+		return nil
+	}
+
 	if !state.unsupportedVertices.Contains(edge.OutV) {
 		return malformedDump(id, edge.OutV, "vertex")
 	}
@@ -492,6 +512,21 @@ func correlateTextDocumentImplementationEdge(state *wrappedState, id int, edge E
 	return nil
 }
 
+func correlateTextDocumentTypeDefinitionEdge(state *wrappedState, id int, edge Edge) error {
+	if _, ok := state.TypeDefinitionData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "typeDefinitionResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
 func correlateTextDocumentHoverEdge(state *wrappedState, id int, edge Edge) error {
 	if _, ok := state.HoverData[edge.InV]; !ok {
 		return malformedDump(id, edge.InV, "hoverResult")
@@ -21,7 +21,7 @@ const resultsPerResultChunk = 512
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State) *precise.GroupedBundleDataChans {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
 
 	meta := precise.MetaData{NumResultChunks: numResultChunks}
@@ -115,6 +115,7 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 			DefinitionResultID:     toID(rangeData.DefinitionResultID),
 			ReferenceResultID:      toID(rangeData.ReferenceResultID),
 			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
 			HoverResultID:          toID(rangeData.HoverResultID),
 			MonikerIDs:             monikerIDs,
 		}
@@ -161,6 +162,10 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 		index := precise.HashKey(toID(id), numResultChunks)
 		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
 	}
+	for id, ranges := range state.TypeDefinitionData {
+		index := precise.HashKey(toID(id), numResultChunks)
+		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
+	}
 
 	ch := make(chan precise.IndexedResultChunkData)
 
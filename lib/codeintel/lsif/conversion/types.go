@@ -13,6 +13,7 @@ type Range struct {
 	DefinitionResultID     int
 	ReferenceResultID      int
 	ImplementationResultID int
+	TypeDefinitionResultID int
 	HoverResultID          int
 }
 
@@ -36,6 +37,7 @@ func (r Range) SetDefinitionResultID(id int) Range {
 		DefinitionResultID:     id,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          r.HoverResultID,
 	}
 }
@@ -49,6 +51,7 @@ func (r Range) SetReferenceResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      id,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          r.HoverResultID,
 	}
 }
@@ -62,6 +65,21 @@ func (r Range) SetImplementationResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: id,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
+		HoverResultID:          r.HoverResultID,
+	}
+}
+
+// Convenience function for setting the field within a map.
+//
+// See Note [Assignment to fields of structs in maps]
+func (r Range) SetTypeDefinitionResultID(id int) Range {
+	return Range{
+		Range:                  r.Range,
+		DefinitionResultID:     r.DefinitionResultID,
+		ReferenceResultID:      r.ReferenceResultID,
+		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: id,
 		HoverResultID:          r.HoverResultID,
 	}
 }
@@ -75,6 +93,7 @@ func (r Range) SetHoverResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          id,
 	}
 }
@@ -84,6 +103,7 @@ type ResultSet struct {
 	DefinitionResultID     int
 	ReferenceResultID      int
 	ImplementationResultID int
+	TypeDefinitionResultID int
 	HoverResultID          int
 }
 
@@ -96,6 +116,7 @@ func (rs ResultSet) SetDefinitionResultID(id int) ResultSet {
 		DefinitionResultID:     id,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          rs.HoverResultID,
 	}
 }
@@ -109,6 +130,7 @@ func (rs ResultSet) SetReferenceResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      id,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          rs.HoverResultID,
 	}
 }
@@ -122,6 +144,21 @@ func (rs ResultSet) SetImplementationResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: id,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
+		HoverResultID:          rs.HoverResultID,
+	}
+}
+
+// Convenience function for setting the field within a map.
+//
+// See Note [Assignment to fields of structs in maps]
+func (rs ResultSet) SetTypeDefinitionResultID(id int) ResultSet {
+	return ResultSet{
+		ResultSet:              rs.ResultSet,
+		DefinitionResultID:     rs.DefinitionResultID,
+		ReferenceResultID:      rs.ReferenceResultID,
+		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: id,
 		HoverResultID:          rs.HoverResultID,
 	}
 }
@@ -135,6 +172,7 @@ func (rs ResultSet) SetHoverResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          id,
 	}
 }
@@ -34,6 +34,7 @@ type RangeData struct {
 	DefinitionResultID     ID   // possibly empty
 	ReferenceResultID      ID   // possibly empty
 	ImplementationResultID ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
 	HoverResultID          ID   // possibly empty
 	MonikerIDs             []ID // possibly empty
 }
@@ -43,11 +44,12 @@ const (
 	Import         = "import"
 	Export         = "export"
 	Implementation = "implementation"
+	TypeDefinition = "typeDefinition"
 )
 
 // MonikerData represent a unique name (eventually) attached to a range.
 type MonikerData struct {
-	Kind                 string // local, import, export, implementation
+	Kind                 string // local, import, export, implementation, typeDefinition
 	Scheme               string // name of the package manager type
 	Identifier           string // unique identifier
 	PackageInformationID ID     // possibly empty
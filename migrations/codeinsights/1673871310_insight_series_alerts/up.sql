@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id SERIAL NOT NULL,
+    series_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    window_seconds INTEGER NOT NULL DEFAULT 0,
+    channel TEXT NOT NULL,
+    url TEXT,
+    recipient_user_id INTEGER,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_series_alerts_pk PRIMARY KEY (id),
+    CONSTRAINT insight_series_alerts_series_id_fk FOREIGN KEY (series_id) REFERENCES insight_series (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_series_id_idx ON insight_series_alerts USING btree (series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules evaluated whenever new points are recorded for an insight series.';
+COMMENT ON COLUMN insight_series_alerts.kind IS 'THRESHOLD compares the value of the latest point with threshold. CHANGE compares the percentage change of the value over window_seconds with threshold.';
+COMMENT ON COLUMN insight_series_alerts.direction IS 'ABOVE fires when the value (or change) rises above the threshold, BELOW when it falls below it.';
+COMMENT ON COLUMN insight_series_alerts.channel IS 'How alerts are delivered: EMAIL to recipient_user_id, or SLACK and WEBHOOK to url.';
+COMMENT ON COLUMN insight_series_alerts.created_by IS 'The user whose repository permissions are used to evaluate the rule. Refers to a user in the main database.';
+
+CREATE TABLE IF NOT EXISTS insight_series_alert_events (
+    id SERIAL NOT NULL,
+    alert_id INTEGER NOT NULL,
+    series_id INTEGER NOT NULL,
+    capture TEXT NOT NULL DEFAULT '',
+    "time" TIMESTAMP WITH TIME ZONE NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    reference_value DOUBLE PRECISION,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_series_alert_events_pk PRIMARY KEY (id),
+    CONSTRAINT insight_series_alert_events_alert_id_fk FOREIGN KEY (alert_id) REFERENCES insight_series_alerts (id) ON DELETE CASCADE,
+    CONSTRAINT insight_series_alert_events_series_id_fk FOREIGN KEY (series_id) REFERENCES insight_series (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS insight_series_alert_events_unique_idx ON insight_series_alert_events USING btree (alert_id, capture, "time");
+CREATE INDEX IF NOT EXISTS insight_series_alert_events_series_id_idx ON insight_series_alert_events USING btree (series_id, "time");
+
+COMMENT ON TABLE insight_series_alert_events IS 'History of the alerts fired for an insight series.';
+COMMENT ON COLUMN insight_series_alert_events."time" IS 'Time of the series point that fired the alert.';
+COMMENT ON COLUMN insight_series_alert_events.reference_value IS 'For CHANGE alerts, the value at the start of the window.';
+COMMENT ON COLUMN insight_series_alert_events.error IS 'The error of the last delivery attempt, if it failed.';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS changeset_auto_merge_decisions (
+    id bigserial PRIMARY KEY,
+    changeset_id integer NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id integer NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    merged boolean NOT NULL DEFAULT false,
+    reason text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_auto_merge_decisions_changeset_id_idx ON changeset_auto_merge_decisions USING btree (changeset_id);
@@ -0,0 +1,6 @@
+ALTER TABLE changeset_specs
+    DROP COLUMN IF EXISTS reviewers,
+    DROP COLUMN IF EXISTS reviewers_from_code_owners,
+    DROP COLUMN IF EXISTS labels,
+    DROP COLUMN IF EXISTS assignees,
+    DROP COLUMN IF EXISTS code_owner_reviewers;
@@ -0,0 +1,6 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS reviewers text[] NOT NULL DEFAULT '{}'::text[],
+    ADD COLUMN IF NOT EXISTS reviewers_from_code_owners boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS labels text[] NOT NULL DEFAULT '{}'::text[],
+    ADD COLUMN IF NOT EXISTS assignees text[] NOT NULL DEFAULT '{}'::text[],
+    ADD COLUMN IF NOT EXISTS code_owner_reviewers text[] NOT NULL DEFAULT '{}'::text[];
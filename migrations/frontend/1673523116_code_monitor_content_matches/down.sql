@@ -0,0 +1,3 @@
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS content_changes;
+
+DROP TABLE IF EXISTS cm_content_matches;
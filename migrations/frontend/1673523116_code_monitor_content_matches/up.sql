@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS cm_content_matches (
+    monitor_id bigint NOT NULL PRIMARY KEY REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    matches jsonb NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT matches_is_array CHECK (jsonb_typeof(matches) = 'array'::text)
+);
+
+COMMENT ON TABLE cm_content_matches IS 'The content matches found by the last run of a code monitor over file content';
+COMMENT ON COLUMN cm_content_matches.matches IS 'The fingerprinted matches of the last run, used to detect matches appearing and disappearing on the next run';
+
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS content_changes jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.content_changes IS 'The content matches that appeared and disappeared since the previous run of a code monitor over file content';
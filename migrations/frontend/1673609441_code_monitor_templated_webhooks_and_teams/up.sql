@@ -0,0 +1,42 @@
+ALTER TABLE cm_webhooks
+    ADD COLUMN IF NOT EXISTS payload_template text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS headers text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS signing_secret text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS encryption_key_id text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN cm_webhooks.payload_template IS 'A Go template that renders the JSON body of the webhook request. The default payload is sent if empty';
+COMMENT ON COLUMN cm_webhooks.headers IS 'The JSON object of additional HTTP headers sent with the webhook request, encrypted if encryption_key_id is set';
+COMMENT ON COLUMN cm_webhooks.signing_secret IS 'The secret used to sign the body of the webhook request with HMAC-SHA256, encrypted if encryption_key_id is set. The request is not signed if empty';
+COMMENT ON COLUMN cm_webhooks.encryption_key_id IS 'The identifier of the key that encrypts headers and signing_secret. Empty if they are not encrypted';
+
+CREATE TABLE IF NOT EXISTS cm_teams_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now(),
+    include_results boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS teams_webhook bigint REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with email, webhook and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id bigserial PRIMARY KEY,
+    audit_id text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    actor_uid text NOT NULL,
+    actor_ip text NOT NULL,
+    actor_forwarded_for text NOT NULL,
+    entity text NOT NULL,
+    action text NOT NULL,
+    fields json NOT NULL DEFAULT '{}'::json,
+    previous_hash text NOT NULL,
+    hash text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_created_at ON audit_logs USING btree (created_at);
+CREATE INDEX IF NOT EXISTS audit_logs_actor_uid ON audit_logs USING btree (actor_uid);
+CREATE INDEX IF NOT EXISTS audit_logs_entity_action ON audit_logs USING btree (entity, action);
+
+COMMENT ON TABLE audit_logs IS 'Persisted audit log records. Each record is chained to the previous one by its hash, so that altering or removing records can be detected';
+COMMENT ON COLUMN audit_logs.audit_id IS 'The ID of the record, as also written to the structured audit log';
+COMMENT ON COLUMN audit_logs.fields IS 'The additional context of the action. Stored as json rather than jsonb to preserve the exact text that is hashed';
+COMMENT ON COLUMN audit_logs.previous_hash IS 'The hash of the previous record, empty for the first record';
+COMMENT ON COLUMN audit_logs.hash IS 'The hex encoded SHA-256 hash of the record contents and previous_hash';
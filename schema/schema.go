@@ -74,6 +74,10 @@ type AuditLog struct {
 	GraphQL bool `json:"graphQL"`
 	// InternalTraffic description: Capture security events performed by the internal traffic (adds significant noise).
 	InternalTraffic bool `json:"internalTraffic"`
+	// Persist description: Persist audit log records to the database, where they can be queried and exported by site admins. Persisted records are hash chained so that tampering can be detected.
+	Persist bool `json:"persist,omitempty"`
+	// RetentionDays description: The number of days persisted audit log records are retained for. Records are retained indefinitely if 0.
+	RetentionDays int `json:"retentionDays,omitempty"`
 	// SeverityLevel description: Severity logging level for the audit log.
 	SeverityLevel string `json:"severityLevel,omitempty"`
 }
@@ -112,12 +116,13 @@ type AuthProviderCommon struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 type AuthProviders struct {
-	Builtin       *BuiltinAuthProvider
-	Saml          *SAMLAuthProvider
-	Openidconnect *OpenIDConnectAuthProvider
-	HttpHeader    *HTTPHeaderAuthProvider
-	Github        *GitHubAuthProvider
-	Gitlab        *GitLabAuthProvider
+	Builtin        *BuiltinAuthProvider
+	Saml           *SAMLAuthProvider
+	Openidconnect  *OpenIDConnectAuthProvider
+	HttpHeader     *HTTPHeaderAuthProvider
+	Github         *GitHubAuthProvider
+	Gitlab         *GitLabAuthProvider
+	Bitbucketcloud *BitbucketCloudAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -139,6 +144,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Bitbucketcloud != nil {
+		return json.Marshal(v.Bitbucketcloud)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -149,6 +157,8 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch d.DiscriminantProperty {
+	case "bitbucketcloud":
+		return json.Unmarshal(data, &v.Bitbucketcloud)
 	case "builtin":
 		return json.Unmarshal(data, &v.Builtin)
 	case "github":
@@ -162,9 +172,28 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "bitbucketcloud"})
 }
 
+// AutoMerge description: A policy to automatically merge published changesets once they pass review and checks. If omitted, changesets are never merged automatically.
+type AutoMerge struct {
+	// ChecksPassed description: Whether all checks on the changeset need to pass before it is merged. Changesets without any checks are considered to pass. Defaults to true.
+	ChecksPassed bool `json:"checksPassed,omitempty"`
+	// RequiredApprovals description: The number of approving reviews a changeset needs before it is merged. Defaults to 1.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// Squash description: Whether to squash the commits of the changeset when merging it, if the code host supports it.
+	Squash bool `json:"squash,omitempty"`
+	// Windows description: Windows in which changesets may be merged. All days and times are handled in UTC. If omitted, changesets are merged as soon as the policy is satisfied.
+	Windows []*AutoMergeWindow `json:"windows,omitempty"`
+}
+type AutoMergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, the window applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, no time window is applied to the day(s) that match this window.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this window.
+	Start string `json:"start,omitempty"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -213,12 +242,32 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthProvider description: Configures the Bitbucket Cloud OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth consumer on your Bitbucket Cloud workspace: https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/. The consumer should have the `account`, `email` and `repository` permissions and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/bitbucketcloud/callback".
+type BitbucketCloudAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via Bitbucket Cloud authentication. If false, users signing in via Bitbucket Cloud must have an existing Sourcegraph account, which will be linked to their Bitbucket Cloud identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// ClientKey description: The Key of the Bitbucket OAuth consumer, accessible from the OAuth consumers page in the settings of your Bitbucket Cloud workspace.
+	ClientKey string `json:"clientKey"`
+	// ClientSecret description: The Secret of the Bitbucket OAuth consumer, accessible from the OAuth consumers page in the settings of your Bitbucket Cloud workspace.
+	ClientSecret string `json:"clientSecret"`
+	DisplayName  string `json:"displayName,omitempty"`
+	Type         string `json:"type"`
+	// Url description: URL of Bitbucket Cloud.
+	Url string `json:"url,omitempty"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions of a user are fetched using the OAuth token of the Bitbucket Cloud account connected to their Sourcegraph account, while the "username" and "appPassword" of this connection are used to fetch the users with access to a repository, which requires that user to be an administrator of the workspaces whose repositories are mirrored.
+type BitbucketCloudAuthorization struct {
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions of a user are fetched using the OAuth token of the Bitbucket Cloud account connected to their Sourcegraph account, while the "username" and "appPassword" of this connection are used to fetch the users with access to a repository, which requires that user to be an administrator of the workspaces whose repositories are mirrored.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -380,6 +429,8 @@ type BitbucketServerUsernameIdentity struct {
 	Type string `json:"type"`
 }
 type BranchChangesetSpec struct {
+	// Assignees description: The usernames of the users to assign the changeset to.
+	Assignees []string `json:"assignees,omitempty"`
 	// BaseRef description: The full name of the Git ref in the base repository that this changeset is based on (and is proposing to be merged into). This ref must exist on the base repository.
 	BaseRef string `json:"baseRef"`
 	// BaseRepository description: The GraphQL ID of the repository that this changeset spec is proposing to change.
@@ -394,8 +445,14 @@ type BranchChangesetSpec struct {
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
+	// Labels description: The labels to apply to the changeset.
+	Labels []string `json:"labels,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published any `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request a review of the changeset from.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// ReviewersFromCodeOwners description: Whether to also request a review from the owners of the changed files, as defined in the CODEOWNERS file of the repository.
+	ReviewersFromCodeOwners bool `json:"reviewersFromCodeOwners,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 	// Version description: A field for versioning the payload.
@@ -433,14 +490,24 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign the changeset to. Assignees are only supported on GitHub and GitLab.
+	Assignees []string `json:"assignees,omitempty"`
+	// AutoMerge description: A policy to automatically merge published changesets once they pass review and checks. If omitted, changesets are never merged automatically.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Labels description: The labels to apply to the changeset. Labels are only supported on GitHub and GitLab.
+	Labels []string `json:"labels,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published any `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request a review of the changeset from. On GitHub, teams can be requested as `organization/team`. Reviewers are not supported on Bitbucket Cloud and Gerrit.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// ReviewersFromCodeOwners description: Whether to also request a review from the owners of the changed files, as defined in the CODEOWNERS file of the repository. Only owners given by username are requested.
+	ReviewersFromCodeOwners bool `json:"reviewersFromCodeOwners,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -631,6 +698,10 @@ type ExperimentalFeatures struct {
 	CustomGitFetch []*CustomGitFetchMapping `json:"customGitFetch,omitempty"`
 	// DebugLog description: Turns on debug logging for specific debugging scenarios.
 	DebugLog *DebugLog `json:"debug.log,omitempty"`
+	// DistributedRateLimits description: Shares the rate limit of each code host connection between all replicas of all services through the Redis cache. When disabled, each process enforces the rate limits on its own.
+	DistributedRateLimits bool `json:"distributedRateLimits,omitempty"`
+	// EnableGRPC description: Enables gRPC for the communication between Sourcegraph services and gitserver. When disabled, the HTTP API of gitserver is used.
+	EnableGRPC bool `json:"enableGRPC,omitempty"`
 	// EnableGithubInternalRepoVisibility description: Enable support for visilibity of internal Github repositories
 	EnableGithubInternalRepoVisibility bool `json:"enableGithubInternalRepoVisibility,omitempty"`
 	// EnableLegacyExtensions description: Enable the extension registry and the use of extensions (doesn't affect code intel and git extras).
@@ -722,6 +793,8 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "bitbucketServerFastPerm")
 	delete(m, "customGitFetch")
 	delete(m, "debug.log")
+	delete(m, "distributedRateLimits")
+	delete(m, "enableGRPC")
 	delete(m, "enableGithubInternalRepoVisibility")
 	delete(m, "enableLegacyExtensions")
 	delete(m, "enablePermissionsWebhooks")
@@ -2596,6 +2669,8 @@ type SrcCliVersionCache struct {
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
+	// ContinueOnError description: Whether to continue with the next step if this step fails after all retries. The changes made by the failed step are kept. Defaults to false.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env any `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
@@ -2606,8 +2681,12 @@ type Step struct {
 	Mount []*Mount `json:"mount,omitempty"`
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
+	// Retries description: The number of times to retry the step if it fails or times out. The repository is reset to its state before the first attempt before every retry. Defaults to 0.
+	Retries int `json:"retries,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
 	Run string `json:"run"`
+	// Timeout description: The maximum duration of a single attempt to run the step, as a Go duration string. The step is terminated and counts as failed when it exceeds the timeout. If omitted, the step can run indefinitely.
+	Timeout string `json:"timeout,omitempty"`
 }
 type SubRepoPermissions struct {
 	// Enabled description: Enables sub-repo permission checking